@@ -75,7 +75,7 @@ func init() {
 		zoneCmd,
 
 		// Miscellaneous commands.
-		// TODO(pmattis): stats
+		statsCmd,
 		versionCmd,
 	)
 
@@ -59,6 +59,11 @@ var flagUsage = map[string]string{
         nodes. For example:
 
           --attrs=us-west-1b,gpu.
+`,
+	"backpressure-max-raft-log-entries": `
+        The number of entries in a range's Raft log beyond which writes to
+        the range wait until the log has been truncated. A value of 0
+        selects the default; a negative value disables this backpressure.
 `,
 	"cache-size": `
         Total size in bytes for caches, shared evenly if there are multiple
@@ -67,6 +72,18 @@ var flagUsage = map[string]string{
 	"certs": `
         Directory containing RSA key and x509 certs. This flag is required if
         --insecure=false.
+`,
+	"closed-timestamp-interval": `
+        The interval (time.Duration) at which range leaders serving bounded
+        staleness reads or change feeds promise not to serve writes at or
+        below the timestamp --closed-timestamp-lag ago. Replicas other than
+        the leader serve reads allowing a staleness of more than the lag
+        plus the interval. The default of 0 disables follower reads.
+`,
+	"closed-timestamp-lag": `
+        How far behind the present (time.Duration) range leaders close
+        timestamps. Transactions writing for longer than the lag restart.
+        Defaults to 30s.
 `,
 	"gossip": `
         A comma-separated list of gossip addresses or resolvers for gossip
@@ -93,6 +110,15 @@ var flagUsage = map[string]string{
 `,
 	"insecure": `
         Run over plain HTTP. WARNING: this is strongly discouraged.
+`,
+	"locality": `
+        An ordered, comma-separated list of key=value tiers describing the
+        failure domain of the node, from the most general to the most
+        specific. Replicas of each range are spread across as many distinct
+        tiers as possible before balancing on capacity, so all nodes should
+        specify the same keys in the same order. For example:
+
+          --locality=region=us-east,datacenter=us-east-1a,rack=12
 `,
 	"max-offset": `
         The maximum clock offset for the cluster. Clock offset is measured on
@@ -103,6 +129,17 @@ var flagUsage = map[string]string{
 `,
 	"metrics-frequency": `
         Adjust the frequency at which the server records its own internal metrics.
+`,
+	"old-store-keys": `
+        The previous key files of stores whose key file was changed, in the
+        format of --store-keys. The store keys are rewrapped with the new key
+        when the store is opened, after which the old key is no longer needed.
+`,
+	"quarantine-inconsistent": `
+        Take a replica out of service when a periodic consistency check finds
+        its data to diverge from that of the range leader, so that it is
+        replaced from a healthy replica. By default, divergences are only
+        logged.
 `,
 	"scan-interval": `
         Adjusts the target for the duration of a single scan through a store's
@@ -112,6 +149,33 @@ var flagUsage = map[string]string{
 	"scan-max-idle-time": `
         Adjusts the max idle time of the scanner. This speeds up the scanner on small
         clusters to be more responsive.
+`,
+	"snapshot-dir": `
+        Directory in which raft snapshots received from other nodes are staged
+        until they have been transferred completely. Defaults to the
+        "snapshots" subdirectory of the first persistent store.
+`,
+	"snapshot-rate": `
+        The maximum rate in bytes per second at which raft snapshots are sent
+        to other nodes, e.g. when replicating a range to a new store. A value
+        of 0 disables the limit.
+`,
+	"store-engine": `
+        The storage engine of the stores: "rocksdb" (the default), or "golsm"
+        for an engine written in pure Go, which doesn't support encryption.
+`,
+	"store-key-rotation": `
+        The period (time.Duration) after which the key encrypting newly
+        written data files of an encrypted store is replaced. Files written
+        under old keys are reencrypted as they are compacted.
+`,
+	"store-keys": `
+        A comma-separated list of persistent store paths, each followed by '='
+        and the path of a file holding a 256-bit AES key, raw or hex-encoded.
+        The data files of the listed stores are encrypted with AES-CTR under
+        store keys which are wrapped by the given key. For example:
+
+          --store-keys=/mnt/ssd01=/etc/cockroach/ssd01.key
 `,
 	"stores": `
         A comma-separated list of stores, specified by a colon-separated list
@@ -146,6 +210,8 @@ func initFlags(ctx *server.Context) {
 
 	if f := initCmd.Flags(); true {
 		f.StringVar(&ctx.Stores, "stores", ctx.Stores, flagUsage["stores"])
+		f.StringVar(&ctx.StoreEngine, "store-engine", ctx.StoreEngine, flagUsage["store-engine"])
+		f.StringVar(&ctx.StoreKeys, "store-keys", ctx.StoreKeys, flagUsage["store-keys"])
 		if err := initCmd.MarkFlagRequired("stores"); err != nil {
 			panic(err)
 		}
@@ -155,7 +221,13 @@ func initFlags(ctx *server.Context) {
 		// Server flags.
 		f.StringVar(&ctx.Addr, "addr", ctx.Addr, flagUsage["addr"])
 		f.StringVar(&ctx.Attrs, "attrs", ctx.Attrs, flagUsage["attrs"])
+		f.StringVar(&ctx.Locality, "locality", ctx.Locality, flagUsage["locality"])
 		f.StringVar(&ctx.Stores, "stores", ctx.Stores, flagUsage["stores"])
+		f.StringVar(&ctx.StoreEngine, "store-engine", ctx.StoreEngine, flagUsage["store-engine"])
+		f.StringVar(&ctx.StoreKeys, "store-keys", ctx.StoreKeys, flagUsage["store-keys"])
+		f.StringVar(&ctx.OldStoreKeys, "old-store-keys", ctx.OldStoreKeys, flagUsage["old-store-keys"])
+		f.DurationVar(&ctx.StoreKeyRotation, "store-key-rotation", ctx.StoreKeyRotation,
+			flagUsage["store-key-rotation"])
 		f.DurationVar(&ctx.MaxOffset, "max-offset", ctx.MaxOffset, flagUsage["max-offset"])
 		f.DurationVar(&ctx.MetricsFrequency, "metrics-frequency", ctx.MetricsFrequency,
 			flagUsage["metrics-frequency"])
@@ -173,10 +245,20 @@ func initFlags(ctx *server.Context) {
 		f.BoolVar(&ctx.Linearizable, "linearizable", ctx.Linearizable, flagUsage["linearizable"])
 
 		// Engine flags.
+		f.DurationVar(&ctx.ClosedTimestampInterval, "closed-timestamp-interval", ctx.ClosedTimestampInterval,
+			flagUsage["closed-timestamp-interval"])
+		f.DurationVar(&ctx.ClosedTimestampLag, "closed-timestamp-lag", ctx.ClosedTimestampLag,
+			flagUsage["closed-timestamp-lag"])
+		f.BoolVar(&ctx.QuarantineInconsistentReplicas, "quarantine-inconsistent",
+			ctx.QuarantineInconsistentReplicas, flagUsage["quarantine-inconsistent"])
+		f.Int64Var(&ctx.BackpressureMaxRaftLogEntries, "backpressure-max-raft-log-entries",
+			ctx.BackpressureMaxRaftLogEntries, flagUsage["backpressure-max-raft-log-entries"])
 		f.Int64Var(&ctx.CacheSize, "cache-size", ctx.CacheSize, flagUsage["cache-size"])
 		f.DurationVar(&ctx.ScanInterval, "scan-interval", ctx.ScanInterval, flagUsage["scan-interval"])
 		f.DurationVar(&ctx.ScanMaxIdleTime, "scan-max-idle-time", ctx.ScanMaxIdleTime,
 			flagUsage["scan-max-idle-time"])
+		f.Int64Var(&ctx.SnapshotRate, "snapshot-rate", ctx.SnapshotRate, flagUsage["snapshot-rate"])
+		f.StringVar(&ctx.SnapshotDir, "snapshot-dir", ctx.SnapshotDir, flagUsage["snapshot-dir"])
 
 		if err := startCmd.MarkFlagRequired("gossip"); err != nil {
 			panic(err)
@@ -188,6 +270,8 @@ func initFlags(ctx *server.Context) {
 
 	if f := exterminateCmd.Flags(); true {
 		f.StringVar(&ctx.Stores, "stores", ctx.Stores, flagUsage["stores"])
+		f.StringVar(&ctx.StoreEngine, "store-engine", ctx.StoreEngine, flagUsage["store-engine"])
+		f.StringVar(&ctx.StoreKeys, "store-keys", ctx.StoreKeys, flagUsage["store-keys"])
 		if err := exterminateCmd.MarkFlagRequired("stores"); err != nil {
 			panic(err)
 		}
@@ -207,7 +291,7 @@ func initFlags(ctx *server.Context) {
 
 	clientCmds := []*cobra.Command{
 		sqlShellCmd, kvCmd, rangeCmd,
-		acctCmd, permCmd, userCmd, zoneCmd,
+		acctCmd, permCmd, userCmd, zoneCmd, statsCmd,
 		exterminateCmd, quitCmd, /* startCmd is covered above */
 	}
 	for _, cmd := range clientCmds {
@@ -28,7 +28,6 @@ import (
 	"github.com/cockroachdb/cockroach/client"
 	"github.com/cockroachdb/cockroach/security"
 	"github.com/cockroachdb/cockroach/server"
-	"github.com/cockroachdb/cockroach/storage/engine"
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/log"
 	"github.com/cockroachdb/cockroach/util/stop"
@@ -207,9 +206,11 @@ func runExterminate(cmd *cobra.Command, args []string) {
 
 	// Exterminate all data held in specified stores.
 	for _, e := range Context.Engines {
-		if rocksdb, ok := e.(*engine.RocksDB); ok {
+		if d, ok := e.(interface {
+			Destroy() error
+		}); ok {
 			log.Infof("exterminating data from store %s", e)
-			if err := rocksdb.Destroy(); err != nil {
+			if err := d.Destroy(); err != nil {
 				log.Errorf("unable to destroy store %s: %s", e, err)
 				osExit(1)
 			}
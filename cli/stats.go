@@ -0,0 +1,202 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cockroachdb/cockroach/client"
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/sql"
+
+	"github.com/spf13/cobra"
+)
+
+// statsHeader lists the columns printed for each span by the stats
+// commands. GC bytes are the non-live bytes: deleted and overwritten
+// versions which garbage collection reclaims once they are old enough.
+const statsHeader = "\tlive bytes\tgc bytes\tintent bytes\tlive keys\tkeys\tintents\tranges\tscanned\n"
+
+// printSpanStats prints a row of the stats table for the named span.
+func printSpanStats(w io.Writer, name string, s *proto.SpanStatsResponse) {
+	fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%d\n", name,
+		s.LiveBytes, s.GCBytes(), s.IntentBytes, s.LiveCount, s.KeyCount,
+		s.IntentCount, s.RangeCount, s.ScannedRangeCount)
+}
+
+// A spanStatsCmd command displays the stats of a key span.
+var spanStatsCmd = &cobra.Command{
+	Use:   "span [options] <start-key> [<end-key>]",
+	Short: "displays the MVCC stats of a key span",
+	Long: `
+Displays the MVCC stats of the keys between <start-key> and <end-key>,
+or of all keys prefixed by <start-key> if <end-key> is omitted.
+`,
+	Run: runSpanStats,
+}
+
+func runSpanStats(cmd *cobra.Command, args []string) {
+	if len(args) < 1 || len(args) > 2 {
+		cmd.Usage()
+		return
+	}
+	start := proto.Key(unquoteArg(args[0], false))
+	end := start.PrefixEnd()
+	if len(args) == 2 {
+		end = proto.Key(unquoteArg(args[1], false))
+	}
+
+	kvDB := makeDBClient()
+	if kvDB == nil {
+		return
+	}
+	stats, err := kvDB.SpanStats(start, end)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "span stats failed: %s\n", err)
+		osExit(1)
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 2, 1, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(w, "span"+statsHeader)
+	printSpanStats(w, fmt.Sprintf("%q-%q", start, end), stats)
+	_ = w.Flush()
+}
+
+// A tableStatsCmd command displays the stats of a SQL table by
+// index.
+var tableStatsCmd = &cobra.Command{
+	Use:   "table [options] <database>.<table>",
+	Short: "displays the MVCC stats of a table by index",
+	Long: `
+Displays the MVCC stats of each index of the specified table, followed
+by their total.
+`,
+	Run: runTableStats,
+}
+
+func runTableStats(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cmd.Usage()
+		return
+	}
+	parts := strings.SplitN(args[0], ".", 2)
+	if len(parts) != 2 {
+		fmt.Fprintf(os.Stderr, "table name must be of the form <database>.<table>: %q\n", args[0])
+		osExit(1)
+		return
+	}
+
+	kvDB := makeDBClient()
+	if kvDB == nil {
+		return
+	}
+	spans, err := sql.TableIndexSpans(kvDB, parts[0], parts[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to look up table %q: %s\n", args[0], err)
+		osExit(1)
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 2, 1, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(w, "index"+statsHeader)
+	total := &proto.SpanStatsResponse{}
+	for _, span := range spans {
+		stats, err := kvDB.SpanStats(span.Start, span.End)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "span stats of index %q failed: %s\n", span.Index, err)
+			osExit(1)
+			return
+		}
+		printSpanStats(w, span.Index, stats)
+		total.Combine(stats)
+	}
+	printSpanStats(w, "total", total)
+	_ = w.Flush()
+}
+
+// A zoneStatsCmd command displays the stats of the key prefixes of
+// zone configs.
+var zoneStatsCmd = &cobra.Command{
+	Use:   "zone [options] [<key-prefix>]",
+	Short: "displays the MVCC stats of zone config key prefixes",
+	Long: `
+Displays the MVCC stats of the keys prefixed by <key-prefix>, or by
+each prefix which has a zone config if <key-prefix> is omitted. The
+stats of a prefix include those of any longer prefixes it contains.
+`,
+	Run: runZoneStats,
+}
+
+func runZoneStats(cmd *cobra.Command, args []string) {
+	if len(args) > 1 {
+		cmd.Usage()
+		return
+	}
+	var prefixes []string
+	if len(args) == 1 {
+		prefixes = []string{args[0]}
+	} else {
+		admin := client.NewAdminClient(&Context.Context, Context.Addr, client.Zone)
+		list, err := admin.List()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to list zone configs: %s\n", err)
+			osExit(1)
+			return
+		}
+		prefixes = list
+		sort.Strings(prefixes)
+	}
+
+	kvDB := makeDBClient()
+	if kvDB == nil {
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 2, 1, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(w, "prefix"+statsHeader)
+	for _, prefix := range prefixes {
+		start := proto.Key(prefix)
+		stats, err := kvDB.SpanStats(start, start.PrefixEnd())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "span stats of prefix %q failed: %s\n", prefix, err)
+			osExit(1)
+			return
+		}
+		printSpanStats(w, fmt.Sprintf("%q", prefix), stats)
+	}
+	_ = w.Flush()
+}
+
+var statsCmds = []*cobra.Command{
+	spanStatsCmd,
+	tableStatsCmd,
+	zoneStatsCmd,
+}
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "display MVCC stats of key spans, tables and zones",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Usage()
+	},
+}
+
+func init() {
+	statsCmd.AddCommand(statsCmds...)
+}
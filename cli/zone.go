@@ -130,6 +130,11 @@ The zone config format has the following YAML schema:
     - attrs:  ...
   range_min_bytes: <size-in-bytes>
   range_max_bytes: <size-in-bytes>
+  range_max_qps: <requests-per-second>
+  gc:
+    ttlseconds: <max-age-of-versions>
+    minversions: <versions-retained-regardless-of-age>
+    maxversions: <max-versions-retained>
 
 For example:
 
@@ -139,10 +144,25 @@ For example:
     - attrs: [us-west-1b, ssd]
   range_min_bytes: 8388608
   range_max_bytes: 67108864
+  range_max_qps: 1000
+  gc:
+    ttlseconds: 86400
+    maxversions: 10
 
 Setting zone configs will guarantee that key ranges will be split
 such that no key range straddles two zone config specifications.
-This feature can be taken advantage of to pre-split ranges.
+This feature can be taken advantage of to pre-split ranges. Ranges
+serving more than range_max_qps requests per second are split at a
+key dividing their load; omitting it disables load-based splitting.
+Ranges smaller than range_min_bytes are merged into their right
+neighbor if it falls under the same zone config.
+
+Old versions of values are garbage collected once they are older than
+gc.ttlseconds or once there are more than gc.maxversions newer
+versions of the same key, but the gc.minversions most recent versions
+are retained regardless of their age. A ttlseconds or maxversions of
+zero disables the corresponding limit; otherwise maxversions must be
+at least minversions.
 `,
 	Run: runSetZone,
 }
@@ -20,7 +20,9 @@ package client
 import (
 	"fmt"
 	"reflect"
+	"time"
 
+	"github.com/cockroachdb/cockroach/keys"
 	"github.com/cockroachdb/cockroach/proto"
 	gogoproto "github.com/gogo/protobuf/proto"
 )
@@ -45,11 +47,21 @@ type Batch struct {
 	//   _ = db.Run(b)
 	//   // string(b.Results[0].Rows[0].Key) == "a"
 	//   // string(b.Results[1].Rows[0].Key) == "b"
-	Results    []Result
-	calls      []proto.Call
-	resultsBuf [8]Result
-	rowsBuf    [8]KeyValue
-	rowsIdx    int
+	Results      []Result
+	calls        []proto.Call
+	resultsBuf   [8]Result
+	rowsBuf      [8]KeyValue
+	rowsIdx      int
+	maxStaleness time.Duration
+}
+
+// SetMaxStaleness allows the read operations within the batch to return
+// values which are up to maxStaleness old. Such reads may be served by
+// any sufficiently up to date replica instead of the range leader,
+// typically the closest one, but do not observe pending writes. They
+// cannot be used within a transaction.
+func (b *Batch) SetMaxStaleness(maxStaleness time.Duration) {
+	b.maxStaleness = maxStaleness
 }
 
 func (b *Batch) prepare() error {
@@ -58,6 +70,14 @@ func (b *Batch) prepare() error {
 			return err
 		}
 	}
+	if b.maxStaleness > 0 {
+		for _, c := range b.calls {
+			if proto.IsReadOnly(c.Args) {
+				c.Args.Header().ReadConsistency = proto.BOUNDED_STALENESS
+				c.Args.Header().MaxStaleness = b.maxStaleness.Nanoseconds()
+			}
+		}
+	}
 	return nil
 }
 
@@ -128,14 +148,25 @@ func (b *Batch) fillResults() error {
 					row.Key = kv.Key
 					row.setValue(&kv.Value)
 				}
+				result.ResumeKey = t.ResumeKey
 			case *proto.DeleteResponse:
 				row := &result.Rows[k]
 				row.Key = []byte(call.Args.(*proto.DeleteRequest).Key)
+			case *proto.ReapQueueResponse:
+				key := keys.KeyAddress(call.Args.(*proto.ReapQueueRequest).Key)
+				result.Rows = make([]KeyValue, len(t.Messages))
+				for j := range t.Messages {
+					row := &result.Rows[j]
+					row.Key = key
+					row.setValue(&t.Messages[j])
+				}
 
 			case *proto.AdminMergeResponse:
 			case *proto.AdminSplitResponse:
 			case *proto.DeleteRangeResponse:
 			case *proto.EndTransactionResponse:
+			case *proto.EnqueueMessageResponse:
+			case *proto.EnqueueUpdateResponse:
 			case *proto.InternalBatchResponse:
 			case *proto.InternalGCResponse:
 			case *proto.InternalMergeResponse:
@@ -351,6 +382,89 @@ func (b *Batch) DelRange(s, e interface{}) {
 	b.initResult(1, 0, nil)
 }
 
+// EnqueueMessage enqueues value as a message for delivery to the inbox of key.
+// Messages are retrieved from the inbox via ReapQueue.
+//
+// A new result will be appended to the batch which will contain 0 rows and
+// Result.Err will indicate success or failure.
+//
+// key can be either a byte slice, a string, a fmt.Stringer or an
+// encoding.BinaryMarshaler. value can be any key type or a proto.Message.
+func (b *Batch) EnqueueMessage(key, value interface{}) {
+	k, err := marshalKey(key)
+	if err != nil {
+		b.initResult(0, 0, err)
+		return
+	}
+	v, err := marshalValue(reflect.ValueOf(value))
+	if err != nil {
+		b.initResult(0, 0, err)
+		return
+	}
+	inbox := keys.InboxPrefix(proto.Key(k))
+	req := &proto.EnqueueMessageRequest{
+		RequestHeader: proto.RequestHeader{
+			Key:    inbox,
+			EndKey: inbox.PrefixEnd(),
+		},
+		Msg: v,
+	}
+	resp := &proto.EnqueueMessageResponse{}
+	b.calls = append(b.calls, proto.Call{Args: req, Reply: resp})
+	b.initResult(1, 0, nil)
+}
+
+// ReapQueue retrieves and deletes the messages in the inbox of key. It may
+// only be used within a transaction; the messages are removed from the inbox
+// when the transaction commits.
+//
+// A new result will be appended to the batch which will contain up to maxRows
+// rows, one per message in the order in which the messages were enqueued, and
+// Result.Err will indicate success or failure. The key of each row is key. If
+// maxRows is 0, all messages are reaped.
+//
+// key can be either a byte slice, a string, a fmt.Stringer or an
+// encoding.BinaryMarshaler.
+func (b *Batch) ReapQueue(key interface{}, maxRows int64) {
+	k, err := marshalKey(key)
+	if err != nil {
+		b.initResult(0, 0, err)
+		return
+	}
+	inbox := keys.InboxPrefix(proto.Key(k))
+	req := &proto.ReapQueueRequest{
+		RequestHeader: proto.RequestHeader{
+			Key:    inbox,
+			EndKey: inbox.PrefixEnd(),
+		},
+		MaxResults: maxRows,
+	}
+	resp := &proto.ReapQueueResponse{}
+	b.calls = append(b.calls, proto.Call{Args: req, Reply: resp})
+	b.initResult(1, 0, nil)
+}
+
+// EnqueueUpdate enqueues update for asynchronous execution. The update must
+// be a Put, Increment, Delete or DeleteRange request. It is stored in the
+// outbox of the range holding the updated key and executed exactly once, in a
+// separate transaction, some time after it has been enqueued.
+//
+// A new result will be appended to the batch which will contain 0 rows and
+// Result.Err will indicate success or failure.
+func (b *Batch) EnqueueUpdate(update proto.Request) {
+	req := &proto.EnqueueUpdateRequest{}
+	if !req.Update.SetValue(update) {
+		b.initResult(0, 0, fmt.Errorf("unable to enqueue update %T", update))
+		return
+	}
+	outbox := keys.OutboxPrefix(update.Header().Key)
+	req.Key = outbox
+	req.EndKey = outbox.PrefixEnd()
+	resp := &proto.EnqueueUpdateResponse{}
+	b.calls = append(b.calls, proto.Call{Args: req, Reply: resp})
+	b.initResult(1, 0, nil)
+}
+
 // adminMerge is only exported on DB. It is here for symmetry with the
 // other operations.
 func (b *Batch) adminMerge(key interface{}) {
@@ -342,6 +342,46 @@ func TestClientGetAndPut(t *testing.T) {
 	}
 }
 
+// TestClientImporter verifies that an importer loads key/value pairs
+// into several ranges and rejects keys which are out of order.
+func TestClientImporter(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	s := server.StartTestServer(t)
+	defer s.Stop()
+	db := createTestClientFor(s.ServingAddr(), security.RootUser)
+
+	if err := db.AdminSplit("i/5"); err != nil {
+		t.Fatal(err)
+	}
+	// The chunk size is chosen so that chunks span the split.
+	im := db.NewImporter(30)
+	for i := 0; i < 10; i++ {
+		if err := im.Add(fmt.Sprintf("i/%d", i), fmt.Sprintf("value-%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := im.Add("i/0", "value"); err == nil {
+		t.Error("expected error adding key out of order")
+	}
+	if err := im.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := db.Scan("i/", "i0", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 10 {
+		t.Fatalf("expected 10 rows; got %d", len(rows))
+	}
+	for i, row := range rows {
+		if expKey, expValue := fmt.Sprintf("i/%d", i), fmt.Sprintf("value-%d", i); string(row.Key) != expKey ||
+			string(row.ValueBytes()) != expValue {
+			t.Errorf("%d: expected %s=%s; got %s=%s", i, expKey, expValue, row.Key, row.ValueBytes())
+		}
+	}
+}
+
 // TestClientEmptyValues verifies that empty values are preserved
 // for both empty []byte and integer=0. This used to fail when we
 // allowed the protobufs to be gob-encoded using the default go rpc
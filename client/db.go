@@ -126,6 +126,10 @@ type Result struct {
 	// rows returned is the number or rows matching the scan capped by the
 	// maxRows parameter. For DelRange Rows is nil.
 	Rows []KeyValue
+	// ResumeKey is set for a Scan which was truncated by the maxRows
+	// parameter before reaching the end of its key range, and is the
+	// key at which to continue the scan. See DB.Iterate.
+	ResumeKey proto.Key
 }
 
 func (r Result) String() string {
@@ -318,6 +322,18 @@ func (db *DB) Scan(begin, end interface{}, maxRows int64) ([]KeyValue, error) {
 	return r.Rows, err
 }
 
+// Iterate scans the rows between begin (inclusive) and end (exclusive)
+// in pages of up to pageSize rows, invoking f with each page. Only one
+// page is held in memory at a time. Iteration stops at the first error
+// returned by f, which is then returned by Iterate. Each page is read
+// at its own timestamp; use Txn.Iterate for a consistent view.
+//
+// key can be either a byte slice, a string, a fmt.Stringer or an
+// encoding.BinaryMarshaler.
+func (db *DB) Iterate(begin, end interface{}, pageSize int64, f func([]KeyValue) error) error {
+	return iterate(db, begin, end, pageSize, f)
+}
+
 // Del deletes one or more keys.
 //
 // key can be either a byte slice, a string, a fmt.Stringer or an
@@ -342,6 +358,57 @@ func (db *DB) DelRange(begin, end interface{}) error {
 	return err
 }
 
+// EnqueueMessage enqueues value as a message for delivery to the inbox of key.
+//
+// key can be either a byte slice, a string, a fmt.Stringer or an
+// encoding.BinaryMarshaler. value can be any key type or a proto.Message.
+func (db *DB) EnqueueMessage(key, value interface{}) error {
+	b := db.NewBatch()
+	b.EnqueueMessage(key, value)
+	_, err := runOneResult(db, b)
+	return err
+}
+
+// EnqueueUpdate enqueues update for asynchronous execution. See
+// Batch.EnqueueUpdate.
+func (db *DB) EnqueueUpdate(update proto.Request) error {
+	b := db.NewBatch()
+	b.EnqueueUpdate(update)
+	_, err := runOneResult(db, b)
+	return err
+}
+
+// SpanStats returns the MVCC statistics of the keys in the interval
+// [begin,end). Ranges contained in the interval contribute the stats
+// they maintain; only ranges straddling its bounds are scanned.
+//
+// key can be either a byte slice, a string, a fmt.Stringer or an
+// encoding.BinaryMarshaler.
+func (db *DB) SpanStats(begin, end interface{}) (*proto.SpanStatsResponse, error) {
+	b, err := marshalKey(begin)
+	if err != nil {
+		return nil, err
+	}
+	e, err := marshalKey(end)
+	if err != nil {
+		return nil, err
+	}
+	reply := &proto.SpanStatsResponse{}
+	call := proto.Call{
+		Args: &proto.SpanStatsRequest{
+			RequestHeader: proto.RequestHeader{
+				Key:    proto.Key(b),
+				EndKey: proto.Key(e),
+			},
+		},
+		Reply: reply,
+	}
+	if err := db.send(call); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
 // AdminMerge merges the range containing key and the subsequent
 // range. After the merge operation is complete, the range containing
 // key will contain all of the key/value pairs of the subsequent range
@@ -481,6 +548,29 @@ func runOneResult(r Runner, b *Batch) (Result, error) {
 	return res, res.Err
 }
 
+func iterate(r Runner, begin, end interface{}, pageSize int64, f func([]KeyValue) error) error {
+	if pageSize <= 0 {
+		return util.Errorf("page size must be positive: %d", pageSize)
+	}
+	for {
+		b := &Batch{}
+		b.Scan(begin, end, pageSize)
+		res, err := runOneResult(r, b)
+		if err != nil {
+			return err
+		}
+		if len(res.Rows) > 0 {
+			if err := f(res.Rows); err != nil {
+				return err
+			}
+		}
+		if res.ResumeKey == nil {
+			return nil
+		}
+		begin = res.ResumeKey
+	}
+}
+
 func runOneRow(r Runner, b *Batch) (KeyValue, error) {
 	if err := r.Run(b); err != nil {
 		return KeyValue{}, err
@@ -0,0 +1,107 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package client
+
+import (
+	"reflect"
+
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util"
+)
+
+// DefaultImportChunkSize is the default number of key and value bytes
+// an Importer buffers before sending them.
+const DefaultImportChunkSize = 4 << 20 // 4MB
+
+// An Importer bulk loads key/value pairs, which must be added in
+// ascending key order. The pairs are buffered and sent in chunks. A
+// chunk is split along the boundaries of the ranges it spans, and each
+// range writes its share of the pairs in a single Raft command instead
+// of one per pair. All pairs of a chunk are written at the same
+// timestamp, outside of any transaction.
+//
+// An Importer is not safe for concurrent use; to load several key
+// spans in parallel, use one Importer per span.
+type Importer struct {
+	db        *DB
+	chunkSize int
+	rows      []proto.KeyValue
+	size      int
+	lastKey   proto.Key
+}
+
+// NewImporter returns an Importer which sends chunks of approximately
+// chunkSize bytes. If chunkSize is not positive, DefaultImportChunkSize
+// is used.
+func (db *DB) NewImporter(chunkSize int) *Importer {
+	if chunkSize <= 0 {
+		chunkSize = DefaultImportChunkSize
+	}
+	return &Importer{db: db, chunkSize: chunkSize}
+}
+
+// Add buffers the pair of key and value, sending the buffered pairs
+// if they've reached the chunk size. The key must sort after the keys
+// of all previously added pairs.
+//
+// key can be either a byte slice, a string, a fmt.Stringer or an
+// encoding.BinaryMarshaler. value can be any key type or a proto.Message.
+func (im *Importer) Add(key, value interface{}) error {
+	k, err := marshalKey(key)
+	if err != nil {
+		return err
+	}
+	if im.lastKey != nil && !im.lastKey.Less(k) {
+		return util.Errorf("import keys must be added in ascending order: %q follows %q", k, im.lastKey)
+	}
+	v, err := marshalValue(reflect.ValueOf(value))
+	if err != nil {
+		return err
+	}
+	v.InitChecksum(k)
+	im.rows = append(im.rows, proto.KeyValue{Key: k, Value: v})
+	im.size += len(k) + len(v.Bytes)
+	im.lastKey = k
+	if im.size >= im.chunkSize {
+		return im.Flush()
+	}
+	return nil
+}
+
+// Flush sends the buffered pairs. It must be called once all pairs
+// have been added. If it fails, the pairs remain buffered, so that
+// Flush may be retried.
+func (im *Importer) Flush() error {
+	if len(im.rows) == 0 {
+		return nil
+	}
+	call := proto.Call{
+		Args: &proto.ImportRequest{
+			RequestHeader: proto.RequestHeader{
+				Key:    im.rows[0].Key,
+				EndKey: im.lastKey.Next(),
+			},
+			Rows: im.rows,
+		},
+		Reply: &proto.ImportResponse{},
+	}
+	if err := im.db.send(call); err != nil {
+		return err
+	}
+	im.rows = nil
+	im.size = 0
+	return nil
+}
@@ -18,18 +18,36 @@
 package client
 
 import (
+	"bytes"
 	"fmt"
+	"math"
 	"path/filepath"
 	"runtime"
 	"time"
 
 	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/log"
 	"github.com/cockroachdb/cockroach/util/retry"
 	gogoproto "github.com/gogo/protobuf/proto"
 	"golang.org/x/net/context"
 )
 
+// UserPriority is the priority of a transaction relative to the other
+// transactions it conflicts with. See Txn.SetUserPriority.
+type UserPriority int32
+
+// User priorities of transactions. They map onto the transaction
+// priorities compared when one transaction pushes another: transactions
+// of normal priority are assigned random priorities, while low and high
+// priority transactions are assigned the lowest and highest fixed
+// priorities respectively.
+const (
+	LowUserPriority    UserPriority = -1
+	NormalUserPriority UserPriority = 1
+	HighUserPriority   UserPriority = -math.MaxInt32
+)
+
 var (
 	// DefaultTxnRetryOptions are the standard retry options used
 	// for transactions.
@@ -69,6 +87,9 @@ type Txn struct {
 	txn          proto.Transaction
 	haveTxnWrite bool // True if there were transactional writes
 	haveEndTxn   bool // True if there was an explicit EndTransaction
+	readOnly     bool // True if the transaction was declared read-only
+	savepoint    bool // True if a savepoint was taken since the last write
+	deadline     *proto.Timestamp
 }
 
 func newTxn(db DB, depth int) *Txn {
@@ -116,6 +137,30 @@ func (txn *Txn) SetSnapshotIsolation() {
 	txn.txn.Isolation = proto.SNAPSHOT
 }
 
+// SetUserPriority sets the transaction's user priority, which decides
+// the outcome of its conflicts with other transactions. A transaction
+// of higher user priority aborts or pushes the transactions of lower
+// user priority it conflicts with, while conflicts between transactions
+// of normal user priority are decided at random. The priority must be
+// set before any operations are performed on the transaction.
+func (txn *Txn) SetUserPriority(priority UserPriority) {
+	txn.db.userPriority = int32(priority)
+}
+
+// SetDeadline sets a deadline for committing the transaction. Committing
+// the transaction fails if its commit timestamp is not before the
+// deadline.
+func (txn *Txn) SetDeadline(deadline time.Time) {
+	txn.deadline = &proto.Timestamp{WallTime: deadline.UnixNano()}
+}
+
+// SetReadOnly declares the transaction read-only. Writes performed by
+// a read-only transaction fail. As it has nothing to commit, it never
+// creates a transaction record and is never heartbeat.
+func (txn *Txn) SetReadOnly() {
+	txn.readOnly = true
+}
+
 // InternalSetPriority sets the transaction priority. It is intended for
 // internal (testing) use only.
 func (txn *Txn) InternalSetPriority(priority int32) {
@@ -124,6 +169,46 @@ func (txn *Txn) InternalSetPriority(priority int32) {
 	txn.db.userPriority = -priority
 }
 
+// A Savepoint marks a point in a transaction to which its writes can be
+// rolled back. See Txn.Savepoint.
+type Savepoint struct {
+	id       []byte
+	epoch    int32
+	sequence int32
+}
+
+// Savepoint returns a savepoint marking the current state of the
+// transaction. The writes performed after the savepoint can later be
+// discarded using RollbackTo without aborting the transaction. A
+// savepoint does not survive a restart of the transaction.
+func (txn *Txn) Savepoint() Savepoint {
+	txn.savepoint = true
+	return Savepoint{
+		id:       txn.txn.ID,
+		epoch:    txn.txn.Epoch,
+		sequence: txn.txn.Sequence,
+	}
+}
+
+// RollbackTo discards the writes performed by the transaction since the
+// savepoint was created. Subsequent reads by the transaction do not
+// observe them and they are not committed. The transaction itself
+// remains usable, and writes performed after RollbackTo are unaffected.
+func (txn *Txn) RollbackTo(sp Savepoint) error {
+	if (len(sp.id) > 0 && !bytes.Equal(sp.id, txn.txn.ID)) || sp.epoch != txn.txn.Epoch {
+		return util.Errorf("savepoint of transaction %q was invalidated by a restart", txn.txn.Name)
+	}
+	if sp.sequence < txn.txn.Sequence {
+		txn.txn.RolledBack = append(txn.txn.RolledBack, proto.SequenceRange{
+			Start: sp.sequence + 1,
+			End:   txn.txn.Sequence,
+		})
+		// Subsequent writes must not fall in the rolled back range.
+		txn.savepoint = true
+	}
+	return nil
+}
+
 // NewBatch creates and returns a new empty batch object for use with the Txn.
 func (txn *Txn) NewBatch() *Batch {
 	return &Batch{DB: &txn.db}
@@ -208,6 +293,17 @@ func (txn *Txn) Scan(begin, end interface{}, maxRows int64) ([]KeyValue, error)
 	return r.Rows, err
 }
 
+// Iterate scans the rows between begin (inclusive) and end (exclusive)
+// in pages of up to pageSize rows, invoking f with each page. Only one
+// page is held in memory at a time. Iteration stops at the first error
+// returned by f, which is then returned by Iterate.
+//
+// key can be either a byte slice, a string, a fmt.Stringer or an
+// encoding.BinaryMarshaler.
+func (txn *Txn) Iterate(begin, end interface{}, pageSize int64, f func([]KeyValue) error) error {
+	return iterate(txn, begin, end, pageSize, f)
+}
+
 // Del deletes one or more keys.
 //
 // key can be either a byte slice, a string, a fmt.Stringer or an
@@ -233,6 +329,40 @@ func (txn *Txn) DelRange(begin, end interface{}) error {
 	return err
 }
 
+// EnqueueMessage enqueues value as a message for delivery to the inbox of key.
+// The message is only delivered if the transaction commits.
+//
+// key can be either a byte slice, a string, a fmt.Stringer or an
+// encoding.BinaryMarshaler. value can be any key type or a proto.Message.
+func (txn *Txn) EnqueueMessage(key, value interface{}) error {
+	b := txn.NewBatch()
+	b.EnqueueMessage(key, value)
+	_, err := runOneResult(txn, b)
+	return err
+}
+
+// ReapQueue retrieves up to maxRows messages from the inbox of key. The
+// messages are deleted from the inbox when the transaction commits. If fewer
+// than maxRows messages are returned, the inbox is empty.
+//
+// key can be either a byte slice, a string, a fmt.Stringer or an
+// encoding.BinaryMarshaler.
+func (txn *Txn) ReapQueue(key interface{}, maxRows int64) ([]KeyValue, error) {
+	b := txn.NewBatch()
+	b.ReapQueue(key, maxRows)
+	r, err := runOneResult(txn, b)
+	return r.Rows, err
+}
+
+// EnqueueUpdate enqueues update for asynchronous execution. The update is
+// only executed if the transaction commits. See Batch.EnqueueUpdate.
+func (txn *Txn) EnqueueUpdate(update proto.Request) error {
+	b := txn.NewBatch()
+	b.EnqueueUpdate(update)
+	_, err := runOneResult(txn, b)
+	return err
+}
+
 // Run executes the operations queued up within a batch. Before executing any
 // of the operations the batch is first checked to see if there were any errors
 // during its construction (e.g. failure to marshal a proto message).
@@ -259,7 +389,11 @@ func (txn *Txn) Run(b *Batch) error {
 // efficient than relying on the implicit commit performed when the transaction
 // function returns without error.
 func (txn *Txn) Commit(b *Batch) error {
-	args := &proto.EndTransactionRequest{Commit: true}
+	if txn.readOnly {
+		// A read-only transaction has nothing to commit.
+		return txn.Run(b)
+	}
+	args := &proto.EndTransactionRequest{Commit: true, Deadline: txn.deadline}
 	reply := &proto.EndTransactionResponse{}
 	b.calls = append(b.calls, proto.Call{Args: args, Reply: reply})
 	b.initResult(1, 0, nil)
@@ -271,13 +405,14 @@ func (txn *Txn) exec(retryable func(txn *Txn) error) (err error) {
 	// error condition this loop isn't capable of handling.
 	for r := retry.Start(txn.db.txnRetryOptions); r.Next(); {
 		txn.haveTxnWrite, txn.haveEndTxn = false, false // always reset before [re]starting txn
+		txn.savepoint = false
 		if err = retryable(txn); err == nil {
 			if !txn.haveEndTxn && txn.haveTxnWrite {
 				// If there were no errors running retryable, commit the txn. This
 				// may block waiting for outstanding writes to complete in case
 				// retryable didn't -- we need the most recent of all response
 				// timestamps in order to commit.
-				etArgs := &proto.EndTransactionRequest{Commit: true}
+				etArgs := &proto.EndTransactionRequest{Commit: true, Deadline: txn.deadline}
 				etReply := &proto.EndTransactionResponse{}
 				err = txn.send(proto.Call{Args: etArgs, Reply: etReply})
 			}
@@ -313,19 +448,56 @@ func (txn *Txn) send(calls ...proto.Call) error {
 	if len(calls) == 0 {
 		return nil
 	}
+	if txn.readOnly {
+		if err := verifyReadOnly(calls); err != nil {
+			return err
+		}
+	}
 	txn.updateState(calls)
 	return txn.db.send(calls...)
 }
 
+// verifyReadOnly returns an error if any of the calls is not read-only.
+func verifyReadOnly(calls []proto.Call) error {
+	for _, c := range calls {
+		requests := []proto.Request{c.Args}
+		if b, ok := c.Args.(*proto.BatchRequest); ok {
+			requests = requests[:0]
+			for _, br := range b.Requests {
+				requests = append(requests, br.GetValue().(proto.Request))
+			}
+		}
+		for _, r := range requests {
+			if !proto.IsReadOnly(r) {
+				return util.Errorf("cannot execute %s in a read-only transaction", r.Method())
+			}
+		}
+	}
+	return nil
+}
+
 func (txn *Txn) updateState(calls []proto.Call) {
+	var write bool
 	for _, c := range calls {
 		if b, ok := c.Args.(*proto.BatchRequest); ok {
 			for _, br := range b.Requests {
-				txn.updateStateForRequest(br.GetValue().(proto.Request))
+				r := br.GetValue().(proto.Request)
+				txn.updateStateForRequest(r)
+				write = write || proto.IsTransactionWrite(r)
 			}
 			continue
 		}
 		txn.updateStateForRequest(c.Args)
+		write = write || proto.IsTransactionWrite(c.Args)
+	}
+	if write && txn.savepoint {
+		// The writes between two savepoints share a sequence number,
+		// which distinguishes them from the writes preceding the first
+		// savepoint. A transaction replacing its own intent only keeps
+		// the replaced value if the sequence number differs, so the
+		// intent history grows with the savepoints, not the writes.
+		txn.txn.Sequence++
+		txn.savepoint = false
 	}
 }
 
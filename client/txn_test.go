@@ -296,3 +296,143 @@ func TestRunTransactionRetryOnErrors(t *testing.T) {
 		}
 	}
 }
+
+// TestTxnSavepoint verifies that transactional writes are assigned a
+// new sequence number only after a savepoint and that rolling back to a
+// savepoint marks the writes made after it as rolled back.
+func TestTxnSavepoint(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	type seqState struct {
+		method     proto.Method
+		sequence   int32
+		rolledBack []proto.SequenceRange
+	}
+	var states []seqState
+	db := newDB(newTestSender(func(call proto.Call) {
+		txn := call.Args.Header().Txn
+		states = append(states, seqState{call.Method(), txn.Sequence, txn.RolledBack})
+	}))
+	if err := db.Txn(func(txn *Txn) error {
+		if err := txn.Put("a", "1"); err != nil {
+			return err
+		}
+		if err := txn.Put("a", "2"); err != nil {
+			return err
+		}
+		sp := txn.Savepoint()
+		if err := txn.Put("b", "2"); err != nil {
+			return err
+		}
+		if _, err := txn.Get("b"); err != nil {
+			return err
+		}
+		if err := txn.RollbackTo(sp); err != nil {
+			return err
+		}
+		return txn.Put("c", "3")
+	}); err != nil {
+		t.Fatalf("unexpected error on commit: %s", err)
+	}
+	rolledBack := []proto.SequenceRange{{Start: 1, End: 1}}
+	expStates := []seqState{
+		{proto.Put, 0, nil},
+		{proto.Put, 0, nil},
+		{proto.Put, 1, nil},
+		{proto.Get, 1, nil},
+		{proto.Put, 2, rolledBack},
+		{proto.EndTransaction, 2, rolledBack},
+	}
+	if !reflect.DeepEqual(expStates, states) {
+		t.Errorf("expected %+v, got %+v", expStates, states)
+	}
+}
+
+// TestTxnRollbackToAfterRestart verifies that a savepoint can't be
+// rolled back to once the transaction has restarted.
+func TestTxnRollbackToAfterRestart(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	db := newDB(newTestSender(nil))
+	txn := newTxn(*db, 0)
+	if err := txn.Put("a", "1"); err != nil {
+		t.Fatal(err)
+	}
+	sp := txn.Savepoint()
+	if err := txn.RollbackTo(sp); err != nil {
+		t.Fatal(err)
+	}
+	txn.txn.Restart(0, 0, proto.ZeroTimestamp)
+	if err := txn.RollbackTo(sp); err == nil {
+		t.Error("expected rolling back to savepoint after restart to fail")
+	}
+}
+
+// TestTxnUserPriority verifies that the user priority of a transaction
+// is sent with its requests.
+func TestTxnUserPriority(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	for _, priority := range []UserPriority{LowUserPriority, NormalUserPriority, HighUserPriority} {
+		var userPriority int32
+		db := newDB(SenderFunc(func(_ context.Context, call proto.Call) {
+			userPriority = call.Args.Header().GetUserPriority()
+			call.Reply.Header().Txn = gogoproto.Clone(call.Args.Header().Txn).(*proto.Transaction)
+		}))
+		if err := db.Txn(func(txn *Txn) error {
+			txn.SetUserPriority(priority)
+			_, err := txn.Get("a")
+			return err
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if userPriority != int32(priority) {
+			t.Errorf("expected user priority %d; got %d", priority, userPriority)
+		}
+	}
+}
+
+// TestTxnDeadline verifies that the deadline of a transaction is sent
+// with the EndTransaction request committing it.
+func TestTxnDeadline(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	deadline := time.Unix(0, 123)
+	var etDeadline *proto.Timestamp
+	db := newDB(newTestSender(func(call proto.Call) {
+		if et, ok := call.Args.(*proto.EndTransactionRequest); ok {
+			etDeadline = et.Deadline
+		}
+	}))
+	if err := db.Txn(func(txn *Txn) error {
+		txn.SetDeadline(deadline)
+		return txn.Put("a", "b")
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if expDeadline := (proto.Timestamp{WallTime: 123}); etDeadline == nil || !etDeadline.Equal(expDeadline) {
+		t.Errorf("expected deadline %s; got %v", expDeadline, etDeadline)
+	}
+}
+
+// TestTxnReadOnly verifies that a read-only transaction fails to write
+// and doesn't send an EndTransaction request, even when committed
+// explicitly.
+func TestTxnReadOnly(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	var calls []proto.Method
+	db := newDB(newTestSender(func(call proto.Call) {
+		calls = append(calls, call.Method())
+	}))
+	if err := db.Txn(func(txn *Txn) error {
+		txn.SetReadOnly()
+		if err := txn.Put("a", "b"); err == nil {
+			t.Error("expected write in read-only transaction to fail")
+		}
+		b := txn.NewBatch()
+		b.Get("a")
+		return txn.Commit(b)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	expectedCalls := []proto.Method{proto.Get}
+	if !reflect.DeepEqual(expectedCalls, calls) {
+		t.Errorf("expected %s, got %s", expectedCalls, calls)
+	}
+}
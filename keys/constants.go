@@ -48,8 +48,9 @@ var (
 	// key suffixes.
 	LocalSuffixLength = 4
 
-	// There are three types of local key data enumerated below:
-	// store-local, range-local by ID, and range-local by key.
+	// There are four types of local key data enumerated below:
+	// store-local, range-local by ID, range-local by key and outboxes,
+	// which are also indexed by key.
 
 	// LocalStorePrefix is the prefix identifying per-store data.
 	LocalStorePrefix = MakeKey(LocalPrefix, proto.Key("s"))
@@ -84,11 +85,29 @@ var (
 	LocalRaftTruncatedStateSuffix = proto.Key("rftt")
 	// LocalRaftLastIndexSuffix is the suffix for raft's last index.
 	LocalRaftLastIndexSuffix = proto.Key("rfti")
+	// LocalRangeChangeLogSuffix is the suffix for the entries of a
+	// range's change log.
+	LocalRangeChangeLogSuffix = proto.Key("rclg")
+	// LocalRangeChangeLogStartSuffix is the suffix for the timestamp
+	// above which a range's change log is complete.
+	LocalRangeChangeLogStartSuffix = proto.Key("rcls")
+	// LocalRangeClosedTimestampSuffix is the suffix for the timestamp at
+	// or below which a range's leader no longer serves writes.
+	LocalRangeClosedTimestampSuffix = proto.Key("rcts")
 	// LocalRangeGCMetadataSuffix is the suffix for a range's GC metadata.
 	LocalRangeGCMetadataSuffix = proto.Key("rgcm")
+	// LocalRangeLastConsistencyCheckSuffix is the suffix for the timestamp
+	// of a range's last cross-replica consistency check.
+	LocalRangeLastConsistencyCheckSuffix = proto.Key("rlcc")
 	// LocalRangeLastVerificationTimestampSuffix is the suffix for a range's
 	// last verification timestamp (for checking integrity of on-disk data).
 	LocalRangeLastVerificationTimestampSuffix = proto.Key("rlvt")
+	// LocalRangeQuarantinedSuffix is the suffix for the marker of a
+	// replica taken out of service because its data is corrupt.
+	LocalRangeQuarantinedSuffix = proto.Key("rqtn")
+	// LocalRangeSnapshotApplySuffix is the suffix for the marker of a
+	// replica whose data is being replaced by a raft snapshot.
+	LocalRangeSnapshotApplySuffix = proto.Key("rsap")
 	// LocalRangeStatsSuffix is the suffix for range statistics.
 	LocalRangeStatsSuffix = proto.Key("stat")
 
@@ -113,6 +132,22 @@ var (
 	// NOTE: if this value changes, it must be updated in C++
 	// (storage/engine/db.cc).
 	LocalTransactionSuffix = proto.Key("txn-")
+	// LocalInboxSuffix is the suffix for keys storing messages
+	// delivered to a key's inbox. The additional detail is the enqueue
+	// timestamp followed by a sequence number.
+	LocalInboxSuffix = proto.Key("inbx")
+
+	// LocalOutboxPrefix is the prefix identifying updates enqueued for
+	// asynchronous execution in the executable outbox of a key. Like
+	// the range-local data above, outbox entries are indexed by the key,
+	// encoded using EncodeBytes, which is followed by LocalOutboxSuffix
+	// and the same detail as for LocalInboxSuffix. They are kept apart
+	// from the other range-local data so that a range's pending updates
+	// are found without scanning its transaction records and message
+	// queues.
+	LocalOutboxPrefix = MakeKey(LocalPrefix, proto.Key("o"))
+	// LocalOutboxSuffix is the suffix for keys storing outbox entries.
+	LocalOutboxSuffix = proto.Key("outx")
 
 	// LocalMax is the end of the local key range.
 	LocalMax = LocalPrefix.PrefixEnd()
@@ -229,18 +229,61 @@ func DecodeRangeKey(key proto.Key) (startKey, suffix, detail proto.Key) {
 	return
 }
 
+// RangeChangeLogKey returns a range-local key for an entry of the
+// range's change log. Entries sort by timestamp; seq disambiguates
+// entries at the same timestamp.
+func RangeChangeLogKey(raftID proto.RaftID, timestamp proto.Timestamp, seq uint64) proto.Key {
+	return MakeRangeIDKey(raftID, LocalRangeChangeLogSuffix, MakeQueueDetail(timestamp, seq))
+}
+
+// RangeChangeLogPrefix returns the range-local prefix shared by all
+// entries of a range's change log.
+func RangeChangeLogPrefix(raftID proto.RaftID) proto.Key {
+	return MakeRangeIDKey(raftID, LocalRangeChangeLogSuffix, proto.Key{})
+}
+
+// RangeChangeLogStartKey returns a range-local key for the timestamp
+// above which the range's change log is complete.
+func RangeChangeLogStartKey(raftID proto.RaftID) proto.Key {
+	return MakeRangeIDKey(raftID, LocalRangeChangeLogStartSuffix, proto.Key{})
+}
+
+// RangeClosedTimestampKey returns a range-local key for the timestamp
+// at or below which the range's leader no longer serves writes.
+func RangeClosedTimestampKey(raftID proto.RaftID) proto.Key {
+	return MakeRangeIDKey(raftID, LocalRangeClosedTimestampSuffix, proto.Key{})
+}
+
 // RangeGCMetadataKey returns a range-local key for range garbage
 // collection metadata.
 func RangeGCMetadataKey(raftID proto.RaftID) proto.Key {
 	return MakeRangeIDKey(raftID, LocalRangeGCMetadataSuffix, proto.Key{})
 }
 
+// RangeLastConsistencyCheckTimestampKey returns a range-local key for
+// the timestamp of the range's last cross-replica consistency check.
+func RangeLastConsistencyCheckTimestampKey(raftID proto.RaftID) proto.Key {
+	return MakeRangeIDKey(raftID, LocalRangeLastConsistencyCheckSuffix, proto.Key{})
+}
+
 // RangeLastVerificationTimestampKey returns a range-local key for
 // the range's last verification timestamp.
 func RangeLastVerificationTimestampKey(raftID proto.RaftID) proto.Key {
 	return MakeRangeIDKey(raftID, LocalRangeLastVerificationTimestampSuffix, proto.Key{})
 }
 
+// RangeQuarantinedKey returns a range-local key for the marker of a
+// quarantined replica of the range.
+func RangeQuarantinedKey(raftID proto.RaftID) proto.Key {
+	return MakeRangeIDKey(raftID, LocalRangeQuarantinedSuffix, proto.Key{})
+}
+
+// RangeSnapshotApplyKey returns a range-local key for the marker of a
+// replica of the range which is applying a raft snapshot.
+func RangeSnapshotApplyKey(raftID proto.RaftID) proto.Key {
+	return MakeRangeIDKey(raftID, LocalRangeSnapshotApplySuffix, proto.Key{})
+}
+
 // RangeTreeNodeKey returns a range-local key for the the range's
 // node in the range tree.
 func RangeTreeNodeKey(key proto.Key) proto.Key {
@@ -260,6 +303,66 @@ func TransactionKey(key proto.Key, id []byte) proto.Key {
 	return MakeRangeKey(key, LocalTransactionSuffix, proto.Key(id))
 }
 
+// InboxPrefix returns the prefix shared by all range-local keys
+// holding messages delivered to the inbox of the specified key.
+func InboxPrefix(key proto.Key) proto.Key {
+	return MakeRangeKey(key, LocalInboxSuffix, proto.Key{})
+}
+
+// InboxKey returns a range-local key for a message delivered to the
+// inbox of the specified key, enqueued at timestamp. seq
+// disambiguates messages enqueued at the same timestamp.
+func InboxKey(key proto.Key, timestamp proto.Timestamp, seq uint64) proto.Key {
+	return MakeRangeKey(key, LocalInboxSuffix, MakeQueueDetail(timestamp, seq))
+}
+
+// OutboxPrefix returns the prefix shared by all local keys holding
+// updates enqueued for execution against the specified key.
+func OutboxPrefix(key proto.Key) proto.Key {
+	return MakeKey(LocalOutboxPrefix, encoding.EncodeBytes(nil, key), LocalOutboxSuffix)
+}
+
+// OutboxKey returns a local key for an update enqueued for execution
+// against the specified key at timestamp. seq disambiguates updates
+// enqueued at the same timestamp.
+func OutboxKey(key proto.Key, timestamp proto.Timestamp, seq uint64) proto.Key {
+	return MakeKey(OutboxPrefix(key), MakeQueueDetail(timestamp, seq))
+}
+
+// DecodeOutboxKey decodes an outbox key as created by OutboxKey into
+// the key it was created for and the detail.
+func DecodeOutboxKey(key proto.Key) (proto.Key, proto.Key) {
+	if !bytes.HasPrefix(key, LocalOutboxPrefix) {
+		panic(fmt.Sprintf("key %q does not have %q prefix", key, LocalOutboxPrefix))
+	}
+	b, k := encoding.DecodeBytes(key[len(LocalOutboxPrefix):], nil)
+	if !bytes.HasPrefix(b, LocalOutboxSuffix) {
+		panic(fmt.Sprintf("key %q does not have %q suffix", key, LocalOutboxSuffix))
+	}
+	return k, b[len(LocalOutboxSuffix):]
+}
+
+// MakeQueueDetail encodes the detail of a message queue key so that
+// entries sort by timestamp, then by sequence number.
+func MakeQueueDetail(timestamp proto.Timestamp, seq uint64) proto.Key {
+	detail := encoding.EncodeUint64(nil, uint64(timestamp.WallTime))
+	detail = encoding.EncodeUint32(detail, uint32(timestamp.Logical))
+	return encoding.EncodeUvarint(detail, seq)
+}
+
+// DecodeQueueDetail decodes the detail of a message queue key as
+// created by MakeQueueDetail into its timestamp and sequence number.
+func DecodeQueueDetail(detail proto.Key) (proto.Timestamp, uint64) {
+	// Wall time, logical time and at least one byte of sequence number.
+	if len(detail) < 8+4+1 {
+		panic(fmt.Sprintf("message queue key detail %q too short", detail))
+	}
+	b, wallTime := encoding.DecodeUint64(detail)
+	b, logical := encoding.DecodeUint32(b)
+	_, seq := encoding.DecodeUvarint(b)
+	return proto.Timestamp{WallTime: int64(wallTime), Logical: int32(logical)}, seq
+}
+
 // KeyAddress returns the address for the key, used to lookup the
 // range containing the key. In the normal case, this is simply the
 // key's value. However, for local keys, such as transaction records,
@@ -287,6 +390,11 @@ func KeyAddress(k proto.Key) proto.Key {
 		_, k = encoding.DecodeBytes(k, nil)
 		return k
 	}
+	if bytes.HasPrefix(k, LocalOutboxPrefix) {
+		k = k[len(LocalOutboxPrefix):]
+		_, k = encoding.DecodeBytes(k, nil)
+		return k
+	}
 	log.Fatalf("local key %q malformed; should contain prefix %q",
 		k, LocalRangePrefix)
 	return nil
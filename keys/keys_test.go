@@ -243,3 +243,56 @@ func TestValidateRangeMetaKey(t *testing.T) {
 		}
 	}
 }
+
+// TestQueueKeys verifies that message queue keys sort by timestamp and
+// sequence number, stay within their queue's prefix and address the
+// key they were created for.
+func TestQueueKeys(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	key := proto.Key("a")
+	timestamps := []proto.Timestamp{
+		{WallTime: 1, Logical: 0},
+		{WallTime: 1, Logical: 1},
+		{WallTime: 2, Logical: 0},
+	}
+	for _, test := range []struct {
+		prefix proto.Key
+		mkKey  func(proto.Key, proto.Timestamp, uint64) proto.Key
+	}{
+		{InboxPrefix(key), InboxKey},
+		{OutboxPrefix(key), OutboxKey},
+	} {
+		var prev proto.Key
+		for _, ts := range timestamps {
+			for seq := uint64(0); seq < 300; seq += 100 {
+				k := test.mkKey(key, ts, seq)
+				if !bytes.HasPrefix(k, test.prefix) || !k.Less(test.prefix.PrefixEnd()) {
+					t.Errorf("key %q not contained in queue prefix %q", k, test.prefix)
+				}
+				if prev != nil && !prev.Less(k) {
+					t.Errorf("expected %q < %q", prev, k)
+				}
+				if a := KeyAddress(k); !a.Equal(key) {
+					t.Errorf("expected key %q to address %q; got %q", k, key, a)
+				}
+				if dTS, dSeq := DecodeQueueDetail(k[len(test.prefix):]); !dTS.Equal(ts) || dSeq != seq {
+					t.Errorf("expected detail %s/%d; got %s/%d", ts, seq, dTS, dSeq)
+				}
+				prev = k
+			}
+		}
+	}
+	if !InboxPrefix(key).PrefixEnd().Less(OutboxPrefix(key)) {
+		t.Errorf("expected inbox and outbox of %q not to overlap", key)
+	}
+	// Outboxes are kept apart from the other range-local data.
+	if !LocalRangePrefix.PrefixEnd().Less(OutboxPrefix(proto.KeyMin)) || !OutboxPrefix(proto.KeyMax).Less(LocalMax) {
+		t.Errorf("expected outboxes between %q and %q", LocalRangePrefix.PrefixEnd(), LocalMax)
+	}
+	if a := KeyAddress(OutboxPrefix(key).PrefixEnd()); !a.Equal(key) {
+		t.Errorf("expected end of outbox to address %q; got %q", key, a)
+	}
+	if k, detail := DecodeOutboxKey(OutboxKey(key, timestamps[0], 1)); !k.Equal(key) || !detail.Equal(MakeQueueDetail(timestamps[0], 1)) {
+		t.Errorf("expected outbox key to decode to %q; got %q", key, k)
+	}
+}
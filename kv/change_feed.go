@@ -0,0 +1,132 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package kv
+
+import (
+	"encoding/binary"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/cockroachdb/cockroach/client"
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/log"
+)
+
+// ChangeFeedMethod is the name, relative to DBPrefix, of the HTTP
+// endpoint which streams the committed writes to a key span.
+const ChangeFeedMethod = "ChangeFeed"
+
+// changeFeedPollInterval is the interval at which a change feed polls
+// its key span for new writes. It is a variable so that tests may
+// lower it.
+var changeFeedPollInterval = 1 * time.Second
+
+// A changeFeed reads the committed writes to a key span in successive
+// polls. Each poll returns the writes above the resolved timestamp of
+// the previous poll, so that every write is returned exactly once and
+// in timestamp order for any given key. The resolved timestamp
+// follows the closed timestamps of the ranges holding the key span,
+// so writes are returned once their range leaders have closed them;
+// feeds only make progress on stores configured to close timestamps.
+// Ranges find the writes through their change logs, so that a poll
+// only reads the writes since the previous one.
+//
+// Versions are only retained until they're garbage collected, so a
+// feed which falls further behind than the GC TTL of its zone may miss
+// writes. Starting a feed at the zero timestamp replays the retained
+// history of the key span.
+type changeFeed struct {
+	sender client.Sender
+	args   proto.ChangesRequest
+}
+
+// newChangeFeed returns a feed for the key span of args, starting
+// after args.StartTimestamp.
+func newChangeFeed(sender client.Sender, args proto.ChangesRequest) *changeFeed {
+	return &changeFeed{sender: sender, args: args}
+}
+
+// next polls the key span for writes since the last resolved timestamp
+// and advances the feed to the newly resolved timestamp.
+func (f *changeFeed) next() (*proto.ChangesResponse, error) {
+	args := f.args
+	// A zero timestamp is assigned the current time by each range.
+	args.Timestamp = proto.ZeroTimestamp
+	reply := &proto.ChangesResponse{}
+	f.sender.Send(context.TODO(), proto.Call{Args: &args, Reply: reply})
+	if err := reply.GoError(); err != nil {
+		return nil, err
+	}
+	f.args.StartTimestamp.Forward(reply.Resolved)
+	return reply, nil
+}
+
+// serveChangeFeed streams the committed writes to the key span of args
+// to the client. Every poll of the key span writes a ChangesResponse
+// holding the new writes and the resolved timestamp up to which the
+// feed is complete; a client may resume from that checkpoint by
+// supplying it as the start timestamp of a new feed. JSON responses
+// are written back to back; protobuf responses are each prefixed by
+// their uvarint-encoded length. The stream ends when the client
+// disconnects or a poll fails.
+func (s *DBServer) serveChangeFeed(w http.ResponseWriter, r *http.Request, args *proto.ChangesRequest) {
+	var closeNotify <-chan bool
+	if cn, ok := w.(http.CloseNotifier); ok {
+		closeNotify = cn.CloseNotify()
+	}
+	feed := newChangeFeed(s.sender, *args)
+	for started := false; ; started = true {
+		reply, err := feed.next()
+		if err != nil {
+			if !started {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			log.Warningf("change feed %q-%q failed: %s", args.Key, args.EndKey, err)
+			return
+		}
+		body, contentType, err := util.MarshalResponse(r, reply, allowedEncodings)
+		if err != nil {
+			if !started {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+		if !started {
+			w.Header().Set(util.ContentTypeHeader, contentType)
+		}
+		if contentType == util.ProtoContentType {
+			var buf [binary.MaxVarintLen64]byte
+			n := binary.PutUvarint(buf[:], uint64(len(body)))
+			body = append(buf[:n], body...)
+		}
+		if _, err := w.Write(body); err != nil {
+			return
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+
+		select {
+		case <-closeNotify:
+			return
+		case <-time.After(changeFeedPollInterval):
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package kv_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/kv"
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/security"
+	"github.com/cockroachdb/cockroach/server"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/leaktest"
+	gogoproto "github.com/gogo/protobuf/proto"
+)
+
+// TestChangeFeedAcrossSplit verifies that the change feed endpoint
+// streams the writes to a key span, and keeps doing so after the key
+// span has been split between two ranges.
+func TestChangeFeedAcrossSplit(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	s := &server.TestServer{Ctx: server.NewTestContext()}
+	s.Ctx.ClosedTimestampInterval = 50 * time.Millisecond
+	s.Ctx.ClosedTimestampLag = 50 * time.Millisecond
+	if err := s.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Stop()
+	db := createTestClient(t, s.ServingAddr())
+
+	// Open the feed.
+	body, err := gogoproto.Marshal(&proto.ChangesRequest{
+		RequestHeader: proto.RequestHeader{
+			Key:    proto.Key("a"),
+			EndKey: proto.Key("z"),
+			User:   security.RootUser,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	url := s.Ctx.RequestScheme() + "://" + s.ServingAddr() + kv.DBPrefix + kv.ChangeFeedMethod
+	req, err := http.NewRequest("POST", url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(util.ContentTypeHeader, util.ProtoContentType)
+	req.Header.Set(util.AcceptHeader, util.ProtoContentType)
+	httpClient, err := s.Ctx.GetHTTPClient()
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %s", resp.Status)
+	}
+
+	// Decode the length-prefixed responses as they're streamed.
+	replies := make(chan *proto.ChangesResponse, 10)
+	go func() {
+		defer close(replies)
+		r := bufio.NewReader(resp.Body)
+		for {
+			size, err := binary.ReadUvarint(r)
+			if err != nil {
+				return
+			}
+			buf := make([]byte, size)
+			if _, err := io.ReadFull(r, buf); err != nil {
+				return
+			}
+			reply := &proto.ChangesResponse{}
+			if err := gogoproto.Unmarshal(buf, reply); err != nil {
+				t.Error(err)
+				return
+			}
+			replies <- reply
+		}
+	}()
+	// expect reads responses until the expected writes have been
+	// streamed and verifies that no other write is. Writes to different
+	// ranges may be resolved in separate responses, so their keys are
+	// compared in sorted order.
+	expect := func(expKeys ...string) {
+		var keys []string
+		timeout := time.After(10 * time.Second)
+		for len(keys) < len(expKeys) {
+			select {
+			case reply, ok := <-replies:
+				if !ok {
+					t.Fatalf("change feed ended; got %q", keys)
+				}
+				for _, e := range reply.Events {
+					keys = append(keys, string(e.Key))
+				}
+			case <-timeout:
+				t.Fatalf("timed out waiting for %q; got %q", expKeys, keys)
+			}
+		}
+		sort.Strings(keys)
+		if !reflect.DeepEqual(keys, expKeys) {
+			t.Fatalf("expected %q; got %q", expKeys, keys)
+		}
+	}
+
+	for _, key := range []string{"b", "p"} {
+		if err := db.Put(key, "1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect("b", "p")
+
+	// Split the key span. The new range inherits the change log entries
+	// of its key span; the feed carries on across both ranges.
+	if err := db.AdminSplit("m"); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"q", "c"} {
+		if err := db.Put(key, "2"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expect("c", "q")
+}
@@ -0,0 +1,107 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package kv
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/client"
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/leaktest"
+)
+
+// verifyChanges checks that the events of reply match the expected
+// keys and values, in order.
+func verifyChanges(reply *proto.ChangesResponse, expKVs ...string) error {
+	if len(reply.Events) != len(expKVs)/2 {
+		return util.Errorf("expected %d events; got %+v", len(expKVs)/2, reply.Events)
+	}
+	for i, e := range reply.Events {
+		if !e.Key.Equal(proto.Key(expKVs[2*i])) || !bytes.Equal(e.Value.Bytes, []byte(expKVs[2*i+1])) {
+			return util.Errorf("%d: expected %s=%s; got %+v", i, expKVs[2*i], expKVs[2*i+1], e)
+		}
+	}
+	return nil
+}
+
+// TestChangeFeed verifies that a change feed returns each committed
+// write to its key span exactly once and doesn't advance its resolved
+// timestamp past a pending intent.
+func TestChangeFeed(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	s := &LocalTestCluster{ClosedTimestampInterval: 10 * time.Millisecond, ClosedTimestampLag: 10 * time.Millisecond}
+	s.Start(t)
+	defer s.Stop()
+
+	feed := newChangeFeed(s.Sender, proto.ChangesRequest{
+		RequestHeader: proto.RequestHeader{Key: proto.Key("a"), EndKey: proto.Key("c")},
+	})
+	// next polls the feed until the expected writes have been returned.
+	var events []proto.ChangeEvent
+	next := func(expKVs ...string) {
+		util.SucceedsWithin(t, time.Second, func() error {
+			reply, err := feed.next()
+			if err != nil {
+				return err
+			}
+			events = append(events, reply.Events...)
+			return verifyChanges(&proto.ChangesResponse{Events: events}, expKVs...)
+		})
+		events = nil
+	}
+
+	for _, kv := range [][]string{{"a", "1"}, {"b", "2"}, {"c", "3"}, {"b", "4"}} {
+		if err := s.DB.Put(kv[0], kv[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Writes are returned once the range leader has closed their
+	// timestamps.
+	s.Manual.Increment(time.Second.Nanoseconds())
+	next("a", "1", "b", "2", "b", "4")
+
+	if err := s.DB.Txn(func(txn *client.Txn) error {
+		if err := txn.Put("a", "5"); err != nil {
+			return err
+		}
+		if err := s.DB.Put("b", "6"); err != nil {
+			return err
+		}
+		s.Manual.Increment(time.Second.Nanoseconds())
+		// The pending intent on "a" holds back the resolved timestamp,
+		// and with it the later write to "b".
+		resolved := feed.args.StartTimestamp
+		return util.IsTrueWithin(func() bool {
+			reply, err := feed.next()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := verifyChanges(reply); err != nil {
+				t.Fatal(err)
+			}
+			return resolved.Less(reply.Resolved)
+		}, time.Second)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Once the intent is resolved, both writes are returned.
+	s.Manual.Increment(time.Second.Nanoseconds())
+	next("a", "5", "b", "6")
+}
@@ -62,6 +62,12 @@ var allPublicMethods = map[string]proto.Method{
 	proto.DeleteRange.String():    proto.DeleteRange,
 	proto.Scan.String():           proto.Scan,
 	proto.EndTransaction.String(): proto.EndTransaction,
+	proto.ReapQueue.String():      proto.ReapQueue,
+	proto.EnqueueUpdate.String():  proto.EnqueueUpdate,
+	proto.EnqueueMessage.String(): proto.EnqueueMessage,
+	proto.Changes.String():        proto.Changes,
+	proto.Import.String():         proto.Import,
+	proto.SpanStats.String():      proto.SpanStats,
 	proto.Batch.String():          proto.Batch,
 	proto.AdminSplit.String():     proto.AdminSplit,
 	proto.AdminMerge.String():     proto.AdminMerge,
@@ -91,6 +97,18 @@ func createArgsAndReply(method string) (proto.Request, proto.Response) {
 			return &proto.ScanRequest{}, &proto.ScanResponse{}
 		case proto.EndTransaction:
 			return &proto.EndTransactionRequest{}, &proto.EndTransactionResponse{}
+		case proto.ReapQueue:
+			return &proto.ReapQueueRequest{}, &proto.ReapQueueResponse{}
+		case proto.EnqueueUpdate:
+			return &proto.EnqueueUpdateRequest{}, &proto.EnqueueUpdateResponse{}
+		case proto.EnqueueMessage:
+			return &proto.EnqueueMessageRequest{}, &proto.EnqueueMessageResponse{}
+		case proto.Changes:
+			return &proto.ChangesRequest{}, &proto.ChangesResponse{}
+		case proto.Import:
+			return &proto.ImportRequest{}, &proto.ImportResponse{}
+		case proto.SpanStats:
+			return &proto.SpanStatsRequest{}, &proto.SpanStatsResponse{}
 		case proto.Batch:
 			return &proto.BatchRequest{}, &proto.BatchResponse{}
 		case proto.AdminSplit:
@@ -121,7 +139,8 @@ func NewDBServer(ctx *base.Context, sender client.Sender) *DBServer {
 // and JSON-encoded requests are supported. The response body is
 // encoded according the the request's Accept header, or if not
 // present, in the same format as the request's incoming Content-Type
-// header.
+// header. The ChangeFeedMethod path accepts a ChangesRequest and
+// streams responses until the client disconnects.
 func (s *DBServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Check TLS settings before anything else.
 	authenticationHook, err := security.AuthenticationHook(s.context.Insecure, r.TLS)
@@ -136,6 +155,10 @@ func (s *DBServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	method = strings.TrimPrefix(method, DBPrefix)
+	feed := method == ChangeFeedMethod
+	if feed {
+		method = proto.Changes.String()
+	}
 	args, reply := createArgsAndReply(method)
 	if args == nil {
 		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
@@ -166,6 +189,11 @@ func (s *DBServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if feed {
+		s.serveChangeFeed(w, r, args.(*proto.ChangesRequest))
+		return
+	}
+
 	// Create a call and invoke through sender.
 	s.sender.Send(context.TODO(), proto.Call{Args: args, Reply: reply})
 
@@ -190,6 +218,12 @@ func (s *DBServer) RegisterRPC(rpcServer *rpc.Server) error {
 		&proto.DeleteRangeRequest{},
 		&proto.ScanRequest{},
 		&proto.EndTransactionRequest{},
+		&proto.ReapQueueRequest{},
+		&proto.EnqueueUpdateRequest{},
+		&proto.EnqueueMessageRequest{},
+		&proto.ChangesRequest{},
+		&proto.ImportRequest{},
+		&proto.SpanStatsRequest{},
 		&proto.BatchRequest{},
 		&proto.AdminSplitRequest{},
 		&proto.AdminMergeRequest{},
@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"sort"
+	"sync"
 	"sync/atomic"
 	"time"
 	"unsafe"
@@ -59,6 +61,9 @@ const (
 	defaultLeaderCacheSize = 1 << 16
 	// The default size of the range descriptor cache.
 	defaultRangeDescriptorCacheSize = 1 << 20
+	// The default maximum number of ranges to which the pieces of a
+	// multi-range call are sent concurrently.
+	defaultMaxParallelSends = 16
 )
 
 var defaultRPCRetryOptions = retry.Options{
@@ -120,6 +125,9 @@ type DistSender struct {
 	// outside of tests.
 	rpcSend         rpcSendFn
 	rpcRetryOptions retry.Options
+	// maxParallelSends bounds the number of pieces of a multi-range
+	// call which are sent concurrently.
+	maxParallelSends int
 }
 
 var _ client.Sender = &DistSender{}
@@ -139,6 +147,9 @@ type DistSenderContext struct {
 	RangeLookupMaxRanges int32
 	LeaderCacheSize      int32
 	RPCRetryOptions      *retry.Options
+	// MaxParallelSends sets how many ranges the pieces of a multi-range
+	// call are sent to concurrently.
+	MaxParallelSends int
 	// nodeDescriptor, if provided, is used to describe which node the DistSender
 	// lives on, for instance when deciding where to send RPCs.
 	// Usually it is filled in from the Gossip network on demand.
@@ -193,6 +204,10 @@ func NewDistSender(ctx *DistSenderContext, gossip *gossip.Gossip) *DistSender {
 	if ctx.RPCRetryOptions != nil {
 		ds.rpcRetryOptions = *ctx.RPCRetryOptions
 	}
+	ds.maxParallelSends = defaultMaxParallelSends
+	if ctx.MaxParallelSends > 0 {
+		ds.maxParallelSends = ctx.MaxParallelSends
+	}
 	return ds
 }
 
@@ -216,6 +231,19 @@ func (ds *DistSender) verifyPermissions(args proto.Request) error {
 		}
 		return nil
 	}
+	// Enqueued updates are executed later on behalf of the requesting
+	// user, so the user must have permission to execute them now.
+	if enqueue, ok := args.(*proto.EnqueueUpdateRequest); ok {
+		update, ok := enqueue.Update.GetValue().(proto.Request)
+		if !ok {
+			return util.Errorf("%s does not contain an update", args.Method())
+		}
+		update = gogoproto.Clone(update).(proto.Request)
+		update.Header().User = header.User
+		if err := ds.verifyPermissions(update); err != nil {
+			return err
+		}
+	}
 	// Get permissions map from gossip.
 	configMap, err := ds.gossip.GetInfo(gossip.KeyConfigPermission)
 	if err != nil {
@@ -374,6 +402,11 @@ func (ds *DistSender) optimizeReplicaOrder(replicas replicaSlice) rpc.OrderingPo
 		// us and hence better candidates.
 		order = rpc.OrderStable
 	}
+	// A replica on our own node is the closest of all.
+	if i := replicas.FindNode(nodeDesc.NodeID); i >= 0 {
+		replicas.MoveToFront(i)
+		order = rpc.OrderStable
+	}
 	return order
 }
 
@@ -474,6 +507,12 @@ func (ds *DistSender) sendRPC(trace *tracer.Trace, raftID proto.RaftID, replicas
 	return replies[0].(proto.Response), nil
 }
 
+// lookupRange implements the rangeLookupSender interface by looking up
+// the descriptor of the range containing key in the range cache.
+func (ds *DistSender) lookupRange(key proto.Key) (*proto.RangeDescriptor, error) {
+	return ds.rangeCache.LookupRangeDescriptor(key, lookupOptions{})
+}
+
 // getDescriptors takes a call and looks up the corresponding range
 // descriptors associated with it. First, the range descriptor for
 // call.Args.Key is looked up. If call.Args.EndKey exceeds that of the
@@ -501,10 +540,11 @@ func (ds *DistSender) getDescriptors(call proto.Call) (*proto.RangeDescriptor, *
 		}
 		// If there's no transaction and op spans ranges, possibly
 		// re-run as part of a transaction for consistency. The
-		// case where we don't need to re-run is if the read
-		// consistency is not required.
+		// cases where we don't need to re-run are if the read
+		// consistency is not required or if the op is never run
+		// in a transaction.
 		if call.Args.Header().Txn == nil &&
-			call.Args.Header().ReadConsistency != proto.INCONSISTENT {
+			!isInconsistentRead(call.Args.Header()) && !proto.IsNonTransactional(call.Args) {
 			return nil, nil, &proto.OpRequiresTxnError{}
 		}
 		// This next lookup is likely for free since we've read the
@@ -518,6 +558,13 @@ func (ds *DistSender) getDescriptors(call proto.Call) (*proto.RangeDescriptor, *
 	return desc, descNext, nil
 }
 
+// isInconsistentRead returns whether the header specifies a read which
+// doesn't observe pending intents and may span ranges without a
+// transaction.
+func isInconsistentRead(header *proto.RequestHeader) bool {
+	return header.ReadConsistency == proto.INCONSISTENT || header.ReadConsistency == proto.BOUNDED_STALENESS
+}
+
 // sendAttempt is invoked by Send. It temporarily truncates the arguments to
 // match the descriptor's EndKey (if necessary) and gathers and rearranges the
 // replicas before making a single attempt at sending the request. It returns
@@ -532,6 +579,11 @@ func (ds *DistSender) sendAttempt(trace *tracer.Trace, args proto.Request, desc
 		defer func(k proto.Key) { args.Header().EndKey = k }(endKey)
 		args.Header().EndKey = desc.EndKey
 	}
+	// Only send the rows of an import which the range is to write.
+	if importArgs, ok := args.(*proto.ImportRequest); ok {
+		defer func(rows []proto.KeyValue) { importArgs.Rows = rows }(importArgs.Rows)
+		importArgs.Rows = importRowsWithin(importArgs.Rows, desc.StartKey, desc.EndKey)
+	}
 	leader := ds.leaderCache.Lookup(proto.RaftID(desc.RaftID))
 
 	// Try to send the call.
@@ -542,9 +594,15 @@ func (ds *DistSender) sendAttempt(trace *tracer.Trace, args proto.Request, desc
 	// no-op.
 	order := ds.optimizeReplicaOrder(replicas)
 
+	// Record the node on which the request entered the cluster, which
+	// lets the range place its leader lease close to its clients.
+	if nd := atomic.LoadPointer(&ds.nodeDescriptor); nd != nil && args.Header().GatewayNodeID == 0 {
+		args.Header().GatewayNodeID = (*proto.NodeDescriptor)(nd).NodeID
+	}
+
 	// If this request needs to go to a leader and we know who that is, move
 	// it to the front.
-	if !(proto.IsRead(args) && args.Header().ReadConsistency == proto.INCONSISTENT) &&
+	if !(proto.IsRead(args) && isInconsistentRead(args.Header())) &&
 		leader.StoreID > 0 {
 		if i := replicas.FindReplica(leader.StoreID); i >= 0 {
 			replicas.MoveToFront(i)
@@ -561,7 +619,9 @@ func (ds *DistSender) sendAttempt(trace *tracer.Trace, args proto.Request, desc
 //
 // If the request spans multiple ranges (which is possible for Scan or
 // DeleteRange requests), Send sends requests to the individual ranges
-// sequentially and combines the results transparently.
+// and combines the results transparently. Requests whose number of
+// results is bounded are sent to one range after the other; all others
+// are sent to their ranges in parallel.
 //
 // This may temporarily adjust the request headers, so the proto.Call
 // must not be used concurrently until Send has returned.
@@ -577,14 +637,136 @@ func (ds *DistSender) Send(ctx context.Context, call proto.Call) {
 	trace := tracer.FromCtx(ctx)
 
 	// In the event that timestamp isn't set and read consistency isn't
-	// required, set the timestamp using the local clock.
-	if args.Header().ReadConsistency == proto.INCONSISTENT && args.Header().Timestamp.Equal(proto.ZeroTimestamp) {
+	// required, set the timestamp using the local clock. Bounded
+	// staleness reads go back in time as far as they are allowed to, so
+	// that replicas other than the leader are more likely to serve them.
+	if isInconsistentRead(args.Header()) && args.Header().Timestamp.Equal(proto.ZeroTimestamp) {
 		// Make sure that after the call, args hasn't changed.
 		defer func(timestamp proto.Timestamp) {
 			args.Header().Timestamp = timestamp
 		}(args.Header().Timestamp)
 		args.Header().Timestamp = ds.clock.Now()
+		if args.Header().ReadConsistency == proto.BOUNDED_STALENESS {
+			args.Header().Timestamp = proto.Timestamp{WallTime: args.Header().Timestamp.WallTime - args.Header().MaxStaleness}
+		}
+	}
+
+	if starts := ds.rangeSpans(call); len(starts) > 1 {
+		ds.sendParallel(trace, call, starts)
+		return
 	}
+	ds.sendSerial(trace, call)
+}
+
+// rangeSpans returns the start keys of the pieces into which the
+// supplied call is split along the boundaries of the ranges it spans.
+// Nil is returned if the call must instead be sent range by range; this
+// is the case for calls whose number of results is bounded, as well as
+// for calls which may not span ranges at all, leaving the error
+// handling to sendSerial.
+func (ds *DistSender) rangeSpans(call proto.Call) []proto.Key {
+	header := call.Args.Header()
+	if _, ok := call.Reply.(proto.Combinable); !ok || len(header.EndKey) == 0 {
+		return nil
+	}
+	if boundedArgs, ok := call.Args.(proto.Bounded); ok && boundedArgs.GetBound() > 0 {
+		return nil
+	}
+	if header.Txn == nil && !isInconsistentRead(header) && !proto.IsNonTransactional(call.Args) {
+		return nil
+	}
+	var starts []proto.Key
+	for key := header.Key; key.Less(header.EndKey); {
+		// Range lookups prefetch adjacent descriptors, so this
+		// rarely requires more than a few round trips.
+		desc, err := ds.rangeCache.LookupRangeDescriptor(key, lookupOptions{})
+		if err != nil {
+			return nil
+		}
+		starts = append(starts, key)
+		key = desc.EndKey
+	}
+	return starts
+}
+
+// sendParallel splits the supplied call at the specified start keys and
+// sends the pieces concurrently, at most maxParallelSends at a time.
+// The replies are combined in key order.
+// If any piece fails, call.Reply is set to the reply of the first
+// failing piece. Each piece is sent via sendSerial, which transparently
+// handles range splits which happened since the call was split.
+func (ds *DistSender) sendParallel(trace *tracer.Trace, call proto.Call, starts []proto.Key) {
+	calls := make([]proto.Call, len(starts))
+	traces := make([]*tracer.Trace, len(starts))
+	// The semaphore bounds the number of pieces in flight. Calls
+	// spanning many ranges would otherwise start a goroutine and an RPC
+	// for every range at once.
+	sem := make(chan struct{}, ds.maxParallelSends)
+	var wg sync.WaitGroup
+	for i, start := range starts {
+		end := call.Args.Header().EndKey
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		var args proto.Request
+		if importArgs, ok := call.Args.(*proto.ImportRequest); ok {
+			// Don't copy the rows of an import to every piece.
+			rows := importArgs.Rows
+			importArgs.Rows = importRowsWithin(rows, start, end)
+			args = gogoproto.Clone(importArgs).(proto.Request)
+			importArgs.Rows = rows
+		} else {
+			args = gogoproto.Clone(call.Args).(proto.Request)
+		}
+		args.Header().Key = start
+		args.Header().EndKey = end
+		calls[i] = proto.Call{Args: args, Reply: args.CreateReply()}
+		// A Trace is not safe for concurrent use, so each piece is
+		// traced separately.
+		traces[i] = trace.Fork()
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			ds.sendSerial(traces[i], calls[i])
+		}(i)
+	}
+	trace.Event(fmt.Sprintf("querying %d ranges in parallel", len(starts)))
+	wg.Wait()
+
+	for _, t := range traces {
+		t.Finalize()
+	}
+	for i, c := range calls {
+		if err := c.Reply.Header().GoError(); err != nil || i == 0 {
+			// Equivalent of `*call.Reply = *c.Reply`.
+			dst := reflect.ValueOf(call.Reply).Elem()
+			dst.Set(reflect.ValueOf(c.Reply).Elem())
+			if err != nil {
+				return
+			}
+			continue
+		}
+		call.Reply.(proto.Combinable).Combine(c.Reply)
+	}
+}
+
+// importRowsWithin returns the rows of an import, which are sorted by
+// key, that fall within the span from key to endKey.
+func importRowsWithin(rows []proto.KeyValue, key, endKey proto.Key) []proto.KeyValue {
+	start := sort.Search(len(rows), func(i int) bool { return !rows[i].Key.Less(key) })
+	end := sort.Search(len(rows), func(i int) bool { return !rows[i].Key.Less(endKey) })
+	return rows[start:end]
+}
+
+// sendSerial sends the supplied call to the ranges it spans one after
+// the other, combining the replies as it goes. Bounded calls stop as
+// soon as enough results have been retrieved.
+func (ds *DistSender) sendSerial(trace *tracer.Trace, call proto.Call) {
+	args := call.Args
 
 	// If this is a bounded request, we will change its bound as we receive
 	// replies. This undoes that when we return.
@@ -737,6 +919,11 @@ func (ds *DistSender) Send(ctx context.Context, call proto.Call) {
 						// We've deferred restoring the original bound earlier.
 						boundedArgs.SetBound(nextBound)
 					} else {
+						// If the bound was exhausted before the end of the
+						// requested key range, tell the caller where to resume.
+						if sReply, ok := call.Reply.(*proto.ScanResponse); ok {
+							sReply.SetResumeKey(args.Header().EndKey)
+						}
 						// Set flag to break the loop.
 						descNext = nil
 					}
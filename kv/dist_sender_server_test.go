@@ -18,6 +18,7 @@
 package kv_test
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
@@ -182,6 +183,59 @@ func TestMultiRangeScanInconsistent(t *testing.T) {
 	}
 }
 
+// TestMultiRangeScanResumeKey verifies that a scan across ranges
+// truncated by its max results returns a resume key, and that
+// DB.Iterate uses it to visit all keys across ranges page by page.
+func TestMultiRangeScanResumeKey(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	s, db := setupMultipleRanges(t, "c")
+	defer s.Stop()
+
+	keys := []string{"a", "b", "c", "d", "e"}
+	for _, key := range keys {
+		if err := db.Put(key, "value"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A scan truncated within the second range resumes after its last row.
+	b := &client.Batch{}
+	b.Scan("a", "q", 3)
+	if err := db.Run(b); err != nil {
+		t.Fatal(err)
+	}
+	if key := string(b.Results[0].ResumeKey); key != "c\x00" {
+		t.Errorf("expected resume key %q; got %q", "c\x00", key)
+	}
+	// A scan which returns everything has no resume key.
+	b = &client.Batch{}
+	b.Scan("a", "q", 5)
+	if err := db.Run(b); err != nil {
+		t.Fatal(err)
+	}
+	if key := b.Results[0].ResumeKey; key != nil {
+		t.Errorf("expected no resume key; got %q", key)
+	}
+
+	for _, pageSize := range []int64{1, 2, 3, 10} {
+		var visited []string
+		if err := db.Iterate("a", "q", pageSize, func(rows []client.KeyValue) error {
+			if int64(len(rows)) > pageSize {
+				t.Errorf("page of %d rows exceeds page size %d", len(rows), pageSize)
+			}
+			for _, row := range rows {
+				visited = append(visited, string(row.Key))
+			}
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(visited, keys) {
+			t.Errorf("page size %d: expected keys %q; got %q", pageSize, keys, visited)
+		}
+	}
+}
+
 // TestStartEqualsEndKeyScan verifies that specifying start==end on scan
 // returns an empty set.
 func TestStartEqualsEndKeyScan(t *testing.T) {
@@ -23,6 +23,8 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -146,6 +148,7 @@ func TestSendRPCOrder(t *testing.T) {
 		// Likely a test setup here will never have a read lease, but good
 		// to keep in mind.
 		consistent bool
+		bounded    bool // bounded staleness read
 	}{
 		// Inconsistent Scan without matching attributes.
 		{
@@ -213,6 +216,17 @@ func TestSendRPCOrder(t *testing.T) {
 			expReplica: []int32{1, 2, 3, 4, 5},
 			leader:     2,
 		},
+		// Bounded staleness Get with matching attributes and leader (node 2).
+		// Should ignore the leader and prefer the nodes matching the
+		// attributes.
+		{
+			args:       &proto.GetRequest{},
+			attrs:      nodeAttrs[5],
+			order:      rpc.OrderStable,
+			expReplica: []int32{5, 4, 0, 0, 0},
+			leader:     2,
+			bounded:    true,
+		},
 	}
 
 	descriptor := proto.RangeDescriptor{
@@ -289,6 +303,10 @@ func TestSendRPCOrder(t *testing.T) {
 		if !tc.consistent {
 			args.Header().ReadConsistency = proto.INCONSISTENT
 		}
+		if tc.bounded {
+			args.Header().ReadConsistency = proto.BOUNDED_STALENESS
+			args.Header().MaxStaleness = time.Second.Nanoseconds()
+		}
 		// Kill the cached NodeDescriptor, enforcing a lookup from Gossip.
 		ds.nodeDescriptor = nil
 		call := proto.Call{Args: args, Reply: args.CreateReply()}
@@ -883,3 +901,224 @@ func TestMultiRangeMergeStaleDescriptor(t *testing.T) {
 		t.Fatalf("expect get %v, actual get %v", existingKVs, reply.Rows)
 	}
 }
+
+// TestMultiRangeParallelScan verifies that an unbounded scan spanning
+// multiple ranges is sent to all of them concurrently and that the
+// replies are combined in key order.
+func TestMultiRangeParallelScan(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	g, s := makeTestGossip(t)
+	defer s()
+	splits := []proto.Key{proto.Key("a"), proto.Key("b"), proto.Key("c"), proto.Key("d"), proto.KeyMax}
+	var descs []proto.RangeDescriptor
+	for i := 0; i < len(splits)-1; i++ {
+		descs = append(descs, proto.RangeDescriptor{
+			RaftID:   proto.RaftID(i + 1),
+			StartKey: splits[i],
+			EndKey:   splits[i+1],
+			Replicas: []proto.Replica{
+				{
+					NodeID:  1,
+					StoreID: 1,
+				},
+			},
+		})
+	}
+	// Every range holds one key, which is returned in a separate row.
+	existingKVs := []proto.KeyValue{
+		{Key: proto.Key("a1"), Value: proto.Value{Bytes: []byte("1")}},
+		{Key: proto.Key("b1"), Value: proto.Value{Bytes: []byte("2")}},
+		{Key: proto.Key("c1"), Value: proto.Value{Bytes: []byte("3")}},
+		{Key: proto.Key("d1"), Value: proto.Value{Bytes: []byte("4")}},
+	}
+	// Each RPC blocks until all of them have been sent, which only
+	// happens if they are sent in parallel.
+	var wg sync.WaitGroup
+	wg.Add(len(descs))
+	var testFn rpcSendFn = func(_ rpc.Options, method string, addrs []net.Addr, getArgs func(addr net.Addr) gogoproto.Message, getReply func() gogoproto.Message, _ *rpc.Context) ([]gogoproto.Message, error) {
+		if method != "Node.Scan" {
+			return nil, util.Errorf("unexpected method: %s", method)
+		}
+		header := getArgs(testAddress).(proto.Request).Header()
+		wg.Done()
+		wg.Wait()
+		reply := getReply().(*proto.ScanResponse)
+		for _, curKV := range existingKVs {
+			if header.Key.Less(curKV.Key.Next()) && curKV.Key.Less(header.EndKey) {
+				reply.Rows = append(reply.Rows, curKV)
+			}
+		}
+		return []gogoproto.Message{reply}, nil
+	}
+	ctx := &DistSenderContext{
+		rpcSend: testFn,
+		rangeDescriptorDB: mockRangeDescriptorDB(func(key proto.Key, _ lookupOptions) ([]proto.RangeDescriptor, error) {
+			for _, desc := range descs {
+				if desc.ContainsKey(key) {
+					return []proto.RangeDescriptor{desc}, nil
+				}
+			}
+			return nil, util.Errorf("no descriptor for key %q", key)
+		}),
+	}
+	ds := NewDistSender(ctx, g)
+	call := proto.ScanCall(proto.Key("a"), proto.Key("e"), 0)
+	// Set the Txn info to avoid an OpRequiresTxnError.
+	call.Args.Header().Txn = &proto.Transaction{}
+	reply := call.Reply.(*proto.ScanResponse)
+	done := make(chan struct{})
+	go func() {
+		ds.Send(context.Background(), call)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scan was not sent to all ranges in parallel")
+	}
+	if err := reply.GoError(); err != nil {
+		t.Fatalf("scan encountered error: %s", err)
+	}
+	if !reflect.DeepEqual(existingKVs, reply.Rows) {
+		t.Fatalf("expected %v, got %v", existingKVs, reply.Rows)
+	}
+	// The call's key range must not have been modified.
+	if header := call.Args.Header(); !header.Key.Equal(proto.Key("a")) || !header.EndKey.Equal(proto.Key("e")) {
+		t.Errorf("expected scan of a-e; got %s-%s", header.Key, header.EndKey)
+	}
+}
+
+// TestMultiRangeParallelScanBounded verifies that no more than the
+// configured number of pieces of a multi-range scan are sent at once.
+func TestMultiRangeParallelScanBounded(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	g, s := makeTestGossip(t)
+	defer s()
+	const maxParallel = 2
+	var descs []proto.RangeDescriptor
+	for i := 0; i < 8; i++ {
+		desc := proto.RangeDescriptor{
+			RaftID:   proto.RaftID(i + 1),
+			StartKey: proto.Key(fmt.Sprintf("%d", i)),
+			EndKey:   proto.Key(fmt.Sprintf("%d", i+1)),
+			Replicas: []proto.Replica{{NodeID: 1, StoreID: 1}},
+		}
+		if i == 0 {
+			desc.StartKey = proto.KeyMin
+		}
+		descs = append(descs, desc)
+	}
+	descs[len(descs)-1].EndKey = proto.KeyMax
+	var inflight, maxInflight int32
+	var testFn rpcSendFn = func(_ rpc.Options, method string, addrs []net.Addr, getArgs func(addr net.Addr) gogoproto.Message, getReply func() gogoproto.Message, _ *rpc.Context) ([]gogoproto.Message, error) {
+		n := atomic.AddInt32(&inflight, 1)
+		defer atomic.AddInt32(&inflight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInflight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInflight, m, n) {
+				break
+			}
+		}
+		// Give the other pieces a chance to be sent meanwhile.
+		time.Sleep(5 * time.Millisecond)
+		return []gogoproto.Message{getReply()}, nil
+	}
+	ctx := &DistSenderContext{
+		MaxParallelSends: maxParallel,
+		rpcSend:          testFn,
+		rangeDescriptorDB: mockRangeDescriptorDB(func(key proto.Key, _ lookupOptions) ([]proto.RangeDescriptor, error) {
+			for _, desc := range descs {
+				if desc.ContainsKey(key) {
+					return []proto.RangeDescriptor{desc}, nil
+				}
+			}
+			return nil, util.Errorf("no descriptor for key %q", key)
+		}),
+	}
+	ds := NewDistSender(ctx, g)
+	call := proto.ScanCall(proto.Key("0"), proto.Key("9"), 0)
+	// Set the Txn info to avoid an OpRequiresTxnError.
+	call.Args.Header().Txn = &proto.Transaction{}
+	ds.Send(context.Background(), call)
+	if err := call.Reply.Header().GoError(); err != nil {
+		t.Fatalf("scan encountered error: %s", err)
+	}
+	if m := atomic.LoadInt32(&maxInflight); m > maxParallel {
+		t.Errorf("expected at most %d pieces in flight; got %d", maxParallel, m)
+	} else if m < 2 {
+		t.Errorf("expected pieces to be sent in parallel; got at most %d in flight", m)
+	}
+}
+
+// TestMultiRangeImport verifies that each range spanned by an import
+// is only sent the rows within its key range, and that the rows of the
+// call are left unmodified.
+func TestMultiRangeImport(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	g, s := makeTestGossip(t)
+	defer s()
+	descs := []proto.RangeDescriptor{
+		{RaftID: 1, StartKey: proto.KeyMin, EndKey: proto.Key("b"), Replicas: []proto.Replica{{NodeID: 1, StoreID: 1}}},
+		{RaftID: 2, StartKey: proto.Key("b"), EndKey: proto.Key("c"), Replicas: []proto.Replica{{NodeID: 1, StoreID: 1}}},
+		{RaftID: 3, StartKey: proto.Key("c"), EndKey: proto.KeyMax, Replicas: []proto.Replica{{NodeID: 1, StoreID: 1}}},
+	}
+	rows := []proto.KeyValue{
+		{Key: proto.Key("a1"), Value: proto.Value{Bytes: []byte("1")}},
+		{Key: proto.Key("a2"), Value: proto.Value{Bytes: []byte("2")}},
+		{Key: proto.Key("c1"), Value: proto.Value{Bytes: []byte("3")}},
+	}
+	var mu sync.Mutex
+	sent := map[string][]proto.KeyValue{}
+	var testFn rpcSendFn = func(_ rpc.Options, method string, addrs []net.Addr, getArgs func(addr net.Addr) gogoproto.Message, getReply func() gogoproto.Message, _ *rpc.Context) ([]gogoproto.Message, error) {
+		if method != "Node.Import" {
+			return nil, util.Errorf("unexpected method: %s", method)
+		}
+		args := getArgs(testAddress).(*proto.ImportRequest)
+		mu.Lock()
+		sent[string(args.Key)] = args.Rows
+		mu.Unlock()
+		reply := getReply().(*proto.ImportResponse)
+		reply.NumImported = int64(len(args.Rows))
+		return []gogoproto.Message{reply}, nil
+	}
+	ctx := &DistSenderContext{
+		rpcSend: testFn,
+		rangeDescriptorDB: mockRangeDescriptorDB(func(key proto.Key, _ lookupOptions) ([]proto.RangeDescriptor, error) {
+			for _, desc := range descs {
+				if desc.ContainsKey(key) {
+					return []proto.RangeDescriptor{desc}, nil
+				}
+			}
+			return nil, util.Errorf("no descriptor for key %q", key)
+		}),
+	}
+	ds := NewDistSender(ctx, g)
+	call := proto.Call{
+		Args: &proto.ImportRequest{
+			RequestHeader: proto.RequestHeader{
+				Key:    proto.Key("a1"),
+				EndKey: proto.Key("c1").Next(),
+			},
+			Rows: rows,
+		},
+		Reply: &proto.ImportResponse{},
+	}
+	ds.Send(context.Background(), call)
+	if err := call.Reply.Header().GoError(); err != nil {
+		t.Fatal(err)
+	}
+	if n := call.Reply.(*proto.ImportResponse).NumImported; n != int64(len(rows)) {
+		t.Errorf("expected %d rows to be imported; got %d", len(rows), n)
+	}
+	expSent := map[string][]proto.KeyValue{
+		"a1": rows[:2],
+		"b":  nil,
+		"c":  rows[2:],
+	}
+	if !reflect.DeepEqual(expSent, sent) {
+		t.Errorf("expected rows %v to be sent; got %v", expSent, sent)
+	}
+	if importArgs := call.Args.(*proto.ImportRequest); !reflect.DeepEqual(rows, importArgs.Rows) {
+		t.Errorf("expected the rows of the call to be unmodified; got %v", importArgs.Rows)
+	}
+}
@@ -201,3 +201,16 @@ func (ls *LocalSender) lookupReplica(start, end proto.Key) (raftID proto.RaftID,
 	}
 	return raftID, replica, err
 }
+
+// lookupRange implements the rangeLookupSender interface by returning
+// the descriptor of the local range containing key.
+func (ls *LocalSender) lookupRange(key proto.Key) (*proto.RangeDescriptor, error) {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+	for _, store := range ls.storeMap {
+		if rng := store.LookupRange(key, nil); rng != nil {
+			return rng.Desc(), nil
+		}
+	}
+	return nil, proto.NewRangeKeyMismatchError(key, nil, nil)
+}
@@ -19,6 +19,7 @@ package kv
 
 import (
 	"fmt"
+	"time"
 
 	"golang.org/x/net/context"
 
@@ -98,6 +99,12 @@ func (rls *retryableLocalSender) Send(_ context.Context, call proto.Call) {
 // in that it doesn't use a distributed sender and doesn't start a
 // server node. There is no RPC traffic.
 type LocalTestCluster struct {
+	// ClosedTimestampInterval, if set before Start, has the store's
+	// range leaders close timestamps at that interval, ClosedTimestampLag
+	// behind the present.
+	ClosedTimestampInterval time.Duration
+	ClosedTimestampLag      time.Duration
+
 	Manual  *hlc.ManualClock
 	Clock   *hlc.Clock
 	Gossip  *gossip.Gossip
@@ -134,6 +141,8 @@ func (ltc *LocalTestCluster) Start(t util.Tester) {
 	ctx.DB = ltc.DB
 	ctx.Gossip = ltc.Gossip
 	ctx.Transport = transport
+	ctx.ClosedTimestampInterval = ltc.ClosedTimestampInterval
+	ctx.ClosedTimestampLag = ltc.ClosedTimestampLag
 	ltc.Store = storage.NewStore(ctx, ltc.Eng, &proto.NodeDescriptor{NodeID: 1})
 	if err := ltc.Store.Bootstrap(proto.StoreIdent{NodeID: 1, StoreID: 1}, ltc.Stopper); err != nil {
 		t.Fatalf("unable to start local test cluster: %s", err)
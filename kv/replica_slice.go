@@ -75,6 +75,17 @@ func (rs replicaSlice) FindReplica(storeID proto.StoreID) int {
 	return -1
 }
 
+// FindNode returns the index of the first replica located on the
+// specified node. If no replica matches, -1 is returned.
+func (rs replicaSlice) FindNode(nodeID proto.NodeID) int {
+	for i := range rs {
+		if rs[i].NodeID == nodeID {
+			return i
+		}
+	}
+	return -1
+}
+
 // SortByCommonAttributePrefix rearranges the replicaSlice by comparing the
 // attributes to the given reference attributes. The basis for the comparison
 // is that of the common prefix of replica attributes (i.e. the number of equal
@@ -20,6 +20,7 @@ package kv
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -137,9 +138,26 @@ func (tm *txnMetadata) hasClientAbandonedCoord(nowNanos int64) bool {
 	return tm.getLastUpdate() < timeout
 }
 
+// A rangeLookupSender is a client.Sender which can look up the
+// descriptor of the range containing a key. If the sender wrapped by a
+// TxnCoordSender implements it, intents are resolved range by range.
+type rangeLookupSender interface {
+	client.Sender
+	lookupRange(key proto.Key) (*proto.RangeDescriptor, error)
+}
+
 // resolve sends resolve intent commands for all key ranges this transaction
 // has covered. Any keys listed in the resolved slice have already been
 // resolved and are skipped.
+//
+// Intents on meta records are resolved first, one after the other and
+// in key order: meta1 records address meta2 records, which in turn
+// address all other keys, so they must be resolved before the
+// remaining intents can be routed to the correct range. The remaining
+// point intents are grouped by range if the sender supports range
+// lookups, and each group is resolved by a single
+// InternalResolveIntentRange command spanning the group's keys. All of
+// these commands are sent in parallel.
 func (tm *txnMetadata) resolve(trace *tracer.Trace, resolved []proto.Key, sender client.Sender) {
 	txn := &tm.txn
 	if tm.keys.Len() > 0 {
@@ -147,72 +165,143 @@ func (tm *txnMetadata) resolve(trace *tracer.Trace, resolved []proto.Key, sender
 			log.Infof("cleaning up %d intent(s) for transaction %s", tm.keys.Len(), txn)
 		}
 	}
-	// TODO(tschottdorf): Should create a Batch here. However, we're resolving
-	// intents and if those are on meta records, there may be a certain order
-	// in which they need to be resolved so that they can get routed to the
-	// correct range. Since a batch runs its commands one by one and we don't
-	// know the correct order, we prefer to fire them off in parallel.
-	var wg sync.WaitGroup
+	var calls []proto.Call
+	var metaKeys, points []proto.Key
 	for _, o := range tm.keys.GetOverlaps(proto.KeyMin, proto.KeyMax) {
 		// If the op was range based, end key != start key: resolve a range.
-		var call proto.Call
 		key := o.Key.Start().(proto.Key)
 		endKey := o.Key.End().(proto.Key)
 		if !key.Next().Equal(endKey) {
-			call.Args = &proto.InternalResolveIntentRangeRequest{
-				RequestHeader: proto.RequestHeader{
-					Timestamp: txn.Timestamp,
-					Key:       key,
-					EndKey:    endKey,
-					User:      security.RootUser,
-					Txn:       txn,
-				},
-			}
-			call.Reply = &proto.InternalResolveIntentRangeResponse{}
-		} else {
-			// Check if the key has already been resolved; skip if yes.
-			found := false
-			for _, k := range resolved {
-				if key.Equal(k) {
-					if log.V(2) {
-						log.Warningf("skipping previously resolved intent at %q", k)
-					}
-					found = true
+			calls = append(calls, resolveIntentCall(txn, key, endKey))
+			continue
+		}
+		// Check if the key has already been resolved; skip if yes.
+		found := false
+		for _, k := range resolved {
+			if key.Equal(k) {
+				if log.V(2) {
+					log.Warningf("skipping previously resolved intent at %q", k)
 				}
+				found = true
 			}
-			if found {
-				continue
-			}
-			call.Args = &proto.InternalResolveIntentRequest{
-				RequestHeader: proto.RequestHeader{
-					Timestamp: txn.Timestamp,
-					Key:       key,
-					User:      security.RootUser,
-					Txn:       txn,
-				},
-			}
-			call.Reply = &proto.InternalResolveIntentResponse{}
 		}
-		ctx := tracer.ToCtx(context.Background(), trace.Fork())
-		if log.V(2) {
-			log.Infof("cleaning up intent %q for txn %s", call.Args.Header().Key, txn)
+		if found {
+			continue
+		}
+		switch {
+		case !key.Less(keys.MetaPrefix) && key.Less(keys.MetaMax):
+			metaKeys = append(metaKeys, key)
+		case key.Less(keys.LocalMax):
+			// Range-local keys are addressed by the key they embed and
+			// can't be part of a key range with other keys.
+			calls = append(calls, resolveIntentCall(txn, key, nil))
+		default:
+			points = append(points, key)
 		}
+	}
+	sort.Sort(proto.KeySlice(points))
+	calls = append(calls, groupIntentsByRange(txn, points, sender)...)
+
+	// Sorting places meta1 records before meta2 records.
+	sort.Sort(proto.KeySlice(metaKeys))
+	for _, key := range metaKeys {
+		sendResolveIntentCall(trace.Fork(), resolveIntentCall(txn, key, nil), sender)
+	}
+	var wg sync.WaitGroup
+	for _, call := range calls {
 		// Each operation gets their own goroutine. We only want to return to
 		// the caller after the operations have finished.
 		wg.Add(1)
-		go func() {
-			sender.Send(ctx, call)
-			wg.Done()
-			if call.Reply.Header().Error != nil {
-				log.Warningf("failed to cleanup %q intent: %s", call.Args.Header().Key, call.Reply.Header().GoError())
-			}
-		}()
+		go func(call proto.Call, trace *tracer.Trace) {
+			defer wg.Done()
+			sendResolveIntentCall(trace, call, sender)
+		}(call, trace.Fork())
 	}
 	defer trace.Epoch("waiting for intent resolution")()
 	wg.Wait()
 	tm.keys.Clear()
 }
 
+// groupIntentsByRange returns the calls which resolve the intents at
+// the specified sorted keys. If the sender supports range lookups,
+// keys in the same range are resolved by a single call spanning them;
+// otherwise, or if a lookup fails, each key is resolved individually.
+func groupIntentsByRange(txn *proto.Transaction, points []proto.Key, sender client.Sender) []proto.Call {
+	var calls []proto.Call
+	lSender, ok := sender.(rangeLookupSender)
+	if !ok {
+		for _, key := range points {
+			calls = append(calls, resolveIntentCall(txn, key, nil))
+		}
+		return calls
+	}
+	var desc *proto.RangeDescriptor
+	var group []proto.Key
+	flush := func() {
+		switch len(group) {
+		case 0:
+		case 1:
+			calls = append(calls, resolveIntentCall(txn, group[0], nil))
+		default:
+			calls = append(calls, resolveIntentCall(txn, group[0], group[len(group)-1].Next()))
+		}
+		group = nil
+	}
+	for _, key := range points {
+		if desc == nil || !desc.ContainsKey(key) {
+			flush()
+			var err error
+			if desc, err = lSender.lookupRange(key); err != nil {
+				if log.V(1) {
+					log.Warningf("unable to look up range of intent %q: %s", key, err)
+				}
+				desc = nil
+			}
+		}
+		group = append(group, key)
+		if desc == nil {
+			flush()
+		}
+	}
+	flush()
+	return calls
+}
+
+// resolveIntentCall returns a call which resolves the intent of txn at
+// key or, if endKey is set, the intents in the range [key, endKey).
+func resolveIntentCall(txn *proto.Transaction, key, endKey proto.Key) proto.Call {
+	header := proto.RequestHeader{
+		Timestamp: txn.Timestamp,
+		Key:       key,
+		EndKey:    endKey,
+		User:      security.RootUser,
+		Txn:       txn,
+	}
+	if endKey != nil {
+		return proto.Call{
+			Args:  &proto.InternalResolveIntentRangeRequest{RequestHeader: header},
+			Reply: &proto.InternalResolveIntentRangeResponse{},
+		}
+	}
+	return proto.Call{
+		Args:  &proto.InternalResolveIntentRequest{RequestHeader: header},
+		Reply: &proto.InternalResolveIntentResponse{},
+	}
+}
+
+// sendResolveIntentCall sends the supplied resolve intent call and logs
+// any error, as intents left behind are eventually cleaned up by
+// readers or the GC queue.
+func sendResolveIntentCall(trace *tracer.Trace, call proto.Call, sender client.Sender) {
+	if log.V(2) {
+		log.Infof("cleaning up intent %q for txn %s", call.Args.Header().Key, call.Args.Header().Txn)
+	}
+	sender.Send(tracer.ToCtx(context.Background(), trace), call)
+	if call.Reply.Header().Error != nil {
+		log.Warningf("failed to cleanup %q intent: %s", call.Args.Header().Key, call.Reply.Header().GoError())
+	}
+}
+
 // txnCoordStats tallies up statistics about the transactions which have
 // completed on this sender.
 type txnCoordStats struct {
@@ -404,6 +493,8 @@ func (tc *TxnCoordSender) maybeBeginTxn(header *proto.RequestHeader) {
 			if newTxn.Priority < header.Txn.Priority {
 				newTxn.Priority = header.Txn.Priority
 			}
+			// Keep the sequence number of the writes the client is sending.
+			newTxn.Sequence = header.Txn.Sequence
 			header.Txn = newTxn
 		}
 	}
@@ -22,6 +22,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -29,10 +31,12 @@ import (
 	"golang.org/x/net/context"
 
 	"github.com/cockroachdb/cockroach/client"
+	"github.com/cockroachdb/cockroach/keys"
 	"github.com/cockroachdb/cockroach/proto"
 	"github.com/cockroachdb/cockroach/security"
 	"github.com/cockroachdb/cockroach/storage/engine"
 	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/cache"
 	"github.com/cockroachdb/cockroach/util/hlc"
 	"github.com/cockroachdb/cockroach/util/leaktest"
 	"github.com/cockroachdb/cockroach/util/stop"
@@ -784,3 +788,75 @@ func TestTxnCoordIdempotentCleanup(t *testing.T) {
 		t.Fatal(etReply.GoError())
 	}
 }
+
+// testRangeLookupSender is a testSender which also implements
+// rangeLookupSender.
+type testRangeLookupSender struct {
+	*testSender
+	descs []proto.RangeDescriptor
+}
+
+func (ts *testRangeLookupSender) lookupRange(key proto.Key) (*proto.RangeDescriptor, error) {
+	for i := range ts.descs {
+		if ts.descs[i].ContainsKey(key) {
+			return &ts.descs[i], nil
+		}
+	}
+	return nil, util.Errorf("no range contains key %q", key)
+}
+
+// TestTxnCoordSenderResolveByRange verifies that intents are resolved
+// with one command per range, that intents on meta records are
+// resolved first and that previously resolved intents are skipped.
+func TestTxnCoordSenderResolveByRange(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	meta2Key := keys.RangeMetaKey(proto.Key("z"))
+	localKey := keys.RangeDescriptorKey(proto.Key("a"))
+	tm := &txnMetadata{
+		txn:  proto.Transaction{ID: []byte("txn")},
+		keys: cache.NewIntervalCache(cache.Config{Policy: cache.CacheNone}),
+	}
+	for _, key := range []string{"e", "b", "a", "d", "c", string(meta2Key), string(localKey)} {
+		tm.addKeyRange(proto.Key(key), nil)
+	}
+	tm.addKeyRange(proto.Key("x"), proto.Key("y"))
+
+	var mu sync.Mutex
+	var sent []string
+	sender := &testRangeLookupSender{
+		testSender: newTestSender(func(call proto.Call) {
+			mu.Lock()
+			defer mu.Unlock()
+			header := call.Args.Header()
+			sent = append(sent, fmt.Sprintf("%s %q-%q", call.Method(), header.Key, header.EndKey))
+		}),
+		descs: []proto.RangeDescriptor{
+			{RaftID: 1, StartKey: proto.KeyMin, EndKey: proto.Key("c")},
+			{RaftID: 2, StartKey: proto.Key("c"), EndKey: proto.Key("e")},
+			{RaftID: 3, StartKey: proto.Key("e"), EndKey: proto.KeyMax},
+		},
+	}
+	tm.resolve(nil, []proto.Key{proto.Key("e")}, sender)
+
+	call := func(method proto.Method, key, endKey proto.Key) string {
+		return fmt.Sprintf("%s %q-%q", method, key, endKey)
+	}
+	if len(sent) == 0 || sent[0] != call(proto.InternalResolveIntent, meta2Key, nil) {
+		t.Fatalf("expected meta record to be resolved first; got %q", sent)
+	}
+	expected := []string{
+		call(proto.InternalResolveIntent, localKey, nil),
+		call(proto.InternalResolveIntentRange, proto.Key("a"), proto.Key("b").Next()),
+		call(proto.InternalResolveIntentRange, proto.Key("c"), proto.Key("d").Next()),
+		call(proto.InternalResolveIntentRange, proto.Key("x"), proto.Key("y")),
+	}
+	rest := sent[1:]
+	sort.Strings(rest)
+	sort.Strings(expected)
+	if !reflect.DeepEqual(rest, expected) {
+		t.Errorf("expected resolve calls %q; got %q", expected, rest)
+	}
+	if tm.keys.Len() != 0 {
+		t.Errorf("expected intents to be cleared; %d remain", tm.keys.Len())
+	}
+}
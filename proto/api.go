@@ -51,6 +51,7 @@ const (
 	isWrite
 	isTxnWrite
 	isRange
+	isNonTxn
 )
 
 // IsAdmin returns true if the request requires admin permissions.
@@ -86,6 +87,12 @@ func IsRange(args Request) bool {
 	return (args.flags() & isRange) != 0
 }
 
+// IsNonTransactional returns true if the request may not be used
+// within a transaction. Such requests may span ranges without one.
+func IsNonTransactional(args Request) bool {
+	return (args.flags() & isNonTxn) != 0
+}
+
 // Request is an interface for RPC requests.
 type Request interface {
 	gogoproto.Message
@@ -190,6 +197,64 @@ func (rr *InternalResolveIntentRangeResponse) Combine(c Response) {
 	}
 }
 
+// Combine implements the Combinable interface for ChangesResponse. The
+// combined resolved timestamp is the minimum over all responses; events
+// above it are dropped, as they may yet be preceded by writes to other
+// parts of the span which have not been resolved.
+func (cr *ChangesResponse) Combine(c Response) {
+	otherCR := c.(*ChangesResponse)
+	if cr != nil {
+		if otherCR.Resolved.Less(cr.Resolved) {
+			cr.Resolved = otherCR.Resolved
+		}
+		events := append(cr.Events, otherCR.Events...)
+		cr.Events = events[:0]
+		for _, e := range events {
+			if !cr.Resolved.Less(*e.Value.Timestamp) {
+				cr.Events = append(cr.Events, e)
+			}
+		}
+		cr.Header().Combine(otherCR.Header())
+	}
+}
+
+// Combine implements the Combinable interface for ImportResponse.
+func (ir *ImportResponse) Combine(c Response) {
+	otherIR := c.(*ImportResponse)
+	if ir != nil {
+		ir.NumImported += otherIR.NumImported
+		ir.Header().Combine(otherIR.Header())
+	}
+}
+
+// Combine implements the Combinable interface for SpanStatsResponse.
+func (sr *SpanStatsResponse) Combine(c Response) {
+	otherSR := c.(*SpanStatsResponse)
+	if sr != nil {
+		sr.LiveBytes += otherSR.LiveBytes
+		sr.KeyBytes += otherSR.KeyBytes
+		sr.ValBytes += otherSR.ValBytes
+		sr.IntentBytes += otherSR.IntentBytes
+		sr.LiveCount += otherSR.LiveCount
+		sr.KeyCount += otherSR.KeyCount
+		sr.ValCount += otherSR.ValCount
+		sr.IntentCount += otherSR.IntentCount
+		sr.GCBytesAge += otherSR.GCBytesAge
+		sr.SysBytes += otherSR.SysBytes
+		sr.SysCount += otherSR.SysCount
+		sr.RangeCount += otherSR.RangeCount
+		sr.ScannedRangeCount += otherSR.ScannedRangeCount
+		sr.Header().Combine(otherSR.Header())
+	}
+}
+
+// GCBytes returns the number of bytes in the span which are not live
+// and may be garbage collected once old enough: deleted and
+// overwritten versions along with their keys.
+func (sr *SpanStatsResponse) GCBytes() int64 {
+	return sr.KeyBytes + sr.ValBytes - sr.LiveBytes
+}
+
 // Header implements the Request interface for RequestHeader.
 func (rh *RequestHeader) Header() *RequestHeader {
 	return rh
@@ -327,6 +392,19 @@ func (sr *ScanResponse) Count() int64 {
 	return int64(len(sr.Rows))
 }
 
+// SetResumeKey sets ResumeKey to the key following the last row of a
+// scan which was truncated by its bound. ResumeKey is cleared if no
+// rows were returned or if the scan already reached endKey.
+func (sr *ScanResponse) SetResumeKey(endKey Key) {
+	sr.ResumeKey = nil
+	if len(sr.Rows) == 0 {
+		return
+	}
+	if next := sr.Rows[len(sr.Rows)-1].Key.Next(); next.Less(endKey) {
+		sr.ResumeKey = next
+	}
+}
+
 // Method implements the Request interface.
 func (*GetRequest) Method() Method { return Get }
 
@@ -351,6 +429,24 @@ func (*ScanRequest) Method() Method { return Scan }
 // Method implements the Request interface.
 func (*EndTransactionRequest) Method() Method { return EndTransaction }
 
+// Method implements the Request interface.
+func (*ReapQueueRequest) Method() Method { return ReapQueue }
+
+// Method implements the Request interface.
+func (*EnqueueUpdateRequest) Method() Method { return EnqueueUpdate }
+
+// Method implements the Request interface.
+func (*EnqueueMessageRequest) Method() Method { return EnqueueMessage }
+
+// Method implements the Request interface.
+func (*ChangesRequest) Method() Method { return Changes }
+
+// Method implements the Request interface.
+func (*ImportRequest) Method() Method { return Import }
+
+// Method implements the Request interface.
+func (*SpanStatsRequest) Method() Method { return SpanStats }
+
 // Method implements the Request interface.
 func (*BatchRequest) Method() Method { return Batch }
 
@@ -387,6 +483,21 @@ func (*InternalLeaderLeaseRequest) Method() Method { return InternalLeaderLease
 // Method implements the Request interface.
 func (*InternalTruncateLogRequest) Method() Method { return InternalTruncateLog }
 
+// Method implements the Request interface.
+func (*InternalComputeChecksumRequest) Method() Method { return InternalComputeChecksum }
+
+// Method implements the Request interface.
+func (*InternalVerifyChecksumRequest) Method() Method { return InternalVerifyChecksum }
+
+// Method implements the Request interface.
+func (*InternalTransferLeaderLeaseRequest) Method() Method { return InternalTransferLeaderLease }
+
+// Method implements the Request interface.
+func (*InternalCloseTimestampRequest) Method() Method { return InternalCloseTimestamp }
+
+// Method implements the Request interface.
+func (*InternalReportChecksumRequest) Method() Method { return InternalReportChecksum }
+
 // Method implements the Request interface.
 func (*InternalBatchRequest) Method() Method { return InternalBatch }
 
@@ -414,6 +525,24 @@ func (*ScanRequest) CreateReply() Response { return &ScanResponse{} }
 // CreateReply implements the Request interface.
 func (*EndTransactionRequest) CreateReply() Response { return &EndTransactionResponse{} }
 
+// CreateReply implements the Request interface.
+func (*ReapQueueRequest) CreateReply() Response { return &ReapQueueResponse{} }
+
+// CreateReply implements the Request interface.
+func (*EnqueueUpdateRequest) CreateReply() Response { return &EnqueueUpdateResponse{} }
+
+// CreateReply implements the Request interface.
+func (*EnqueueMessageRequest) CreateReply() Response { return &EnqueueMessageResponse{} }
+
+// CreateReply implements the Request interface.
+func (*ChangesRequest) CreateReply() Response { return &ChangesResponse{} }
+
+// CreateReply implements the Request interface.
+func (*ImportRequest) CreateReply() Response { return &ImportResponse{} }
+
+// CreateReply implements the Request interface.
+func (*SpanStatsRequest) CreateReply() Response { return &SpanStatsResponse{} }
+
 // CreateReply implements the Request interface.
 func (*BatchRequest) CreateReply() Response { return &BatchResponse{} }
 
@@ -452,27 +581,63 @@ func (*InternalTruncateLogRequest) CreateReply() Response { return &InternalTrun
 // CreateReply implements the Request interface.
 func (*InternalLeaderLeaseRequest) CreateReply() Response { return &InternalLeaderLeaseResponse{} }
 
+// CreateReply implements the Request interface.
+func (*InternalComputeChecksumRequest) CreateReply() Response {
+	return &InternalComputeChecksumResponse{}
+}
+
+// CreateReply implements the Request interface.
+func (*InternalVerifyChecksumRequest) CreateReply() Response {
+	return &InternalVerifyChecksumResponse{}
+}
+
+// CreateReply implements the Request interface.
+func (*InternalTransferLeaderLeaseRequest) CreateReply() Response {
+	return &InternalTransferLeaderLeaseResponse{}
+}
+
+// CreateReply implements the Request interface.
+func (*InternalCloseTimestampRequest) CreateReply() Response {
+	return &InternalCloseTimestampResponse{}
+}
+
+// CreateReply implements the Request interface.
+func (*InternalReportChecksumRequest) CreateReply() Response {
+	return &InternalReportChecksumResponse{}
+}
+
 // CreateReply implements the Request interface.
 func (*InternalBatchRequest) CreateReply() Response { return &InternalBatchResponse{} }
 
-func (*GetRequest) flags() int                        { return isRead }
-func (*PutRequest) flags() int                        { return isWrite | isTxnWrite }
-func (*ConditionalPutRequest) flags() int             { return isRead | isWrite | isTxnWrite }
-func (*IncrementRequest) flags() int                  { return isRead | isWrite | isTxnWrite }
-func (*DeleteRequest) flags() int                     { return isWrite | isTxnWrite }
-func (*DeleteRangeRequest) flags() int                { return isWrite | isTxnWrite | isRange }
-func (*ScanRequest) flags() int                       { return isRead | isRange }
-func (*EndTransactionRequest) flags() int             { return isWrite }
-func (*BatchRequest) flags() int                      { return isWrite }
-func (*AdminSplitRequest) flags() int                 { return isAdmin }
-func (*AdminMergeRequest) flags() int                 { return isAdmin }
-func (*InternalHeartbeatTxnRequest) flags() int       { return isWrite }
-func (*InternalGCRequest) flags() int                 { return isWrite | isRange }
-func (*InternalPushTxnRequest) flags() int            { return isWrite }
-func (*InternalRangeLookupRequest) flags() int        { return isRead }
-func (*InternalResolveIntentRequest) flags() int      { return isWrite }
-func (*InternalResolveIntentRangeRequest) flags() int { return isWrite | isRange }
-func (*InternalMergeRequest) flags() int              { return isWrite }
-func (*InternalTruncateLogRequest) flags() int        { return isWrite }
-func (*InternalLeaderLeaseRequest) flags() int        { return isWrite }
-func (*InternalBatchRequest) flags() int              { return isWrite }
+func (*GetRequest) flags() int                         { return isRead }
+func (*PutRequest) flags() int                         { return isWrite | isTxnWrite }
+func (*ConditionalPutRequest) flags() int              { return isRead | isWrite | isTxnWrite }
+func (*IncrementRequest) flags() int                   { return isRead | isWrite | isTxnWrite }
+func (*DeleteRequest) flags() int                      { return isWrite | isTxnWrite }
+func (*DeleteRangeRequest) flags() int                 { return isWrite | isTxnWrite | isRange }
+func (*ScanRequest) flags() int                        { return isRead | isRange }
+func (*EndTransactionRequest) flags() int              { return isWrite }
+func (*ReapQueueRequest) flags() int                   { return isRead | isWrite | isTxnWrite | isRange }
+func (*EnqueueUpdateRequest) flags() int               { return isWrite | isTxnWrite | isRange }
+func (*EnqueueMessageRequest) flags() int              { return isWrite | isTxnWrite | isRange }
+func (*ChangesRequest) flags() int                     { return isRead | isRange | isNonTxn }
+func (*ImportRequest) flags() int                      { return isWrite | isRange | isNonTxn }
+func (*SpanStatsRequest) flags() int                   { return isRead | isRange | isNonTxn }
+func (*BatchRequest) flags() int                       { return isWrite }
+func (*AdminSplitRequest) flags() int                  { return isAdmin }
+func (*AdminMergeRequest) flags() int                  { return isAdmin }
+func (*InternalHeartbeatTxnRequest) flags() int        { return isWrite }
+func (*InternalGCRequest) flags() int                  { return isWrite | isRange }
+func (*InternalPushTxnRequest) flags() int             { return isWrite }
+func (*InternalRangeLookupRequest) flags() int         { return isRead }
+func (*InternalResolveIntentRequest) flags() int       { return isWrite }
+func (*InternalResolveIntentRangeRequest) flags() int  { return isWrite | isRange }
+func (*InternalMergeRequest) flags() int               { return isWrite }
+func (*InternalTruncateLogRequest) flags() int         { return isWrite }
+func (*InternalLeaderLeaseRequest) flags() int         { return isWrite }
+func (*InternalComputeChecksumRequest) flags() int     { return isWrite }
+func (*InternalVerifyChecksumRequest) flags() int      { return isWrite }
+func (*InternalTransferLeaderLeaseRequest) flags() int { return isWrite }
+func (*InternalCloseTimestampRequest) flags() int      { return isWrite }
+func (*InternalReportChecksumRequest) flags() int      { return isWrite }
+func (*InternalBatchRequest) flags() int               { return isWrite }
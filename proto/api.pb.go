@@ -35,6 +35,18 @@
 		ScanResponse
 		EndTransactionRequest
 		EndTransactionResponse
+		ReapQueueRequest
+		ReapQueueResponse
+		EnqueueUpdateRequest
+		EnqueueUpdateResponse
+		EnqueueMessageRequest
+		EnqueueMessageResponse
+		ChangesRequest
+		ChangesResponse
+		ImportRequest
+		ImportResponse
+		SpanStatsRequest
+		SpanStatsResponse
 		RequestUnion
 		ResponseUnion
 		BatchRequest
@@ -75,17 +87,24 @@ const (
 	// They are more efficient, but may read stale values as pending
 	// intents are ignored.
 	INCONSISTENT ReadConsistencyType = 2
+	// BOUNDED_STALENESS reads are like INCONSISTENT reads, but read
+	// values no older than the request's max_staleness. They may be
+	// served by any replica, not only the leader, whose applied state
+	// is at least as recent as the read timestamp.
+	BOUNDED_STALENESS ReadConsistencyType = 3
 )
 
 var ReadConsistencyType_name = map[int32]string{
 	0: "CONSISTENT",
 	1: "CONSENSUS",
 	2: "INCONSISTENT",
+	3: "BOUNDED_STALENESS",
 }
 var ReadConsistencyType_value = map[string]int32{
-	"CONSISTENT":   0,
-	"CONSENSUS":    1,
-	"INCONSISTENT": 2,
+	"CONSISTENT":        0,
+	"CONSENSUS":         1,
+	"INCONSISTENT":      2,
+	"BOUNDED_STALENESS": 3,
 }
 
 func (x ReadConsistencyType) Enum() *ReadConsistencyType {
@@ -191,8 +210,17 @@ type RequestHeader struct {
 	// ReadConsistency specifies the consistency for read
 	// operations. The default is CONSISTENT. This value is ignored for
 	// write operations.
-	ReadConsistency  ReadConsistencyType `protobuf:"varint,10,opt,name=read_consistency,enum=cockroach.proto.ReadConsistencyType" json:"read_consistency"`
-	XXX_unrecognized []byte              `json:"-"`
+	ReadConsistency ReadConsistencyType `protobuf:"varint,10,opt,name=read_consistency,enum=cockroach.proto.ReadConsistencyType" json:"read_consistency"`
+	// MaxStaleness is the maximum staleness, in nanoseconds, of values
+	// returned by BOUNDED_STALENESS reads. If the timestamp is not set,
+	// it is initialized to the wall time of the sending node less the
+	// max staleness.
+	MaxStaleness int64 `protobuf:"varint,11,opt,name=max_staleness" json:"max_staleness"`
+	// GatewayNodeID is the ID of the node on which the request was
+	// submitted to the cluster. It is used to place leader leases close
+	// to the clients of a range.
+	GatewayNodeID    NodeID `protobuf:"varint,12,opt,name=gateway_node_id,casttype=NodeID" json:"gateway_node_id"`
+	XXX_unrecognized []byte `json:"-"`
 }
 
 func (m *RequestHeader) Reset()         { *m = RequestHeader{} }
@@ -250,6 +278,13 @@ func (m *RequestHeader) GetReadConsistency() ReadConsistencyType {
 	return CONSISTENT
 }
 
+func (m *RequestHeader) GetMaxStaleness() int64 {
+	if m != nil {
+		return m.MaxStaleness
+	}
+	return 0
+}
+
 // ResponseHeader is returned with every storage node response.
 type ResponseHeader struct {
 	// Error is non-nil if an error occurred.
@@ -525,8 +560,12 @@ func (m *ScanRequest) GetMaxResults() int64 {
 type ScanResponse struct {
 	ResponseHeader `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
 	// Empty if no rows were scanned.
-	Rows             []KeyValue `protobuf:"bytes,2,rep,name=rows" json:"rows"`
-	XXX_unrecognized []byte     `json:"-"`
+	Rows []KeyValue `protobuf:"bytes,2,rep,name=rows" json:"rows"`
+	// If the scan was truncated by max_results before reaching the end
+	// of the requested key range, resume_key is the key at which a
+	// subsequent scan should begin. Empty if the scan completed.
+	ResumeKey        Key    `protobuf:"bytes,3,opt,name=resume_key,casttype=Key" json:"resume_key,omitempty"`
+	XXX_unrecognized []byte `json:"-"`
 }
 
 func (m *ScanResponse) Reset()         { *m = ScanResponse{} }
@@ -550,7 +589,10 @@ type EndTransactionRequest struct {
 	// internal use only and will be ignored if requested through the
 	// public-facing KV API.
 	InternalCommitTrigger *InternalCommitTrigger `protobuf:"bytes,3,opt,name=internal_commit_trigger" json:"internal_commit_trigger,omitempty"`
-	XXX_unrecognized      []byte                 `json:"-"`
+	// If set, the transaction may only commit at a timestamp before the
+	// deadline; committing it later fails.
+	Deadline         *Timestamp `protobuf:"bytes,4,opt,name=deadline" json:"deadline,omitempty"`
+	XXX_unrecognized []byte     `json:"-"`
 }
 
 func (m *EndTransactionRequest) Reset()         { *m = EndTransactionRequest{} }
@@ -571,6 +613,13 @@ func (m *EndTransactionRequest) GetInternalCommitTrigger() *InternalCommitTrigge
 	return nil
 }
 
+func (m *EndTransactionRequest) GetDeadline() *Timestamp {
+	if m != nil {
+		return m.Deadline
+	}
+	return nil
+}
+
 // An EndTransactionResponse is the return value from the
 // EndTransaction() method. The final transaction record is returned
 // as part of the response header. In particular, transaction status
@@ -601,6 +650,337 @@ func (m *EndTransactionResponse) GetCommitWait() int64 {
 	return 0
 }
 
+// A ReapQueueRequest is the argument to the ReapQueue() method. The
+// header's key span must cover exactly the recipient's inbox. Reaped
+// messages are deleted as part of the enclosing transaction, which
+// is mandatory.
+type ReapQueueRequest struct {
+	RequestHeader `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	// Maximum number of messages to reap. If 0, all messages are reaped.
+	MaxResults       int64  `protobuf:"varint,2,opt,name=max_results" json:"max_results"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *ReapQueueRequest) Reset()         { *m = ReapQueueRequest{} }
+func (m *ReapQueueRequest) String() string { return proto1.CompactTextString(m) }
+func (*ReapQueueRequest) ProtoMessage()    {}
+
+func (m *ReapQueueRequest) GetMaxResults() int64 {
+	if m != nil {
+		return m.MaxResults
+	}
+	return 0
+}
+
+// A ReapQueueResponse is the return value from the ReapQueue() method.
+type ReapQueueResponse struct {
+	ResponseHeader `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	// The reaped messages, in approximate order of their enqueue time.
+	// Empty if the queue was empty.
+	Messages         []Value `protobuf:"bytes,2,rep,name=messages" json:"messages"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *ReapQueueResponse) Reset()         { *m = ReapQueueResponse{} }
+func (m *ReapQueueResponse) String() string { return proto1.CompactTextString(m) }
+func (*ReapQueueResponse) ProtoMessage()    {}
+
+func (m *ReapQueueResponse) GetMessages() []Value {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+// An EnqueueUpdateRequest is the argument to the EnqueueUpdate()
+// method. The update is stored in the executable outbox of the range
+// which holds the updated key and is executed asynchronously. The
+// header's key span must cover exactly that outbox.
+type EnqueueUpdateRequest struct {
+	RequestHeader `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	// The update to execute. Must be one of Put, Increment, Delete or
+	// DeleteRange.
+	Update           RequestUnion `protobuf:"bytes,2,opt,name=update" json:"update"`
+	XXX_unrecognized []byte       `json:"-"`
+}
+
+func (m *EnqueueUpdateRequest) Reset()         { *m = EnqueueUpdateRequest{} }
+func (m *EnqueueUpdateRequest) String() string { return proto1.CompactTextString(m) }
+func (*EnqueueUpdateRequest) ProtoMessage()    {}
+
+func (m *EnqueueUpdateRequest) GetUpdate() RequestUnion {
+	if m != nil {
+		return m.Update
+	}
+	return RequestUnion{}
+}
+
+// An EnqueueUpdateResponse is the return value from the
+// EnqueueUpdate() method.
+type EnqueueUpdateResponse struct {
+	ResponseHeader   `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *EnqueueUpdateResponse) Reset()         { *m = EnqueueUpdateResponse{} }
+func (m *EnqueueUpdateResponse) String() string { return proto1.CompactTextString(m) }
+func (*EnqueueUpdateResponse) ProtoMessage()    {}
+
+// An EnqueueMessageRequest is the argument to the EnqueueMessage()
+// method. The header's key span must cover exactly the recipient's
+// inbox.
+type EnqueueMessageRequest struct {
+	RequestHeader `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	// The message to deliver.
+	Msg              Value  `protobuf:"bytes,2,opt,name=msg" json:"msg"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *EnqueueMessageRequest) Reset()         { *m = EnqueueMessageRequest{} }
+func (m *EnqueueMessageRequest) String() string { return proto1.CompactTextString(m) }
+func (*EnqueueMessageRequest) ProtoMessage()    {}
+
+func (m *EnqueueMessageRequest) GetMsg() Value {
+	if m != nil {
+		return m.Msg
+	}
+	return Value{}
+}
+
+// An EnqueueMessageResponse is the return value from the
+// EnqueueMessage() method.
+type EnqueueMessageResponse struct {
+	ResponseHeader   `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *EnqueueMessageResponse) Reset()         { *m = EnqueueMessageResponse{} }
+func (m *EnqueueMessageResponse) String() string { return proto1.CompactTextString(m) }
+func (*EnqueueMessageResponse) ProtoMessage()    {}
+
+// A ChangesRequest is the argument to the Changes() method. It
+// requests the committed changes to the keys between key and end_key
+// which were made after start_timestamp and no later than the request
+// timestamp.
+type ChangesRequest struct {
+	RequestHeader    `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	StartTimestamp   Timestamp `protobuf:"bytes,2,opt,name=start_timestamp" json:"start_timestamp"`
+	XXX_unrecognized []byte    `json:"-"`
+}
+
+func (m *ChangesRequest) Reset()         { *m = ChangesRequest{} }
+func (m *ChangesRequest) String() string { return proto1.CompactTextString(m) }
+func (*ChangesRequest) ProtoMessage()    {}
+
+func (m *ChangesRequest) GetStartTimestamp() Timestamp {
+	if m != nil {
+		return m.StartTimestamp
+	}
+	return Timestamp{}
+}
+
+// A ChangesResponse is the return value from the Changes() method.
+type ChangesResponse struct {
+	ResponseHeader `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	// The changes made after the start timestamp and no later than the
+	// resolved timestamp, ordered by key and, for each key, by timestamp.
+	Events []ChangeEvent `protobuf:"bytes,2,rep,name=events" json:"events"`
+	// The timestamp up to which the changes are resolved: all changes
+	// made no later than it have been returned, and no further ones can
+	// be made. Subsequent changes can be requested starting from it.
+	Resolved         Timestamp `protobuf:"bytes,3,opt,name=resolved" json:"resolved"`
+	XXX_unrecognized []byte    `json:"-"`
+}
+
+func (m *ChangesResponse) Reset()         { *m = ChangesResponse{} }
+func (m *ChangesResponse) String() string { return proto1.CompactTextString(m) }
+func (*ChangesResponse) ProtoMessage()    {}
+
+func (m *ChangesResponse) GetEvents() []ChangeEvent {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *ChangesResponse) GetResolved() Timestamp {
+	if m != nil {
+		return m.Resolved
+	}
+	return Timestamp{}
+}
+
+// An ImportRequest is the argument to the Import() method. It writes
+// a chunk of key/value pairs, sorted by key and without duplicates, at
+// the request timestamp. Pairs outside of the range receiving the
+// request are ignored, so that a chunk spanning several ranges is
+// written by each of them in a single Raft command.
+type ImportRequest struct {
+	RequestHeader    `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	Rows             []KeyValue `protobuf:"bytes,2,rep,name=rows" json:"rows"`
+	XXX_unrecognized []byte     `json:"-"`
+}
+
+func (m *ImportRequest) Reset()         { *m = ImportRequest{} }
+func (m *ImportRequest) String() string { return proto1.CompactTextString(m) }
+func (*ImportRequest) ProtoMessage()    {}
+
+func (m *ImportRequest) GetRows() []KeyValue {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+// An ImportResponse is the return value from the Import() method.
+type ImportResponse struct {
+	ResponseHeader `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	// The number of key/value pairs written.
+	NumImported      int64  `protobuf:"varint,2,opt,name=num_imported" json:"num_imported"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *ImportResponse) Reset()         { *m = ImportResponse{} }
+func (m *ImportResponse) String() string { return proto1.CompactTextString(m) }
+func (*ImportResponse) ProtoMessage()    {}
+
+func (m *ImportResponse) GetNumImported() int64 {
+	if m != nil {
+		return m.NumImported
+	}
+	return 0
+}
+
+// A SpanStatsRequest is the argument to the SpanStats() method. It
+// requests the MVCC statistics of the keys between key and end_key.
+type SpanStatsRequest struct {
+	RequestHeader    `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *SpanStatsRequest) Reset()         { *m = SpanStatsRequest{} }
+func (m *SpanStatsRequest) String() string { return proto1.CompactTextString(m) }
+func (*SpanStatsRequest) ProtoMessage()    {}
+
+// A SpanStatsResponse is the return value from the SpanStats()
+// method. The fields up to sys_count mirror those of MVCCStats, with
+// gc_bytes_age computed as of the request timestamp.
+type SpanStatsResponse struct {
+	ResponseHeader `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	LiveBytes      int64 `protobuf:"varint,2,opt,name=live_bytes" json:"live_bytes"`
+	KeyBytes       int64 `protobuf:"varint,3,opt,name=key_bytes" json:"key_bytes"`
+	ValBytes       int64 `protobuf:"varint,4,opt,name=val_bytes" json:"val_bytes"`
+	IntentBytes    int64 `protobuf:"varint,5,opt,name=intent_bytes" json:"intent_bytes"`
+	LiveCount      int64 `protobuf:"varint,6,opt,name=live_count" json:"live_count"`
+	KeyCount       int64 `protobuf:"varint,7,opt,name=key_count" json:"key_count"`
+	ValCount       int64 `protobuf:"varint,8,opt,name=val_count" json:"val_count"`
+	IntentCount    int64 `protobuf:"varint,9,opt,name=intent_count" json:"intent_count"`
+	GCBytesAge     int64 `protobuf:"varint,10,opt,name=gc_bytes_age" json:"gc_bytes_age"`
+	SysBytes       int64 `protobuf:"varint,11,opt,name=sys_bytes" json:"sys_bytes"`
+	SysCount       int64 `protobuf:"varint,12,opt,name=sys_count" json:"sys_count"`
+	// The number of ranges overlapping the span.
+	RangeCount int64 `protobuf:"varint,13,opt,name=range_count" json:"range_count"`
+	// The number of ranges which only partially overlap the span and
+	// had to be scanned; the others were covered by their cached stats.
+	ScannedRangeCount int64  `protobuf:"varint,14,opt,name=scanned_range_count" json:"scanned_range_count"`
+	XXX_unrecognized  []byte `json:"-"`
+}
+
+func (m *SpanStatsResponse) Reset()         { *m = SpanStatsResponse{} }
+func (m *SpanStatsResponse) String() string { return proto1.CompactTextString(m) }
+func (*SpanStatsResponse) ProtoMessage()    {}
+
+func (m *SpanStatsResponse) GetLiveBytes() int64 {
+	if m != nil {
+		return m.LiveBytes
+	}
+	return 0
+}
+
+func (m *SpanStatsResponse) GetKeyBytes() int64 {
+	if m != nil {
+		return m.KeyBytes
+	}
+	return 0
+}
+
+func (m *SpanStatsResponse) GetValBytes() int64 {
+	if m != nil {
+		return m.ValBytes
+	}
+	return 0
+}
+
+func (m *SpanStatsResponse) GetIntentBytes() int64 {
+	if m != nil {
+		return m.IntentBytes
+	}
+	return 0
+}
+
+func (m *SpanStatsResponse) GetLiveCount() int64 {
+	if m != nil {
+		return m.LiveCount
+	}
+	return 0
+}
+
+func (m *SpanStatsResponse) GetKeyCount() int64 {
+	if m != nil {
+		return m.KeyCount
+	}
+	return 0
+}
+
+func (m *SpanStatsResponse) GetValCount() int64 {
+	if m != nil {
+		return m.ValCount
+	}
+	return 0
+}
+
+func (m *SpanStatsResponse) GetIntentCount() int64 {
+	if m != nil {
+		return m.IntentCount
+	}
+	return 0
+}
+
+func (m *SpanStatsResponse) GetGCBytesAge() int64 {
+	if m != nil {
+		return m.GCBytesAge
+	}
+	return 0
+}
+
+func (m *SpanStatsResponse) GetSysBytes() int64 {
+	if m != nil {
+		return m.SysBytes
+	}
+	return 0
+}
+
+func (m *SpanStatsResponse) GetSysCount() int64 {
+	if m != nil {
+		return m.SysCount
+	}
+	return 0
+}
+
+func (m *SpanStatsResponse) GetRangeCount() int64 {
+	if m != nil {
+		return m.RangeCount
+	}
+	return 0
+}
+
+func (m *SpanStatsResponse) GetScannedRangeCount() int64 {
+	if m != nil {
+		return m.ScannedRangeCount
+	}
+	return 0
+}
+
 // A RequestUnion contains exactly one of the optional requests.
 // Values added here must be added to InternalRequestUnion as well.
 type RequestUnion struct {
@@ -612,6 +992,12 @@ type RequestUnion struct {
 	DeleteRange      *DeleteRangeRequest    `protobuf:"bytes,7,opt,name=delete_range" json:"delete_range,omitempty"`
 	Scan             *ScanRequest           `protobuf:"bytes,8,opt,name=scan" json:"scan,omitempty"`
 	EndTransaction   *EndTransactionRequest `protobuf:"bytes,9,opt,name=end_transaction" json:"end_transaction,omitempty"`
+	ReapQueue        *ReapQueueRequest      `protobuf:"bytes,10,opt,name=reap_queue" json:"reap_queue,omitempty"`
+	EnqueueUpdate    *EnqueueUpdateRequest  `protobuf:"bytes,11,opt,name=enqueue_update" json:"enqueue_update,omitempty"`
+	EnqueueMessage   *EnqueueMessageRequest `protobuf:"bytes,12,opt,name=enqueue_message" json:"enqueue_message,omitempty"`
+	Changes          *ChangesRequest        `protobuf:"bytes,13,opt,name=changes" json:"changes,omitempty"`
+	Import           *ImportRequest         `protobuf:"bytes,14,opt,name=import" json:"import,omitempty"`
+	SpanStats        *SpanStatsRequest      `protobuf:"bytes,15,opt,name=span_stats" json:"span_stats,omitempty"`
 	XXX_unrecognized []byte                 `json:"-"`
 }
 
@@ -675,6 +1061,48 @@ func (m *RequestUnion) GetEndTransaction() *EndTransactionRequest {
 	return nil
 }
 
+func (m *RequestUnion) GetReapQueue() *ReapQueueRequest {
+	if m != nil {
+		return m.ReapQueue
+	}
+	return nil
+}
+
+func (m *RequestUnion) GetEnqueueUpdate() *EnqueueUpdateRequest {
+	if m != nil {
+		return m.EnqueueUpdate
+	}
+	return nil
+}
+
+func (m *RequestUnion) GetEnqueueMessage() *EnqueueMessageRequest {
+	if m != nil {
+		return m.EnqueueMessage
+	}
+	return nil
+}
+
+func (m *RequestUnion) GetChanges() *ChangesRequest {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *RequestUnion) GetImport() *ImportRequest {
+	if m != nil {
+		return m.Import
+	}
+	return nil
+}
+
+func (m *RequestUnion) GetSpanStats() *SpanStatsRequest {
+	if m != nil {
+		return m.SpanStats
+	}
+	return nil
+}
+
 // A ResponseUnion contains exactly one of the optional responses.
 // Values added here must be added to InternalResponseUnion as well.
 type ResponseUnion struct {
@@ -686,6 +1114,12 @@ type ResponseUnion struct {
 	DeleteRange      *DeleteRangeResponse    `protobuf:"bytes,7,opt,name=delete_range" json:"delete_range,omitempty"`
 	Scan             *ScanResponse           `protobuf:"bytes,8,opt,name=scan" json:"scan,omitempty"`
 	EndTransaction   *EndTransactionResponse `protobuf:"bytes,9,opt,name=end_transaction" json:"end_transaction,omitempty"`
+	ReapQueue        *ReapQueueResponse      `protobuf:"bytes,10,opt,name=reap_queue" json:"reap_queue,omitempty"`
+	EnqueueUpdate    *EnqueueUpdateResponse  `protobuf:"bytes,11,opt,name=enqueue_update" json:"enqueue_update,omitempty"`
+	EnqueueMessage   *EnqueueMessageResponse `protobuf:"bytes,12,opt,name=enqueue_message" json:"enqueue_message,omitempty"`
+	Changes          *ChangesResponse        `protobuf:"bytes,13,opt,name=changes" json:"changes,omitempty"`
+	Import           *ImportResponse         `protobuf:"bytes,14,opt,name=import" json:"import,omitempty"`
+	SpanStats        *SpanStatsResponse      `protobuf:"bytes,15,opt,name=span_stats" json:"span_stats,omitempty"`
 	XXX_unrecognized []byte                  `json:"-"`
 }
 
@@ -749,6 +1183,48 @@ func (m *ResponseUnion) GetEndTransaction() *EndTransactionResponse {
 	return nil
 }
 
+func (m *ResponseUnion) GetReapQueue() *ReapQueueResponse {
+	if m != nil {
+		return m.ReapQueue
+	}
+	return nil
+}
+
+func (m *ResponseUnion) GetEnqueueUpdate() *EnqueueUpdateResponse {
+	if m != nil {
+		return m.EnqueueUpdate
+	}
+	return nil
+}
+
+func (m *ResponseUnion) GetEnqueueMessage() *EnqueueMessageResponse {
+	if m != nil {
+		return m.EnqueueMessage
+	}
+	return nil
+}
+
+func (m *ResponseUnion) GetChanges() *ChangesResponse {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *ResponseUnion) GetImport() *ImportResponse {
+	if m != nil {
+		return m.Import
+	}
+	return nil
+}
+
+func (m *ResponseUnion) GetSpanStats() *SpanStatsResponse {
+	if m != nil {
+		return m.SpanStats
+	}
+	return nil
+}
+
 // A BatchRequest contains one or more requests to be executed in
 // parallel, or if applicable (based on write-only commands and
 // range-locality), as a single update.
@@ -1172,12 +1648,42 @@ func (m *RequestHeader) Unmarshal(data []byte) error {
 					break
 				}
 			}
-		default:
-			var sizeOfWire int
-			for {
-				sizeOfWire++
-				wire >>= 7
-				if wire == 0 {
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxStaleness", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MaxStaleness |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GatewayNodeID", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.GatewayNodeID |= (NodeID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
 					break
 				}
 			}
@@ -2433,6 +2939,28 @@ func (m *ScanResponse) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumeKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ResumeKey = append([]byte{}, data[iNdEx:postIndex]...)
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -2544,6 +3072,33 @@ func (m *EndTransactionRequest) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deadline", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Deadline == nil {
+				m.Deadline = &Timestamp{}
+			}
+			if err := m.Deadline.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -2673,7 +3228,8 @@ func (m *EndTransactionResponse) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *RequestUnion) Unmarshal(data []byte) error {
+
+func (m *ReapQueueRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2692,9 +3248,9 @@ func (m *RequestUnion) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Get", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2712,70 +3268,72 @@ func (m *RequestUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Get == nil {
-				m.Get = &GetRequest{}
-			}
-			if err := m.Get.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.RequestHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Put", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxResults", wireType)
 			}
-			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				m.MaxResults |= (int64(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Put == nil {
-				m.Put = &PutRequest{}
-			}
-			if err := m.Put.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConditionalPut", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			iNdEx -= sizeOfWire
+			skippy, err := skipApi(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConditionalPut == nil {
-				m.ConditionalPut = &ConditionalPutRequest{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *ReapQueueResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			if err := m.ConditionalPut.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 5:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Increment", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResponseHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2793,16 +3351,13 @@ func (m *RequestUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Increment == nil {
-				m.Increment = &IncrementRequest{}
-			}
-			if err := m.Increment.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.ResponseHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Messages", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2820,43 +3375,58 @@ func (m *RequestUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Delete == nil {
-				m.Delete = &DeleteRequest{}
-			}
-			if err := m.Delete.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			m.Messages = append(m.Messages, Value{})
+			if err := m.Messages[len(m.Messages)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeleteRange", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			iNdEx -= sizeOfWire
+			skippy, err := skipApi(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DeleteRange == nil {
-				m.DeleteRange = &DeleteRangeRequest{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *EnqueueUpdateRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			if err := m.DeleteRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 8:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Scan", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2874,16 +3444,13 @@ func (m *RequestUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Scan == nil {
-				m.Scan = &ScanRequest{}
-			}
-			if err := m.Scan.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.RequestHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndTransaction", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Update", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2901,10 +3468,7 @@ func (m *RequestUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.EndTransaction == nil {
-				m.EndTransaction = &EndTransactionRequest{}
-			}
-			if err := m.EndTransaction.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Update.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2932,7 +3496,8 @@ func (m *RequestUnion) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *ResponseUnion) Unmarshal(data []byte) error {
+
+func (m *EnqueueUpdateResponse) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2951,9 +3516,9 @@ func (m *ResponseUnion) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Get", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResponseHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2971,70 +3536,57 @@ func (m *ResponseUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Get == nil {
-				m.Get = &GetResponse{}
-			}
-			if err := m.Get.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.ResponseHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Put", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Put == nil {
-				m.Put = &PutResponse{}
-			}
-			if err := m.Put.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			iNdEx -= sizeOfWire
+			skippy, err := skipApi(data[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConditionalPut", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConditionalPut == nil {
-				m.ConditionalPut = &ConditionalPutResponse{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *EnqueueMessageRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			if err := m.ConditionalPut.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 5:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Increment", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3052,16 +3604,13 @@ func (m *ResponseUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Increment == nil {
-				m.Increment = &IncrementResponse{}
-			}
-			if err := m.Increment.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.RequestHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3079,70 +3628,57 @@ func (m *ResponseUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Delete == nil {
-				m.Delete = &DeleteResponse{}
-			}
-			if err := m.Delete.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Msg.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeleteRange", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.DeleteRange == nil {
-				m.DeleteRange = &DeleteRangeResponse{}
-			}
-			if err := m.DeleteRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			iNdEx -= sizeOfWire
+			skippy, err := skipApi(data[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Scan", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Scan == nil {
-				m.Scan = &ScanResponse{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *EnqueueMessageResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			if err := m.Scan.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 9:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndTransaction", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResponseHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3160,10 +3696,7 @@ func (m *ResponseUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.EndTransaction == nil {
-				m.EndTransaction = &EndTransactionResponse{}
-			}
-			if err := m.EndTransaction.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.ResponseHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3191,7 +3724,8 @@ func (m *ResponseUnion) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *BatchRequest) Unmarshal(data []byte) error {
+
+func (m *ChangesRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3236,7 +3770,7 @@ func (m *BatchRequest) Unmarshal(data []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestamp", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3254,8 +3788,7 @@ func (m *BatchRequest) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Requests = append(m.Requests, RequestUnion{})
-			if err := m.Requests[len(m.Requests)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.StartTimestamp.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3283,7 +3816,8 @@ func (m *BatchRequest) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *BatchResponse) Unmarshal(data []byte) error {
+
+func (m *ChangesResponse) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3328,7 +3862,7 @@ func (m *BatchResponse) Unmarshal(data []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Events", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3346,8 +3880,32 @@ func (m *BatchResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Responses = append(m.Responses, ResponseUnion{})
-			if err := m.Responses[len(m.Responses)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+			m.Events = append(m.Events, ChangeEvent{})
+			if err := m.Events[len(m.Events)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resolved", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Resolved.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3375,7 +3933,8 @@ func (m *BatchResponse) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *AdminSplitRequest) Unmarshal(data []byte) error {
+
+func (m *ImportRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3420,25 +3979,28 @@ func (m *AdminSplitRequest) Unmarshal(data []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SplitKey", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Rows", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.SplitKey = append([]byte{}, data[iNdEx:postIndex]...)
+			m.Rows = append(m.Rows, KeyValue{})
+			if err := m.Rows[len(m.Rows)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			var sizeOfWire int
@@ -3464,7 +4026,8 @@ func (m *AdminSplitRequest) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *AdminSplitResponse) Unmarshal(data []byte) error {
+
+func (m *ImportResponse) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3507,6 +4070,21 @@ func (m *AdminSplitResponse) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NumImported", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.NumImported |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			var sizeOfWire int
 			for {
@@ -3531,7 +4109,8 @@ func (m *AdminSplitResponse) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *AdminMergeRequest) Unmarshal(data []byte) error {
+
+func (m *SpanStatsRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3598,7 +4177,8 @@ func (m *AdminMergeRequest) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *AdminMergeResponse) Unmarshal(data []byte) error {
+
+func (m *SpanStatsResponse) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3641,17 +4221,212 @@ func (m *AdminMergeResponse) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			var sizeOfWire int
-			for {
-				sizeOfWire++
-				wire >>= 7
-				if wire == 0 {
-					break
-				}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LiveBytes", wireType)
 			}
-			iNdEx -= sizeOfWire
-			skippy, err := skipApi(data[iNdEx:])
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.LiveBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyBytes", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.KeyBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValBytes", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ValBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntentBytes", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.IntentBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LiveCount", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.LiveCount |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KeyCount", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.KeyCount |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValCount", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ValCount |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntentCount", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.IntentCount |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GCBytesAge", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.GCBytesAge |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SysBytes", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.SysBytes |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SysCount", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.SysCount |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RangeCount", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.RangeCount |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ScannedRangeCount", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.ScannedRangeCount |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipApi(data[iNdEx:])
 			if err != nil {
 				return err
 			}
@@ -3665,14 +4440,14 @@ func (m *AdminMergeResponse) Unmarshal(data []byte) error {
 
 	return nil
 }
-func skipApi(data []byte) (n int, err error) {
+func (m *RequestUnion) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
 		var wire uint64
 		for shift := uint(0); ; shift += 7 {
 			if iNdEx >= l {
-				return 0, io.ErrUnexpectedEOF
+				return io.ErrUnexpectedEOF
 			}
 			b := data[iNdEx]
 			iNdEx++
@@ -3681,299 +4456,2067 @@ func skipApi(data []byte) (n int, err error) {
 				break
 			}
 		}
+		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
-		switch wireType {
-		case 0:
-			for {
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Get", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
-					return 0, io.ErrUnexpectedEOF
+					return io.ErrUnexpectedEOF
 				}
+				b := data[iNdEx]
 				iNdEx++
-				if data[iNdEx-1] < 0x80 {
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
 					break
 				}
 			}
-			return iNdEx, nil
-		case 1:
-			iNdEx += 8
-			return iNdEx, nil
-		case 2:
-			var length int
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Get == nil {
+				m.Get = &GetRequest{}
+			}
+			if err := m.Get.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Put", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
-					return 0, io.ErrUnexpectedEOF
+					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				length |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			iNdEx += length
-			return iNdEx, nil
-		case 3:
-			for {
-				var innerWire uint64
-				var start int = iNdEx
-				for shift := uint(0); ; shift += 7 {
-					if iNdEx >= l {
-						return 0, io.ErrUnexpectedEOF
-					}
-					b := data[iNdEx]
-					iNdEx++
-					innerWire |= (uint64(b) & 0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				innerWireType := int(innerWire & 0x7)
-				if innerWireType == 4 {
-					break
-				}
-				next, err := skipApi(data[start:])
-				if err != nil {
-					return 0, err
-				}
-				iNdEx = start + next
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			return iNdEx, nil
-		case 4:
-			return iNdEx, nil
-		case 5:
-			iNdEx += 4
-			return iNdEx, nil
-		default:
+			if m.Put == nil {
+				m.Put = &PutRequest{}
+			}
+			if err := m.Put.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConditionalPut", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConditionalPut == nil {
+				m.ConditionalPut = &ConditionalPutRequest{}
+			}
+			if err := m.ConditionalPut.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Increment", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Increment == nil {
+				m.Increment = &IncrementRequest{}
+			}
+			if err := m.Increment.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Delete == nil {
+				m.Delete = &DeleteRequest{}
+			}
+			if err := m.Delete.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeleteRange", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DeleteRange == nil {
+				m.DeleteRange = &DeleteRangeRequest{}
+			}
+			if err := m.DeleteRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scan", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Scan == nil {
+				m.Scan = &ScanRequest{}
+			}
+			if err := m.Scan.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTransaction", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EndTransaction == nil {
+				m.EndTransaction = &EndTransactionRequest{}
+			}
+			if err := m.EndTransaction.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReapQueue", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReapQueue == nil {
+				m.ReapQueue = &ReapQueueRequest{}
+			}
+			if err := m.ReapQueue.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnqueueUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EnqueueUpdate == nil {
+				m.EnqueueUpdate = &EnqueueUpdateRequest{}
+			}
+			if err := m.EnqueueUpdate.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnqueueMessage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EnqueueMessage == nil {
+				m.EnqueueMessage = &EnqueueMessageRequest{}
+			}
+			if err := m.EnqueueMessage.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Changes == nil {
+				m.Changes = &ChangesRequest{}
+			}
+			if err := m.Changes.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Import", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Import == nil {
+				m.Import = &ImportRequest{}
+			}
+			if err := m.Import.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpanStats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SpanStats == nil {
+				m.SpanStats = &SpanStatsRequest{}
+			}
+			if err := m.SpanStats.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipApi(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *ResponseUnion) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Get", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Get == nil {
+				m.Get = &GetResponse{}
+			}
+			if err := m.Get.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Put", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Put == nil {
+				m.Put = &PutResponse{}
+			}
+			if err := m.Put.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConditionalPut", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConditionalPut == nil {
+				m.ConditionalPut = &ConditionalPutResponse{}
+			}
+			if err := m.ConditionalPut.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Increment", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Increment == nil {
+				m.Increment = &IncrementResponse{}
+			}
+			if err := m.Increment.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Delete == nil {
+				m.Delete = &DeleteResponse{}
+			}
+			if err := m.Delete.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeleteRange", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DeleteRange == nil {
+				m.DeleteRange = &DeleteRangeResponse{}
+			}
+			if err := m.DeleteRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scan", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Scan == nil {
+				m.Scan = &ScanResponse{}
+			}
+			if err := m.Scan.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTransaction", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EndTransaction == nil {
+				m.EndTransaction = &EndTransactionResponse{}
+			}
+			if err := m.EndTransaction.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReapQueue", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReapQueue == nil {
+				m.ReapQueue = &ReapQueueResponse{}
+			}
+			if err := m.ReapQueue.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnqueueUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EnqueueUpdate == nil {
+				m.EnqueueUpdate = &EnqueueUpdateResponse{}
+			}
+			if err := m.EnqueueUpdate.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnqueueMessage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EnqueueMessage == nil {
+				m.EnqueueMessage = &EnqueueMessageResponse{}
+			}
+			if err := m.EnqueueMessage.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Changes == nil {
+				m.Changes = &ChangesResponse{}
+			}
+			if err := m.Changes.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Import", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Import == nil {
+				m.Import = &ImportResponse{}
+			}
+			if err := m.Import.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpanStats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SpanStats == nil {
+				m.SpanStats = &SpanStatsResponse{}
+			}
+			if err := m.SpanStats.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipApi(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *BatchRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RequestHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Requests = append(m.Requests, RequestUnion{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipApi(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *BatchResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResponseHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ResponseHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Responses = append(m.Responses, ResponseUnion{})
+			if err := m.Responses[len(m.Responses)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipApi(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *AdminSplitRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RequestHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SplitKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SplitKey = append([]byte{}, data[iNdEx:postIndex]...)
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipApi(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *AdminSplitResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResponseHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ResponseHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipApi(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *AdminMergeRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RequestHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipApi(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *AdminMergeResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResponseHeader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ResponseHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipApi(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func skipApi(data []byte) (n int, err error) {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for {
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if data[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			iNdEx += length
+			return iNdEx, nil
+		case 3:
+			for {
+				var innerWire uint64
+				var start int = iNdEx
+				for shift := uint(0); ; shift += 7 {
+					if iNdEx >= l {
+						return 0, io.ErrUnexpectedEOF
+					}
+					b := data[iNdEx]
+					iNdEx++
+					innerWire |= (uint64(b) & 0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				innerWireType := int(innerWire & 0x7)
+				if innerWireType == 4 {
+					break
+				}
+				next, err := skipApi(data[start:])
+				if err != nil {
+					return 0, err
+				}
+				iNdEx = start + next
+			}
+			return iNdEx, nil
+		case 4:
+			return iNdEx, nil
+		case 5:
+			iNdEx += 4
+			return iNdEx, nil
+		default:
 			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
 		}
 	}
-	panic("unreachable")
+	panic("unreachable")
+}
+func (this *RequestUnion) GetValue() interface{} {
+	if this.Get != nil {
+		return this.Get
+	}
+	if this.Put != nil {
+		return this.Put
+	}
+	if this.ConditionalPut != nil {
+		return this.ConditionalPut
+	}
+	if this.Increment != nil {
+		return this.Increment
+	}
+	if this.Delete != nil {
+		return this.Delete
+	}
+	if this.DeleteRange != nil {
+		return this.DeleteRange
+	}
+	if this.Scan != nil {
+		return this.Scan
+	}
+	if this.EndTransaction != nil {
+		return this.EndTransaction
+	}
+	if this.ReapQueue != nil {
+		return this.ReapQueue
+	}
+	if this.EnqueueUpdate != nil {
+		return this.EnqueueUpdate
+	}
+	if this.EnqueueMessage != nil {
+		return this.EnqueueMessage
+	}
+	if this.Changes != nil {
+		return this.Changes
+	}
+	if this.Import != nil {
+		return this.Import
+	}
+	if this.SpanStats != nil {
+		return this.SpanStats
+	}
+	return nil
+}
+
+func (this *RequestUnion) SetValue(value interface{}) bool {
+	switch vt := value.(type) {
+	case *GetRequest:
+		this.Get = vt
+	case *PutRequest:
+		this.Put = vt
+	case *ConditionalPutRequest:
+		this.ConditionalPut = vt
+	case *IncrementRequest:
+		this.Increment = vt
+	case *DeleteRequest:
+		this.Delete = vt
+	case *DeleteRangeRequest:
+		this.DeleteRange = vt
+	case *ScanRequest:
+		this.Scan = vt
+	case *EndTransactionRequest:
+		this.EndTransaction = vt
+	case *ReapQueueRequest:
+		this.ReapQueue = vt
+	case *EnqueueUpdateRequest:
+		this.EnqueueUpdate = vt
+	case *EnqueueMessageRequest:
+		this.EnqueueMessage = vt
+	case *ChangesRequest:
+		this.Changes = vt
+	case *ImportRequest:
+		this.Import = vt
+	case *SpanStatsRequest:
+		this.SpanStats = vt
+	default:
+		return false
+	}
+	return true
+}
+func (this *ResponseUnion) GetValue() interface{} {
+	if this.Get != nil {
+		return this.Get
+	}
+	if this.Put != nil {
+		return this.Put
+	}
+	if this.ConditionalPut != nil {
+		return this.ConditionalPut
+	}
+	if this.Increment != nil {
+		return this.Increment
+	}
+	if this.Delete != nil {
+		return this.Delete
+	}
+	if this.DeleteRange != nil {
+		return this.DeleteRange
+	}
+	if this.Scan != nil {
+		return this.Scan
+	}
+	if this.EndTransaction != nil {
+		return this.EndTransaction
+	}
+	if this.ReapQueue != nil {
+		return this.ReapQueue
+	}
+	if this.EnqueueUpdate != nil {
+		return this.EnqueueUpdate
+	}
+	if this.EnqueueMessage != nil {
+		return this.EnqueueMessage
+	}
+	if this.Changes != nil {
+		return this.Changes
+	}
+	if this.Import != nil {
+		return this.Import
+	}
+	if this.SpanStats != nil {
+		return this.SpanStats
+	}
+	return nil
+}
+
+func (this *ResponseUnion) SetValue(value interface{}) bool {
+	switch vt := value.(type) {
+	case *GetResponse:
+		this.Get = vt
+	case *PutResponse:
+		this.Put = vt
+	case *ConditionalPutResponse:
+		this.ConditionalPut = vt
+	case *IncrementResponse:
+		this.Increment = vt
+	case *DeleteResponse:
+		this.Delete = vt
+	case *DeleteRangeResponse:
+		this.DeleteRange = vt
+	case *ScanResponse:
+		this.Scan = vt
+	case *EndTransactionResponse:
+		this.EndTransaction = vt
+	case *ReapQueueResponse:
+		this.ReapQueue = vt
+	case *EnqueueUpdateResponse:
+		this.EnqueueUpdate = vt
+	case *EnqueueMessageResponse:
+		this.EnqueueMessage = vt
+	case *ChangesResponse:
+		this.Changes = vt
+	case *ImportResponse:
+		this.Import = vt
+	case *SpanStatsResponse:
+		this.SpanStats = vt
+	default:
+		return false
+	}
+	return true
+}
+func (m *ClientCmdID) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovApi(uint64(m.WallTime))
+	n += 1 + sovApi(uint64(m.Random))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestHeader) Size() (n int) {
+	var l int
+	_ = l
+	l = m.Timestamp.Size()
+	n += 1 + l + sovApi(uint64(l))
+	l = m.CmdID.Size()
+	n += 1 + l + sovApi(uint64(l))
+	if m.Key != nil {
+		l = len(m.Key)
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.EndKey != nil {
+		l = len(m.EndKey)
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = len(m.User)
+	n += 1 + l + sovApi(uint64(l))
+	l = m.Replica.Size()
+	n += 1 + l + sovApi(uint64(l))
+	n += 1 + sovApi(uint64(m.RaftID))
+	if m.UserPriority != nil {
+		n += 1 + sovApi(uint64(*m.UserPriority))
+	}
+	if m.Txn != nil {
+		l = m.Txn.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	n += 1 + sovApi(uint64(m.ReadConsistency))
+	n += 1 + sovApi(uint64(m.MaxStaleness))
+	n += 1 + sovApi(uint64(m.GatewayNodeID))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ResponseHeader) Size() (n int) {
+	var l int
+	_ = l
+	if m.Error != nil {
+		l = m.Error.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	l = m.Timestamp.Size()
+	n += 1 + l + sovApi(uint64(l))
+	if m.Txn != nil {
+		l = m.Txn.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
 }
-func (this *RequestUnion) GetValue() interface{} {
-	if this.Get != nil {
-		return this.Get
+
+func (m *GetRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if this.Put != nil {
-		return this.Put
+	return n
+}
+
+func (m *GetResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	if m.Value != nil {
+		l = m.Value.Size()
+		n += 1 + l + sovApi(uint64(l))
 	}
-	if this.ConditionalPut != nil {
-		return this.ConditionalPut
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if this.Increment != nil {
-		return this.Increment
+	return n
+}
+
+func (m *PutRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	l = m.Value.Size()
+	n += 1 + l + sovApi(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if this.Delete != nil {
-		return this.Delete
+	return n
+}
+
+func (m *PutResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if this.DeleteRange != nil {
-		return this.DeleteRange
+	return n
+}
+
+func (m *ConditionalPutRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	l = m.Value.Size()
+	n += 1 + l + sovApi(uint64(l))
+	if m.ExpValue != nil {
+		l = m.ExpValue.Size()
+		n += 1 + l + sovApi(uint64(l))
 	}
-	if this.Scan != nil {
-		return this.Scan
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if this.EndTransaction != nil {
-		return this.EndTransaction
+	return n
+}
+
+func (m *ConditionalPutResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	return nil
+	return n
 }
 
-func (this *RequestUnion) SetValue(value interface{}) bool {
-	switch vt := value.(type) {
-	case *GetRequest:
-		this.Get = vt
-	case *PutRequest:
-		this.Put = vt
-	case *ConditionalPutRequest:
-		this.ConditionalPut = vt
-	case *IncrementRequest:
-		this.Increment = vt
-	case *DeleteRequest:
-		this.Delete = vt
-	case *DeleteRangeRequest:
-		this.DeleteRange = vt
-	case *ScanRequest:
-		this.Scan = vt
-	case *EndTransactionRequest:
-		this.EndTransaction = vt
-	default:
-		return false
+func (m *IncrementRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	n += 1 + sovApi(uint64(m.Increment))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	return true
+	return n
 }
-func (this *ResponseUnion) GetValue() interface{} {
-	if this.Get != nil {
-		return this.Get
+
+func (m *IncrementResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	n += 1 + sovApi(uint64(m.NewValue))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if this.Put != nil {
-		return this.Put
+	return n
+}
+
+func (m *DeleteRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if this.ConditionalPut != nil {
-		return this.ConditionalPut
+	return n
+}
+
+func (m *DeleteResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if this.Increment != nil {
-		return this.Increment
+	return n
+}
+
+func (m *DeleteRangeRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	n += 1 + sovApi(uint64(m.MaxEntriesToDelete))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if this.Delete != nil {
-		return this.Delete
+	return n
+}
+
+func (m *DeleteRangeResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	n += 1 + sovApi(uint64(m.NumDeleted))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if this.DeleteRange != nil {
-		return this.DeleteRange
+	return n
+}
+
+func (m *ScanRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	n += 1 + sovApi(uint64(m.MaxResults))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if this.Scan != nil {
-		return this.Scan
+	return n
+}
+
+func (m *ScanResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	if len(m.Rows) > 0 {
+		for _, e := range m.Rows {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if m.ResumeKey != nil {
+		l = len(m.ResumeKey)
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *EndTransactionRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	n += 2
+	if m.InternalCommitTrigger != nil {
+		l = m.InternalCommitTrigger.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.Deadline != nil {
+		l = m.Deadline.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *EndTransactionResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	n += 1 + sovApi(uint64(m.CommitWait))
+	if len(m.Resolved) > 0 {
+		for _, b := range m.Resolved {
+			l = len(b)
+			n += 1 + l + sovApi(uint64(l))
+		}
 	}
-	if this.EndTransaction != nil {
-		return this.EndTransaction
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	return nil
+	return n
 }
 
-func (this *ResponseUnion) SetValue(value interface{}) bool {
-	switch vt := value.(type) {
-	case *GetResponse:
-		this.Get = vt
-	case *PutResponse:
-		this.Put = vt
-	case *ConditionalPutResponse:
-		this.ConditionalPut = vt
-	case *IncrementResponse:
-		this.Increment = vt
-	case *DeleteResponse:
-		this.Delete = vt
-	case *DeleteRangeResponse:
-		this.DeleteRange = vt
-	case *ScanResponse:
-		this.Scan = vt
-	case *EndTransactionResponse:
-		this.EndTransaction = vt
-	default:
-		return false
+func (m *ReapQueueRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	n += 1 + sovApi(uint64(m.MaxResults))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	return true
+	return n
 }
-func (m *ClientCmdID) Size() (n int) {
+
+func (m *ReapQueueResponse) Size() (n int) {
 	var l int
 	_ = l
-	n += 1 + sovApi(uint64(m.WallTime))
-	n += 1 + sovApi(uint64(m.Random))
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	if len(m.Messages) > 0 {
+		for _, e := range m.Messages {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *RequestHeader) Size() (n int) {
+func (m *EnqueueUpdateRequest) Size() (n int) {
 	var l int
 	_ = l
-	l = m.Timestamp.Size()
+	l = m.RequestHeader.Size()
 	n += 1 + l + sovApi(uint64(l))
-	l = m.CmdID.Size()
+	l = m.Update.Size()
 	n += 1 + l + sovApi(uint64(l))
-	if m.Key != nil {
-		l = len(m.Key)
-		n += 1 + l + sovApi(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if m.EndKey != nil {
-		l = len(m.EndKey)
-		n += 1 + l + sovApi(uint64(l))
+	return n
+}
+
+func (m *EnqueueUpdateResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	l = len(m.User)
+	return n
+}
+
+func (m *EnqueueMessageRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
 	n += 1 + l + sovApi(uint64(l))
-	l = m.Replica.Size()
+	l = m.Msg.Size()
 	n += 1 + l + sovApi(uint64(l))
-	n += 1 + sovApi(uint64(m.RaftID))
-	if m.UserPriority != nil {
-		n += 1 + sovApi(uint64(*m.UserPriority))
-	}
-	if m.Txn != nil {
-		l = m.Txn.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	n += 1 + sovApi(uint64(m.ReadConsistency))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *ResponseHeader) Size() (n int) {
+func (m *EnqueueMessageResponse) Size() (n int) {
 	var l int
 	_ = l
-	if m.Error != nil {
-		l = m.Error.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	l = m.Timestamp.Size()
+	l = m.ResponseHeader.Size()
 	n += 1 + l + sovApi(uint64(l))
-	if m.Txn != nil {
-		l = m.Txn.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *GetRequest) Size() (n int) {
+func (m *ChangesRequest) Size() (n int) {
 	var l int
 	_ = l
 	l = m.RequestHeader.Size()
 	n += 1 + l + sovApi(uint64(l))
+	l = m.StartTimestamp.Size()
+	n += 1 + l + sovApi(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *GetResponse) Size() (n int) {
+func (m *ChangesResponse) Size() (n int) {
 	var l int
 	_ = l
 	l = m.ResponseHeader.Size()
 	n += 1 + l + sovApi(uint64(l))
-	if m.Value != nil {
-		l = m.Value.Size()
-		n += 1 + l + sovApi(uint64(l))
+	if len(m.Events) > 0 {
+		for _, e := range m.Events {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
 	}
+	l = m.Resolved.Size()
+	n += 1 + l + sovApi(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *PutRequest) Size() (n int) {
+func (m *ImportRequest) Size() (n int) {
 	var l int
 	_ = l
 	l = m.RequestHeader.Size()
 	n += 1 + l + sovApi(uint64(l))
-	l = m.Value.Size()
-	n += 1 + l + sovApi(uint64(l))
+	if len(m.Rows) > 0 {
+		for _, e := range m.Rows {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *PutResponse) Size() (n int) {
+func (m *ImportResponse) Size() (n int) {
 	var l int
 	_ = l
 	l = m.ResponseHeader.Size()
 	n += 1 + l + sovApi(uint64(l))
+	n += 1 + sovApi(uint64(m.NumImported))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *ConditionalPutRequest) Size() (n int) {
+func (m *SpanStatsRequest) Size() (n int) {
 	var l int
 	_ = l
 	l = m.RequestHeader.Size()
 	n += 1 + l + sovApi(uint64(l))
-	l = m.Value.Size()
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *SpanStatsResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
 	n += 1 + l + sovApi(uint64(l))
-	if m.ExpValue != nil {
-		l = m.ExpValue.Size()
+	n += 1 + sovApi(uint64(m.LiveBytes))
+	n += 1 + sovApi(uint64(m.KeyBytes))
+	n += 1 + sovApi(uint64(m.ValBytes))
+	n += 1 + sovApi(uint64(m.IntentBytes))
+	n += 1 + sovApi(uint64(m.LiveCount))
+	n += 1 + sovApi(uint64(m.KeyCount))
+	n += 1 + sovApi(uint64(m.ValCount))
+	n += 1 + sovApi(uint64(m.IntentCount))
+	n += 1 + sovApi(uint64(m.GCBytesAge))
+	n += 1 + sovApi(uint64(m.SysBytes))
+	n += 1 + sovApi(uint64(m.SysCount))
+	n += 1 + sovApi(uint64(m.RangeCount))
+	n += 1 + sovApi(uint64(m.ScannedRangeCount))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RequestUnion) Size() (n int) {
+	var l int
+	_ = l
+	if m.Get != nil {
+		l = m.Get.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.Put != nil {
+		l = m.Put.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.ConditionalPut != nil {
+		l = m.ConditionalPut.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.Increment != nil {
+		l = m.Increment.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.Delete != nil {
+		l = m.Delete.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.DeleteRange != nil {
+		l = m.DeleteRange.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.Scan != nil {
+		l = m.Scan.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.EndTransaction != nil {
+		l = m.EndTransaction.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.ReapQueue != nil {
+		l = m.ReapQueue.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.EnqueueUpdate != nil {
+		l = m.EnqueueUpdate.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.EnqueueMessage != nil {
+		l = m.EnqueueMessage.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.Changes != nil {
+		l = m.Changes.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.Import != nil {
+		l = m.Import.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.SpanStats != nil {
+		l = m.SpanStats.Size()
 		n += 1 + l + sovApi(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
@@ -3982,42 +6525,132 @@ func (m *ConditionalPutRequest) Size() (n int) {
 	return n
 }
 
-func (m *ConditionalPutResponse) Size() (n int) {
+func (m *ResponseUnion) Size() (n int) {
+	var l int
+	_ = l
+	if m.Get != nil {
+		l = m.Get.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.Put != nil {
+		l = m.Put.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.ConditionalPut != nil {
+		l = m.ConditionalPut.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.Increment != nil {
+		l = m.Increment.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.Delete != nil {
+		l = m.Delete.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.DeleteRange != nil {
+		l = m.DeleteRange.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.Scan != nil {
+		l = m.Scan.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.EndTransaction != nil {
+		l = m.EndTransaction.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.ReapQueue != nil {
+		l = m.ReapQueue.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.EnqueueUpdate != nil {
+		l = m.EnqueueUpdate.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.EnqueueMessage != nil {
+		l = m.EnqueueMessage.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.Changes != nil {
+		l = m.Changes.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.Import != nil {
+		l = m.Import.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.SpanStats != nil {
+		l = m.SpanStats.Size()
+		n += 1 + l + sovApi(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BatchRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovApi(uint64(l))
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *BatchResponse) Size() (n int) {
 	var l int
 	_ = l
 	l = m.ResponseHeader.Size()
 	n += 1 + l + sovApi(uint64(l))
+	if len(m.Responses) > 0 {
+		for _, e := range m.Responses {
+			l = e.Size()
+			n += 1 + l + sovApi(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *IncrementRequest) Size() (n int) {
+func (m *AdminSplitRequest) Size() (n int) {
 	var l int
 	_ = l
 	l = m.RequestHeader.Size()
 	n += 1 + l + sovApi(uint64(l))
-	n += 1 + sovApi(uint64(m.Increment))
+	if m.SplitKey != nil {
+		l = len(m.SplitKey)
+		n += 1 + l + sovApi(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *IncrementResponse) Size() (n int) {
+func (m *AdminSplitResponse) Size() (n int) {
 	var l int
 	_ = l
 	l = m.ResponseHeader.Size()
 	n += 1 + l + sovApi(uint64(l))
-	n += 1 + sovApi(uint64(m.NewValue))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *DeleteRequest) Size() (n int) {
+func (m *AdminMergeRequest) Size() (n int) {
 	var l int
 	_ = l
 	l = m.RequestHeader.Size()
@@ -4028,7 +6661,7 @@ func (m *DeleteRequest) Size() (n int) {
 	return n
 }
 
-func (m *DeleteResponse) Size() (n int) {
+func (m *AdminMergeResponse) Size() (n int) {
 	var l int
 	_ = l
 	l = m.ResponseHeader.Size()
@@ -4039,271 +6672,426 @@ func (m *DeleteResponse) Size() (n int) {
 	return n
 }
 
-func (m *DeleteRangeRequest) Size() (n int) {
+func sovApi(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
+	}
+	return n
+}
+func sozApi(x uint64) (n int) {
+	return sovApi(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *ClientCmdID) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *ClientCmdID) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.RequestHeader.Size()
-	n += 1 + l + sovApi(uint64(l))
-	n += 1 + sovApi(uint64(m.MaxEntriesToDelete))
+	data[i] = 0x8
+	i++
+	i = encodeVarintApi(data, i, uint64(m.WallTime))
+	data[i] = 0x10
+	i++
+	i = encodeVarintApi(data, i, uint64(m.Random))
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *DeleteRangeResponse) Size() (n int) {
+func (m *RequestHeader) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *RequestHeader) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.ResponseHeader.Size()
-	n += 1 + l + sovApi(uint64(l))
-	n += 1 + sovApi(uint64(m.NumDeleted))
+	data[i] = 0xa
+	i++
+	i = encodeVarintApi(data, i, uint64(m.Timestamp.Size()))
+	n1, err := m.Timestamp.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n1
+	data[i] = 0x12
+	i++
+	i = encodeVarintApi(data, i, uint64(m.CmdID.Size()))
+	n2, err := m.CmdID.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n2
+	if m.Key != nil {
+		data[i] = 0x1a
+		i++
+		i = encodeVarintApi(data, i, uint64(len(m.Key)))
+		i += copy(data[i:], m.Key)
+	}
+	if m.EndKey != nil {
+		data[i] = 0x22
+		i++
+		i = encodeVarintApi(data, i, uint64(len(m.EndKey)))
+		i += copy(data[i:], m.EndKey)
+	}
+	data[i] = 0x2a
+	i++
+	i = encodeVarintApi(data, i, uint64(len(m.User)))
+	i += copy(data[i:], m.User)
+	data[i] = 0x32
+	i++
+	i = encodeVarintApi(data, i, uint64(m.Replica.Size()))
+	n3, err := m.Replica.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n3
+	data[i] = 0x38
+	i++
+	i = encodeVarintApi(data, i, uint64(m.RaftID))
+	if m.UserPriority != nil {
+		data[i] = 0x40
+		i++
+		i = encodeVarintApi(data, i, uint64(*m.UserPriority))
+	}
+	if m.Txn != nil {
+		data[i] = 0x4a
+		i++
+		i = encodeVarintApi(data, i, uint64(m.Txn.Size()))
+		n4, err := m.Txn.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n4
+	}
+	data[i] = 0x50
+	i++
+	i = encodeVarintApi(data, i, uint64(m.ReadConsistency))
+	data[i] = 0x58
+	i++
+	i = encodeVarintApi(data, i, uint64(m.MaxStaleness))
+	data[i] = 0x60
+	i++
+	i = encodeVarintApi(data, i, uint64(m.GatewayNodeID))
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ScanRequest) Size() (n int) {
+func (m *ResponseHeader) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *ResponseHeader) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.RequestHeader.Size()
-	n += 1 + l + sovApi(uint64(l))
-	n += 1 + sovApi(uint64(m.MaxResults))
+	if m.Error != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintApi(data, i, uint64(m.Error.Size()))
+		n5, err := m.Error.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n5
+	}
+	data[i] = 0x12
+	i++
+	i = encodeVarintApi(data, i, uint64(m.Timestamp.Size()))
+	n6, err := m.Timestamp.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n6
+	if m.Txn != nil {
+		data[i] = 0x1a
+		i++
+		i = encodeVarintApi(data, i, uint64(m.Txn.Size()))
+		n7, err := m.Txn.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n7
+	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ScanResponse) Size() (n int) {
+func (m *GetRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *GetRequest) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.ResponseHeader.Size()
-	n += 1 + l + sovApi(uint64(l))
-	if len(m.Rows) > 0 {
-		for _, e := range m.Rows {
-			l = e.Size()
-			n += 1 + l + sovApi(uint64(l))
-		}
+	data[i] = 0xa
+	i++
+	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
+	n8, err := m.RequestHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n8
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *EndTransactionRequest) Size() (n int) {
+func (m *GetResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *GetResponse) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.RequestHeader.Size()
-	n += 1 + l + sovApi(uint64(l))
-	n += 2
-	if m.InternalCommitTrigger != nil {
-		l = m.InternalCommitTrigger.Size()
-		n += 1 + l + sovApi(uint64(l))
+	data[i] = 0xa
+	i++
+	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
+	n9, err := m.ResponseHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n9
+	if m.Value != nil {
+		data[i] = 0x12
+		i++
+		i = encodeVarintApi(data, i, uint64(m.Value.Size()))
+		n10, err := m.Value.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n10
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *EndTransactionResponse) Size() (n int) {
-	var l int
-	_ = l
-	l = m.ResponseHeader.Size()
-	n += 1 + l + sovApi(uint64(l))
-	n += 1 + sovApi(uint64(m.CommitWait))
-	if len(m.Resolved) > 0 {
-		for _, b := range m.Resolved {
-			l = len(b)
-			n += 1 + l + sovApi(uint64(l))
-		}
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *PutRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return data[:n], nil
 }
 
-func (m *RequestUnion) Size() (n int) {
+func (m *PutRequest) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Get != nil {
-		l = m.Get.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.Put != nil {
-		l = m.Put.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.ConditionalPut != nil {
-		l = m.ConditionalPut.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.Increment != nil {
-		l = m.Increment.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.Delete != nil {
-		l = m.Delete.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.DeleteRange != nil {
-		l = m.DeleteRange.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.Scan != nil {
-		l = m.Scan.Size()
-		n += 1 + l + sovApi(uint64(l))
+	data[i] = 0xa
+	i++
+	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
+	n11, err := m.RequestHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
 	}
-	if m.EndTransaction != nil {
-		l = m.EndTransaction.Size()
-		n += 1 + l + sovApi(uint64(l))
+	i += n11
+	data[i] = 0x12
+	i++
+	i = encodeVarintApi(data, i, uint64(m.Value.Size()))
+	n12, err := m.Value.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n12
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *ResponseUnion) Size() (n int) {
-	var l int
-	_ = l
-	if m.Get != nil {
-		l = m.Get.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.Put != nil {
-		l = m.Put.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.ConditionalPut != nil {
-		l = m.ConditionalPut.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.Increment != nil {
-		l = m.Increment.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.Delete != nil {
-		l = m.Delete.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.DeleteRange != nil {
-		l = m.DeleteRange.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.Scan != nil {
-		l = m.Scan.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.EndTransaction != nil {
-		l = m.EndTransaction.Size()
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *PutResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return data[:n], nil
 }
 
-func (m *BatchRequest) Size() (n int) {
+func (m *PutResponse) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.RequestHeader.Size()
-	n += 1 + l + sovApi(uint64(l))
-	if len(m.Requests) > 0 {
-		for _, e := range m.Requests {
-			l = e.Size()
-			n += 1 + l + sovApi(uint64(l))
-		}
+	data[i] = 0xa
+	i++
+	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
+	n13, err := m.ResponseHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n13
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *BatchResponse) Size() (n int) {
+func (m *ConditionalPutRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *ConditionalPutRequest) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.ResponseHeader.Size()
-	n += 1 + l + sovApi(uint64(l))
-	if len(m.Responses) > 0 {
-		for _, e := range m.Responses {
-			l = e.Size()
-			n += 1 + l + sovApi(uint64(l))
+	data[i] = 0xa
+	i++
+	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
+	n14, err := m.RequestHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n14
+	data[i] = 0x12
+	i++
+	i = encodeVarintApi(data, i, uint64(m.Value.Size()))
+	n15, err := m.Value.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n15
+	if m.ExpValue != nil {
+		data[i] = 0x1a
+		i++
+		i = encodeVarintApi(data, i, uint64(m.ExpValue.Size()))
+		n16, err := m.ExpValue.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
 		}
+		i += n16
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *AdminSplitRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = m.RequestHeader.Size()
-	n += 1 + l + sovApi(uint64(l))
-	if m.SplitKey != nil {
-		l = len(m.SplitKey)
-		n += 1 + l + sovApi(uint64(l))
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *ConditionalPutResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return data[:n], nil
 }
 
-func (m *AdminSplitResponse) Size() (n int) {
+func (m *ConditionalPutResponse) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.ResponseHeader.Size()
-	n += 1 + l + sovApi(uint64(l))
+	data[i] = 0xa
+	i++
+	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
+	n17, err := m.ResponseHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n17
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *AdminMergeRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = m.RequestHeader.Size()
-	n += 1 + l + sovApi(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *IncrementRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return data[:n], nil
 }
 
-func (m *AdminMergeResponse) Size() (n int) {
+func (m *IncrementRequest) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.ResponseHeader.Size()
-	n += 1 + l + sovApi(uint64(l))
+	data[i] = 0xa
+	i++
+	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
+	n18, err := m.RequestHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n18
+	data[i] = 0x10
+	i++
+	i = encodeVarintApi(data, i, uint64(m.Increment))
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func sovApi(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
-	}
-	return n
-}
-func sozApi(x uint64) (n int) {
-	return sovApi(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (m *ClientCmdID) Marshal() (data []byte, err error) {
+func (m *IncrementResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4313,24 +7101,29 @@ func (m *ClientCmdID) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *ClientCmdID) MarshalTo(data []byte) (n int, err error) {
+func (m *IncrementResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	data[i] = 0x8
+	data[i] = 0xa
 	i++
-	i = encodeVarintApi(data, i, uint64(m.WallTime))
+	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
+	n19, err := m.ResponseHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n19
 	data[i] = 0x10
 	i++
-	i = encodeVarintApi(data, i, uint64(m.Random))
+	i = encodeVarintApi(data, i, uint64(m.NewValue))
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *RequestHeader) Marshal() (data []byte, err error) {
+func (m *DeleteRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4340,79 +7133,87 @@ func (m *RequestHeader) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *RequestHeader) MarshalTo(data []byte) (n int, err error) {
+func (m *DeleteRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
 	data[i] = 0xa
 	i++
-	i = encodeVarintApi(data, i, uint64(m.Timestamp.Size()))
-	n1, err := m.Timestamp.MarshalTo(data[i:])
+	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
+	n20, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n1
-	data[i] = 0x12
+	i += n20
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *DeleteResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *DeleteResponse) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
 	i++
-	i = encodeVarintApi(data, i, uint64(m.CmdID.Size()))
-	n2, err := m.CmdID.MarshalTo(data[i:])
+	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
+	n21, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n2
-	if m.Key != nil {
-		data[i] = 0x1a
-		i++
-		i = encodeVarintApi(data, i, uint64(len(m.Key)))
-		i += copy(data[i:], m.Key)
+	i += n21
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	if m.EndKey != nil {
-		data[i] = 0x22
-		i++
-		i = encodeVarintApi(data, i, uint64(len(m.EndKey)))
-		i += copy(data[i:], m.EndKey)
+	return i, nil
+}
+
+func (m *DeleteRangeRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	data[i] = 0x2a
-	i++
-	i = encodeVarintApi(data, i, uint64(len(m.User)))
-	i += copy(data[i:], m.User)
-	data[i] = 0x32
+	return data[:n], nil
+}
+
+func (m *DeleteRangeRequest) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
 	i++
-	i = encodeVarintApi(data, i, uint64(m.Replica.Size()))
-	n3, err := m.Replica.MarshalTo(data[i:])
+	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
+	n22, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n3
-	data[i] = 0x38
-	i++
-	i = encodeVarintApi(data, i, uint64(m.RaftID))
-	if m.UserPriority != nil {
-		data[i] = 0x40
-		i++
-		i = encodeVarintApi(data, i, uint64(*m.UserPriority))
-	}
-	if m.Txn != nil {
-		data[i] = 0x4a
-		i++
-		i = encodeVarintApi(data, i, uint64(m.Txn.Size()))
-		n4, err := m.Txn.MarshalTo(data[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n4
-	}
-	data[i] = 0x50
+	i += n22
+	data[i] = 0x10
 	i++
-	i = encodeVarintApi(data, i, uint64(m.ReadConsistency))
+	i = encodeVarintApi(data, i, uint64(m.MaxEntriesToDelete))
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *ResponseHeader) Marshal() (data []byte, err error) {
+func (m *DeleteRangeResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4422,46 +7223,29 @@ func (m *ResponseHeader) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *ResponseHeader) MarshalTo(data []byte) (n int, err error) {
+func (m *DeleteRangeResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Error != nil {
-		data[i] = 0xa
-		i++
-		i = encodeVarintApi(data, i, uint64(m.Error.Size()))
-		n5, err := m.Error.MarshalTo(data[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n5
-	}
-	data[i] = 0x12
+	data[i] = 0xa
 	i++
-	i = encodeVarintApi(data, i, uint64(m.Timestamp.Size()))
-	n6, err := m.Timestamp.MarshalTo(data[i:])
+	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
+	n23, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
-	if m.Txn != nil {
-		data[i] = 0x1a
-		i++
-		i = encodeVarintApi(data, i, uint64(m.Txn.Size()))
-		n7, err := m.Txn.MarshalTo(data[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n7
-	}
+	i += n23
+	data[i] = 0x10
+	i++
+	i = encodeVarintApi(data, i, uint64(m.NumDeleted))
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *GetRequest) Marshal() (data []byte, err error) {
+func (m *ScanRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4471,7 +7255,7 @@ func (m *GetRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *GetRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *ScanRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4479,18 +7263,21 @@ func (m *GetRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
-	n8, err := m.RequestHeader.MarshalTo(data[i:])
+	n24, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n8
+	i += n24
+	data[i] = 0x10
+	i++
+	i = encodeVarintApi(data, i, uint64(m.MaxResults))
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *GetResponse) Marshal() (data []byte, err error) {
+func (m *ScanResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4500,7 +7287,7 @@ func (m *GetResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *GetResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *ScanResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4508,20 +7295,28 @@ func (m *GetResponse) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
-	n9, err := m.ResponseHeader.MarshalTo(data[i:])
+	n25, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n9
-	if m.Value != nil {
-		data[i] = 0x12
-		i++
-		i = encodeVarintApi(data, i, uint64(m.Value.Size()))
-		n10, err := m.Value.MarshalTo(data[i:])
-		if err != nil {
-			return 0, err
+	i += n25
+	if len(m.Rows) > 0 {
+		for _, msg := range m.Rows {
+			data[i] = 0x12
+			i++
+			i = encodeVarintApi(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
-		i += n10
+	}
+	if m.ResumeKey != nil {
+		data[i] = 0x1a
+		i++
+		i = encodeVarintApi(data, i, uint64(len(m.ResumeKey)))
+		i += copy(data[i:], m.ResumeKey)
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
@@ -4529,7 +7324,7 @@ func (m *GetResponse) MarshalTo(data []byte) (n int, err error) {
 	return i, nil
 }
 
-func (m *PutRequest) Marshal() (data []byte, err error) {
+func (m *EndTransactionRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4539,7 +7334,7 @@ func (m *PutRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *PutRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *EndTransactionRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4547,26 +7342,46 @@ func (m *PutRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
-	n11, err := m.RequestHeader.MarshalTo(data[i:])
+	n26, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n11
-	data[i] = 0x12
+	i += n26
+	data[i] = 0x10
 	i++
-	i = encodeVarintApi(data, i, uint64(m.Value.Size()))
-	n12, err := m.Value.MarshalTo(data[i:])
-	if err != nil {
-		return 0, err
+	if m.Commit {
+		data[i] = 1
+	} else {
+		data[i] = 0
+	}
+	i++
+	if m.InternalCommitTrigger != nil {
+		data[i] = 0x1a
+		i++
+		i = encodeVarintApi(data, i, uint64(m.InternalCommitTrigger.Size()))
+		n27, err := m.InternalCommitTrigger.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n27
+	}
+	if m.Deadline != nil {
+		data[i] = 0x22
+		i++
+		i = encodeVarintApi(data, i, uint64(m.Deadline.Size()))
+		n28, err := m.Deadline.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n28
 	}
-	i += n12
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *PutResponse) Marshal() (data []byte, err error) {
+func (m *EndTransactionResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4576,7 +7391,7 @@ func (m *PutResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *PutResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *EndTransactionResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4584,18 +7399,29 @@ func (m *PutResponse) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
-	n13, err := m.ResponseHeader.MarshalTo(data[i:])
+	n29, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n13
+	i += n29
+	data[i] = 0x10
+	i++
+	i = encodeVarintApi(data, i, uint64(m.CommitWait))
+	if len(m.Resolved) > 0 {
+		for _, b := range m.Resolved {
+			data[i] = 0x1a
+			i++
+			i = encodeVarintApi(data, i, uint64(len(b)))
+			i += copy(data[i:], b)
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *ConditionalPutRequest) Marshal() (data []byte, err error) {
+func (m *ReapQueueRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4605,7 +7431,7 @@ func (m *ConditionalPutRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *ConditionalPutRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *ReapQueueRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4613,36 +7439,21 @@ func (m *ConditionalPutRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
-	n14, err := m.RequestHeader.MarshalTo(data[i:])
+	n30, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n14
-	data[i] = 0x12
+	i += n30
+	data[i] = 0x10
 	i++
-	i = encodeVarintApi(data, i, uint64(m.Value.Size()))
-	n15, err := m.Value.MarshalTo(data[i:])
-	if err != nil {
-		return 0, err
-	}
-	i += n15
-	if m.ExpValue != nil {
-		data[i] = 0x1a
-		i++
-		i = encodeVarintApi(data, i, uint64(m.ExpValue.Size()))
-		n16, err := m.ExpValue.MarshalTo(data[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n16
-	}
+	i = encodeVarintApi(data, i, uint64(m.MaxResults))
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *ConditionalPutResponse) Marshal() (data []byte, err error) {
+func (m *ReapQueueResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4652,7 +7463,7 @@ func (m *ConditionalPutResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *ConditionalPutResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *ReapQueueResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4660,18 +7471,30 @@ func (m *ConditionalPutResponse) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
-	n17, err := m.ResponseHeader.MarshalTo(data[i:])
+	n31, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n17
+	i += n31
+	if len(m.Messages) > 0 {
+		for _, msg := range m.Messages {
+			data[i] = 0x12
+			i++
+			i = encodeVarintApi(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *IncrementRequest) Marshal() (data []byte, err error) {
+func (m *EnqueueUpdateRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4681,7 +7504,7 @@ func (m *IncrementRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *IncrementRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *EnqueueUpdateRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4689,21 +7512,26 @@ func (m *IncrementRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
-	n18, err := m.RequestHeader.MarshalTo(data[i:])
+	n32, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n18
-	data[i] = 0x10
+	i += n32
+	data[i] = 0x12
 	i++
-	i = encodeVarintApi(data, i, uint64(m.Increment))
+	i = encodeVarintApi(data, i, uint64(m.Update.Size()))
+	n33, err := m.Update.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n33
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *IncrementResponse) Marshal() (data []byte, err error) {
+func (m *EnqueueUpdateResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4713,7 +7541,7 @@ func (m *IncrementResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *IncrementResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *EnqueueUpdateResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4721,21 +7549,18 @@ func (m *IncrementResponse) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
-	n19, err := m.ResponseHeader.MarshalTo(data[i:])
+	n34, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n19
-	data[i] = 0x10
-	i++
-	i = encodeVarintApi(data, i, uint64(m.NewValue))
+	i += n34
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *DeleteRequest) Marshal() (data []byte, err error) {
+func (m *EnqueueMessageRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4745,7 +7570,7 @@ func (m *DeleteRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *DeleteRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *EnqueueMessageRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4753,18 +7578,26 @@ func (m *DeleteRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
-	n20, err := m.RequestHeader.MarshalTo(data[i:])
+	n35, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n20
+	i += n35
+	data[i] = 0x12
+	i++
+	i = encodeVarintApi(data, i, uint64(m.Msg.Size()))
+	n36, err := m.Msg.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n36
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *DeleteResponse) Marshal() (data []byte, err error) {
+func (m *EnqueueMessageResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4774,7 +7607,7 @@ func (m *DeleteResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *DeleteResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *EnqueueMessageResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4782,18 +7615,18 @@ func (m *DeleteResponse) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
-	n21, err := m.ResponseHeader.MarshalTo(data[i:])
+	n37, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n21
+	i += n37
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *DeleteRangeRequest) Marshal() (data []byte, err error) {
+func (m *ChangesRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4803,7 +7636,7 @@ func (m *DeleteRangeRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *DeleteRangeRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *ChangesRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4811,21 +7644,26 @@ func (m *DeleteRangeRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
-	n22, err := m.RequestHeader.MarshalTo(data[i:])
+	n38, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n22
-	data[i] = 0x10
+	i += n38
+	data[i] = 0x12
 	i++
-	i = encodeVarintApi(data, i, uint64(m.MaxEntriesToDelete))
+	i = encodeVarintApi(data, i, uint64(m.StartTimestamp.Size()))
+	n39, err := m.StartTimestamp.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n39
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *DeleteRangeResponse) Marshal() (data []byte, err error) {
+func (m *ChangesResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4835,7 +7673,7 @@ func (m *DeleteRangeResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *DeleteRangeResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *ChangesResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4843,21 +7681,38 @@ func (m *DeleteRangeResponse) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
-	n23, err := m.ResponseHeader.MarshalTo(data[i:])
+	n40, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n23
-	data[i] = 0x10
+	i += n40
+	if len(m.Events) > 0 {
+		for _, msg := range m.Events {
+			data[i] = 0x12
+			i++
+			i = encodeVarintApi(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	data[i] = 0x1a
 	i++
-	i = encodeVarintApi(data, i, uint64(m.NumDeleted))
+	i = encodeVarintApi(data, i, uint64(m.Resolved.Size()))
+	n41, err := m.Resolved.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n41
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *ScanRequest) Marshal() (data []byte, err error) {
+func (m *ImportRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4867,7 +7722,7 @@ func (m *ScanRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *ScanRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *ImportRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4875,21 +7730,30 @@ func (m *ScanRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
-	n24, err := m.RequestHeader.MarshalTo(data[i:])
+	n42, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n24
-	data[i] = 0x10
-	i++
-	i = encodeVarintApi(data, i, uint64(m.MaxResults))
+	i += n42
+	if len(m.Rows) > 0 {
+		for _, msg := range m.Rows {
+			data[i] = 0x12
+			i++
+			i = encodeVarintApi(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *ScanResponse) Marshal() (data []byte, err error) {
+func (m *ImportResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4899,7 +7763,7 @@ func (m *ScanResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *ScanResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *ImportResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4907,30 +7771,21 @@ func (m *ScanResponse) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
-	n25, err := m.ResponseHeader.MarshalTo(data[i:])
+	n43, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n25
-	if len(m.Rows) > 0 {
-		for _, msg := range m.Rows {
-			data[i] = 0x12
-			i++
-			i = encodeVarintApi(data, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(data[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
+	i += n43
+	data[i] = 0x10
+	i++
+	i = encodeVarintApi(data, i, uint64(m.NumImported))
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *EndTransactionRequest) Marshal() (data []byte, err error) {
+func (m *SpanStatsRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4940,7 +7795,7 @@ func (m *EndTransactionRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *EndTransactionRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *SpanStatsRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4948,36 +7803,18 @@ func (m *EndTransactionRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
-	n26, err := m.RequestHeader.MarshalTo(data[i:])
+	n44, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n26
-	data[i] = 0x10
-	i++
-	if m.Commit {
-		data[i] = 1
-	} else {
-		data[i] = 0
-	}
-	i++
-	if m.InternalCommitTrigger != nil {
-		data[i] = 0x1a
-		i++
-		i = encodeVarintApi(data, i, uint64(m.InternalCommitTrigger.Size()))
-		n27, err := m.InternalCommitTrigger.MarshalTo(data[i:])
-		if err != nil {
-			return 0, err
-		}
-		i += n27
-	}
+	i += n44
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *EndTransactionResponse) Marshal() (data []byte, err error) {
+func (m *SpanStatsResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -4987,7 +7824,7 @@ func (m *EndTransactionResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *EndTransactionResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *SpanStatsResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -4995,22 +7832,50 @@ func (m *EndTransactionResponse) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
-	n28, err := m.ResponseHeader.MarshalTo(data[i:])
+	n45, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n28
+	i += n45
 	data[i] = 0x10
 	i++
-	i = encodeVarintApi(data, i, uint64(m.CommitWait))
-	if len(m.Resolved) > 0 {
-		for _, b := range m.Resolved {
-			data[i] = 0x1a
-			i++
-			i = encodeVarintApi(data, i, uint64(len(b)))
-			i += copy(data[i:], b)
-		}
-	}
+	i = encodeVarintApi(data, i, uint64(m.LiveBytes))
+	data[i] = 0x18
+	i++
+	i = encodeVarintApi(data, i, uint64(m.KeyBytes))
+	data[i] = 0x20
+	i++
+	i = encodeVarintApi(data, i, uint64(m.ValBytes))
+	data[i] = 0x28
+	i++
+	i = encodeVarintApi(data, i, uint64(m.IntentBytes))
+	data[i] = 0x30
+	i++
+	i = encodeVarintApi(data, i, uint64(m.LiveCount))
+	data[i] = 0x38
+	i++
+	i = encodeVarintApi(data, i, uint64(m.KeyCount))
+	data[i] = 0x40
+	i++
+	i = encodeVarintApi(data, i, uint64(m.ValCount))
+	data[i] = 0x48
+	i++
+	i = encodeVarintApi(data, i, uint64(m.IntentCount))
+	data[i] = 0x50
+	i++
+	i = encodeVarintApi(data, i, uint64(m.GCBytesAge))
+	data[i] = 0x58
+	i++
+	i = encodeVarintApi(data, i, uint64(m.SysBytes))
+	data[i] = 0x60
+	i++
+	i = encodeVarintApi(data, i, uint64(m.SysCount))
+	data[i] = 0x68
+	i++
+	i = encodeVarintApi(data, i, uint64(m.RangeCount))
+	data[i] = 0x70
+	i++
+	i = encodeVarintApi(data, i, uint64(m.ScannedRangeCount))
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
@@ -5036,81 +7901,141 @@ func (m *RequestUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x12
 		i++
 		i = encodeVarintApi(data, i, uint64(m.Get.Size()))
-		n29, err := m.Get.MarshalTo(data[i:])
+		n46, err := m.Get.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n29
+		i += n46
 	}
 	if m.Put != nil {
 		data[i] = 0x1a
 		i++
 		i = encodeVarintApi(data, i, uint64(m.Put.Size()))
-		n30, err := m.Put.MarshalTo(data[i:])
+		n47, err := m.Put.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n30
+		i += n47
 	}
 	if m.ConditionalPut != nil {
 		data[i] = 0x22
 		i++
 		i = encodeVarintApi(data, i, uint64(m.ConditionalPut.Size()))
-		n31, err := m.ConditionalPut.MarshalTo(data[i:])
+		n48, err := m.ConditionalPut.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n31
+		i += n48
 	}
 	if m.Increment != nil {
 		data[i] = 0x2a
 		i++
 		i = encodeVarintApi(data, i, uint64(m.Increment.Size()))
-		n32, err := m.Increment.MarshalTo(data[i:])
+		n49, err := m.Increment.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n32
+		i += n49
 	}
 	if m.Delete != nil {
 		data[i] = 0x32
 		i++
 		i = encodeVarintApi(data, i, uint64(m.Delete.Size()))
-		n33, err := m.Delete.MarshalTo(data[i:])
+		n50, err := m.Delete.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n33
+		i += n50
 	}
 	if m.DeleteRange != nil {
 		data[i] = 0x3a
 		i++
 		i = encodeVarintApi(data, i, uint64(m.DeleteRange.Size()))
-		n34, err := m.DeleteRange.MarshalTo(data[i:])
+		n51, err := m.DeleteRange.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n34
+		i += n51
 	}
 	if m.Scan != nil {
 		data[i] = 0x42
 		i++
 		i = encodeVarintApi(data, i, uint64(m.Scan.Size()))
-		n35, err := m.Scan.MarshalTo(data[i:])
+		n52, err := m.Scan.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n35
+		i += n52
 	}
 	if m.EndTransaction != nil {
 		data[i] = 0x4a
 		i++
 		i = encodeVarintApi(data, i, uint64(m.EndTransaction.Size()))
-		n36, err := m.EndTransaction.MarshalTo(data[i:])
+		n53, err := m.EndTransaction.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n53
+	}
+	if m.ReapQueue != nil {
+		data[i] = 0x52
+		i++
+		i = encodeVarintApi(data, i, uint64(m.ReapQueue.Size()))
+		n54, err := m.ReapQueue.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n54
+	}
+	if m.EnqueueUpdate != nil {
+		data[i] = 0x5a
+		i++
+		i = encodeVarintApi(data, i, uint64(m.EnqueueUpdate.Size()))
+		n55, err := m.EnqueueUpdate.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n55
+	}
+	if m.EnqueueMessage != nil {
+		data[i] = 0x62
+		i++
+		i = encodeVarintApi(data, i, uint64(m.EnqueueMessage.Size()))
+		n56, err := m.EnqueueMessage.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n56
+	}
+	if m.Changes != nil {
+		data[i] = 0x6a
+		i++
+		i = encodeVarintApi(data, i, uint64(m.Changes.Size()))
+		n57, err := m.Changes.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n57
+	}
+	if m.Import != nil {
+		data[i] = 0x72
+		i++
+		i = encodeVarintApi(data, i, uint64(m.Import.Size()))
+		n58, err := m.Import.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n58
+	}
+	if m.SpanStats != nil {
+		data[i] = 0x7a
+		i++
+		i = encodeVarintApi(data, i, uint64(m.SpanStats.Size()))
+		n59, err := m.SpanStats.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n36
+		i += n59
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
@@ -5137,81 +8062,141 @@ func (m *ResponseUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x12
 		i++
 		i = encodeVarintApi(data, i, uint64(m.Get.Size()))
-		n37, err := m.Get.MarshalTo(data[i:])
+		n60, err := m.Get.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n37
+		i += n60
 	}
 	if m.Put != nil {
 		data[i] = 0x1a
 		i++
 		i = encodeVarintApi(data, i, uint64(m.Put.Size()))
-		n38, err := m.Put.MarshalTo(data[i:])
+		n61, err := m.Put.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n38
+		i += n61
 	}
 	if m.ConditionalPut != nil {
 		data[i] = 0x22
 		i++
 		i = encodeVarintApi(data, i, uint64(m.ConditionalPut.Size()))
-		n39, err := m.ConditionalPut.MarshalTo(data[i:])
+		n62, err := m.ConditionalPut.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n39
+		i += n62
 	}
 	if m.Increment != nil {
 		data[i] = 0x2a
 		i++
 		i = encodeVarintApi(data, i, uint64(m.Increment.Size()))
-		n40, err := m.Increment.MarshalTo(data[i:])
+		n63, err := m.Increment.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n40
+		i += n63
 	}
 	if m.Delete != nil {
 		data[i] = 0x32
 		i++
 		i = encodeVarintApi(data, i, uint64(m.Delete.Size()))
-		n41, err := m.Delete.MarshalTo(data[i:])
+		n64, err := m.Delete.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n41
+		i += n64
 	}
 	if m.DeleteRange != nil {
 		data[i] = 0x3a
 		i++
 		i = encodeVarintApi(data, i, uint64(m.DeleteRange.Size()))
-		n42, err := m.DeleteRange.MarshalTo(data[i:])
+		n65, err := m.DeleteRange.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n42
+		i += n65
 	}
 	if m.Scan != nil {
 		data[i] = 0x42
 		i++
 		i = encodeVarintApi(data, i, uint64(m.Scan.Size()))
-		n43, err := m.Scan.MarshalTo(data[i:])
+		n66, err := m.Scan.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n43
+		i += n66
 	}
 	if m.EndTransaction != nil {
 		data[i] = 0x4a
 		i++
 		i = encodeVarintApi(data, i, uint64(m.EndTransaction.Size()))
-		n44, err := m.EndTransaction.MarshalTo(data[i:])
+		n67, err := m.EndTransaction.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n67
+	}
+	if m.ReapQueue != nil {
+		data[i] = 0x52
+		i++
+		i = encodeVarintApi(data, i, uint64(m.ReapQueue.Size()))
+		n68, err := m.ReapQueue.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n68
+	}
+	if m.EnqueueUpdate != nil {
+		data[i] = 0x5a
+		i++
+		i = encodeVarintApi(data, i, uint64(m.EnqueueUpdate.Size()))
+		n69, err := m.EnqueueUpdate.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n69
+	}
+	if m.EnqueueMessage != nil {
+		data[i] = 0x62
+		i++
+		i = encodeVarintApi(data, i, uint64(m.EnqueueMessage.Size()))
+		n70, err := m.EnqueueMessage.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n44
+		i += n70
+	}
+	if m.Changes != nil {
+		data[i] = 0x6a
+		i++
+		i = encodeVarintApi(data, i, uint64(m.Changes.Size()))
+		n71, err := m.Changes.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n71
+	}
+	if m.Import != nil {
+		data[i] = 0x72
+		i++
+		i = encodeVarintApi(data, i, uint64(m.Import.Size()))
+		n72, err := m.Import.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n72
+	}
+	if m.SpanStats != nil {
+		data[i] = 0x7a
+		i++
+		i = encodeVarintApi(data, i, uint64(m.SpanStats.Size()))
+		n73, err := m.SpanStats.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n73
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
@@ -5237,11 +8222,11 @@ func (m *BatchRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
-	n45, err := m.RequestHeader.MarshalTo(data[i:])
+	n74, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n45
+	i += n74
 	if len(m.Requests) > 0 {
 		for _, msg := range m.Requests {
 			data[i] = 0x12
@@ -5278,11 +8263,11 @@ func (m *BatchResponse) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
-	n46, err := m.ResponseHeader.MarshalTo(data[i:])
+	n75, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n46
+	i += n75
 	if len(m.Responses) > 0 {
 		for _, msg := range m.Responses {
 			data[i] = 0x12
@@ -5319,11 +8304,11 @@ func (m *AdminSplitRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
-	n47, err := m.RequestHeader.MarshalTo(data[i:])
+	n76, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n47
+	i += n76
 	if m.SplitKey != nil {
 		data[i] = 0x12
 		i++
@@ -5354,11 +8339,11 @@ func (m *AdminSplitResponse) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
-	n48, err := m.ResponseHeader.MarshalTo(data[i:])
+	n77, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n48
+	i += n77
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
@@ -5383,11 +8368,11 @@ func (m *AdminMergeRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.RequestHeader.Size()))
-	n49, err := m.RequestHeader.MarshalTo(data[i:])
+	n78, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n49
+	i += n78
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
@@ -5412,11 +8397,11 @@ func (m *AdminMergeResponse) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintApi(data, i, uint64(m.ResponseHeader.Size()))
-	n50, err := m.ResponseHeader.MarshalTo(data[i:])
+	n79, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n50
+	i += n79
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
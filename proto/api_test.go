@@ -138,6 +138,73 @@ func TestCombinable(t *testing.T) {
 	if !reflect.DeepEqual(dr1, wantedDR) {
 		t.Errorf("wanted %v, got %v", wantedDR, dr1)
 	}
+
+	// The combined resolved timestamp is the minimum, and events above
+	// it are dropped.
+	ts1, ts2, ts3 := Timestamp{WallTime: 1}, Timestamp{WallTime: 2}, Timestamp{WallTime: 3}
+	cr1 := &ChangesResponse{
+		Events: []ChangeEvent{
+			{Key: Key("A"), Value: Value{Timestamp: &ts1}},
+			{Key: Key("A"), Value: Value{Timestamp: &ts3}},
+		},
+		Resolved: ts3,
+	}
+	if _, ok := interface{}(cr1).(Combinable); !ok {
+		t.Fatalf("ChangesResponse does not implement Combinable")
+	}
+	cr2 := &ChangesResponse{
+		Events: []ChangeEvent{
+			{Key: Key("B"), Value: Value{Timestamp: &ts2}},
+		},
+		Resolved: ts2,
+	}
+	wantedCR := &ChangesResponse{
+		Events:   []ChangeEvent{cr1.Events[0], cr2.Events[0]},
+		Resolved: ts2,
+	}
+	cr1.Combine(cr2)
+
+	if !reflect.DeepEqual(cr1, wantedCR) {
+		t.Errorf("wanted %v, got %v", wantedCR, cr1)
+	}
+
+	// Span stats are summed.
+	ss1 := &SpanStatsResponse{LiveBytes: 1, KeyBytes: 2, ValBytes: 3, KeyCount: 1, GCBytesAge: 10, RangeCount: 1, ScannedRangeCount: 1}
+	if _, ok := interface{}(ss1).(Combinable); !ok {
+		t.Fatalf("SpanStatsResponse does not implement Combinable")
+	}
+	ss2 := &SpanStatsResponse{LiveBytes: 4, KeyBytes: 5, ValBytes: 6, KeyCount: 2, GCBytesAge: 20, SysBytes: 7, RangeCount: 1}
+	wantedSS := &SpanStatsResponse{LiveBytes: 5, KeyBytes: 7, ValBytes: 9, KeyCount: 3, GCBytesAge: 30, SysBytes: 7, RangeCount: 2, ScannedRangeCount: 1}
+	ss1.Combine(ss2)
+
+	if !reflect.DeepEqual(ss1, wantedSS) {
+		t.Errorf("wanted %v, got %v", wantedSS, ss1)
+	}
+	if gcBytes := ss1.GCBytes(); gcBytes != 11 {
+		t.Errorf("expected 11 gc bytes, got %d", gcBytes)
+	}
+}
+
+// TestScanResponseSetResumeKey verifies the resume key of truncated
+// scans.
+func TestScanResponseSetResumeKey(t *testing.T) {
+	testCases := []struct {
+		rows   []KeyValue
+		endKey Key
+		expKey Key
+	}{
+		{nil, Key("z"), nil},
+		{[]KeyValue{{Key: Key("a")}, {Key: Key("b")}}, Key("z"), Key("b\x00")},
+		{[]KeyValue{{Key: Key("a")}}, Key("a\x00"), nil},
+		{[]KeyValue{{Key: Key("a")}}, Key("a\x00\x00"), Key("a\x00")},
+	}
+	for i, test := range testCases {
+		sr := &ScanResponse{Rows: test.rows, ResumeKey: Key("stale")}
+		sr.SetResumeKey(test.endKey)
+		if !sr.ResumeKey.Equal(test.expKey) {
+			t.Errorf("%d: expected resume key %q; got %q", i, test.expKey, sr.ResumeKey)
+		}
+	}
 }
 
 func TestSetGoErrorCopy(t *testing.T) {
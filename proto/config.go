@@ -102,6 +102,20 @@ func (a Attributes) String() string {
 	return strings.Join(a.uniqueAttrs(), ",")
 }
 
+// CommonPrefix returns the number of leading attributes which
+// attribute lists a and b have in common. Node attributes are ordered
+// from the most general to the most specific, so nodes sharing a longer
+// prefix are closer to each other.
+func (a Attributes) CommonPrefix(b Attributes) int {
+	n := 0
+	for ; n < len(a.Attrs) && n < len(b.Attrs); n++ {
+		if a.Attrs[n] != b.Attrs[n] {
+			break
+		}
+	}
+	return n
+}
+
 // SortedString returns a sorted, de-duplicated, comma-separated list
 // of the attributes.
 func (a Attributes) SortedString() string {
@@ -110,6 +124,46 @@ func (a Attributes) SortedString() string {
 	return strings.Join(attrs, ",")
 }
 
+// String returns the tiers of the locality as a comma-separated list
+// of key=value pairs, in the format accepted by ParseLocality.
+func (l Locality) String() string {
+	tiers := make([]string, len(l.Tiers))
+	for i, t := range l.Tiers {
+		tiers[i] = t.Key + "=" + t.Value
+	}
+	return strings.Join(tiers, ",")
+}
+
+// ParseLocality parses a comma-separated list of key=value pairs, from
+// the most general tier to the most specific, into a Locality.
+func ParseLocality(s string) (Locality, error) {
+	var l Locality
+	if len(s) == 0 {
+		return l, nil
+	}
+	for _, pair := range strings.Split(s, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || len(kv[0]) == 0 || len(kv[1]) == 0 {
+			return Locality{}, fmt.Errorf("invalid locality tier %q; expected key=value", pair)
+		}
+		l.Tiers = append(l.Tiers, Tier{Key: kv[0], Value: kv[1]})
+	}
+	return l, nil
+}
+
+// SharedTiers returns the number of leading tiers which localities l
+// and o have in common. Nodes sharing more tiers are more likely to
+// fail together; nodes with no locality share none.
+func (l Locality) SharedTiers(o Locality) int {
+	n := 0
+	for ; n < len(l.Tiers) && n < len(o.Tiers); n++ {
+		if l.Tiers[n].Key != o.Tiers[n].Key || l.Tiers[n].Value != o.Tiers[n].Value {
+			break
+		}
+	}
+	return n
+}
+
 // ContainsKey returns whether this RangeDescriptor contains the specified key.
 func (r *RangeDescriptor) ContainsKey(key []byte) bool {
 	return bytes.Compare(key, r.StartKey) >= 0 && bytes.Compare(key, r.EndKey) < 0
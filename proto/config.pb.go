@@ -75,16 +75,22 @@ func (m *RangeDescriptor) GetReplicas() []Replica {
 }
 
 // GCPolicy defines garbage collection policies which apply to MVCC
-// values within a zone.
-//
-// TODO(spencer): flesh this out to include maximum number of values
-//   as well as whether there's an intersection between max values
-//   and TTL or a union.
+// values within a zone. A version of a value is retained if it is
+// among the MinVersions most recent versions, or if it is younger than
+// TTLSeconds and among the MaxVersions most recent versions. The most
+// recent version is always retained unless it is a deletion.
 type GCPolicy struct {
 	// TTLSeconds specifies the maximum age of a value before it's
 	// garbage collected. Only older versions of values are garbage
 	// collected. Specifying <=0 mean older versions are never GC'd.
-	TTLSeconds       int32  `protobuf:"varint,1,opt,name=ttl_seconds" json:"ttl_seconds"`
+	TTLSeconds int32 `protobuf:"varint,1,opt,name=ttl_seconds" json:"ttl_seconds"`
+	// MinVersions specifies the number of most recent versions of a value
+	// which are retained regardless of their age.
+	MinVersions int32 `protobuf:"varint,2,opt,name=min_versions" json:"min_versions"`
+	// MaxVersions specifies the number of most recent versions of a value
+	// beyond which older versions are garbage collected regardless of
+	// their age. Specifying 0 means the number of versions is unlimited.
+	MaxVersions      int32  `protobuf:"varint,3,opt,name=max_versions" json:"max_versions"`
 	XXX_unrecognized []byte `json:"-"`
 }
 
@@ -99,6 +105,20 @@ func (m *GCPolicy) GetTTLSeconds() int32 {
 	return 0
 }
 
+func (m *GCPolicy) GetMinVersions() int32 {
+	if m != nil {
+		return m.MinVersions
+	}
+	return 0
+}
+
+func (m *GCPolicy) GetMaxVersions() int32 {
+	if m != nil {
+		return m.MaxVersions
+	}
+	return 0
+}
+
 // AcctConfig holds accounting configuration.
 type AcctConfig struct {
 	ClusterId        string `protobuf:"bytes,1,opt,name=cluster_id" json:"cluster_id" yaml:"cluster_id,omitempty"`
@@ -171,8 +191,12 @@ type ZoneConfig struct {
 	RangeMaxBytes int64        `protobuf:"varint,3,opt,name=range_max_bytes" json:"range_max_bytes" yaml:"range_max_bytes,omitempty"`
 	// If GC policy is not set, uses the next highest, non-null policy
 	// in the zone config hierarchy, up to the default policy if necessary.
-	GC               *GCPolicy `protobuf:"bytes,4,opt,name=gc" json:"gc,omitempty" yaml:"gc,omitempty"`
-	XXX_unrecognized []byte    `json:"-"`
+	GC *GCPolicy `protobuf:"bytes,4,opt,name=gc" json:"gc,omitempty" yaml:"gc,omitempty"`
+	// RangeMaxQPS is the request rate, in requests per second, above which
+	// a range is split at a key dividing its load. Zero disables load-based
+	// splitting.
+	RangeMaxQPS      int64  `protobuf:"varint,5,opt,name=range_max_qps" json:"range_max_qps,omitempty" yaml:"range_max_qps,omitempty"`
+	XXX_unrecognized []byte `json:"-"`
 }
 
 func (m *ZoneConfig) Reset()         { *m = ZoneConfig{} }
@@ -207,6 +231,13 @@ func (m *ZoneConfig) GetGC() *GCPolicy {
 	return nil
 }
 
+func (m *ZoneConfig) GetRangeMaxQPS() int64 {
+	if m != nil {
+		return m.RangeMaxQPS
+	}
+	return 0
+}
+
 // TODO(bram): this comment has rotted, there is no size.
 // RangeTree holds the root node and size of the range tree.
 type RangeTree struct {
@@ -273,6 +304,7 @@ type StoreCapacity struct {
 	Capacity         int64  `protobuf:"varint,1,opt" json:"Capacity"`
 	Available        int64  `protobuf:"varint,2,opt" json:"Available"`
 	RangeCount       int32  `protobuf:"varint,3,opt" json:"RangeCount"`
+	LeaseCount       int32  `protobuf:"varint,4,opt" json:"LeaseCount"`
 	XXX_unrecognized []byte `json:"-"`
 }
 
@@ -301,11 +333,66 @@ func (m *StoreCapacity) GetRangeCount() int32 {
 	return 0
 }
 
+func (m *StoreCapacity) GetLeaseCount() int32 {
+	if m != nil {
+		return m.LeaseCount
+	}
+	return 0
+}
+
+// Tier is a single level of a locality, e.g. the datacenter a node
+// is located in.
+type Tier struct {
+	// Key is the name of the tier, e.g. "region" or "rack".
+	Key string `protobuf:"bytes,1,opt,name=key" json:"key"`
+	// Value is the value of the tier, e.g. "us-east" or "r12".
+	Value            string `protobuf:"bytes,2,opt,name=value" json:"value"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *Tier) Reset()         { *m = Tier{} }
+func (m *Tier) String() string { return proto1.CompactTextString(m) }
+func (*Tier) ProtoMessage()    {}
+
+func (m *Tier) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *Tier) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+// Locality is the failure domain of a node, as an ordered list of tiers
+// from the most general to the most specific, e.g.
+// "region=us-east,datacenter=us-east-1a,rack=12". Replicas of a range
+// are spread across as many distinct tiers as possible.
+type Locality struct {
+	Tiers            []Tier `protobuf:"bytes,1,rep,name=tiers" json:"tiers"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *Locality) Reset()      { *m = Locality{} }
+func (*Locality) ProtoMessage() {}
+
+func (m *Locality) GetTiers() []Tier {
+	if m != nil {
+		return m.Tiers
+	}
+	return nil
+}
+
 // NodeDescriptor holds details on node physical/network topology.
 type NodeDescriptor struct {
 	NodeID           NodeID     `protobuf:"varint,1,opt,name=node_id,casttype=NodeID" json:"node_id"`
 	Address          Addr       `protobuf:"bytes,2,opt,name=address" json:"address"`
 	Attrs            Attributes `protobuf:"bytes,3,opt,name=attrs" json:"attrs"`
+	Locality         Locality   `protobuf:"bytes,4,opt,name=locality" json:"locality"`
 	XXX_unrecognized []byte     `json:"-"`
 }
 
@@ -327,14 +414,24 @@ func (m *NodeDescriptor) GetAttrs() Attributes {
 	return Attributes{}
 }
 
+func (m *NodeDescriptor) GetLocality() Locality {
+	if m != nil {
+		return m.Locality
+	}
+	return Locality{}
+}
+
 // StoreDescriptor holds store information including store attributes, node
 // descriptor and store capacity.
 type StoreDescriptor struct {
-	StoreID          StoreID        `protobuf:"varint,1,opt,name=store_id,casttype=StoreID" json:"store_id"`
-	Attrs            Attributes     `protobuf:"bytes,2,opt,name=attrs" json:"attrs"`
-	Node             NodeDescriptor `protobuf:"bytes,3,opt,name=node" json:"node"`
-	Capacity         StoreCapacity  `protobuf:"bytes,4,opt,name=capacity" json:"capacity"`
-	XXX_unrecognized []byte         `json:"-"`
+	StoreID  StoreID        `protobuf:"varint,1,opt,name=store_id,casttype=StoreID" json:"store_id"`
+	Attrs    Attributes     `protobuf:"bytes,2,opt,name=attrs" json:"attrs"`
+	Node     NodeDescriptor `protobuf:"bytes,3,opt,name=node" json:"node"`
+	Capacity StoreCapacity  `protobuf:"bytes,4,opt,name=capacity" json:"capacity"`
+	// QuarantinedRaftIDs lists the ranges whose replicas on this store
+	// have been quarantined after failing checksum verification.
+	QuarantinedRaftIDs []RaftID `protobuf:"varint,5,rep,name=quarantined_raft_ids,casttype=RaftID" json:"quarantined_raft_ids,omitempty"`
+	XXX_unrecognized   []byte   `json:"-"`
 }
 
 func (m *StoreDescriptor) Reset()         { *m = StoreDescriptor{} }
@@ -663,6 +760,36 @@ func (m *GCPolicy) Unmarshal(data []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinVersions", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MinVersions |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxVersions", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.MaxVersions |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			var sizeOfWire int
 			for {
@@ -1005,6 +1132,21 @@ func (m *ZoneConfig) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RangeMaxQPS", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.RangeMaxQPS |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			var sizeOfWire int
 			for {
@@ -1401,6 +1543,178 @@ func (m *StoreCapacity) Unmarshal(data []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseCount", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.LeaseCount |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipConfig(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *Tier) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + int(stringLen)
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(data[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipConfig(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *Locality) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tiers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Tiers = append(m.Tiers, Tier{})
+			if err := m.Tiers[len(m.Tiers)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -1507,6 +1821,30 @@ func (m *NodeDescriptor) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Locality", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Locality.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -1637,6 +1975,23 @@ func (m *StoreDescriptor) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuarantinedRaftIDs", wireType)
+			}
+			var v RaftID
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (RaftID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.QuarantinedRaftIDs = append(m.QuarantinedRaftIDs, v)
 		default:
 			var sizeOfWire int
 			for {
@@ -1799,6 +2154,8 @@ func (m *GCPolicy) Size() (n int) {
 	var l int
 	_ = l
 	n += 1 + sovConfig(uint64(m.TTLSeconds))
+	n += 1 + sovConfig(uint64(m.MinVersions))
+	n += 1 + sovConfig(uint64(m.MaxVersions))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1865,6 +2222,7 @@ func (m *ZoneConfig) Size() (n int) {
 		l = m.GC.Size()
 		n += 1 + l + sovConfig(uint64(l))
 	}
+	n += 1 + sovConfig(uint64(m.RangeMaxQPS))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1929,6 +2287,35 @@ func (m *StoreCapacity) Size() (n int) {
 	n += 1 + sovConfig(uint64(m.Capacity))
 	n += 1 + sovConfig(uint64(m.Available))
 	n += 1 + sovConfig(uint64(m.RangeCount))
+	n += 1 + sovConfig(uint64(m.LeaseCount))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Tier) Size() (n int) {
+	var l int
+	_ = l
+	l = len(m.Key)
+	n += 1 + l + sovConfig(uint64(l))
+	l = len(m.Value)
+	n += 1 + l + sovConfig(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Locality) Size() (n int) {
+	var l int
+	_ = l
+	if len(m.Tiers) > 0 {
+		for _, e := range m.Tiers {
+			l = e.Size()
+			n += 1 + l + sovConfig(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1943,6 +2330,8 @@ func (m *NodeDescriptor) Size() (n int) {
 	n += 1 + l + sovConfig(uint64(l))
 	l = m.Attrs.Size()
 	n += 1 + l + sovConfig(uint64(l))
+	l = m.Locality.Size()
+	n += 1 + l + sovConfig(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1959,6 +2348,11 @@ func (m *StoreDescriptor) Size() (n int) {
 	n += 1 + l + sovConfig(uint64(l))
 	l = m.Capacity.Size()
 	n += 1 + l + sovConfig(uint64(l))
+	if len(m.QuarantinedRaftIDs) > 0 {
+		for _, e := range m.QuarantinedRaftIDs {
+			n += 1 + sovConfig(uint64(e))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2107,6 +2501,12 @@ func (m *GCPolicy) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0x8
 	i++
 	i = encodeVarintConfig(data, i, uint64(m.TTLSeconds))
+	data[i] = 0x10
+	i++
+	i = encodeVarintConfig(data, i, uint64(m.MinVersions))
+	data[i] = 0x18
+	i++
+	i = encodeVarintConfig(data, i, uint64(m.MaxVersions))
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
@@ -2259,6 +2659,9 @@ func (m *ZoneConfig) MarshalTo(data []byte) (n int, err error) {
 		}
 		i += n1
 	}
+	data[i] = 0x28
+	i++
+	i = encodeVarintConfig(data, i, uint64(m.RangeMaxQPS))
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
@@ -2406,6 +2809,71 @@ func (m *StoreCapacity) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0x18
 	i++
 	i = encodeVarintConfig(data, i, uint64(m.RangeCount))
+	data[i] = 0x20
+	i++
+	i = encodeVarintConfig(data, i, uint64(m.LeaseCount))
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Tier) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *Tier) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintConfig(data, i, uint64(len(m.Key)))
+	i += copy(data[i:], m.Key)
+	data[i] = 0x12
+	i++
+	i = encodeVarintConfig(data, i, uint64(len(m.Value)))
+	i += copy(data[i:], m.Value)
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *Locality) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *Locality) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if len(m.Tiers) > 0 {
+		for _, msg := range m.Tiers {
+			data[i] = 0xa
+			i++
+			i = encodeVarintConfig(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
@@ -2446,6 +2914,14 @@ func (m *NodeDescriptor) MarshalTo(data []byte) (n int, err error) {
 		return 0, err
 	}
 	i += n5
+	data[i] = 0x22
+	i++
+	i = encodeVarintConfig(data, i, uint64(m.Locality.Size()))
+	n6, err := m.Locality.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n6
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
@@ -2473,27 +2949,34 @@ func (m *StoreDescriptor) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0x12
 	i++
 	i = encodeVarintConfig(data, i, uint64(m.Attrs.Size()))
-	n6, err := m.Attrs.MarshalTo(data[i:])
+	n7, err := m.Attrs.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
+	i += n7
 	data[i] = 0x1a
 	i++
 	i = encodeVarintConfig(data, i, uint64(m.Node.Size()))
-	n7, err := m.Node.MarshalTo(data[i:])
+	n8, err := m.Node.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n7
+	i += n8
 	data[i] = 0x22
 	i++
 	i = encodeVarintConfig(data, i, uint64(m.Capacity.Size()))
-	n8, err := m.Capacity.MarshalTo(data[i:])
+	n9, err := m.Capacity.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n8
+	i += n9
+	if len(m.QuarantinedRaftIDs) > 0 {
+		for _, num := range m.QuarantinedRaftIDs {
+			data[i] = 0x28
+			i++
+			i = encodeVarintConfig(data, i, uint64(num))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
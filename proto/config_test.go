@@ -62,6 +62,79 @@ func TestAttributesSortedString(t *testing.T) {
 	}
 }
 
+func TestParseLocality(t *testing.T) {
+	testCases := []struct {
+		s     string
+		tiers int
+		err   bool
+	}{
+		{"", 0, false},
+		{"region=us-east", 1, false},
+		{"region=us-east,datacenter=us-east-1a,rack=12", 3, false},
+		{"us-east", 0, true},
+		{"region=", 0, true},
+		{"region=us-east,,rack=12", 0, true},
+	}
+	for i, test := range testCases {
+		l, err := ParseLocality(test.s)
+		if (err != nil) != test.err {
+			t.Errorf("%d: expected error %t; got %v", i, test.err, err)
+			continue
+		}
+		if len(l.Tiers) != test.tiers {
+			t.Errorf("%d: expected %d tiers; got %+v", i, test.tiers, l)
+		}
+		if err == nil && l.String() != test.s {
+			t.Errorf("%d: expected %q to round trip; got %q", i, test.s, l)
+		}
+	}
+}
+
+func TestLocalitySharedTiers(t *testing.T) {
+	parse := func(s string) Locality {
+		l, err := ParseLocality(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return l
+	}
+	testCases := []struct {
+		a, b   string
+		shared int
+	}{
+		{"", "", 0},
+		{"region=us-east", "", 0},
+		{"region=us-east,rack=1", "region=us-east,rack=1", 2},
+		{"region=us-east,rack=1", "region=us-east,rack=2", 1},
+		{"region=us-east,rack=1", "region=us-west,rack=1", 0},
+		{"region=us-east,rack=1", "region=us-east", 1},
+	}
+	for i, test := range testCases {
+		if shared := parse(test.a).SharedTiers(parse(test.b)); shared != test.shared {
+			t.Errorf("%d: expected %q and %q to share %d tiers; got %d", i, test.a, test.b, test.shared, shared)
+		}
+	}
+}
+
+func TestAttributesCommonPrefix(t *testing.T) {
+	testCases := []struct {
+		a, b   []string
+		common int
+	}{
+		{nil, nil, 0},
+		{[]string{"us-east"}, nil, 0},
+		{[]string{"us-east", "rack1"}, []string{"us-east", "rack1"}, 2},
+		{[]string{"us-east", "rack1"}, []string{"us-east", "rack2"}, 1},
+		{[]string{"us-east", "rack1"}, []string{"us-west", "rack1"}, 0},
+		{[]string{"us-east", "rack1"}, []string{"us-east"}, 1},
+	}
+	for i, test := range testCases {
+		if common := (Attributes{Attrs: test.a}).CommonPrefix(Attributes{Attrs: test.b}); common != test.common {
+			t.Errorf("%d: expected %v and %v to share %d attributes; got %d", i, test.a, test.b, test.common, common)
+		}
+	}
+}
+
 func TestRangeDescriptorFindReplica(t *testing.T) {
 	desc := RangeDescriptor{
 		Replicas: []Replica{
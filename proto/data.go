@@ -436,10 +436,10 @@ func (t *Transaction) TraceName() string {
 // priority is 100x more likely to be probabilistically greater
 // than a similar invocation with userPriority=1.
 func MakePriority(r *rand.Rand, userPriority int32) int32 {
-	// A currently undocumented feature allows an explicit priority to
-	// be set by specifying priority < 1. The explicit priority is
-	// simply -userPriority in this case. This is hacky, but currently
-	// used for unittesting. Perhaps this should be documented and allowed.
+	// An explicit priority can be set by specifying priority < 1. The
+	// explicit priority is simply -userPriority in this case. This is
+	// used for unittesting and for the low and high user priorities of
+	// the client.
 	if userPriority < 0 {
 		return -userPriority
 	}
@@ -513,6 +513,24 @@ func (t *Transaction) Update(o *Transaction) {
 	t.CertainNodes = NodeList{Nodes: append(Int32Slice(nil),
 		o.CertainNodes.Nodes...)}
 	t.UpgradePriority(o.Priority)
+	if t.Sequence < o.Sequence {
+		t.Sequence = o.Sequence
+	}
+	// Rolled back sequence ranges are only ever appended.
+	if len(t.RolledBack) < len(o.RolledBack) {
+		t.RolledBack = append([]SequenceRange(nil), o.RolledBack...)
+	}
+}
+
+// IsRolledBack returns true if the writes made by the transaction at
+// the given sequence number were rolled back to a savepoint.
+func (t *Transaction) IsRolledBack(seq int32) bool {
+	for _, r := range t.RolledBack {
+		if r.Start <= seq && seq <= r.End {
+			return true
+		}
+	}
+	return false
 }
 
 // UpgradePriority sets transaction priority to the maximum of current
@@ -240,6 +240,34 @@ func (m *KeyValue) GetValue() Value {
 	return Value{}
 }
 
+// A ChangeEvent is a committed change to a key: either a new value or,
+// if deleted is set, the deletion of the key. The timestamp of the value
+// is the commit timestamp of the change.
+type ChangeEvent struct {
+	Key              Key    `protobuf:"bytes,1,opt,name=key,casttype=Key" json:"key,omitempty"`
+	Value            Value  `protobuf:"bytes,2,opt,name=value" json:"value"`
+	Deleted          bool   `protobuf:"varint,3,opt,name=deleted" json:"deleted"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *ChangeEvent) Reset()         { *m = ChangeEvent{} }
+func (m *ChangeEvent) String() string { return proto1.CompactTextString(m) }
+func (*ChangeEvent) ProtoMessage()    {}
+
+func (m *ChangeEvent) GetValue() Value {
+	if m != nil {
+		return m.Value
+	}
+	return Value{}
+}
+
+func (m *ChangeEvent) GetDeleted() bool {
+	if m != nil {
+		return m.Deleted
+	}
+	return false
+}
+
 // RawKeyValue contains the raw bytes of the value for a key.
 type RawKeyValue struct {
 	Key              EncodedKey `protobuf:"bytes,1,opt,name=key,casttype=EncodedKey" json:"key,omitempty"`
@@ -413,6 +441,31 @@ func (m *NodeList) GetNodes() []int32 {
 	return nil
 }
 
+// A SequenceRange is an inclusive range of transaction sequence numbers.
+type SequenceRange struct {
+	Start            int32  `protobuf:"varint,1,opt,name=start" json:"start"`
+	End              int32  `protobuf:"varint,2,opt,name=end" json:"end"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *SequenceRange) Reset()         { *m = SequenceRange{} }
+func (m *SequenceRange) String() string { return proto1.CompactTextString(m) }
+func (*SequenceRange) ProtoMessage()    {}
+
+func (m *SequenceRange) GetStart() int32 {
+	if m != nil {
+		return m.Start
+	}
+	return 0
+}
+
+func (m *SequenceRange) GetEnd() int32 {
+	if m != nil {
+		return m.End
+	}
+	return 0
+}
+
 // A Transaction is a unit of work performed on the database.
 // Cockroach transactions support two isolation levels: snapshot
 // isolation and serializable snapshot isolation. Each Cockroach
@@ -468,8 +521,18 @@ type Transaction struct {
 	// Bits of this mechanism are found in the local sender, the range and the
 	// txn_coord_sender, with brief comments referring here.
 	// See https://github.com/cockroachdb/cockroach/pull/221.
-	CertainNodes     NodeList `protobuf:"bytes,12,opt,name=certain_nodes" json:"certain_nodes"`
-	XXX_unrecognized []byte   `json:"-"`
+	CertainNodes NodeList `protobuf:"bytes,12,opt,name=certain_nodes" json:"certain_nodes"`
+	// The sequence number of the transaction's most recent writes. It is
+	// incremented by the client before the first batch of writes following
+	// a savepoint and recorded with the intents the writes make, allowing
+	// writes made after a savepoint to be told apart from those made
+	// before it.
+	Sequence int32 `protobuf:"varint,13,opt,name=sequence" json:"sequence"`
+	// The ranges of sequence numbers whose writes were rolled back to a
+	// savepoint. Intents written at these sequence numbers are ignored
+	// when read and discarded when resolved.
+	RolledBack       []SequenceRange `protobuf:"bytes,14,rep,name=rolled_back" json:"rolled_back"`
+	XXX_unrecognized []byte          `json:"-"`
 }
 
 func (m *Transaction) Reset()      { *m = Transaction{} }
@@ -552,6 +615,20 @@ func (m *Transaction) GetCertainNodes() NodeList {
 	return NodeList{}
 }
 
+func (m *Transaction) GetSequence() int32 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *Transaction) GetRolledBack() []SequenceRange {
+	if m != nil {
+		return m.RolledBack
+	}
+	return nil
+}
+
 // Lease contains information about leader leases including the
 // expiration and lease holder.
 type Lease struct {
@@ -609,7 +686,10 @@ type GCMetadata struct {
 	// The oldest unresolved write intent in nanoseconds since epoch.
 	// Null if there are no unresolved write intents.
 	OldestIntentNanos *int64 `protobuf:"varint,2,opt,name=oldest_intent_nanos" json:"oldest_intent_nanos,omitempty"`
-	XXX_unrecognized  []byte `json:"-"`
+	// The non-live bytes of the range retained by the last GC. Null
+	// if the range hasn't been GC'd yet.
+	RetainedGCBytes  *int64 `protobuf:"varint,3,opt,name=retained_gc_bytes" json:"retained_gc_bytes,omitempty"`
+	XXX_unrecognized []byte `json:"-"`
 }
 
 func (m *GCMetadata) Reset()         { *m = GCMetadata{} }
@@ -630,6 +710,13 @@ func (m *GCMetadata) GetOldestIntentNanos() int64 {
 	return 0
 }
 
+func (m *GCMetadata) GetRetainedGCBytes() int64 {
+	if m != nil && m.RetainedGCBytes != nil {
+		return *m.RetainedGCBytes
+	}
+	return 0
+}
+
 func init() {
 	proto1.RegisterEnum("cockroach.proto.ReplicaChangeType", ReplicaChangeType_name, ReplicaChangeType_value)
 	proto1.RegisterEnum("cockroach.proto.IsolationType", IsolationType_name, IsolationType_value)
@@ -926,6 +1013,113 @@ func (m *KeyValue) Unmarshal(data []byte) error {
 
 	return nil
 }
+
+func (m *ChangeEvent) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append([]byte{}, data[iNdEx:postIndex]...)
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Value.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Deleted", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Deleted = bool(v != 0)
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipData(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
 func (m *RawKeyValue) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
@@ -1634,6 +1828,80 @@ func (m *NodeList) Unmarshal(data []byte) error {
 
 	return nil
 }
+
+func (m *SequenceRange) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Start", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Start |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field End", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.End |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipData(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
 func (m *Transaction) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
@@ -1902,6 +2170,46 @@ func (m *Transaction) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Sequence |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RolledBack", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RolledBack = append(m.RolledBack, SequenceRange{})
+			if err := m.RolledBack[len(m.RolledBack)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -2172,6 +2480,23 @@ func (m *GCMetadata) Unmarshal(data []byte) error {
 				}
 			}
 			m.OldestIntentNanos = &v
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RetainedGCBytes", wireType)
+			}
+			var v int64
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RetainedGCBytes = &v
 		default:
 			var sizeOfWire int
 			for {
@@ -2330,6 +2655,22 @@ func (m *KeyValue) Size() (n int) {
 	return n
 }
 
+func (m *ChangeEvent) Size() (n int) {
+	var l int
+	_ = l
+	if m.Key != nil {
+		l = len(m.Key)
+		n += 1 + l + sovData(uint64(l))
+	}
+	l = m.Value.Size()
+	n += 1 + l + sovData(uint64(l))
+	n += 2
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *RawKeyValue) Size() (n int) {
 	var l int
 	_ = l
@@ -2446,6 +2787,17 @@ func (m *NodeList) Size() (n int) {
 	return n
 }
 
+func (m *SequenceRange) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovData(uint64(m.Start))
+	n += 1 + sovData(uint64(m.End))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *Transaction) Size() (n int) {
 	var l int
 	_ = l
@@ -2475,6 +2827,13 @@ func (m *Transaction) Size() (n int) {
 	n += 1 + l + sovData(uint64(l))
 	l = m.CertainNodes.Size()
 	n += 1 + l + sovData(uint64(l))
+	n += 1 + sovData(uint64(m.Sequence))
+	if len(m.RolledBack) > 0 {
+		for _, e := range m.RolledBack {
+			l = e.Size()
+			n += 1 + l + sovData(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2517,6 +2876,9 @@ func (m *GCMetadata) Size() (n int) {
 	if m.OldestIntentNanos != nil {
 		n += 1 + sovData(uint64(*m.OldestIntentNanos))
 	}
+	if m.RetainedGCBytes != nil {
+		n += 1 + sovData(uint64(*m.RetainedGCBytes))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -2646,6 +3008,49 @@ func (m *KeyValue) MarshalTo(data []byte) (n int, err error) {
 	return i, nil
 }
 
+func (m *ChangeEvent) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *ChangeEvent) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Key != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintData(data, i, uint64(len(m.Key)))
+		i += copy(data[i:], m.Key)
+	}
+	data[i] = 0x12
+	i++
+	i = encodeVarintData(data, i, uint64(m.Value.Size()))
+	n901, err := m.Value.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n901
+	data[i] = 0x18
+	i++
+	if m.Deleted {
+		data[i] = 1
+	} else {
+		data[i] = 0
+	}
+	i++
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *RawKeyValue) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
@@ -2919,6 +3324,33 @@ func (m *NodeList) MarshalTo(data []byte) (n int, err error) {
 	return i, nil
 }
 
+func (m *SequenceRange) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *SequenceRange) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0x8
+	i++
+	i = encodeVarintData(data, i, uint64(m.Start))
+	data[i] = 0x10
+	i++
+	i = encodeVarintData(data, i, uint64(m.End))
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *Transaction) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
@@ -3004,6 +3436,21 @@ func (m *Transaction) MarshalTo(data []byte) (n int, err error) {
 		return 0, err
 	}
 	i += n15
+	data[i] = 0x68
+	i++
+	i = encodeVarintData(data, i, uint64(m.Sequence))
+	if len(m.RolledBack) > 0 {
+		for _, msg := range m.RolledBack {
+			data[i] = 0x72
+			i++
+			i = encodeVarintData(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
@@ -3108,6 +3555,11 @@ func (m *GCMetadata) MarshalTo(data []byte) (n int, err error) {
 		i++
 		i = encodeVarintData(data, i, uint64(*m.OldestIntentNanos))
 	}
+	if m.RetainedGCBytes != nil {
+		data[i] = 0x18
+		i++
+		i = encodeVarintData(data, i, uint64(*m.RetainedGCBytes))
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
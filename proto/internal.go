@@ -52,6 +52,12 @@ func InternalTimeSeriesDataFromValue(value *Value) (*InternalTimeSeriesData, err
 	return &ts, nil
 }
 
+// IsTimeSeriesData returns true if the "tag" string of the value indicates
+// that its "bytes" field holds an InternalTimeSeriesData message.
+func (v *Value) IsTimeSeriesData() bool {
+	return v.GetTag() == _CR_TS.String()
+}
+
 // Add adds a request to the internal batch request.
 func (br *InternalBatchRequest) Add(args Request) {
 	union := InternalRequestUnion{}
@@ -184,10 +184,12 @@ func (*InternalHeartbeatTxnResponse) ProtoMessage()    {}
 // sent by range leaders after scanning range data to find expired
 // MVCC values.
 type InternalGCRequest struct {
-	RequestHeader    `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
-	GCMeta           GCMetadata                `protobuf:"bytes,2,opt,name=gc_meta" json:"gc_meta"`
-	Keys             []InternalGCRequest_GCKey `protobuf:"bytes,3,rep,name=keys" json:"keys"`
-	XXX_unrecognized []byte                    `json:"-"`
+	RequestHeader `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	GCMeta        GCMetadata                `protobuf:"bytes,2,opt,name=gc_meta" json:"gc_meta"`
+	Keys          []InternalGCRequest_GCKey `protobuf:"bytes,3,rep,name=keys" json:"keys"`
+	// Change log entries below the threshold are discarded.
+	ChangeLogThreshold Timestamp `protobuf:"bytes,4,opt,name=change_log_threshold" json:"change_log_threshold"`
+	XXX_unrecognized   []byte    `json:"-"`
 }
 
 func (m *InternalGCRequest) Reset()         { *m = InternalGCRequest{} }
@@ -208,6 +210,13 @@ func (m *InternalGCRequest) GetKeys() []InternalGCRequest_GCKey {
 	return nil
 }
 
+func (m *InternalGCRequest) GetChangeLogThreshold() Timestamp {
+	if m != nil {
+		return m.ChangeLogThreshold
+	}
+	return Timestamp{}
+}
+
 type InternalGCRequest_GCKey struct {
 	Key              Key       `protobuf:"bytes,1,opt,name=key,casttype=Key" json:"key,omitempty"`
 	Timestamp        Timestamp `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp"`
@@ -469,6 +478,287 @@ func (m *InternalLeaderLeaseResponse) Reset()         { *m = InternalLeaderLease
 func (m *InternalLeaderLeaseResponse) String() string { return proto1.CompactTextString(m) }
 func (*InternalLeaderLeaseResponse) ProtoMessage()    {}
 
+// An InternalTransferLeaderLeaseRequest is arguments to the
+// InternalTransferLeaderLease() method. It is proposed by the holder of
+// the leader lease to hand the lease over to another replica of the
+// range, ending its own lease early.
+type InternalTransferLeaderLeaseRequest struct {
+	RequestHeader `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	Lease         Lease `protobuf:"bytes,2,opt,name=lease" json:"lease"`
+	// A summary of the timestamp cache of the holder of the lease, with
+	// which the new holder seeds its timestamp cache.
+	TimestampCache   *TimestampCacheSummary `protobuf:"bytes,3,opt,name=timestamp_cache" json:"timestamp_cache,omitempty"`
+	XXX_unrecognized []byte                 `json:"-"`
+}
+
+func (m *InternalTransferLeaderLeaseRequest) Reset()         { *m = InternalTransferLeaderLeaseRequest{} }
+func (m *InternalTransferLeaderLeaseRequest) String() string { return proto1.CompactTextString(m) }
+func (*InternalTransferLeaderLeaseRequest) ProtoMessage()    {}
+
+func (m *InternalTransferLeaderLeaseRequest) GetLease() Lease {
+	if m != nil {
+		return m.Lease
+	}
+	return Lease{}
+}
+
+func (m *InternalTransferLeaderLeaseRequest) GetTimestampCache() *TimestampCacheSummary {
+	if m != nil {
+		return m.TimestampCache
+	}
+	return nil
+}
+
+// An InternalTransferLeaderLeaseResponse is the response to an
+// InternalTransferLeaderLease() operation.
+type InternalTransferLeaderLeaseResponse struct {
+	ResponseHeader   `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *InternalTransferLeaderLeaseResponse) Reset()         { *m = InternalTransferLeaderLeaseResponse{} }
+func (m *InternalTransferLeaderLeaseResponse) String() string { return proto1.CompactTextString(m) }
+func (*InternalTransferLeaderLeaseResponse) ProtoMessage()    {}
+
+// An InternalCloseTimestampRequest is arguments to the
+// InternalCloseTimestamp() method. It is proposed periodically by the
+// holder of the leader lease, which promises not to serve writes at or
+// below the closed timestamp from then on. Replicas which have applied
+// it may serve reads at or below the closed timestamp.
+type InternalCloseTimestampRequest struct {
+	RequestHeader    `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	Closed           Timestamp `protobuf:"bytes,2,opt,name=closed" json:"closed"`
+	XXX_unrecognized []byte    `json:"-"`
+}
+
+func (m *InternalCloseTimestampRequest) Reset()         { *m = InternalCloseTimestampRequest{} }
+func (m *InternalCloseTimestampRequest) String() string { return proto1.CompactTextString(m) }
+func (*InternalCloseTimestampRequest) ProtoMessage()    {}
+
+func (m *InternalCloseTimestampRequest) GetClosed() Timestamp {
+	if m != nil {
+		return m.Closed
+	}
+	return Timestamp{}
+}
+
+// An InternalCloseTimestampResponse is the response to an
+// InternalCloseTimestamp() operation.
+type InternalCloseTimestampResponse struct {
+	ResponseHeader   `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *InternalCloseTimestampResponse) Reset()         { *m = InternalCloseTimestampResponse{} }
+func (m *InternalCloseTimestampResponse) String() string { return proto1.CompactTextString(m) }
+func (*InternalCloseTimestampResponse) ProtoMessage()    {}
+
+// A TimestampCacheSummary summarizes the timestamp cache of a range's
+// leader. No read or write on the range was served by the leader at a
+// timestamp above the low water mark unless it is covered by one of the
+// entries.
+type TimestampCacheSummary struct {
+	LowWater         Timestamp             `protobuf:"bytes,1,opt,name=low_water" json:"low_water"`
+	Entries          []TimestampCacheEntry `protobuf:"bytes,2,rep,name=entries" json:"entries"`
+	XXX_unrecognized []byte                `json:"-"`
+}
+
+func (m *TimestampCacheSummary) Reset()         { *m = TimestampCacheSummary{} }
+func (m *TimestampCacheSummary) String() string { return proto1.CompactTextString(m) }
+func (*TimestampCacheSummary) ProtoMessage()    {}
+
+func (m *TimestampCacheSummary) GetLowWater() Timestamp {
+	if m != nil {
+		return m.LowWater
+	}
+	return Timestamp{}
+}
+
+func (m *TimestampCacheSummary) GetEntries() []TimestampCacheEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+// A TimestampCacheEntry is the most recent timestamp at which the keys
+// from start_key to end_key were read or written.
+type TimestampCacheEntry struct {
+	StartKey  Key       `protobuf:"bytes,1,opt,name=start_key,casttype=Key" json:"start_key,omitempty"`
+	EndKey    Key       `protobuf:"bytes,2,opt,name=end_key,casttype=Key" json:"end_key,omitempty"`
+	Timestamp Timestamp `protobuf:"bytes,3,opt,name=timestamp" json:"timestamp"`
+	// The ID of the transaction which read or wrote the keys, if any.
+	TxnID            []byte `protobuf:"bytes,4,opt,name=txn_id" json:"txn_id,omitempty"`
+	ReadOnly         bool   `protobuf:"varint,5,opt,name=read_only" json:"read_only"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *TimestampCacheEntry) Reset()         { *m = TimestampCacheEntry{} }
+func (m *TimestampCacheEntry) String() string { return proto1.CompactTextString(m) }
+func (*TimestampCacheEntry) ProtoMessage()    {}
+
+func (m *TimestampCacheEntry) GetTimestamp() Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return Timestamp{}
+}
+
+func (m *TimestampCacheEntry) GetTxnID() []byte {
+	if m != nil {
+		return m.TxnID
+	}
+	return nil
+}
+
+func (m *TimestampCacheEntry) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+// An InternalComputeChecksumRequest is arguments to the
+// InternalComputeChecksum() method. It is proposed by the range leader
+// to have every replica checksum its range data at the same applied
+// index. The range data is split into spans at the given boundaries and
+// a checksum is computed for each span, so that a divergence can be
+// narrowed down to a key span.
+type InternalComputeChecksumRequest struct {
+	RequestHeader `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	// A unique identifier for the consistency check, used to match the
+	// checksums computed here with a later InternalVerifyChecksum().
+	ChecksumID []byte `protobuf:"bytes,2,opt,name=checksum_id" json:"checksum_id,omitempty"`
+	// The sorted keys at which the range data is split into spans.
+	Boundaries       []Key  `protobuf:"bytes,3,rep,name=boundaries,casttype=Key" json:"boundaries,omitempty"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *InternalComputeChecksumRequest) Reset()         { *m = InternalComputeChecksumRequest{} }
+func (m *InternalComputeChecksumRequest) String() string { return proto1.CompactTextString(m) }
+func (*InternalComputeChecksumRequest) ProtoMessage()    {}
+
+func (m *InternalComputeChecksumRequest) GetChecksumID() []byte {
+	if m != nil {
+		return m.ChecksumID
+	}
+	return nil
+}
+
+// An InternalComputeChecksumResponse is the response to an
+// InternalComputeChecksum() operation.
+type InternalComputeChecksumResponse struct {
+	ResponseHeader   `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *InternalComputeChecksumResponse) Reset()         { *m = InternalComputeChecksumResponse{} }
+func (m *InternalComputeChecksumResponse) String() string { return proto1.CompactTextString(m) }
+func (*InternalComputeChecksumResponse) ProtoMessage()    {}
+
+// An InternalVerifyChecksumRequest is arguments to the
+// InternalVerifyChecksum() method. It carries the outcome of the vote
+// held by the range leader over the checksums reported by the replicas
+// for an earlier InternalComputeChecksum(): the span checksums agreed
+// upon by a majority of the replicas, and the replicas whose checksums
+// differ from them.
+type InternalVerifyChecksumRequest struct {
+	RequestHeader `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	ChecksumID    []byte `protobuf:"bytes,2,opt,name=checksum_id" json:"checksum_id,omitempty"`
+	// The majority's checksums, one per span.
+	Checksums [][]byte `protobuf:"bytes,3,rep,name=checksums" json:"checksums,omitempty"`
+	// The replicas in the minority, which compare their checksums with
+	// the majority's and report the spans which differ.
+	Minority         []Replica `protobuf:"bytes,4,rep,name=minority" json:"minority"`
+	XXX_unrecognized []byte    `json:"-"`
+}
+
+func (m *InternalVerifyChecksumRequest) Reset()         { *m = InternalVerifyChecksumRequest{} }
+func (m *InternalVerifyChecksumRequest) String() string { return proto1.CompactTextString(m) }
+func (*InternalVerifyChecksumRequest) ProtoMessage()    {}
+
+func (m *InternalVerifyChecksumRequest) GetChecksumID() []byte {
+	if m != nil {
+		return m.ChecksumID
+	}
+	return nil
+}
+
+func (m *InternalVerifyChecksumRequest) GetChecksums() [][]byte {
+	if m != nil {
+		return m.Checksums
+	}
+	return nil
+}
+
+func (m *InternalVerifyChecksumRequest) GetMinority() []Replica {
+	if m != nil {
+		return m.Minority
+	}
+	return nil
+}
+
+// An InternalVerifyChecksumResponse is the response to an
+// InternalVerifyChecksum() operation.
+type InternalVerifyChecksumResponse struct {
+	ResponseHeader   `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *InternalVerifyChecksumResponse) Reset()         { *m = InternalVerifyChecksumResponse{} }
+func (m *InternalVerifyChecksumResponse) String() string { return proto1.CompactTextString(m) }
+func (*InternalVerifyChecksumResponse) ProtoMessage()    {}
+
+// An InternalReportChecksumRequest is arguments to the
+// InternalReportChecksum() method. It is proposed by every replica of
+// a range once it has computed its checksums for an
+// InternalComputeChecksum(), so that they reach the range leader.
+type InternalReportChecksumRequest struct {
+	RequestHeader `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	ChecksumID    []byte `protobuf:"bytes,2,opt,name=checksum_id" json:"checksum_id,omitempty"`
+	// The reporting replica's checksums, one per span.
+	Checksums [][]byte `protobuf:"bytes,3,rep,name=checksums" json:"checksums,omitempty"`
+	// The reporting replica.
+	Replica          Replica `protobuf:"bytes,4,opt,name=replica" json:"replica"`
+	XXX_unrecognized []byte  `json:"-"`
+}
+
+func (m *InternalReportChecksumRequest) Reset()         { *m = InternalReportChecksumRequest{} }
+func (m *InternalReportChecksumRequest) String() string { return proto1.CompactTextString(m) }
+func (*InternalReportChecksumRequest) ProtoMessage()    {}
+
+func (m *InternalReportChecksumRequest) GetChecksumID() []byte {
+	if m != nil {
+		return m.ChecksumID
+	}
+	return nil
+}
+
+func (m *InternalReportChecksumRequest) GetChecksums() [][]byte {
+	if m != nil {
+		return m.Checksums
+	}
+	return nil
+}
+
+func (m *InternalReportChecksumRequest) GetReplica() Replica {
+	if m != nil {
+		return m.Replica
+	}
+	return Replica{}
+}
+
+// An InternalReportChecksumResponse is the response to an
+// InternalReportChecksum() operation.
+type InternalReportChecksumResponse struct {
+	ResponseHeader   `protobuf:"bytes,1,opt,name=header,embedded=header" json:"header"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *InternalReportChecksumResponse) Reset()         { *m = InternalReportChecksumResponse{} }
+func (m *InternalReportChecksumResponse) String() string { return proto1.CompactTextString(m) }
+func (*InternalReportChecksumResponse) ProtoMessage()    {}
+
 // An InternalRequestUnion contains exactly one of the optional requests.
 // Non-internal values added to RequestUnion must be added here.
 type InternalRequestUnion struct {
@@ -480,6 +770,12 @@ type InternalRequestUnion struct {
 	DeleteRange                *DeleteRangeRequest                `protobuf:"bytes,7,opt,name=delete_range" json:"delete_range,omitempty"`
 	Scan                       *ScanRequest                       `protobuf:"bytes,8,opt,name=scan" json:"scan,omitempty"`
 	EndTransaction             *EndTransactionRequest             `protobuf:"bytes,9,opt,name=end_transaction" json:"end_transaction,omitempty"`
+	ReapQueue                  *ReapQueueRequest                  `protobuf:"bytes,10,opt,name=reap_queue" json:"reap_queue,omitempty"`
+	EnqueueUpdate              *EnqueueUpdateRequest              `protobuf:"bytes,11,opt,name=enqueue_update" json:"enqueue_update,omitempty"`
+	EnqueueMessage             *EnqueueMessageRequest             `protobuf:"bytes,12,opt,name=enqueue_message" json:"enqueue_message,omitempty"`
+	Changes                    *ChangesRequest                    `protobuf:"bytes,13,opt,name=changes" json:"changes,omitempty"`
+	Import                     *ImportRequest                     `protobuf:"bytes,14,opt,name=import" json:"import,omitempty"`
+	SpanStats                  *SpanStatsRequest                  `protobuf:"bytes,15,opt,name=span_stats" json:"span_stats,omitempty"`
 	InternalPushTxn            *InternalPushTxnRequest            `protobuf:"bytes,30,opt,name=internal_push_txn" json:"internal_push_txn,omitempty"`
 	InternalResolveIntent      *InternalResolveIntentRequest      `protobuf:"bytes,31,opt,name=internal_resolve_intent" json:"internal_resolve_intent,omitempty"`
 	InternalResolveIntentRange *InternalResolveIntentRangeRequest `protobuf:"bytes,32,opt,name=internal_resolve_intent_range" json:"internal_resolve_intent_range,omitempty"`
@@ -546,6 +842,48 @@ func (m *InternalRequestUnion) GetEndTransaction() *EndTransactionRequest {
 	return nil
 }
 
+func (m *InternalRequestUnion) GetReapQueue() *ReapQueueRequest {
+	if m != nil {
+		return m.ReapQueue
+	}
+	return nil
+}
+
+func (m *InternalRequestUnion) GetEnqueueUpdate() *EnqueueUpdateRequest {
+	if m != nil {
+		return m.EnqueueUpdate
+	}
+	return nil
+}
+
+func (m *InternalRequestUnion) GetEnqueueMessage() *EnqueueMessageRequest {
+	if m != nil {
+		return m.EnqueueMessage
+	}
+	return nil
+}
+
+func (m *InternalRequestUnion) GetChanges() *ChangesRequest {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *InternalRequestUnion) GetImport() *ImportRequest {
+	if m != nil {
+		return m.Import
+	}
+	return nil
+}
+
+func (m *InternalRequestUnion) GetSpanStats() *SpanStatsRequest {
+	if m != nil {
+		return m.SpanStats
+	}
+	return nil
+}
+
 func (m *InternalRequestUnion) GetInternalPushTxn() *InternalPushTxnRequest {
 	if m != nil {
 		return m.InternalPushTxn
@@ -578,6 +916,12 @@ type InternalResponseUnion struct {
 	DeleteRange                *DeleteRangeResponse                `protobuf:"bytes,7,opt,name=delete_range" json:"delete_range,omitempty"`
 	Scan                       *ScanResponse                       `protobuf:"bytes,8,opt,name=scan" json:"scan,omitempty"`
 	EndTransaction             *EndTransactionResponse             `protobuf:"bytes,9,opt,name=end_transaction" json:"end_transaction,omitempty"`
+	ReapQueue                  *ReapQueueResponse                  `protobuf:"bytes,10,opt,name=reap_queue" json:"reap_queue,omitempty"`
+	EnqueueUpdate              *EnqueueUpdateResponse              `protobuf:"bytes,11,opt,name=enqueue_update" json:"enqueue_update,omitempty"`
+	EnqueueMessage             *EnqueueMessageResponse             `protobuf:"bytes,12,opt,name=enqueue_message" json:"enqueue_message,omitempty"`
+	Changes                    *ChangesResponse                    `protobuf:"bytes,13,opt,name=changes" json:"changes,omitempty"`
+	Import                     *ImportResponse                     `protobuf:"bytes,14,opt,name=import" json:"import,omitempty"`
+	SpanStats                  *SpanStatsResponse                  `protobuf:"bytes,15,opt,name=span_stats" json:"span_stats,omitempty"`
 	InternalPushTxn            *InternalPushTxnResponse            `protobuf:"bytes,30,opt,name=internal_push_txn" json:"internal_push_txn,omitempty"`
 	InternalResolveIntent      *InternalResolveIntentResponse      `protobuf:"bytes,31,opt,name=internal_resolve_intent" json:"internal_resolve_intent,omitempty"`
 	InternalResolveIntentRange *InternalResolveIntentRangeResponse `protobuf:"bytes,32,opt,name=internal_resolve_intent_range" json:"internal_resolve_intent_range,omitempty"`
@@ -644,6 +988,48 @@ func (m *InternalResponseUnion) GetEndTransaction() *EndTransactionResponse {
 	return nil
 }
 
+func (m *InternalResponseUnion) GetReapQueue() *ReapQueueResponse {
+	if m != nil {
+		return m.ReapQueue
+	}
+	return nil
+}
+
+func (m *InternalResponseUnion) GetEnqueueUpdate() *EnqueueUpdateResponse {
+	if m != nil {
+		return m.EnqueueUpdate
+	}
+	return nil
+}
+
+func (m *InternalResponseUnion) GetEnqueueMessage() *EnqueueMessageResponse {
+	if m != nil {
+		return m.EnqueueMessage
+	}
+	return nil
+}
+
+func (m *InternalResponseUnion) GetChanges() *ChangesResponse {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func (m *InternalResponseUnion) GetImport() *ImportResponse {
+	if m != nil {
+		return m.Import
+	}
+	return nil
+}
+
+func (m *InternalResponseUnion) GetSpanStats() *SpanStatsResponse {
+	if m != nil {
+		return m.SpanStats
+	}
+	return nil
+}
+
 func (m *InternalResponseUnion) GetInternalPushTxn() *InternalPushTxnResponse {
 	if m != nil {
 		return m.InternalPushTxn
@@ -710,21 +1096,30 @@ func (m *InternalBatchResponse) GetResponses() []InternalResponseUnion {
 // mutating commands. Note that any entry added here must be handled
 // in storage/engine/db.cc in GetResponseHeader().
 type ReadWriteCmdResponse struct {
-	Put                        *PutResponse                        `protobuf:"bytes,1,opt,name=put" json:"put,omitempty"`
-	ConditionalPut             *ConditionalPutResponse             `protobuf:"bytes,2,opt,name=conditional_put" json:"conditional_put,omitempty"`
-	Increment                  *IncrementResponse                  `protobuf:"bytes,3,opt,name=increment" json:"increment,omitempty"`
-	Delete                     *DeleteResponse                     `protobuf:"bytes,4,opt,name=delete" json:"delete,omitempty"`
-	DeleteRange                *DeleteRangeResponse                `protobuf:"bytes,5,opt,name=delete_range" json:"delete_range,omitempty"`
-	EndTransaction             *EndTransactionResponse             `protobuf:"bytes,6,opt,name=end_transaction" json:"end_transaction,omitempty"`
-	InternalHeartbeatTxn       *InternalHeartbeatTxnResponse       `protobuf:"bytes,10,opt,name=internal_heartbeat_txn" json:"internal_heartbeat_txn,omitempty"`
-	InternalPushTxn            *InternalPushTxnResponse            `protobuf:"bytes,11,opt,name=internal_push_txn" json:"internal_push_txn,omitempty"`
-	InternalResolveIntent      *InternalResolveIntentResponse      `protobuf:"bytes,12,opt,name=internal_resolve_intent" json:"internal_resolve_intent,omitempty"`
-	InternalResolveIntentRange *InternalResolveIntentRangeResponse `protobuf:"bytes,13,opt,name=internal_resolve_intent_range" json:"internal_resolve_intent_range,omitempty"`
-	InternalMerge              *InternalMergeResponse              `protobuf:"bytes,14,opt,name=internal_merge" json:"internal_merge,omitempty"`
-	InternalTruncateLog        *InternalTruncateLogResponse        `protobuf:"bytes,15,opt,name=internal_truncate_log" json:"internal_truncate_log,omitempty"`
-	InternalGc                 *InternalGCResponse                 `protobuf:"bytes,16,opt,name=internal_gc" json:"internal_gc,omitempty"`
-	InternalLeaderLease        *InternalLeaderLeaseResponse        `protobuf:"bytes,17,opt,name=internal_leader_lease" json:"internal_leader_lease,omitempty"`
-	XXX_unrecognized           []byte                              `json:"-"`
+	Put                         *PutResponse                         `protobuf:"bytes,1,opt,name=put" json:"put,omitempty"`
+	ConditionalPut              *ConditionalPutResponse              `protobuf:"bytes,2,opt,name=conditional_put" json:"conditional_put,omitempty"`
+	Increment                   *IncrementResponse                   `protobuf:"bytes,3,opt,name=increment" json:"increment,omitempty"`
+	Delete                      *DeleteResponse                      `protobuf:"bytes,4,opt,name=delete" json:"delete,omitempty"`
+	DeleteRange                 *DeleteRangeResponse                 `protobuf:"bytes,5,opt,name=delete_range" json:"delete_range,omitempty"`
+	EndTransaction              *EndTransactionResponse              `protobuf:"bytes,6,opt,name=end_transaction" json:"end_transaction,omitempty"`
+	ReapQueue                   *ReapQueueResponse                   `protobuf:"bytes,7,opt,name=reap_queue" json:"reap_queue,omitempty"`
+	EnqueueUpdate               *EnqueueUpdateResponse               `protobuf:"bytes,8,opt,name=enqueue_update" json:"enqueue_update,omitempty"`
+	EnqueueMessage              *EnqueueMessageResponse              `protobuf:"bytes,9,opt,name=enqueue_message" json:"enqueue_message,omitempty"`
+	InternalHeartbeatTxn        *InternalHeartbeatTxnResponse        `protobuf:"bytes,10,opt,name=internal_heartbeat_txn" json:"internal_heartbeat_txn,omitempty"`
+	InternalPushTxn             *InternalPushTxnResponse             `protobuf:"bytes,11,opt,name=internal_push_txn" json:"internal_push_txn,omitempty"`
+	InternalResolveIntent       *InternalResolveIntentResponse       `protobuf:"bytes,12,opt,name=internal_resolve_intent" json:"internal_resolve_intent,omitempty"`
+	InternalResolveIntentRange  *InternalResolveIntentRangeResponse  `protobuf:"bytes,13,opt,name=internal_resolve_intent_range" json:"internal_resolve_intent_range,omitempty"`
+	InternalMerge               *InternalMergeResponse               `protobuf:"bytes,14,opt,name=internal_merge" json:"internal_merge,omitempty"`
+	InternalTruncateLog         *InternalTruncateLogResponse         `protobuf:"bytes,15,opt,name=internal_truncate_log" json:"internal_truncate_log,omitempty"`
+	InternalGc                  *InternalGCResponse                  `protobuf:"bytes,16,opt,name=internal_gc" json:"internal_gc,omitempty"`
+	InternalLeaderLease         *InternalLeaderLeaseResponse         `protobuf:"bytes,17,opt,name=internal_leader_lease" json:"internal_leader_lease,omitempty"`
+	Import                      *ImportResponse                      `protobuf:"bytes,18,opt,name=import" json:"import,omitempty"`
+	InternalComputeChecksum     *InternalComputeChecksumResponse     `protobuf:"bytes,19,opt,name=internal_compute_checksum" json:"internal_compute_checksum,omitempty"`
+	InternalVerifyChecksum      *InternalVerifyChecksumResponse      `protobuf:"bytes,20,opt,name=internal_verify_checksum" json:"internal_verify_checksum,omitempty"`
+	InternalTransferLeaderLease *InternalTransferLeaderLeaseResponse `protobuf:"bytes,21,opt,name=internal_transfer_leader_lease" json:"internal_transfer_leader_lease,omitempty"`
+	InternalCloseTimestamp      *InternalCloseTimestampResponse      `protobuf:"bytes,22,opt,name=internal_close_timestamp" json:"internal_close_timestamp,omitempty"`
+	InternalReportChecksum      *InternalReportChecksumResponse      `protobuf:"bytes,23,opt,name=internal_report_checksum" json:"internal_report_checksum,omitempty"`
+	XXX_unrecognized            []byte                               `json:"-"`
 }
 
 func (m *ReadWriteCmdResponse) Reset()         { *m = ReadWriteCmdResponse{} }
@@ -773,6 +1168,27 @@ func (m *ReadWriteCmdResponse) GetEndTransaction() *EndTransactionResponse {
 	return nil
 }
 
+func (m *ReadWriteCmdResponse) GetReapQueue() *ReapQueueResponse {
+	if m != nil {
+		return m.ReapQueue
+	}
+	return nil
+}
+
+func (m *ReadWriteCmdResponse) GetEnqueueUpdate() *EnqueueUpdateResponse {
+	if m != nil {
+		return m.EnqueueUpdate
+	}
+	return nil
+}
+
+func (m *ReadWriteCmdResponse) GetEnqueueMessage() *EnqueueMessageResponse {
+	if m != nil {
+		return m.EnqueueMessage
+	}
+	return nil
+}
+
 func (m *ReadWriteCmdResponse) GetInternalHeartbeatTxn() *InternalHeartbeatTxnResponse {
 	if m != nil {
 		return m.InternalHeartbeatTxn
@@ -829,6 +1245,48 @@ func (m *ReadWriteCmdResponse) GetInternalLeaderLease() *InternalLeaderLeaseResp
 	return nil
 }
 
+func (m *ReadWriteCmdResponse) GetImport() *ImportResponse {
+	if m != nil {
+		return m.Import
+	}
+	return nil
+}
+
+func (m *ReadWriteCmdResponse) GetInternalComputeChecksum() *InternalComputeChecksumResponse {
+	if m != nil {
+		return m.InternalComputeChecksum
+	}
+	return nil
+}
+
+func (m *ReadWriteCmdResponse) GetInternalVerifyChecksum() *InternalVerifyChecksumResponse {
+	if m != nil {
+		return m.InternalVerifyChecksum
+	}
+	return nil
+}
+
+func (m *ReadWriteCmdResponse) GetInternalTransferLeaderLease() *InternalTransferLeaderLeaseResponse {
+	if m != nil {
+		return m.InternalTransferLeaderLease
+	}
+	return nil
+}
+
+func (m *ReadWriteCmdResponse) GetInternalCloseTimestamp() *InternalCloseTimestampResponse {
+	if m != nil {
+		return m.InternalCloseTimestamp
+	}
+	return nil
+}
+
+func (m *ReadWriteCmdResponse) GetInternalReportChecksum() *InternalReportChecksumResponse {
+	if m != nil {
+		return m.InternalReportChecksum
+	}
+	return nil
+}
+
 // An InternalRaftCommandUnion is the union of all commands which can be
 // sent via raft.
 type InternalRaftCommandUnion struct {
@@ -841,20 +1299,31 @@ type InternalRaftCommandUnion struct {
 	DeleteRange    *DeleteRangeRequest    `protobuf:"bytes,7,opt,name=delete_range" json:"delete_range,omitempty"`
 	Scan           *ScanRequest           `protobuf:"bytes,8,opt,name=scan" json:"scan,omitempty"`
 	EndTransaction *EndTransactionRequest `protobuf:"bytes,9,opt,name=end_transaction" json:"end_transaction,omitempty"`
+	ReapQueue      *ReapQueueRequest      `protobuf:"bytes,10,opt,name=reap_queue" json:"reap_queue,omitempty"`
+	EnqueueUpdate  *EnqueueUpdateRequest  `protobuf:"bytes,11,opt,name=enqueue_update" json:"enqueue_update,omitempty"`
+	EnqueueMessage *EnqueueMessageRequest `protobuf:"bytes,12,opt,name=enqueue_message" json:"enqueue_message,omitempty"`
+	Changes        *ChangesRequest        `protobuf:"bytes,13,opt,name=changes" json:"changes,omitempty"`
+	Import         *ImportRequest         `protobuf:"bytes,14,opt,name=import" json:"import,omitempty"`
+	SpanStats      *SpanStatsRequest      `protobuf:"bytes,15,opt,name=span_stats" json:"span_stats,omitempty"`
 	// Other requests. Allow a gap in tag numbers so the previous list can
 	// be copy/pasted from RequestUnion.
-	Batch                      *BatchRequest                      `protobuf:"bytes,30,opt,name=batch" json:"batch,omitempty"`
-	InternalRangeLookup        *InternalRangeLookupRequest        `protobuf:"bytes,31,opt,name=internal_range_lookup" json:"internal_range_lookup,omitempty"`
-	InternalHeartbeatTxn       *InternalHeartbeatTxnRequest       `protobuf:"bytes,32,opt,name=internal_heartbeat_txn" json:"internal_heartbeat_txn,omitempty"`
-	InternalPushTxn            *InternalPushTxnRequest            `protobuf:"bytes,33,opt,name=internal_push_txn" json:"internal_push_txn,omitempty"`
-	InternalResolveIntent      *InternalResolveIntentRequest      `protobuf:"bytes,34,opt,name=internal_resolve_intent" json:"internal_resolve_intent,omitempty"`
-	InternalResolveIntentRange *InternalResolveIntentRangeRequest `protobuf:"bytes,35,opt,name=internal_resolve_intent_range" json:"internal_resolve_intent_range,omitempty"`
-	InternalMergeResponse      *InternalMergeRequest              `protobuf:"bytes,36,opt,name=internal_merge_response" json:"internal_merge_response,omitempty"`
-	InternalTruncateLog        *InternalTruncateLogRequest        `protobuf:"bytes,37,opt,name=internal_truncate_log" json:"internal_truncate_log,omitempty"`
-	InternalGC                 *InternalGCRequest                 `protobuf:"bytes,38,opt,name=internal_gc" json:"internal_gc,omitempty"`
-	InternalLease              *InternalLeaderLeaseRequest        `protobuf:"bytes,39,opt,name=internal_lease" json:"internal_lease,omitempty"`
-	InternalBatch              *InternalBatchRequest              `protobuf:"bytes,40,opt,name=internal_batch" json:"internal_batch,omitempty"`
-	XXX_unrecognized           []byte                             `json:"-"`
+	Batch                       *BatchRequest                       `protobuf:"bytes,30,opt,name=batch" json:"batch,omitempty"`
+	InternalRangeLookup         *InternalRangeLookupRequest         `protobuf:"bytes,31,opt,name=internal_range_lookup" json:"internal_range_lookup,omitempty"`
+	InternalHeartbeatTxn        *InternalHeartbeatTxnRequest        `protobuf:"bytes,32,opt,name=internal_heartbeat_txn" json:"internal_heartbeat_txn,omitempty"`
+	InternalPushTxn             *InternalPushTxnRequest             `protobuf:"bytes,33,opt,name=internal_push_txn" json:"internal_push_txn,omitempty"`
+	InternalResolveIntent       *InternalResolveIntentRequest       `protobuf:"bytes,34,opt,name=internal_resolve_intent" json:"internal_resolve_intent,omitempty"`
+	InternalResolveIntentRange  *InternalResolveIntentRangeRequest  `protobuf:"bytes,35,opt,name=internal_resolve_intent_range" json:"internal_resolve_intent_range,omitempty"`
+	InternalMergeResponse       *InternalMergeRequest               `protobuf:"bytes,36,opt,name=internal_merge_response" json:"internal_merge_response,omitempty"`
+	InternalTruncateLog         *InternalTruncateLogRequest         `protobuf:"bytes,37,opt,name=internal_truncate_log" json:"internal_truncate_log,omitempty"`
+	InternalGC                  *InternalGCRequest                  `protobuf:"bytes,38,opt,name=internal_gc" json:"internal_gc,omitempty"`
+	InternalLease               *InternalLeaderLeaseRequest         `protobuf:"bytes,39,opt,name=internal_lease" json:"internal_lease,omitempty"`
+	InternalBatch               *InternalBatchRequest               `protobuf:"bytes,40,opt,name=internal_batch" json:"internal_batch,omitempty"`
+	InternalComputeChecksum     *InternalComputeChecksumRequest     `protobuf:"bytes,41,opt,name=internal_compute_checksum" json:"internal_compute_checksum,omitempty"`
+	InternalVerifyChecksum      *InternalVerifyChecksumRequest      `protobuf:"bytes,42,opt,name=internal_verify_checksum" json:"internal_verify_checksum,omitempty"`
+	InternalTransferLeaderLease *InternalTransferLeaderLeaseRequest `protobuf:"bytes,43,opt,name=internal_transfer_leader_lease" json:"internal_transfer_leader_lease,omitempty"`
+	InternalCloseTimestamp      *InternalCloseTimestampRequest      `protobuf:"bytes,44,opt,name=internal_close_timestamp" json:"internal_close_timestamp,omitempty"`
+	InternalReportChecksum      *InternalReportChecksumRequest      `protobuf:"bytes,45,opt,name=internal_report_checksum" json:"internal_report_checksum,omitempty"`
+	XXX_unrecognized            []byte                              `json:"-"`
 }
 
 func (m *InternalRaftCommandUnion) Reset()         { *m = InternalRaftCommandUnion{} }
@@ -917,37 +1386,79 @@ func (m *InternalRaftCommandUnion) GetEndTransaction() *EndTransactionRequest {
 	return nil
 }
 
-func (m *InternalRaftCommandUnion) GetBatch() *BatchRequest {
+func (m *InternalRaftCommandUnion) GetReapQueue() *ReapQueueRequest {
 	if m != nil {
-		return m.Batch
+		return m.ReapQueue
 	}
 	return nil
 }
 
-func (m *InternalRaftCommandUnion) GetInternalRangeLookup() *InternalRangeLookupRequest {
+func (m *InternalRaftCommandUnion) GetEnqueueUpdate() *EnqueueUpdateRequest {
 	if m != nil {
-		return m.InternalRangeLookup
+		return m.EnqueueUpdate
 	}
 	return nil
 }
 
-func (m *InternalRaftCommandUnion) GetInternalHeartbeatTxn() *InternalHeartbeatTxnRequest {
+func (m *InternalRaftCommandUnion) GetEnqueueMessage() *EnqueueMessageRequest {
 	if m != nil {
-		return m.InternalHeartbeatTxn
+		return m.EnqueueMessage
 	}
 	return nil
 }
 
-func (m *InternalRaftCommandUnion) GetInternalPushTxn() *InternalPushTxnRequest {
+func (m *InternalRaftCommandUnion) GetChanges() *ChangesRequest {
 	if m != nil {
-		return m.InternalPushTxn
+		return m.Changes
 	}
 	return nil
 }
 
-func (m *InternalRaftCommandUnion) GetInternalResolveIntent() *InternalResolveIntentRequest {
+func (m *InternalRaftCommandUnion) GetImport() *ImportRequest {
 	if m != nil {
-		return m.InternalResolveIntent
+		return m.Import
+	}
+	return nil
+}
+
+func (m *InternalRaftCommandUnion) GetSpanStats() *SpanStatsRequest {
+	if m != nil {
+		return m.SpanStats
+	}
+	return nil
+}
+
+func (m *InternalRaftCommandUnion) GetBatch() *BatchRequest {
+	if m != nil {
+		return m.Batch
+	}
+	return nil
+}
+
+func (m *InternalRaftCommandUnion) GetInternalRangeLookup() *InternalRangeLookupRequest {
+	if m != nil {
+		return m.InternalRangeLookup
+	}
+	return nil
+}
+
+func (m *InternalRaftCommandUnion) GetInternalHeartbeatTxn() *InternalHeartbeatTxnRequest {
+	if m != nil {
+		return m.InternalHeartbeatTxn
+	}
+	return nil
+}
+
+func (m *InternalRaftCommandUnion) GetInternalPushTxn() *InternalPushTxnRequest {
+	if m != nil {
+		return m.InternalPushTxn
+	}
+	return nil
+}
+
+func (m *InternalRaftCommandUnion) GetInternalResolveIntent() *InternalResolveIntentRequest {
+	if m != nil {
+		return m.InternalResolveIntent
 	}
 	return nil
 }
@@ -994,6 +1505,41 @@ func (m *InternalRaftCommandUnion) GetInternalBatch() *InternalBatchRequest {
 	return nil
 }
 
+func (m *InternalRaftCommandUnion) GetInternalComputeChecksum() *InternalComputeChecksumRequest {
+	if m != nil {
+		return m.InternalComputeChecksum
+	}
+	return nil
+}
+
+func (m *InternalRaftCommandUnion) GetInternalVerifyChecksum() *InternalVerifyChecksumRequest {
+	if m != nil {
+		return m.InternalVerifyChecksum
+	}
+	return nil
+}
+
+func (m *InternalRaftCommandUnion) GetInternalTransferLeaderLease() *InternalTransferLeaderLeaseRequest {
+	if m != nil {
+		return m.InternalTransferLeaderLease
+	}
+	return nil
+}
+
+func (m *InternalRaftCommandUnion) GetInternalCloseTimestamp() *InternalCloseTimestampRequest {
+	if m != nil {
+		return m.InternalCloseTimestamp
+	}
+	return nil
+}
+
+func (m *InternalRaftCommandUnion) GetInternalReportChecksum() *InternalReportChecksumRequest {
+	if m != nil {
+		return m.InternalReportChecksum
+	}
+	return nil
+}
+
 // An InternalRaftCommand is a command which can be serialized and
 // sent via raft.
 type InternalRaftCommand struct {
@@ -1049,6 +1595,70 @@ func (m *RaftMessageResponse) Reset()         { *m = RaftMessageResponse{} }
 func (m *RaftMessageResponse) String() string { return proto1.CompactTextString(m) }
 func (*RaftMessageResponse) ProtoMessage()    {}
 
+// RaftSnapshotChunkRequest carries a piece of a raft snapshot message.
+// Snapshots are streamed in chunks over a service separate from
+// RaftMessageRequest so that transferring a large range neither holds
+// up other raft traffic nor requires the whole snapshot to be buffered
+// in a single request.
+type RaftSnapshotChunkRequest struct {
+	GroupID          RaftID `protobuf:"varint,1,opt,name=group_id,casttype=RaftID" json:"group_id"`
+	SnapshotID       uint64 `protobuf:"varint,2,opt,name=snapshot_id" json:"snapshot_id"`
+	Seq              int32  `protobuf:"varint,3,opt,name=seq" json:"seq"`
+	Msg              []byte `protobuf:"bytes,4,opt,name=msg" json:"msg,omitempty"`
+	Data             []byte `protobuf:"bytes,5,opt,name=data" json:"data,omitempty"`
+	Last             bool   `protobuf:"varint,6,opt,name=last" json:"last"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *RaftSnapshotChunkRequest) Reset()         { *m = RaftSnapshotChunkRequest{} }
+func (m *RaftSnapshotChunkRequest) String() string { return proto1.CompactTextString(m) }
+func (*RaftSnapshotChunkRequest) ProtoMessage()    {}
+
+func (m *RaftSnapshotChunkRequest) GetSnapshotID() uint64 {
+	if m != nil {
+		return m.SnapshotID
+	}
+	return 0
+}
+
+func (m *RaftSnapshotChunkRequest) GetSeq() int32 {
+	if m != nil {
+		return m.Seq
+	}
+	return 0
+}
+
+func (m *RaftSnapshotChunkRequest) GetMsg() []byte {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (m *RaftSnapshotChunkRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *RaftSnapshotChunkRequest) GetLast() bool {
+	if m != nil {
+		return m.Last
+	}
+	return false
+}
+
+// RaftSnapshotChunkResponse is an empty message returned by snapshot
+// chunk RPCs.
+type RaftSnapshotChunkResponse struct {
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *RaftSnapshotChunkResponse) Reset()         { *m = RaftSnapshotChunkResponse{} }
+func (m *RaftSnapshotChunkResponse) String() string { return proto1.CompactTextString(m) }
+func (*RaftSnapshotChunkResponse) ProtoMessage()    {}
+
 // InternalTimeSeriesData is a collection of data samples for some measurable
 // value, where each sample is taken over a uniform time interval.
 //
@@ -1207,13 +1817,40 @@ func (m *RaftTruncatedState) GetTerm() uint64 {
 	return 0
 }
 
+// A ChangeLogEntry records a write to a span of a range's keys at the
+// timestamp encoded in its change log key. The change log lets change
+// feeds read the writes since a timestamp without scanning the range.
+type ChangeLogEntry struct {
+	Key    Key `protobuf:"bytes,1,opt,name=key,casttype=Key" json:"key,omitempty"`
+	EndKey Key `protobuf:"bytes,2,opt,name=end_key,casttype=Key" json:"end_key,omitempty"`
+	// Intent is true if the span holds write intents at the timestamp
+	// instead of committed versions.
+	Intent           bool   `protobuf:"varint,3,opt,name=intent" json:"intent"`
+	XXX_unrecognized []byte `json:"-"`
+}
+
+func (m *ChangeLogEntry) Reset()         { *m = ChangeLogEntry{} }
+func (m *ChangeLogEntry) String() string { return proto1.CompactTextString(m) }
+func (*ChangeLogEntry) ProtoMessage()    {}
+
+func (m *ChangeLogEntry) GetIntent() bool {
+	if m != nil {
+		return m.Intent
+	}
+	return false
+}
+
 // RaftSnapshotData is the payload of a raftpb.Snapshot. It contains a raw copy of
 // all of the range's data and metadata, including the raft log, response cache, etc.
 type RaftSnapshotData struct {
 	// The latest RangeDescriptor
-	RangeDescriptor  RangeDescriptor              `protobuf:"bytes,1,opt,name=range_descriptor" json:"range_descriptor"`
-	KV               []*RaftSnapshotData_KeyValue `protobuf:"bytes,2,rep" json:"KV,omitempty"`
-	XXX_unrecognized []byte                       `json:"-"`
+	RangeDescriptor RangeDescriptor              `protobuf:"bytes,1,opt,name=range_descriptor" json:"range_descriptor"`
+	KV              []*RaftSnapshotData_KeyValue `protobuf:"bytes,2,rep" json:"KV,omitempty"`
+	// If nonzero, the key/value pairs are not carried in KV but read from
+	// the snapshot stream with this ID, which is registered in the process
+	// handling the snapshot. See storage/snapshot_stream.go.
+	StreamID         uint64 `protobuf:"varint,3,opt,name=stream_id" json:"stream_id"`
+	XXX_unrecognized []byte `json:"-"`
 }
 
 func (m *RaftSnapshotData) Reset()         { *m = RaftSnapshotData{} }
@@ -1234,6 +1871,13 @@ func (m *RaftSnapshotData) GetKV() []*RaftSnapshotData_KeyValue {
 	return nil
 }
 
+func (m *RaftSnapshotData) GetStreamID() uint64 {
+	if m != nil {
+		return m.StreamID
+	}
+	return 0
+}
+
 type RaftSnapshotData_KeyValue struct {
 	Key              []byte `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
 	Value            []byte `protobuf:"bytes,2,opt,name=value" json:"value,omitempty"`
@@ -1679,6 +2323,30 @@ func (m *InternalGCRequest) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangeLogThreshold", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ChangeLogThreshold.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -2833,7 +3501,8 @@ func (m *InternalLeaderLeaseResponse) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *InternalRequestUnion) Unmarshal(data []byte) error {
+
+func (m *InternalTransferLeaderLeaseRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2852,9 +3521,9 @@ func (m *InternalRequestUnion) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Get", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2872,16 +3541,13 @@ func (m *InternalRequestUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Get == nil {
-				m.Get = &GetRequest{}
-			}
-			if err := m.Get.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.RequestHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Put", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2899,16 +3565,13 @@ func (m *InternalRequestUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Put == nil {
-				m.Put = &PutRequest{}
-			}
-			if err := m.Put.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Lease.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConditionalPut", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TimestampCache", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2926,43 +3589,60 @@ func (m *InternalRequestUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConditionalPut == nil {
-				m.ConditionalPut = &ConditionalPutRequest{}
+			if m.TimestampCache == nil {
+				m.TimestampCache = &TimestampCacheSummary{}
 			}
-			if err := m.ConditionalPut.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.TimestampCache.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Increment", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Increment == nil {
-				m.Increment = &IncrementRequest{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *InternalTransferLeaderLeaseResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			if err := m.Increment.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 6:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResponseHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2980,43 +3660,57 @@ func (m *InternalRequestUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Delete == nil {
-				m.Delete = &DeleteRequest{}
-			}
-			if err := m.Delete.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.ResponseHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeleteRange", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DeleteRange == nil {
-				m.DeleteRange = &DeleteRangeRequest{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *InternalCloseTimestampRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			if err := m.DeleteRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 8:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Scan", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3034,16 +3728,13 @@ func (m *InternalRequestUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Scan == nil {
-				m.Scan = &ScanRequest{}
-			}
-			if err := m.Scan.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.RequestHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndTransaction", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Closed", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3061,70 +3752,57 @@ func (m *InternalRequestUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.EndTransaction == nil {
-				m.EndTransaction = &EndTransactionRequest{}
-			}
-			if err := m.EndTransaction.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Closed.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 30:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalPushTxn", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.InternalPushTxn == nil {
-				m.InternalPushTxn = &InternalPushTxnRequest{}
-			}
-			if err := m.InternalPushTxn.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 31:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntent", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalResolveIntent == nil {
-				m.InternalResolveIntent = &InternalResolveIntentRequest{}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *InternalCloseTimestampResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			if err := m.InternalResolveIntent.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			iNdEx = postIndex
-		case 32:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntentRange", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResponseHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3142,10 +3820,7 @@ func (m *InternalRequestUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalResolveIntentRange == nil {
-				m.InternalResolveIntentRange = &InternalResolveIntentRangeRequest{}
-			}
-			if err := m.InternalResolveIntentRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.ResponseHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3173,7 +3848,8 @@ func (m *InternalRequestUnion) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *InternalResponseUnion) Unmarshal(data []byte) error {
+
+func (m *TimestampCacheSummary) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3192,9 +3868,9 @@ func (m *InternalResponseUnion) Unmarshal(data []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		switch fieldNum {
-		case 2:
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Get", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LowWater", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3212,16 +3888,13 @@ func (m *InternalResponseUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Get == nil {
-				m.Get = &GetResponse{}
-			}
-			if err := m.Get.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.LowWater.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Put", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3239,70 +3912,102 @@ func (m *InternalResponseUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Put == nil {
-				m.Put = &PutResponse{}
-			}
-			if err := m.Put.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			m.Entries = append(m.Entries, TimestampCacheEntry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *TimestampCacheEntry) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConditionalPut", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field StartKey", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConditionalPut == nil {
-				m.ConditionalPut = &ConditionalPutResponse{}
-			}
-			if err := m.ConditionalPut.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.StartKey = append([]byte{}, data[iNdEx:postIndex]...)
 			iNdEx = postIndex
-		case 5:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Increment", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndKey", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Increment == nil {
-				m.Increment = &IncrementResponse{}
-			}
-			if err := m.Increment.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.EndKey = append([]byte{}, data[iNdEx:postIndex]...)
 			iNdEx = postIndex
-		case 6:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3320,70 +4025,96 @@ func (m *InternalResponseUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Delete == nil {
-				m.Delete = &DeleteResponse{}
-			}
-			if err := m.Delete.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Timestamp.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeleteRange", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field TxnID", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DeleteRange == nil {
-				m.DeleteRange = &DeleteRangeResponse{}
-			}
-			if err := m.DeleteRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.TxnID = append([]byte{}, data[iNdEx:postIndex]...)
 			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Scan", wireType)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReadOnly", wireType)
 			}
-			var msglen int
+			var v int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				v |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Scan == nil {
-				m.Scan = &ScanResponse{}
+			m.ReadOnly = bool(v != 0)
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
 			}
-			if err := m.Scan.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 9:
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *InternalComputeChecksumRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndTransaction", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3401,70 +4132,102 @@ func (m *InternalResponseUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.EndTransaction == nil {
-				m.EndTransaction = &EndTransactionResponse{}
-			}
-			if err := m.EndTransaction.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.RequestHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 30:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalPushTxn", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChecksumID", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalPushTxn == nil {
-				m.InternalPushTxn = &InternalPushTxnResponse{}
-			}
-			if err := m.InternalPushTxn.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ChecksumID = append([]byte{}, data[iNdEx:postIndex]...)
 			iNdEx = postIndex
-		case 31:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Boundaries", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalResolveIntent == nil {
-				m.InternalResolveIntent = &InternalResolveIntentResponse{}
+			m.Boundaries = append(m.Boundaries, make([]byte, postIndex-iNdEx))
+			copy(m.Boundaries[len(m.Boundaries)-1], data[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
 			}
-			if err := m.InternalResolveIntent.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
 				return err
 			}
-			iNdEx = postIndex
-		case 32:
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *InternalComputeChecksumResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntentRange", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ResponseHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3482,10 +4245,7 @@ func (m *InternalResponseUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalResolveIntentRange == nil {
-				m.InternalResolveIntentRange = &InternalResolveIntentRangeResponse{}
-			}
-			if err := m.InternalResolveIntentRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.ResponseHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3513,7 +4273,8 @@ func (m *InternalResponseUnion) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *InternalBatchRequest) Unmarshal(data []byte) error {
+
+func (m *InternalVerifyChecksumRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3558,7 +4319,52 @@ func (m *InternalBatchRequest) Unmarshal(data []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChecksumID", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChecksumID = append([]byte{}, data[iNdEx:postIndex]...)
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Checksums", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Checksums = append(m.Checksums, make([]byte, postIndex-iNdEx))
+			copy(m.Checksums[len(m.Checksums)-1], data[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Minority", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3576,8 +4382,8 @@ func (m *InternalBatchRequest) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Requests = append(m.Requests, InternalRequestUnion{})
-			if err := m.Requests[len(m.Requests)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+			m.Minority = append(m.Minority, Replica{})
+			if err := m.Minority[len(m.Minority)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -3605,7 +4411,8 @@ func (m *InternalBatchRequest) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *InternalBatchResponse) Unmarshal(data []byte) error {
+
+func (m *InternalVerifyChecksumResponse) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3648,31 +4455,6 @@ func (m *InternalBatchResponse) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			postIndex := iNdEx + msglen
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Responses = append(m.Responses, InternalResponseUnion{})
-			if err := m.Responses[len(m.Responses)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -3697,7 +4479,8 @@ func (m *InternalBatchResponse) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
+
+func (m *InternalReportChecksumRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -3718,7 +4501,7 @@ func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Put", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3736,70 +4519,58 @@ func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Put == nil {
-				m.Put = &PutResponse{}
-			}
-			if err := m.Put.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.RequestHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConditionalPut", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChecksumID", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConditionalPut == nil {
-				m.ConditionalPut = &ConditionalPutResponse{}
-			}
-			if err := m.ConditionalPut.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.ChecksumID = append([]byte{}, data[iNdEx:postIndex]...)
 			iNdEx = postIndex
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Increment", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Checksums", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				msglen |= (int(b) & 0x7F) << shift
+				byteLen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Increment == nil {
-				m.Increment = &IncrementResponse{}
-			}
-			if err := m.Increment.Unmarshal(data[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Checksums = append(m.Checksums, make([]byte, postIndex-iNdEx))
+			copy(m.Checksums[len(m.Checksums)-1], data[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Replica", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3817,18 +4588,59 @@ func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Delete == nil {
-				m.Delete = &DeleteResponse{}
-			}
-			if err := m.Delete.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Replica.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeleteRange", wireType)
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
 			}
-			var msglen int
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *InternalReportChecksumResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResponseHeader", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
@@ -3844,16 +4656,56 @@ func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DeleteRange == nil {
-				m.DeleteRange = &DeleteRangeResponse{}
-			}
-			if err := m.DeleteRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.ResponseHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *InternalRequestUnion) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndTransaction", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Get", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3871,16 +4723,16 @@ func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.EndTransaction == nil {
-				m.EndTransaction = &EndTransactionResponse{}
+			if m.Get == nil {
+				m.Get = &GetRequest{}
 			}
-			if err := m.EndTransaction.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Get.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 10:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalHeartbeatTxn", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Put", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3898,16 +4750,16 @@ func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalHeartbeatTxn == nil {
-				m.InternalHeartbeatTxn = &InternalHeartbeatTxnResponse{}
+			if m.Put == nil {
+				m.Put = &PutRequest{}
 			}
-			if err := m.InternalHeartbeatTxn.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Put.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 11:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalPushTxn", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConditionalPut", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3925,16 +4777,16 @@ func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalPushTxn == nil {
-				m.InternalPushTxn = &InternalPushTxnResponse{}
+			if m.ConditionalPut == nil {
+				m.ConditionalPut = &ConditionalPutRequest{}
 			}
-			if err := m.InternalPushTxn.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.ConditionalPut.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 12:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Increment", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3952,16 +4804,16 @@ func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalResolveIntent == nil {
-				m.InternalResolveIntent = &InternalResolveIntentResponse{}
+			if m.Increment == nil {
+				m.Increment = &IncrementRequest{}
 			}
-			if err := m.InternalResolveIntent.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Increment.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 13:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntentRange", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3979,16 +4831,16 @@ func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalResolveIntentRange == nil {
-				m.InternalResolveIntentRange = &InternalResolveIntentRangeResponse{}
+			if m.Delete == nil {
+				m.Delete = &DeleteRequest{}
 			}
-			if err := m.InternalResolveIntentRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Delete.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 14:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalMerge", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DeleteRange", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4006,16 +4858,16 @@ func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalMerge == nil {
-				m.InternalMerge = &InternalMergeResponse{}
+			if m.DeleteRange == nil {
+				m.DeleteRange = &DeleteRangeRequest{}
 			}
-			if err := m.InternalMerge.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.DeleteRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 15:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalTruncateLog", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Scan", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4033,16 +4885,16 @@ func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalTruncateLog == nil {
-				m.InternalTruncateLog = &InternalTruncateLogResponse{}
+			if m.Scan == nil {
+				m.Scan = &ScanRequest{}
 			}
-			if err := m.InternalTruncateLog.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Scan.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 16:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalGc", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTransaction", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4060,16 +4912,16 @@ func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalGc == nil {
-				m.InternalGc = &InternalGCResponse{}
+			if m.EndTransaction == nil {
+				m.EndTransaction = &EndTransactionRequest{}
 			}
-			if err := m.InternalGc.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.EndTransaction.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 17:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalLeaderLease", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReapQueue", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4087,59 +4939,16 @@ func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalLeaderLease == nil {
-				m.InternalLeaderLease = &InternalLeaderLeaseResponse{}
+			if m.ReapQueue == nil {
+				m.ReapQueue = &ReapQueueRequest{}
 			}
-			if err := m.InternalLeaderLease.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.ReapQueue.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			var sizeOfWire int
-			for {
-				sizeOfWire++
-				wire >>= 7
-				if wire == 0 {
-					break
-				}
-			}
-			iNdEx -= sizeOfWire
-			skippy, err := skipInternal(data[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	return nil
-}
-func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
-	l := len(data)
-	iNdEx := 0
-	for iNdEx < l {
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := data[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		switch fieldNum {
-		case 2:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Get", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EnqueueUpdate", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4157,16 +4966,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Get == nil {
-				m.Get = &GetRequest{}
+			if m.EnqueueUpdate == nil {
+				m.EnqueueUpdate = &EnqueueUpdateRequest{}
 			}
-			if err := m.Get.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.EnqueueUpdate.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 3:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Put", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EnqueueMessage", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4184,16 +4993,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Put == nil {
-				m.Put = &PutRequest{}
+			if m.EnqueueMessage == nil {
+				m.EnqueueMessage = &EnqueueMessageRequest{}
 			}
-			if err := m.Put.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.EnqueueMessage.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 4:
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ConditionalPut", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4211,16 +5020,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.ConditionalPut == nil {
-				m.ConditionalPut = &ConditionalPutRequest{}
+			if m.Changes == nil {
+				m.Changes = &ChangesRequest{}
 			}
-			if err := m.ConditionalPut.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Changes.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 5:
+		case 14:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Increment", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Import", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4238,16 +5047,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Increment == nil {
-				m.Increment = &IncrementRequest{}
+			if m.Import == nil {
+				m.Import = &ImportRequest{}
 			}
-			if err := m.Increment.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Import.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 6:
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SpanStats", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4265,16 +5074,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Delete == nil {
-				m.Delete = &DeleteRequest{}
+			if m.SpanStats == nil {
+				m.SpanStats = &SpanStatsRequest{}
 			}
-			if err := m.Delete.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.SpanStats.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 7:
+		case 30:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DeleteRange", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalPushTxn", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4292,16 +5101,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.DeleteRange == nil {
-				m.DeleteRange = &DeleteRangeRequest{}
+			if m.InternalPushTxn == nil {
+				m.InternalPushTxn = &InternalPushTxnRequest{}
 			}
-			if err := m.DeleteRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.InternalPushTxn.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
+		case 31:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Scan", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntent", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4319,16 +5128,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Scan == nil {
-				m.Scan = &ScanRequest{}
+			if m.InternalResolveIntent == nil {
+				m.InternalResolveIntent = &InternalResolveIntentRequest{}
 			}
-			if err := m.Scan.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.InternalResolveIntent.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 9:
+		case 32:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field EndTransaction", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntentRange", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4346,16 +5155,59 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.EndTransaction == nil {
-				m.EndTransaction = &EndTransactionRequest{}
+			if m.InternalResolveIntentRange == nil {
+				m.InternalResolveIntentRange = &InternalResolveIntentRangeRequest{}
 			}
-			if err := m.EndTransaction.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.InternalResolveIntentRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 30:
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *InternalResponseUnion) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Batch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Get", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4373,16 +5225,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Batch == nil {
-				m.Batch = &BatchRequest{}
+			if m.Get == nil {
+				m.Get = &GetResponse{}
 			}
-			if err := m.Batch.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Get.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 31:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalRangeLookup", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Put", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4400,16 +5252,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalRangeLookup == nil {
-				m.InternalRangeLookup = &InternalRangeLookupRequest{}
+			if m.Put == nil {
+				m.Put = &PutResponse{}
 			}
-			if err := m.InternalRangeLookup.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Put.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 32:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalHeartbeatTxn", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ConditionalPut", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4427,16 +5279,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalHeartbeatTxn == nil {
-				m.InternalHeartbeatTxn = &InternalHeartbeatTxnRequest{}
+			if m.ConditionalPut == nil {
+				m.ConditionalPut = &ConditionalPutResponse{}
 			}
-			if err := m.InternalHeartbeatTxn.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.ConditionalPut.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 33:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalPushTxn", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Increment", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4454,16 +5306,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalPushTxn == nil {
-				m.InternalPushTxn = &InternalPushTxnRequest{}
+			if m.Increment == nil {
+				m.Increment = &IncrementResponse{}
 			}
-			if err := m.InternalPushTxn.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Increment.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 34:
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntent", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4481,16 +5333,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalResolveIntent == nil {
-				m.InternalResolveIntent = &InternalResolveIntentRequest{}
+			if m.Delete == nil {
+				m.Delete = &DeleteResponse{}
 			}
-			if err := m.InternalResolveIntent.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Delete.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 35:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntentRange", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DeleteRange", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4508,16 +5360,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalResolveIntentRange == nil {
-				m.InternalResolveIntentRange = &InternalResolveIntentRangeRequest{}
+			if m.DeleteRange == nil {
+				m.DeleteRange = &DeleteRangeResponse{}
 			}
-			if err := m.InternalResolveIntentRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.DeleteRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 36:
+		case 8:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalMergeResponse", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Scan", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4535,16 +5387,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalMergeResponse == nil {
-				m.InternalMergeResponse = &InternalMergeRequest{}
+			if m.Scan == nil {
+				m.Scan = &ScanResponse{}
 			}
-			if err := m.InternalMergeResponse.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.Scan.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 37:
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalTruncateLog", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTransaction", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4562,16 +5414,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalTruncateLog == nil {
-				m.InternalTruncateLog = &InternalTruncateLogRequest{}
+			if m.EndTransaction == nil {
+				m.EndTransaction = &EndTransactionResponse{}
 			}
-			if err := m.InternalTruncateLog.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.EndTransaction.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 38:
+		case 10:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalGC", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReapQueue", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4589,16 +5441,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalGC == nil {
-				m.InternalGC = &InternalGCRequest{}
+			if m.ReapQueue == nil {
+				m.ReapQueue = &ReapQueueResponse{}
 			}
-			if err := m.InternalGC.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.ReapQueue.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 39:
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalLease", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EnqueueUpdate", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4616,16 +5468,16 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalLease == nil {
-				m.InternalLease = &InternalLeaderLeaseRequest{}
+			if m.EnqueueUpdate == nil {
+				m.EnqueueUpdate = &EnqueueUpdateResponse{}
 			}
-			if err := m.InternalLease.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.EnqueueUpdate.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 40:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field InternalBatch", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EnqueueMessage", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4643,89 +5495,97 @@ func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.InternalBatch == nil {
-				m.InternalBatch = &InternalBatchRequest{}
+			if m.EnqueueMessage == nil {
+				m.EnqueueMessage = &EnqueueMessageResponse{}
 			}
-			if err := m.InternalBatch.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if err := m.EnqueueMessage.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			var sizeOfWire int
-			for {
-				sizeOfWire++
-				wire >>= 7
-				if wire == 0 {
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
 					break
 				}
 			}
-			iNdEx -= sizeOfWire
-			skippy, err := skipInternal(data[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	return nil
-}
-func (m *InternalRaftCommand) Unmarshal(data []byte) error {
-	l := len(data)
-	iNdEx := 0
-	for iNdEx < l {
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.Changes == nil {
+				m.Changes = &ChangesResponse{}
 			}
-			b := data[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.Changes.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RaftID", wireType)
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Import", wireType)
 			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.RaftID |= (RaftID(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OriginNodeID", wireType)
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Import == nil {
+				m.Import = &ImportResponse{}
+			}
+			if err := m.Import.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpanStats", wireType)
 			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.OriginNodeID |= (RaftNodeID(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 3:
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SpanStats == nil {
+				m.SpanStats = &SpanStatsResponse{}
+			}
+			if err := m.SpanStats.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 30:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Cmd", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalPushTxn", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4743,132 +5603,67 @@ func (m *InternalRaftCommand) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.Cmd.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if m.InternalPushTxn == nil {
+				m.InternalPushTxn = &InternalPushTxnResponse{}
+			}
+			if err := m.InternalPushTxn.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			var sizeOfWire int
-			for {
-				sizeOfWire++
-				wire >>= 7
-				if wire == 0 {
-					break
-				}
-			}
-			iNdEx -= sizeOfWire
-			skippy, err := skipInternal(data[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	return nil
-}
-func (m *RaftMessageRequest) Unmarshal(data []byte) error {
-	l := len(data)
-	iNdEx := 0
-	for iNdEx < l {
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := data[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+		case 31:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntent", wireType)
 			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.GroupID |= (RaftID(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalResolveIntent == nil {
+				m.InternalResolveIntent = &InternalResolveIntentResponse{}
+			}
+			if err := m.InternalResolveIntent.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 32:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntentRange", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Msg = append([]byte{}, data[iNdEx:postIndex]...)
-			iNdEx = postIndex
-		default:
-			var sizeOfWire int
-			for {
-				sizeOfWire++
-				wire >>= 7
-				if wire == 0 {
-					break
-				}
+			if m.InternalResolveIntentRange == nil {
+				m.InternalResolveIntentRange = &InternalResolveIntentRangeResponse{}
 			}
-			iNdEx -= sizeOfWire
-			skippy, err := skipInternal(data[iNdEx:])
-			if err != nil {
+			if err := m.InternalResolveIntentRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	return nil
-}
-func (m *RaftMessageResponse) Unmarshal(data []byte) error {
-	l := len(data)
-	iNdEx := 0
-	for iNdEx < l {
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := data[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		switch fieldNum {
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -4893,7 +5688,7 @@ func (m *RaftMessageResponse) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *InternalTimeSeriesData) Unmarshal(data []byte) error {
+func (m *InternalBatchRequest) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -4913,38 +5708,32 @@ func (m *InternalTimeSeriesData) Unmarshal(data []byte) error {
 		wireType := int(wire & 0x7)
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampNanos", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RequestHeader", wireType)
 			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.StartTimestampNanos |= (int64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SampleDurationNanos", wireType)
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			for shift := uint(0); ; shift += 7 {
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := data[iNdEx]
-				iNdEx++
-				m.SampleDurationNanos |= (int64(b) & 0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if err := m.RequestHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		case 3:
+			iNdEx = postIndex
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Samples", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Requests", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -4962,8 +5751,8 @@ func (m *InternalTimeSeriesData) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Samples = append(m.Samples, &InternalTimeSeriesSample{})
-			if err := m.Samples[len(m.Samples)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+			m.Requests = append(m.Requests, InternalRequestUnion{})
+			if err := m.Requests[len(m.Requests)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -4991,7 +5780,7 @@ func (m *InternalTimeSeriesData) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *InternalTimeSeriesSample) Unmarshal(data []byte) error {
+func (m *InternalBatchResponse) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5011,91 +5800,54 @@ func (m *InternalTimeSeriesSample) Unmarshal(data []byte) error {
 		wireType := int(wire & 0x7)
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResponseHeader", wireType)
 			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.Offset |= (int32(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 6:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.ResponseHeader.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Responses", wireType)
 			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.Count |= (uint32(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 7:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Sum", wireType)
-			}
-			var v uint64
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += 8
-			v = uint64(data[iNdEx-8])
-			v |= uint64(data[iNdEx-7]) << 8
-			v |= uint64(data[iNdEx-6]) << 16
-			v |= uint64(data[iNdEx-5]) << 24
-			v |= uint64(data[iNdEx-4]) << 32
-			v |= uint64(data[iNdEx-3]) << 40
-			v |= uint64(data[iNdEx-2]) << 48
-			v |= uint64(data[iNdEx-1]) << 56
-			m.Sum = float64(math.Float64frombits(v))
-		case 8:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Max", wireType)
-			}
-			var v uint64
-			if (iNdEx + 8) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			iNdEx += 8
-			v = uint64(data[iNdEx-8])
-			v |= uint64(data[iNdEx-7]) << 8
-			v |= uint64(data[iNdEx-6]) << 16
-			v |= uint64(data[iNdEx-5]) << 24
-			v |= uint64(data[iNdEx-4]) << 32
-			v |= uint64(data[iNdEx-3]) << 40
-			v |= uint64(data[iNdEx-2]) << 48
-			v |= uint64(data[iNdEx-1]) << 56
-			v2 := float64(math.Float64frombits(v))
-			m.Max = &v2
-		case 9:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Min", wireType)
-			}
-			var v uint64
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
+			m.Responses = append(m.Responses, InternalResponseUnion{})
+			if err := m.Responses[len(m.Responses)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			iNdEx += 8
-			v = uint64(data[iNdEx-8])
-			v |= uint64(data[iNdEx-7]) << 8
-			v |= uint64(data[iNdEx-6]) << 16
-			v |= uint64(data[iNdEx-5]) << 24
-			v |= uint64(data[iNdEx-4]) << 32
-			v |= uint64(data[iNdEx-3]) << 40
-			v |= uint64(data[iNdEx-2]) << 48
-			v |= uint64(data[iNdEx-1]) << 56
-			v2 := float64(math.Float64frombits(v))
-			m.Min = &v2
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -5120,7 +5872,7 @@ func (m *InternalTimeSeriesSample) Unmarshal(data []byte) error {
 
 	return nil
 }
-func (m *RaftTruncatedState) Unmarshal(data []byte) error {
+func (m *ReadWriteCmdResponse) Unmarshal(data []byte) error {
 	l := len(data)
 	iNdEx := 0
 	for iNdEx < l {
@@ -5140,81 +5892,62 @@ func (m *RaftTruncatedState) Unmarshal(data []byte) error {
 		wireType := int(wire & 0x7)
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Put", wireType)
 			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				m.Index |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
 			}
-			for shift := uint(0); ; shift += 7 {
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
+			if m.Put == nil {
+				m.Put = &PutResponse{}
+			}
+			if err := m.Put.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConditionalPut", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
 				b := data[iNdEx]
 				iNdEx++
-				m.Term |= (uint64(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		default:
-			var sizeOfWire int
-			for {
-				sizeOfWire++
-				wire >>= 7
-				if wire == 0 {
-					break
-				}
-			}
-			iNdEx -= sizeOfWire
-			skippy, err := skipInternal(data[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	return nil
-}
-func (m *RaftSnapshotData) Unmarshal(data []byte) error {
-	l := len(data)
-	iNdEx := 0
-	for iNdEx < l {
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.ConditionalPut == nil {
+				m.ConditionalPut = &ConditionalPutResponse{}
 			}
-			b := data[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.ConditionalPut.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field RangeDescriptor", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Increment", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5232,13 +5965,16 @@ func (m *RaftSnapshotData) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := m.RangeDescriptor.Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if m.Increment == nil {
+				m.Increment = &IncrementResponse{}
+			}
+			if err := m.Increment.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field KV", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -5256,163 +5992,2455 @@ func (m *RaftSnapshotData) Unmarshal(data []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.KV = append(m.KV, &RaftSnapshotData_KeyValue{})
-			if err := m.KV[len(m.KV)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+			if m.Delete == nil {
+				m.Delete = &DeleteResponse{}
+			}
+			if err := m.Delete.Unmarshal(data[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			var sizeOfWire int
-			for {
-				sizeOfWire++
-				wire >>= 7
-				if wire == 0 {
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeleteRange", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
 					break
 				}
 			}
-			iNdEx -= sizeOfWire
-			skippy, err := skipInternal(data[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	return nil
-}
-func (m *RaftSnapshotData_KeyValue) Unmarshal(data []byte) error {
-	l := len(data)
-	iNdEx := 0
-	for iNdEx < l {
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			if m.DeleteRange == nil {
+				m.DeleteRange = &DeleteRangeResponse{}
 			}
-			b := data[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.DeleteRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		switch fieldNum {
-		case 1:
+			iNdEx = postIndex
+		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTransaction", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Key = append([]byte{}, data[iNdEx:postIndex]...)
+			if m.EndTransaction == nil {
+				m.EndTransaction = &EndTransactionResponse{}
+			}
+			if err := m.EndTransaction.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 2:
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ReapQueue", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
 					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				byteLen |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append([]byte{}, data[iNdEx:postIndex]...)
+			if m.ReapQueue == nil {
+				m.ReapQueue = &ReapQueueResponse{}
+			}
+			if err := m.ReapQueue.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		default:
-			var sizeOfWire int
-			for {
-				sizeOfWire++
-				wire >>= 7
-				if wire == 0 {
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnqueueUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
 					break
 				}
 			}
-			iNdEx -= sizeOfWire
-			skippy, err := skipInternal(data[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (iNdEx + skippy) > l {
+			postIndex := iNdEx + msglen
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
-			iNdEx += skippy
-		}
-	}
-
-	return nil
-}
-func skipInternal(data []byte) (n int, err error) {
-	l := len(data)
-	iNdEx := 0
-	for iNdEx < l {
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if iNdEx >= l {
-				return 0, io.ErrUnexpectedEOF
+			if m.EnqueueUpdate == nil {
+				m.EnqueueUpdate = &EnqueueUpdateResponse{}
 			}
-			b := data[iNdEx]
-			iNdEx++
-			wire |= (uint64(b) & 0x7F) << shift
-			if b < 0x80 {
-				break
+			if err := m.EnqueueUpdate.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		wireType := int(wire & 0x7)
-		switch wireType {
-		case 0:
-			for {
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnqueueMessage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
-					return 0, io.ErrUnexpectedEOF
+					return io.ErrUnexpectedEOF
 				}
+				b := data[iNdEx]
 				iNdEx++
-				if data[iNdEx-1] < 0x80 {
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
 					break
 				}
 			}
-			return iNdEx, nil
-		case 1:
-			iNdEx += 8
-			return iNdEx, nil
-		case 2:
-			var length int
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EnqueueMessage == nil {
+				m.EnqueueMessage = &EnqueueMessageResponse{}
+			}
+			if err := m.EnqueueMessage.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalHeartbeatTxn", wireType)
+			}
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if iNdEx >= l {
-					return 0, io.ErrUnexpectedEOF
+					return io.ErrUnexpectedEOF
 				}
 				b := data[iNdEx]
 				iNdEx++
-				length |= (int(b) & 0x7F) << shift
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalHeartbeatTxn == nil {
+				m.InternalHeartbeatTxn = &InternalHeartbeatTxnResponse{}
+			}
+			if err := m.InternalHeartbeatTxn.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalPushTxn", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalPushTxn == nil {
+				m.InternalPushTxn = &InternalPushTxnResponse{}
+			}
+			if err := m.InternalPushTxn.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalResolveIntent == nil {
+				m.InternalResolveIntent = &InternalResolveIntentResponse{}
+			}
+			if err := m.InternalResolveIntent.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntentRange", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalResolveIntentRange == nil {
+				m.InternalResolveIntentRange = &InternalResolveIntentRangeResponse{}
+			}
+			if err := m.InternalResolveIntentRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalMerge", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalMerge == nil {
+				m.InternalMerge = &InternalMergeResponse{}
+			}
+			if err := m.InternalMerge.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalTruncateLog", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalTruncateLog == nil {
+				m.InternalTruncateLog = &InternalTruncateLogResponse{}
+			}
+			if err := m.InternalTruncateLog.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalGc", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalGc == nil {
+				m.InternalGc = &InternalGCResponse{}
+			}
+			if err := m.InternalGc.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalLeaderLease", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalLeaderLease == nil {
+				m.InternalLeaderLease = &InternalLeaderLeaseResponse{}
+			}
+			if err := m.InternalLeaderLease.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Import", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Import == nil {
+				m.Import = &ImportResponse{}
+			}
+			if err := m.Import.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 19:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalComputeChecksum", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalComputeChecksum == nil {
+				m.InternalComputeChecksum = &InternalComputeChecksumResponse{}
+			}
+			if err := m.InternalComputeChecksum.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalVerifyChecksum", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalVerifyChecksum == nil {
+				m.InternalVerifyChecksum = &InternalVerifyChecksumResponse{}
+			}
+			if err := m.InternalVerifyChecksum.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 21:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalTransferLeaderLease", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalTransferLeaderLease == nil {
+				m.InternalTransferLeaderLease = &InternalTransferLeaderLeaseResponse{}
+			}
+			if err := m.InternalTransferLeaderLease.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 22:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalCloseTimestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalCloseTimestamp == nil {
+				m.InternalCloseTimestamp = &InternalCloseTimestampResponse{}
+			}
+			if err := m.InternalCloseTimestamp.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 23:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalReportChecksum", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalReportChecksum == nil {
+				m.InternalReportChecksum = &InternalReportChecksumResponse{}
+			}
+			if err := m.InternalReportChecksum.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *InternalRaftCommandUnion) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Get", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Get == nil {
+				m.Get = &GetRequest{}
+			}
+			if err := m.Get.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Put", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Put == nil {
+				m.Put = &PutRequest{}
+			}
+			if err := m.Put.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConditionalPut", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConditionalPut == nil {
+				m.ConditionalPut = &ConditionalPutRequest{}
+			}
+			if err := m.ConditionalPut.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Increment", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Increment == nil {
+				m.Increment = &IncrementRequest{}
+			}
+			if err := m.Increment.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Delete", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Delete == nil {
+				m.Delete = &DeleteRequest{}
+			}
+			if err := m.Delete.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DeleteRange", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.DeleteRange == nil {
+				m.DeleteRange = &DeleteRangeRequest{}
+			}
+			if err := m.DeleteRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scan", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Scan == nil {
+				m.Scan = &ScanRequest{}
+			}
+			if err := m.Scan.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTransaction", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EndTransaction == nil {
+				m.EndTransaction = &EndTransactionRequest{}
+			}
+			if err := m.EndTransaction.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ReapQueue", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ReapQueue == nil {
+				m.ReapQueue = &ReapQueueRequest{}
+			}
+			if err := m.ReapQueue.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnqueueUpdate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EnqueueUpdate == nil {
+				m.EnqueueUpdate = &EnqueueUpdateRequest{}
+			}
+			if err := m.EnqueueUpdate.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EnqueueMessage", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.EnqueueMessage == nil {
+				m.EnqueueMessage = &EnqueueMessageRequest{}
+			}
+			if err := m.EnqueueMessage.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Changes == nil {
+				m.Changes = &ChangesRequest{}
+			}
+			if err := m.Changes.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Import", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Import == nil {
+				m.Import = &ImportRequest{}
+			}
+			if err := m.Import.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpanStats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.SpanStats == nil {
+				m.SpanStats = &SpanStatsRequest{}
+			}
+			if err := m.SpanStats.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 30:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Batch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Batch == nil {
+				m.Batch = &BatchRequest{}
+			}
+			if err := m.Batch.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 31:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalRangeLookup", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalRangeLookup == nil {
+				m.InternalRangeLookup = &InternalRangeLookupRequest{}
+			}
+			if err := m.InternalRangeLookup.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 32:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalHeartbeatTxn", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalHeartbeatTxn == nil {
+				m.InternalHeartbeatTxn = &InternalHeartbeatTxnRequest{}
+			}
+			if err := m.InternalHeartbeatTxn.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 33:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalPushTxn", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalPushTxn == nil {
+				m.InternalPushTxn = &InternalPushTxnRequest{}
+			}
+			if err := m.InternalPushTxn.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 34:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntent", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalResolveIntent == nil {
+				m.InternalResolveIntent = &InternalResolveIntentRequest{}
+			}
+			if err := m.InternalResolveIntent.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 35:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalResolveIntentRange", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalResolveIntentRange == nil {
+				m.InternalResolveIntentRange = &InternalResolveIntentRangeRequest{}
+			}
+			if err := m.InternalResolveIntentRange.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 36:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalMergeResponse", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalMergeResponse == nil {
+				m.InternalMergeResponse = &InternalMergeRequest{}
+			}
+			if err := m.InternalMergeResponse.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 37:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalTruncateLog", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalTruncateLog == nil {
+				m.InternalTruncateLog = &InternalTruncateLogRequest{}
+			}
+			if err := m.InternalTruncateLog.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 38:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalGC", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalGC == nil {
+				m.InternalGC = &InternalGCRequest{}
+			}
+			if err := m.InternalGC.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 39:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalLease", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalLease == nil {
+				m.InternalLease = &InternalLeaderLeaseRequest{}
+			}
+			if err := m.InternalLease.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 40:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalBatch", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalBatch == nil {
+				m.InternalBatch = &InternalBatchRequest{}
+			}
+			if err := m.InternalBatch.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 41:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalComputeChecksum", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalComputeChecksum == nil {
+				m.InternalComputeChecksum = &InternalComputeChecksumRequest{}
+			}
+			if err := m.InternalComputeChecksum.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 42:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalVerifyChecksum", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalVerifyChecksum == nil {
+				m.InternalVerifyChecksum = &InternalVerifyChecksumRequest{}
+			}
+			if err := m.InternalVerifyChecksum.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 43:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalTransferLeaderLease", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalTransferLeaderLease == nil {
+				m.InternalTransferLeaderLease = &InternalTransferLeaderLeaseRequest{}
+			}
+			if err := m.InternalTransferLeaderLease.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 44:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalCloseTimestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalCloseTimestamp == nil {
+				m.InternalCloseTimestamp = &InternalCloseTimestampRequest{}
+			}
+			if err := m.InternalCloseTimestamp.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 45:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InternalReportChecksum", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.InternalReportChecksum == nil {
+				m.InternalReportChecksum = &InternalReportChecksumRequest{}
+			}
+			if err := m.InternalReportChecksum.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *InternalRaftCommand) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RaftID", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.RaftID |= (RaftID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OriginNodeID", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.OriginNodeID |= (RaftNodeID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cmd", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Cmd.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *RaftMessageRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.GroupID |= (RaftID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = append([]byte{}, data[iNdEx:postIndex]...)
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *RaftMessageResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		switch fieldNum {
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *RaftSnapshotChunkRequest) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.GroupID |= (RaftID(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SnapshotID", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.SnapshotID |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Seq", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Seq |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Msg", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Msg = append([]byte{}, data[iNdEx:postIndex]...)
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Data = append([]byte{}, data[iNdEx:postIndex]...)
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Last", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Last = bool(v != 0)
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *RaftSnapshotChunkResponse) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		switch fieldNum {
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *InternalTimeSeriesData) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampNanos", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.StartTimestampNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SampleDurationNanos", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.SampleDurationNanos |= (int64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Samples", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Samples = append(m.Samples, &InternalTimeSeriesSample{})
+			if err := m.Samples[len(m.Samples)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *InternalTimeSeriesSample) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Offset |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Count |= (uint32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sum", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += 8
+			v = uint64(data[iNdEx-8])
+			v |= uint64(data[iNdEx-7]) << 8
+			v |= uint64(data[iNdEx-6]) << 16
+			v |= uint64(data[iNdEx-5]) << 24
+			v |= uint64(data[iNdEx-4]) << 32
+			v |= uint64(data[iNdEx-3]) << 40
+			v |= uint64(data[iNdEx-2]) << 48
+			v |= uint64(data[iNdEx-1]) << 56
+			m.Sum = float64(math.Float64frombits(v))
+		case 8:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Max", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += 8
+			v = uint64(data[iNdEx-8])
+			v |= uint64(data[iNdEx-7]) << 8
+			v |= uint64(data[iNdEx-6]) << 16
+			v |= uint64(data[iNdEx-5]) << 24
+			v |= uint64(data[iNdEx-4]) << 32
+			v |= uint64(data[iNdEx-3]) << 40
+			v |= uint64(data[iNdEx-2]) << 48
+			v |= uint64(data[iNdEx-1]) << 56
+			v2 := float64(math.Float64frombits(v))
+			m.Max = &v2
+		case 9:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Min", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += 8
+			v = uint64(data[iNdEx-8])
+			v |= uint64(data[iNdEx-7]) << 8
+			v |= uint64(data[iNdEx-6]) << 16
+			v |= uint64(data[iNdEx-5]) << 24
+			v |= uint64(data[iNdEx-4]) << 32
+			v |= uint64(data[iNdEx-3]) << 40
+			v |= uint64(data[iNdEx-2]) << 48
+			v |= uint64(data[iNdEx-1]) << 56
+			v2 := float64(math.Float64frombits(v))
+			m.Min = &v2
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *RaftTruncatedState) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Index |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Term |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *ChangeLogEntry) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append([]byte{}, data[iNdEx:postIndex]...)
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EndKey = append([]byte{}, data[iNdEx:postIndex]...)
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Intent", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				v |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Intent = bool(v != 0)
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *RaftSnapshotData) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RangeDescriptor", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.RangeDescriptor.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field KV", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.KV = append(m.KV, &RaftSnapshotData_KeyValue{})
+			if err := m.KV[len(m.KV)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StreamID", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.StreamID |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func (m *RaftSnapshotData_KeyValue) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = append([]byte{}, data[iNdEx:postIndex]...)
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append([]byte{}, data[iNdEx:postIndex]...)
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipInternal(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+func skipInternal(data []byte) (n int, err error) {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for {
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if data[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+			return iNdEx, nil
+		case 1:
+			iNdEx += 8
+			return iNdEx, nil
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -5481,6 +8509,24 @@ func (this *InternalRequestUnion) GetValue() interface{} {
 	if this.EndTransaction != nil {
 		return this.EndTransaction
 	}
+	if this.ReapQueue != nil {
+		return this.ReapQueue
+	}
+	if this.EnqueueUpdate != nil {
+		return this.EnqueueUpdate
+	}
+	if this.EnqueueMessage != nil {
+		return this.EnqueueMessage
+	}
+	if this.Changes != nil {
+		return this.Changes
+	}
+	if this.Import != nil {
+		return this.Import
+	}
+	if this.SpanStats != nil {
+		return this.SpanStats
+	}
 	if this.InternalPushTxn != nil {
 		return this.InternalPushTxn
 	}
@@ -5511,6 +8557,18 @@ func (this *InternalRequestUnion) SetValue(value interface{}) bool {
 		this.Scan = vt
 	case *EndTransactionRequest:
 		this.EndTransaction = vt
+	case *ReapQueueRequest:
+		this.ReapQueue = vt
+	case *EnqueueUpdateRequest:
+		this.EnqueueUpdate = vt
+	case *EnqueueMessageRequest:
+		this.EnqueueMessage = vt
+	case *ChangesRequest:
+		this.Changes = vt
+	case *ImportRequest:
+		this.Import = vt
+	case *SpanStatsRequest:
+		this.SpanStats = vt
 	case *InternalPushTxnRequest:
 		this.InternalPushTxn = vt
 	case *InternalResolveIntentRequest:
@@ -5547,6 +8605,24 @@ func (this *InternalResponseUnion) GetValue() interface{} {
 	if this.EndTransaction != nil {
 		return this.EndTransaction
 	}
+	if this.ReapQueue != nil {
+		return this.ReapQueue
+	}
+	if this.EnqueueUpdate != nil {
+		return this.EnqueueUpdate
+	}
+	if this.EnqueueMessage != nil {
+		return this.EnqueueMessage
+	}
+	if this.Changes != nil {
+		return this.Changes
+	}
+	if this.Import != nil {
+		return this.Import
+	}
+	if this.SpanStats != nil {
+		return this.SpanStats
+	}
 	if this.InternalPushTxn != nil {
 		return this.InternalPushTxn
 	}
@@ -5577,6 +8653,18 @@ func (this *InternalResponseUnion) SetValue(value interface{}) bool {
 		this.Scan = vt
 	case *EndTransactionResponse:
 		this.EndTransaction = vt
+	case *ReapQueueResponse:
+		this.ReapQueue = vt
+	case *EnqueueUpdateResponse:
+		this.EnqueueUpdate = vt
+	case *EnqueueMessageResponse:
+		this.EnqueueMessage = vt
+	case *ChangesResponse:
+		this.Changes = vt
+	case *ImportResponse:
+		this.Import = vt
+	case *SpanStatsResponse:
+		this.SpanStats = vt
 	case *InternalPushTxnResponse:
 		this.InternalPushTxn = vt
 	case *InternalResolveIntentResponse:
@@ -5607,6 +8695,15 @@ func (this *ReadWriteCmdResponse) GetValue() interface{} {
 	if this.EndTransaction != nil {
 		return this.EndTransaction
 	}
+	if this.ReapQueue != nil {
+		return this.ReapQueue
+	}
+	if this.EnqueueUpdate != nil {
+		return this.EnqueueUpdate
+	}
+	if this.EnqueueMessage != nil {
+		return this.EnqueueMessage
+	}
 	if this.InternalHeartbeatTxn != nil {
 		return this.InternalHeartbeatTxn
 	}
@@ -5631,6 +8728,24 @@ func (this *ReadWriteCmdResponse) GetValue() interface{} {
 	if this.InternalLeaderLease != nil {
 		return this.InternalLeaderLease
 	}
+	if this.Import != nil {
+		return this.Import
+	}
+	if this.InternalComputeChecksum != nil {
+		return this.InternalComputeChecksum
+	}
+	if this.InternalVerifyChecksum != nil {
+		return this.InternalVerifyChecksum
+	}
+	if this.InternalTransferLeaderLease != nil {
+		return this.InternalTransferLeaderLease
+	}
+	if this.InternalCloseTimestamp != nil {
+		return this.InternalCloseTimestamp
+	}
+	if this.InternalReportChecksum != nil {
+		return this.InternalReportChecksum
+	}
 	return nil
 }
 
@@ -5648,6 +8763,12 @@ func (this *ReadWriteCmdResponse) SetValue(value interface{}) bool {
 		this.DeleteRange = vt
 	case *EndTransactionResponse:
 		this.EndTransaction = vt
+	case *ReapQueueResponse:
+		this.ReapQueue = vt
+	case *EnqueueUpdateResponse:
+		this.EnqueueUpdate = vt
+	case *EnqueueMessageResponse:
+		this.EnqueueMessage = vt
 	case *InternalHeartbeatTxnResponse:
 		this.InternalHeartbeatTxn = vt
 	case *InternalPushTxnResponse:
@@ -5664,6 +8785,18 @@ func (this *ReadWriteCmdResponse) SetValue(value interface{}) bool {
 		this.InternalGc = vt
 	case *InternalLeaderLeaseResponse:
 		this.InternalLeaderLease = vt
+	case *ImportResponse:
+		this.Import = vt
+	case *InternalComputeChecksumResponse:
+		this.InternalComputeChecksum = vt
+	case *InternalVerifyChecksumResponse:
+		this.InternalVerifyChecksum = vt
+	case *InternalTransferLeaderLeaseResponse:
+		this.InternalTransferLeaderLease = vt
+	case *InternalCloseTimestampResponse:
+		this.InternalCloseTimestamp = vt
+	case *InternalReportChecksumResponse:
+		this.InternalReportChecksum = vt
 	default:
 		return false
 	}
@@ -5694,6 +8827,24 @@ func (this *InternalRaftCommandUnion) GetValue() interface{} {
 	if this.EndTransaction != nil {
 		return this.EndTransaction
 	}
+	if this.ReapQueue != nil {
+		return this.ReapQueue
+	}
+	if this.EnqueueUpdate != nil {
+		return this.EnqueueUpdate
+	}
+	if this.EnqueueMessage != nil {
+		return this.EnqueueMessage
+	}
+	if this.Changes != nil {
+		return this.Changes
+	}
+	if this.Import != nil {
+		return this.Import
+	}
+	if this.SpanStats != nil {
+		return this.SpanStats
+	}
 	if this.Batch != nil {
 		return this.Batch
 	}
@@ -5721,102 +8872,309 @@ func (this *InternalRaftCommandUnion) GetValue() interface{} {
 	if this.InternalGC != nil {
 		return this.InternalGC
 	}
-	if this.InternalLease != nil {
-		return this.InternalLease
+	if this.InternalLease != nil {
+		return this.InternalLease
+	}
+	if this.InternalBatch != nil {
+		return this.InternalBatch
+	}
+	if this.InternalComputeChecksum != nil {
+		return this.InternalComputeChecksum
+	}
+	if this.InternalVerifyChecksum != nil {
+		return this.InternalVerifyChecksum
+	}
+	if this.InternalTransferLeaderLease != nil {
+		return this.InternalTransferLeaderLease
+	}
+	if this.InternalCloseTimestamp != nil {
+		return this.InternalCloseTimestamp
+	}
+	if this.InternalReportChecksum != nil {
+		return this.InternalReportChecksum
+	}
+	return nil
+}
+
+func (this *InternalRaftCommandUnion) SetValue(value interface{}) bool {
+	switch vt := value.(type) {
+	case *GetRequest:
+		this.Get = vt
+	case *PutRequest:
+		this.Put = vt
+	case *ConditionalPutRequest:
+		this.ConditionalPut = vt
+	case *IncrementRequest:
+		this.Increment = vt
+	case *DeleteRequest:
+		this.Delete = vt
+	case *DeleteRangeRequest:
+		this.DeleteRange = vt
+	case *ScanRequest:
+		this.Scan = vt
+	case *EndTransactionRequest:
+		this.EndTransaction = vt
+	case *ReapQueueRequest:
+		this.ReapQueue = vt
+	case *EnqueueUpdateRequest:
+		this.EnqueueUpdate = vt
+	case *EnqueueMessageRequest:
+		this.EnqueueMessage = vt
+	case *ChangesRequest:
+		this.Changes = vt
+	case *ImportRequest:
+		this.Import = vt
+	case *SpanStatsRequest:
+		this.SpanStats = vt
+	case *BatchRequest:
+		this.Batch = vt
+	case *InternalRangeLookupRequest:
+		this.InternalRangeLookup = vt
+	case *InternalHeartbeatTxnRequest:
+		this.InternalHeartbeatTxn = vt
+	case *InternalPushTxnRequest:
+		this.InternalPushTxn = vt
+	case *InternalResolveIntentRequest:
+		this.InternalResolveIntent = vt
+	case *InternalResolveIntentRangeRequest:
+		this.InternalResolveIntentRange = vt
+	case *InternalMergeRequest:
+		this.InternalMergeResponse = vt
+	case *InternalTruncateLogRequest:
+		this.InternalTruncateLog = vt
+	case *InternalGCRequest:
+		this.InternalGC = vt
+	case *InternalLeaderLeaseRequest:
+		this.InternalLease = vt
+	case *InternalBatchRequest:
+		this.InternalBatch = vt
+	case *InternalComputeChecksumRequest:
+		this.InternalComputeChecksum = vt
+	case *InternalVerifyChecksumRequest:
+		this.InternalVerifyChecksum = vt
+	case *InternalTransferLeaderLeaseRequest:
+		this.InternalTransferLeaderLease = vt
+	case *InternalCloseTimestampRequest:
+		this.InternalCloseTimestamp = vt
+	case *InternalReportChecksumRequest:
+		this.InternalReportChecksum = vt
+	default:
+		return false
+	}
+	return true
+}
+func (m *InternalRangeLookupRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	n += 1 + sovInternal(uint64(m.MaxRanges))
+	n += 2
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InternalRangeLookupResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if len(m.Ranges) > 0 {
+		for _, e := range m.Ranges {
+			l = e.Size()
+			n += 1 + l + sovInternal(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InternalHeartbeatTxnRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InternalHeartbeatTxnResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InternalGCRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	l = m.GCMeta.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if len(m.Keys) > 0 {
+		for _, e := range m.Keys {
+			l = e.Size()
+			n += 1 + l + sovInternal(uint64(l))
+		}
+	}
+	l = m.ChangeLogThreshold.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InternalGCRequest_GCKey) Size() (n int) {
+	var l int
+	_ = l
+	if m.Key != nil {
+		l = len(m.Key)
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = m.Timestamp.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InternalGCResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InternalPushTxnRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	l = m.PusheeTxn.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	l = m.Now.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	n += 1 + sovInternal(uint64(m.PushType))
+	n += 2
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InternalPushTxnResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if m.PusheeTxn != nil {
+		l = m.PusheeTxn.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InternalResolveIntentRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InternalResolveIntentResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if this.InternalBatch != nil {
-		return this.InternalBatch
+	return n
+}
+
+func (m *InternalResolveIntentRangeRequest) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	return nil
+	return n
 }
 
-func (this *InternalRaftCommandUnion) SetValue(value interface{}) bool {
-	switch vt := value.(type) {
-	case *GetRequest:
-		this.Get = vt
-	case *PutRequest:
-		this.Put = vt
-	case *ConditionalPutRequest:
-		this.ConditionalPut = vt
-	case *IncrementRequest:
-		this.Increment = vt
-	case *DeleteRequest:
-		this.Delete = vt
-	case *DeleteRangeRequest:
-		this.DeleteRange = vt
-	case *ScanRequest:
-		this.Scan = vt
-	case *EndTransactionRequest:
-		this.EndTransaction = vt
-	case *BatchRequest:
-		this.Batch = vt
-	case *InternalRangeLookupRequest:
-		this.InternalRangeLookup = vt
-	case *InternalHeartbeatTxnRequest:
-		this.InternalHeartbeatTxn = vt
-	case *InternalPushTxnRequest:
-		this.InternalPushTxn = vt
-	case *InternalResolveIntentRequest:
-		this.InternalResolveIntent = vt
-	case *InternalResolveIntentRangeRequest:
-		this.InternalResolveIntentRange = vt
-	case *InternalMergeRequest:
-		this.InternalMergeResponse = vt
-	case *InternalTruncateLogRequest:
-		this.InternalTruncateLog = vt
-	case *InternalGCRequest:
-		this.InternalGC = vt
-	case *InternalLeaderLeaseRequest:
-		this.InternalLease = vt
-	case *InternalBatchRequest:
-		this.InternalBatch = vt
-	default:
-		return false
+func (m *InternalResolveIntentRangeResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	return true
+	return n
 }
-func (m *InternalRangeLookupRequest) Size() (n int) {
+
+func (m *InternalMergeRequest) Size() (n int) {
 	var l int
 	_ = l
 	l = m.RequestHeader.Size()
 	n += 1 + l + sovInternal(uint64(l))
-	n += 1 + sovInternal(uint64(m.MaxRanges))
-	n += 2
+	l = m.Value.Size()
+	n += 1 + l + sovInternal(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalRangeLookupResponse) Size() (n int) {
+func (m *InternalMergeResponse) Size() (n int) {
 	var l int
 	_ = l
 	l = m.ResponseHeader.Size()
 	n += 1 + l + sovInternal(uint64(l))
-	if len(m.Ranges) > 0 {
-		for _, e := range m.Ranges {
-			l = e.Size()
-			n += 1 + l + sovInternal(uint64(l))
-		}
-	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalHeartbeatTxnRequest) Size() (n int) {
+func (m *InternalTruncateLogRequest) Size() (n int) {
 	var l int
 	_ = l
 	l = m.RequestHeader.Size()
 	n += 1 + l + sovInternal(uint64(l))
+	n += 1 + sovInternal(uint64(m.Index))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalHeartbeatTxnResponse) Size() (n int) {
+func (m *InternalTruncateLogResponse) Size() (n int) {
 	var l int
 	_ = l
 	l = m.ResponseHeader.Size()
@@ -5827,41 +9185,48 @@ func (m *InternalHeartbeatTxnResponse) Size() (n int) {
 	return n
 }
 
-func (m *InternalGCRequest) Size() (n int) {
+func (m *InternalLeaderLeaseRequest) Size() (n int) {
 	var l int
 	_ = l
 	l = m.RequestHeader.Size()
 	n += 1 + l + sovInternal(uint64(l))
-	l = m.GCMeta.Size()
+	l = m.Lease.Size()
 	n += 1 + l + sovInternal(uint64(l))
-	if len(m.Keys) > 0 {
-		for _, e := range m.Keys {
-			l = e.Size()
-			n += 1 + l + sovInternal(uint64(l))
-		}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
+	return n
+}
+
+func (m *InternalLeaderLeaseResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
+	n += 1 + l + sovInternal(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalGCRequest_GCKey) Size() (n int) {
+func (m *InternalTransferLeaderLeaseRequest) Size() (n int) {
 	var l int
 	_ = l
-	if m.Key != nil {
-		l = len(m.Key)
+	l = m.RequestHeader.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	l = m.Lease.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if m.TimestampCache != nil {
+		l = m.TimestampCache.Size()
 		n += 1 + l + sovInternal(uint64(l))
 	}
-	l = m.Timestamp.Size()
-	n += 1 + l + sovInternal(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalGCResponse) Size() (n int) {
+func (m *InternalTransferLeaderLeaseResponse) Size() (n int) {
 	var l int
 	_ = l
 	l = m.ResponseHeader.Size()
@@ -5872,50 +9237,93 @@ func (m *InternalGCResponse) Size() (n int) {
 	return n
 }
 
-func (m *InternalPushTxnRequest) Size() (n int) {
+func (m *InternalCloseTimestampRequest) Size() (n int) {
 	var l int
 	_ = l
 	l = m.RequestHeader.Size()
 	n += 1 + l + sovInternal(uint64(l))
-	l = m.PusheeTxn.Size()
-	n += 1 + l + sovInternal(uint64(l))
-	l = m.Now.Size()
+	l = m.Closed.Size()
 	n += 1 + l + sovInternal(uint64(l))
-	n += 1 + sovInternal(uint64(m.PushType))
-	n += 2
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalPushTxnResponse) Size() (n int) {
+func (m *InternalCloseTimestampResponse) Size() (n int) {
 	var l int
 	_ = l
 	l = m.ResponseHeader.Size()
 	n += 1 + l + sovInternal(uint64(l))
-	if m.PusheeTxn != nil {
-		l = m.PusheeTxn.Size()
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TimestampCacheSummary) Size() (n int) {
+	var l int
+	_ = l
+	l = m.LowWater.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovInternal(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TimestampCacheEntry) Size() (n int) {
+	var l int
+	_ = l
+	if m.StartKey != nil {
+		l = len(m.StartKey)
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.EndKey != nil {
+		l = len(m.EndKey)
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	l = m.Timestamp.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if m.TxnID != nil {
+		l = len(m.TxnID)
 		n += 1 + l + sovInternal(uint64(l))
 	}
+	n += 2
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalResolveIntentRequest) Size() (n int) {
+func (m *InternalComputeChecksumRequest) Size() (n int) {
 	var l int
 	_ = l
 	l = m.RequestHeader.Size()
 	n += 1 + l + sovInternal(uint64(l))
+	if m.ChecksumID != nil {
+		l = len(m.ChecksumID)
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if len(m.Boundaries) > 0 {
+		for _, b := range m.Boundaries {
+			l = len(b)
+			n += 1 + l + sovInternal(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalResolveIntentResponse) Size() (n int) {
+func (m *InternalComputeChecksumResponse) Size() (n int) {
 	var l int
 	_ = l
 	l = m.ResponseHeader.Size()
@@ -5926,18 +9334,34 @@ func (m *InternalResolveIntentResponse) Size() (n int) {
 	return n
 }
 
-func (m *InternalResolveIntentRangeRequest) Size() (n int) {
+func (m *InternalVerifyChecksumRequest) Size() (n int) {
 	var l int
 	_ = l
 	l = m.RequestHeader.Size()
 	n += 1 + l + sovInternal(uint64(l))
+	if m.ChecksumID != nil {
+		l = len(m.ChecksumID)
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if len(m.Checksums) > 0 {
+		for _, b := range m.Checksums {
+			l = len(b)
+			n += 1 + l + sovInternal(uint64(l))
+		}
+	}
+	if len(m.Minority) > 0 {
+		for _, e := range m.Minority {
+			l = e.Size()
+			n += 1 + l + sovInternal(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalResolveIntentRangeResponse) Size() (n int) {
+func (m *InternalVerifyChecksumResponse) Size() (n int) {
 	var l int
 	_ = l
 	l = m.ResponseHeader.Size()
@@ -5948,84 +9372,231 @@ func (m *InternalResolveIntentRangeResponse) Size() (n int) {
 	return n
 }
 
-func (m *InternalMergeRequest) Size() (n int) {
+func (m *InternalReportChecksumRequest) Size() (n int) {
 	var l int
 	_ = l
 	l = m.RequestHeader.Size()
 	n += 1 + l + sovInternal(uint64(l))
-	l = m.Value.Size()
+	if m.ChecksumID != nil {
+		l = len(m.ChecksumID)
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if len(m.Checksums) > 0 {
+		for _, b := range m.Checksums {
+			l = len(b)
+			n += 1 + l + sovInternal(uint64(l))
+		}
+	}
+	l = m.Replica.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InternalReportChecksumResponse) Size() (n int) {
+	var l int
+	_ = l
+	l = m.ResponseHeader.Size()
 	n += 1 + l + sovInternal(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
-	return n
-}
-
-func (m *InternalMergeResponse) Size() (n int) {
-	var l int
-	_ = l
-	l = m.ResponseHeader.Size()
-	n += 1 + l + sovInternal(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	return n
+}
+
+func (m *InternalRequestUnion) Size() (n int) {
+	var l int
+	_ = l
+	if m.Get != nil {
+		l = m.Get.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Put != nil {
+		l = m.Put.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.ConditionalPut != nil {
+		l = m.ConditionalPut.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Increment != nil {
+		l = m.Increment.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Delete != nil {
+		l = m.Delete.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.DeleteRange != nil {
+		l = m.DeleteRange.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Scan != nil {
+		l = m.Scan.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.EndTransaction != nil {
+		l = m.EndTransaction.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.ReapQueue != nil {
+		l = m.ReapQueue.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.EnqueueUpdate != nil {
+		l = m.EnqueueUpdate.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.EnqueueMessage != nil {
+		l = m.EnqueueMessage.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Changes != nil {
+		l = m.Changes.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Import != nil {
+		l = m.Import.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.SpanStats != nil {
+		l = m.SpanStats.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.InternalPushTxn != nil {
+		l = m.InternalPushTxn.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalResolveIntent != nil {
+		l = m.InternalResolveIntent.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalResolveIntentRange != nil {
+		l = m.InternalResolveIntentRange.Size()
+		n += 2 + l + sovInternal(uint64(l))
 	}
-	return n
-}
-
-func (m *InternalTruncateLogRequest) Size() (n int) {
-	var l int
-	_ = l
-	l = m.RequestHeader.Size()
-	n += 1 + l + sovInternal(uint64(l))
-	n += 1 + sovInternal(uint64(m.Index))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalTruncateLogResponse) Size() (n int) {
+func (m *InternalResponseUnion) Size() (n int) {
 	var l int
 	_ = l
-	l = m.ResponseHeader.Size()
-	n += 1 + l + sovInternal(uint64(l))
+	if m.Get != nil {
+		l = m.Get.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Put != nil {
+		l = m.Put.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.ConditionalPut != nil {
+		l = m.ConditionalPut.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Increment != nil {
+		l = m.Increment.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Delete != nil {
+		l = m.Delete.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.DeleteRange != nil {
+		l = m.DeleteRange.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Scan != nil {
+		l = m.Scan.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.EndTransaction != nil {
+		l = m.EndTransaction.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.ReapQueue != nil {
+		l = m.ReapQueue.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.EnqueueUpdate != nil {
+		l = m.EnqueueUpdate.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.EnqueueMessage != nil {
+		l = m.EnqueueMessage.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Changes != nil {
+		l = m.Changes.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Import != nil {
+		l = m.Import.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.SpanStats != nil {
+		l = m.SpanStats.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.InternalPushTxn != nil {
+		l = m.InternalPushTxn.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalResolveIntent != nil {
+		l = m.InternalResolveIntent.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalResolveIntentRange != nil {
+		l = m.InternalResolveIntentRange.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalLeaderLeaseRequest) Size() (n int) {
+func (m *InternalBatchRequest) Size() (n int) {
 	var l int
 	_ = l
 	l = m.RequestHeader.Size()
 	n += 1 + l + sovInternal(uint64(l))
-	l = m.Lease.Size()
-	n += 1 + l + sovInternal(uint64(l))
+	if len(m.Requests) > 0 {
+		for _, e := range m.Requests {
+			l = e.Size()
+			n += 1 + l + sovInternal(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalLeaderLeaseResponse) Size() (n int) {
+func (m *InternalBatchResponse) Size() (n int) {
 	var l int
 	_ = l
 	l = m.ResponseHeader.Size()
 	n += 1 + l + sovInternal(uint64(l))
+	if len(m.Responses) > 0 {
+		for _, e := range m.Responses {
+			l = e.Size()
+			n += 1 + l + sovInternal(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalRequestUnion) Size() (n int) {
+func (m *ReadWriteCmdResponse) Size() (n int) {
 	var l int
 	_ = l
-	if m.Get != nil {
-		l = m.Get.Size()
-		n += 1 + l + sovInternal(uint64(l))
-	}
 	if m.Put != nil {
 		l = m.Put.Size()
 		n += 1 + l + sovInternal(uint64(l))
@@ -6046,24 +9617,76 @@ func (m *InternalRequestUnion) Size() (n int) {
 		l = m.DeleteRange.Size()
 		n += 1 + l + sovInternal(uint64(l))
 	}
-	if m.Scan != nil {
-		l = m.Scan.Size()
-		n += 1 + l + sovInternal(uint64(l))
-	}
 	if m.EndTransaction != nil {
 		l = m.EndTransaction.Size()
 		n += 1 + l + sovInternal(uint64(l))
 	}
+	if m.ReapQueue != nil {
+		l = m.ReapQueue.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.EnqueueUpdate != nil {
+		l = m.EnqueueUpdate.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.EnqueueMessage != nil {
+		l = m.EnqueueMessage.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.InternalHeartbeatTxn != nil {
+		l = m.InternalHeartbeatTxn.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
 	if m.InternalPushTxn != nil {
 		l = m.InternalPushTxn.Size()
-		n += 2 + l + sovInternal(uint64(l))
+		n += 1 + l + sovInternal(uint64(l))
 	}
 	if m.InternalResolveIntent != nil {
 		l = m.InternalResolveIntent.Size()
-		n += 2 + l + sovInternal(uint64(l))
+		n += 1 + l + sovInternal(uint64(l))
 	}
 	if m.InternalResolveIntentRange != nil {
 		l = m.InternalResolveIntentRange.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.InternalMerge != nil {
+		l = m.InternalMerge.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.InternalTruncateLog != nil {
+		l = m.InternalTruncateLog.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.InternalGc != nil {
+		l = m.InternalGc.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalLeaderLease != nil {
+		l = m.InternalLeaderLease.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.Import != nil {
+		l = m.Import.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalComputeChecksum != nil {
+		l = m.InternalComputeChecksum.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalVerifyChecksum != nil {
+		l = m.InternalVerifyChecksum.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalTransferLeaderLease != nil {
+		l = m.InternalTransferLeaderLease.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalCloseTimestamp != nil {
+		l = m.InternalCloseTimestamp.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalReportChecksum != nil {
+		l = m.InternalReportChecksum.Size()
 		n += 2 + l + sovInternal(uint64(l))
 	}
 	if m.XXX_unrecognized != nil {
@@ -6072,7 +9695,7 @@ func (m *InternalRequestUnion) Size() (n int) {
 	return n
 }
 
-func (m *InternalResponseUnion) Size() (n int) {
+func (m *InternalRaftCommandUnion) Size() (n int) {
 	var l int
 	_ = l
 	if m.Get != nil {
@@ -6107,6 +9730,42 @@ func (m *InternalResponseUnion) Size() (n int) {
 		l = m.EndTransaction.Size()
 		n += 1 + l + sovInternal(uint64(l))
 	}
+	if m.ReapQueue != nil {
+		l = m.ReapQueue.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.EnqueueUpdate != nil {
+		l = m.EnqueueUpdate.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.EnqueueMessage != nil {
+		l = m.EnqueueMessage.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Changes != nil {
+		l = m.Changes.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Import != nil {
+		l = m.Import.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.SpanStats != nil {
+		l = m.SpanStats.Size()
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Batch != nil {
+		l = m.Batch.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalRangeLookup != nil {
+		l = m.InternalRangeLookup.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalHeartbeatTxn != nil {
+		l = m.InternalHeartbeatTxn.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
 	if m.InternalPushTxn != nil {
 		l = m.InternalPushTxn.Size()
 		n += 2 + l + sovInternal(uint64(l))
@@ -6115,40 +9774,129 @@ func (m *InternalResponseUnion) Size() (n int) {
 		l = m.InternalResolveIntent.Size()
 		n += 2 + l + sovInternal(uint64(l))
 	}
-	if m.InternalResolveIntentRange != nil {
-		l = m.InternalResolveIntentRange.Size()
-		n += 2 + l + sovInternal(uint64(l))
+	if m.InternalResolveIntentRange != nil {
+		l = m.InternalResolveIntentRange.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalMergeResponse != nil {
+		l = m.InternalMergeResponse.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalTruncateLog != nil {
+		l = m.InternalTruncateLog.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalGC != nil {
+		l = m.InternalGC.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalLease != nil {
+		l = m.InternalLease.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalBatch != nil {
+		l = m.InternalBatch.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalComputeChecksum != nil {
+		l = m.InternalComputeChecksum.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalVerifyChecksum != nil {
+		l = m.InternalVerifyChecksum.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalTransferLeaderLease != nil {
+		l = m.InternalTransferLeaderLease.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalCloseTimestamp != nil {
+		l = m.InternalCloseTimestamp.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.InternalReportChecksum != nil {
+		l = m.InternalReportChecksum.Size()
+		n += 2 + l + sovInternal(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *InternalRaftCommand) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovInternal(uint64(m.RaftID))
+	n += 1 + sovInternal(uint64(m.OriginNodeID))
+	l = m.Cmd.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RaftMessageRequest) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovInternal(uint64(m.GroupID))
+	if m.Msg != nil {
+		l = len(m.Msg)
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RaftMessageResponse) Size() (n int) {
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *RaftSnapshotChunkRequest) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovInternal(uint64(m.GroupID))
+	n += 1 + sovInternal(uint64(m.SnapshotID))
+	n += 1 + sovInternal(uint64(m.Seq))
+	if m.Msg != nil {
+		l = len(m.Msg)
+		n += 1 + l + sovInternal(uint64(l))
+	}
+	if m.Data != nil {
+		l = len(m.Data)
+		n += 1 + l + sovInternal(uint64(l))
 	}
+	n += 2
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalBatchRequest) Size() (n int) {
+func (m *RaftSnapshotChunkResponse) Size() (n int) {
 	var l int
 	_ = l
-	l = m.RequestHeader.Size()
-	n += 1 + l + sovInternal(uint64(l))
-	if len(m.Requests) > 0 {
-		for _, e := range m.Requests {
-			l = e.Size()
-			n += 1 + l + sovInternal(uint64(l))
-		}
-	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
 	return n
 }
 
-func (m *InternalBatchResponse) Size() (n int) {
+func (m *InternalTimeSeriesData) Size() (n int) {
 	var l int
 	_ = l
-	l = m.ResponseHeader.Size()
-	n += 1 + l + sovInternal(uint64(l))
-	if len(m.Responses) > 0 {
-		for _, e := range m.Responses {
+	n += 1 + sovInternal(uint64(m.StartTimestampNanos))
+	n += 1 + sovInternal(uint64(m.SampleDurationNanos))
+	if len(m.Samples) > 0 {
+		for _, e := range m.Samples {
 			l = e.Size()
 			n += 1 + l + sovInternal(uint64(l))
 		}
@@ -6159,64 +9907,17 @@ func (m *InternalBatchResponse) Size() (n int) {
 	return n
 }
 
-func (m *ReadWriteCmdResponse) Size() (n int) {
+func (m *InternalTimeSeriesSample) Size() (n int) {
 	var l int
 	_ = l
-	if m.Put != nil {
-		l = m.Put.Size()
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if m.ConditionalPut != nil {
-		l = m.ConditionalPut.Size()
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if m.Increment != nil {
-		l = m.Increment.Size()
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if m.Delete != nil {
-		l = m.Delete.Size()
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if m.DeleteRange != nil {
-		l = m.DeleteRange.Size()
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if m.EndTransaction != nil {
-		l = m.EndTransaction.Size()
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if m.InternalHeartbeatTxn != nil {
-		l = m.InternalHeartbeatTxn.Size()
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if m.InternalPushTxn != nil {
-		l = m.InternalPushTxn.Size()
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if m.InternalResolveIntent != nil {
-		l = m.InternalResolveIntent.Size()
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if m.InternalResolveIntentRange != nil {
-		l = m.InternalResolveIntentRange.Size()
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if m.InternalMerge != nil {
-		l = m.InternalMerge.Size()
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if m.InternalTruncateLog != nil {
-		l = m.InternalTruncateLog.Size()
-		n += 1 + l + sovInternal(uint64(l))
-	}
-	if m.InternalGc != nil {
-		l = m.InternalGc.Size()
-		n += 2 + l + sovInternal(uint64(l))
+	n += 1 + sovInternal(uint64(m.Offset))
+	n += 1 + sovInternal(uint64(m.Count))
+	n += 9
+	if m.Max != nil {
+		n += 9
 	}
-	if m.InternalLeaderLease != nil {
-		l = m.InternalLeaderLease.Size()
-		n += 2 + l + sovInternal(uint64(l))
+	if m.Min != nil {
+		n += 9
 	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
@@ -6224,221 +9925,439 @@ func (m *ReadWriteCmdResponse) Size() (n int) {
 	return n
 }
 
-func (m *InternalRaftCommandUnion) Size() (n int) {
+func (m *RaftTruncatedState) Size() (n int) {
 	var l int
 	_ = l
-	if m.Get != nil {
-		l = m.Get.Size()
-		n += 1 + l + sovInternal(uint64(l))
+	n += 1 + sovInternal(uint64(m.Index))
+	n += 1 + sovInternal(uint64(m.Term))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if m.Put != nil {
-		l = m.Put.Size()
+	return n
+}
+
+func (m *ChangeLogEntry) Size() (n int) {
+	var l int
+	_ = l
+	if m.Key != nil {
+		l = len(m.Key)
 		n += 1 + l + sovInternal(uint64(l))
 	}
-	if m.ConditionalPut != nil {
-		l = m.ConditionalPut.Size()
+	if m.EndKey != nil {
+		l = len(m.EndKey)
 		n += 1 + l + sovInternal(uint64(l))
 	}
-	if m.Increment != nil {
-		l = m.Increment.Size()
-		n += 1 + l + sovInternal(uint64(l))
+	n += 2
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if m.Delete != nil {
-		l = m.Delete.Size()
-		n += 1 + l + sovInternal(uint64(l))
+	return n
+}
+
+func (m *RaftSnapshotData) Size() (n int) {
+	var l int
+	_ = l
+	l = m.RangeDescriptor.Size()
+	n += 1 + l + sovInternal(uint64(l))
+	if len(m.KV) > 0 {
+		for _, e := range m.KV {
+			l = e.Size()
+			n += 1 + l + sovInternal(uint64(l))
+		}
 	}
-	if m.DeleteRange != nil {
-		l = m.DeleteRange.Size()
-		n += 1 + l + sovInternal(uint64(l))
+	n += 1 + sovInternal(uint64(m.StreamID))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if m.Scan != nil {
-		l = m.Scan.Size()
+	return n
+}
+
+func (m *RaftSnapshotData_KeyValue) Size() (n int) {
+	var l int
+	_ = l
+	if m.Key != nil {
+		l = len(m.Key)
 		n += 1 + l + sovInternal(uint64(l))
 	}
-	if m.EndTransaction != nil {
-		l = m.EndTransaction.Size()
+	if m.Value != nil {
+		l = len(m.Value)
 		n += 1 + l + sovInternal(uint64(l))
 	}
-	if m.Batch != nil {
-		l = m.Batch.Size()
-		n += 2 + l + sovInternal(uint64(l))
-	}
-	if m.InternalRangeLookup != nil {
-		l = m.InternalRangeLookup.Size()
-		n += 2 + l + sovInternal(uint64(l))
-	}
-	if m.InternalHeartbeatTxn != nil {
-		l = m.InternalHeartbeatTxn.Size()
-		n += 2 + l + sovInternal(uint64(l))
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
 	}
-	if m.InternalPushTxn != nil {
-		l = m.InternalPushTxn.Size()
-		n += 2 + l + sovInternal(uint64(l))
+	return n
+}
+
+func sovInternal(x uint64) (n int) {
+	for {
+		n++
+		x >>= 7
+		if x == 0 {
+			break
+		}
 	}
-	if m.InternalResolveIntent != nil {
-		l = m.InternalResolveIntent.Size()
-		n += 2 + l + sovInternal(uint64(l))
+	return n
+}
+func sozInternal(x uint64) (n int) {
+	return sovInternal(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *InternalRangeLookupRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	if m.InternalResolveIntentRange != nil {
-		l = m.InternalResolveIntentRange.Size()
-		n += 2 + l + sovInternal(uint64(l))
+	return data[:n], nil
+}
+
+func (m *InternalRangeLookupRequest) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
+	n1, err := m.RequestHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
 	}
-	if m.InternalMergeResponse != nil {
-		l = m.InternalMergeResponse.Size()
-		n += 2 + l + sovInternal(uint64(l))
+	i += n1
+	data[i] = 0x10
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.MaxRanges))
+	data[i] = 0x18
+	i++
+	if m.IgnoreIntents {
+		data[i] = 1
+	} else {
+		data[i] = 0
 	}
-	if m.InternalTruncateLog != nil {
-		l = m.InternalTruncateLog.Size()
-		n += 2 + l + sovInternal(uint64(l))
+	i++
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	if m.InternalGC != nil {
-		l = m.InternalGC.Size()
-		n += 2 + l + sovInternal(uint64(l))
+	return i, nil
+}
+
+func (m *InternalRangeLookupResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	if m.InternalLease != nil {
-		l = m.InternalLease.Size()
-		n += 2 + l + sovInternal(uint64(l))
+	return data[:n], nil
+}
+
+func (m *InternalRangeLookupResponse) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
+	n2, err := m.ResponseHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
 	}
-	if m.InternalBatch != nil {
-		l = m.InternalBatch.Size()
-		n += 2 + l + sovInternal(uint64(l))
+	i += n2
+	if len(m.Ranges) > 0 {
+		for _, msg := range m.Ranges {
+			data[i] = 0x12
+			i++
+			i = encodeVarintInternal(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *InternalRaftCommand) Size() (n int) {
-	var l int
-	_ = l
-	n += 1 + sovInternal(uint64(m.RaftID))
-	n += 1 + sovInternal(uint64(m.OriginNodeID))
-	l = m.Cmd.Size()
-	n += 1 + l + sovInternal(uint64(l))
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+func (m *InternalHeartbeatTxnRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return data[:n], nil
 }
 
-func (m *RaftMessageRequest) Size() (n int) {
+func (m *InternalHeartbeatTxnRequest) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	n += 1 + sovInternal(uint64(m.GroupID))
-	if m.Msg != nil {
-		l = len(m.Msg)
-		n += 1 + l + sovInternal(uint64(l))
+	data[i] = 0xa
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
+	n3, err := m.RequestHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n3
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *RaftMessageResponse) Size() (n int) {
+func (m *InternalHeartbeatTxnResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *InternalHeartbeatTxnResponse) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
+	n4, err := m.ResponseHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n4
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *InternalTimeSeriesData) Size() (n int) {
+func (m *InternalGCRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *InternalGCRequest) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	n += 1 + sovInternal(uint64(m.StartTimestampNanos))
-	n += 1 + sovInternal(uint64(m.SampleDurationNanos))
-	if len(m.Samples) > 0 {
-		for _, e := range m.Samples {
-			l = e.Size()
-			n += 1 + l + sovInternal(uint64(l))
+	data[i] = 0xa
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
+	n5, err := m.RequestHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n5
+	data[i] = 0x12
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.GCMeta.Size()))
+	n6, err := m.GCMeta.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n6
+	if len(m.Keys) > 0 {
+		for _, msg := range m.Keys {
+			data[i] = 0x1a
+			i++
+			i = encodeVarintInternal(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
 		}
 	}
+	data[i] = 0x22
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.ChangeLogThreshold.Size()))
+	n7, err := m.ChangeLogThreshold.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n7
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *InternalTimeSeriesSample) Size() (n int) {
+func (m *InternalGCRequest_GCKey) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *InternalGCRequest_GCKey) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	n += 1 + sovInternal(uint64(m.Offset))
-	n += 1 + sovInternal(uint64(m.Count))
-	n += 9
-	if m.Max != nil {
-		n += 9
+	if m.Key != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintInternal(data, i, uint64(len(m.Key)))
+		i += copy(data[i:], m.Key)
 	}
-	if m.Min != nil {
-		n += 9
+	data[i] = 0x12
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.Timestamp.Size()))
+	n8, err := m.Timestamp.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n8
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *RaftTruncatedState) Size() (n int) {
+func (m *InternalGCResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *InternalGCResponse) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	n += 1 + sovInternal(uint64(m.Index))
-	n += 1 + sovInternal(uint64(m.Term))
+	data[i] = 0xa
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
+	n9, err := m.ResponseHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n9
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func (m *RaftSnapshotData) Size() (n int) {
+func (m *InternalPushTxnRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *InternalPushTxnRequest) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	l = m.RangeDescriptor.Size()
-	n += 1 + l + sovInternal(uint64(l))
-	if len(m.KV) > 0 {
-		for _, e := range m.KV {
-			l = e.Size()
-			n += 1 + l + sovInternal(uint64(l))
-		}
+	data[i] = 0xa
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
+	n10, err := m.RequestHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n10
+	data[i] = 0x12
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.PusheeTxn.Size()))
+	n11, err := m.PusheeTxn.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n11
+	data[i] = 0x1a
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.Now.Size()))
+	n12, err := m.Now.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n12
+	data[i] = 0x20
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.PushType))
+	data[i] = 0x28
+	i++
+	if m.RangeLookup {
+		data[i] = 1
+	} else {
+		data[i] = 0
 	}
+	i++
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *InternalPushTxnResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return data[:n], nil
 }
 
-func (m *RaftSnapshotData_KeyValue) Size() (n int) {
+func (m *InternalPushTxnResponse) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
 	var l int
 	_ = l
-	if m.Key != nil {
-		l = len(m.Key)
-		n += 1 + l + sovInternal(uint64(l))
+	data[i] = 0xa
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
+	n13, err := m.ResponseHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
 	}
-	if m.Value != nil {
-		l = len(m.Value)
-		n += 1 + l + sovInternal(uint64(l))
+	i += n13
+	if m.PusheeTxn != nil {
+		data[i] = 0x12
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.PusheeTxn.Size()))
+		n14, err := m.PusheeTxn.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n14
 	}
 	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+		i += copy(data[i:], m.XXX_unrecognized)
 	}
-	return n
+	return i, nil
 }
 
-func sovInternal(x uint64) (n int) {
-	for {
-		n++
-		x >>= 7
-		if x == 0 {
-			break
-		}
-	}
-	return n
-}
-func sozInternal(x uint64) (n int) {
-	return sovInternal(uint64((x << 1) ^ uint64((int64(x) >> 63))))
-}
-func (m *InternalRangeLookupRequest) Marshal() (data []byte, err error) {
+func (m *InternalResolveIntentRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6448,7 +10367,7 @@ func (m *InternalRangeLookupRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalRangeLookupRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalResolveIntentRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -6456,29 +10375,18 @@ func (m *InternalRangeLookupRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
-	n1, err := m.RequestHeader.MarshalTo(data[i:])
+	n15, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n1
-	data[i] = 0x10
-	i++
-	i = encodeVarintInternal(data, i, uint64(m.MaxRanges))
-	data[i] = 0x18
-	i++
-	if m.IgnoreIntents {
-		data[i] = 1
-	} else {
-		data[i] = 0
-	}
-	i++
+	i += n15
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalRangeLookupResponse) Marshal() (data []byte, err error) {
+func (m *InternalResolveIntentResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6488,7 +10396,7 @@ func (m *InternalRangeLookupResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalRangeLookupResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalResolveIntentResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -6496,30 +10404,18 @@ func (m *InternalRangeLookupResponse) MarshalTo(data []byte) (n int, err error)
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
-	n2, err := m.ResponseHeader.MarshalTo(data[i:])
+	n16, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n2
-	if len(m.Ranges) > 0 {
-		for _, msg := range m.Ranges {
-			data[i] = 0x12
-			i++
-			i = encodeVarintInternal(data, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(data[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
+	i += n16
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalHeartbeatTxnRequest) Marshal() (data []byte, err error) {
+func (m *InternalResolveIntentRangeRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6529,7 +10425,7 @@ func (m *InternalHeartbeatTxnRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalHeartbeatTxnRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalResolveIntentRangeRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -6537,18 +10433,18 @@ func (m *InternalHeartbeatTxnRequest) MarshalTo(data []byte) (n int, err error)
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
-	n3, err := m.RequestHeader.MarshalTo(data[i:])
+	n17, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n3
+	i += n17
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalHeartbeatTxnResponse) Marshal() (data []byte, err error) {
+func (m *InternalResolveIntentRangeResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6558,7 +10454,7 @@ func (m *InternalHeartbeatTxnResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalHeartbeatTxnResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalResolveIntentRangeResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -6566,18 +10462,18 @@ func (m *InternalHeartbeatTxnResponse) MarshalTo(data []byte) (n int, err error)
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
-	n4, err := m.ResponseHeader.MarshalTo(data[i:])
+	n18, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n4
+	i += n18
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalGCRequest) Marshal() (data []byte, err error) {
+func (m *InternalMergeRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6587,7 +10483,7 @@ func (m *InternalGCRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalGCRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalMergeRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -6595,38 +10491,26 @@ func (m *InternalGCRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
-	n5, err := m.RequestHeader.MarshalTo(data[i:])
+	n19, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n5
+	i += n19
 	data[i] = 0x12
 	i++
-	i = encodeVarintInternal(data, i, uint64(m.GCMeta.Size()))
-	n6, err := m.GCMeta.MarshalTo(data[i:])
+	i = encodeVarintInternal(data, i, uint64(m.Value.Size()))
+	n20, err := m.Value.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n6
-	if len(m.Keys) > 0 {
-		for _, msg := range m.Keys {
-			data[i] = 0x1a
-			i++
-			i = encodeVarintInternal(data, i, uint64(msg.Size()))
-			n, err := msg.MarshalTo(data[i:])
-			if err != nil {
-				return 0, err
-			}
-			i += n
-		}
-	}
+	i += n20
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalGCRequest_GCKey) Marshal() (data []byte, err error) {
+func (m *InternalMergeResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6636,32 +10520,58 @@ func (m *InternalGCRequest_GCKey) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalGCRequest_GCKey) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalMergeResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	if m.Key != nil {
-		data[i] = 0xa
-		i++
-		i = encodeVarintInternal(data, i, uint64(len(m.Key)))
-		i += copy(data[i:], m.Key)
+	data[i] = 0xa
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
+	n21, err := m.ResponseHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
 	}
-	data[i] = 0x12
+	i += n21
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *InternalTruncateLogRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *InternalTruncateLogRequest) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
 	i++
-	i = encodeVarintInternal(data, i, uint64(m.Timestamp.Size()))
-	n7, err := m.Timestamp.MarshalTo(data[i:])
+	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
+	n22, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n7
+	i += n22
+	data[i] = 0x10
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.Index))
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalGCResponse) Marshal() (data []byte, err error) {
+func (m *InternalTruncateLogResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6671,7 +10581,7 @@ func (m *InternalGCResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalGCResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalTruncateLogResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -6679,18 +10589,18 @@ func (m *InternalGCResponse) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
-	n8, err := m.ResponseHeader.MarshalTo(data[i:])
+	n23, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n8
+	i += n23
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalPushTxnRequest) Marshal() (data []byte, err error) {
+func (m *InternalLeaderLeaseRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6700,7 +10610,7 @@ func (m *InternalPushTxnRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalPushTxnRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalLeaderLeaseRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -6708,45 +10618,55 @@ func (m *InternalPushTxnRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
-	n9, err := m.RequestHeader.MarshalTo(data[i:])
+	n24, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n9
+	i += n24
 	data[i] = 0x12
 	i++
-	i = encodeVarintInternal(data, i, uint64(m.PusheeTxn.Size()))
-	n10, err := m.PusheeTxn.MarshalTo(data[i:])
+	i = encodeVarintInternal(data, i, uint64(m.Lease.Size()))
+	n25, err := m.Lease.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n10
-	data[i] = 0x1a
-	i++
-	i = encodeVarintInternal(data, i, uint64(m.Now.Size()))
-	n11, err := m.Now.MarshalTo(data[i:])
+	i += n25
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *InternalLeaderLeaseResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	i += n11
-	data[i] = 0x20
-	i++
-	i = encodeVarintInternal(data, i, uint64(m.PushType))
-	data[i] = 0x28
+	return data[:n], nil
+}
+
+func (m *InternalLeaderLeaseResponse) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
 	i++
-	if m.RangeLookup {
-		data[i] = 1
-	} else {
-		data[i] = 0
+	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
+	n26, err := m.ResponseHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
 	}
-	i++
+	i += n26
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalPushTxnResponse) Marshal() (data []byte, err error) {
+func (m *InternalTransferLeaderLeaseRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6756,36 +10676,73 @@ func (m *InternalPushTxnResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalPushTxnResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalTransferLeaderLeaseRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
 	data[i] = 0xa
 	i++
-	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
-	n12, err := m.ResponseHeader.MarshalTo(data[i:])
+	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
+	n27, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n12
-	if m.PusheeTxn != nil {
-		data[i] = 0x12
+	i += n27
+	data[i] = 0x12
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.Lease.Size()))
+	n28, err := m.Lease.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n28
+	if m.TimestampCache != nil {
+		data[i] = 0x1a
 		i++
-		i = encodeVarintInternal(data, i, uint64(m.PusheeTxn.Size()))
-		n13, err := m.PusheeTxn.MarshalTo(data[i:])
+		i = encodeVarintInternal(data, i, uint64(m.TimestampCache.Size()))
+		n29, err := m.TimestampCache.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n13
+		i += n29
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *InternalTransferLeaderLeaseResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *InternalTransferLeaderLeaseResponse) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0xa
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
+	n30, err := m.ResponseHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
 	}
+	i += n30
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalResolveIntentRequest) Marshal() (data []byte, err error) {
+func (m *InternalCloseTimestampRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6795,7 +10752,7 @@ func (m *InternalResolveIntentRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalResolveIntentRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalCloseTimestampRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -6803,18 +10760,26 @@ func (m *InternalResolveIntentRequest) MarshalTo(data []byte) (n int, err error)
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
-	n14, err := m.RequestHeader.MarshalTo(data[i:])
+	n31, err := m.RequestHeader.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n31
+	data[i] = 0x12
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.Closed.Size()))
+	n32, err := m.Closed.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n14
+	i += n32
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalResolveIntentResponse) Marshal() (data []byte, err error) {
+func (m *InternalCloseTimestampResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6824,7 +10789,7 @@ func (m *InternalResolveIntentResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalResolveIntentResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalCloseTimestampResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -6832,18 +10797,18 @@ func (m *InternalResolveIntentResponse) MarshalTo(data []byte) (n int, err error
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
-	n15, err := m.ResponseHeader.MarshalTo(data[i:])
+	n33, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n15
+	i += n33
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalResolveIntentRangeRequest) Marshal() (data []byte, err error) {
+func (m *TimestampCacheSummary) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6853,26 +10818,38 @@ func (m *InternalResolveIntentRangeRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalResolveIntentRangeRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *TimestampCacheSummary) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
 	data[i] = 0xa
 	i++
-	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
-	n16, err := m.RequestHeader.MarshalTo(data[i:])
+	i = encodeVarintInternal(data, i, uint64(m.LowWater.Size()))
+	n34, err := m.LowWater.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n16
+	i += n34
+	if len(m.Entries) > 0 {
+		for _, msg := range m.Entries {
+			data[i] = 0x12
+			i++
+			i = encodeVarintInternal(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalResolveIntentRangeResponse) Marshal() (data []byte, err error) {
+func (m *TimestampCacheEntry) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6882,26 +10859,52 @@ func (m *InternalResolveIntentRangeResponse) Marshal() (data []byte, err error)
 	return data[:n], nil
 }
 
-func (m *InternalResolveIntentRangeResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *TimestampCacheEntry) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
 	_ = l
-	data[i] = 0xa
+	if m.StartKey != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintInternal(data, i, uint64(len(m.StartKey)))
+		i += copy(data[i:], m.StartKey)
+	}
+	if m.EndKey != nil {
+		data[i] = 0x12
+		i++
+		i = encodeVarintInternal(data, i, uint64(len(m.EndKey)))
+		i += copy(data[i:], m.EndKey)
+	}
+	data[i] = 0x1a
 	i++
-	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
-	n17, err := m.ResponseHeader.MarshalTo(data[i:])
+	i = encodeVarintInternal(data, i, uint64(m.Timestamp.Size()))
+	n35, err := m.Timestamp.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n17
+	i += n35
+	if m.TxnID != nil {
+		data[i] = 0x22
+		i++
+		i = encodeVarintInternal(data, i, uint64(len(m.TxnID)))
+		i += copy(data[i:], m.TxnID)
+	}
+	data[i] = 0x28
+	i++
+	if m.ReadOnly {
+		data[i] = 1
+	} else {
+		data[i] = 0
+	}
+	i++
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalMergeRequest) Marshal() (data []byte, err error) {
+func (m *InternalComputeChecksumRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6911,7 +10914,7 @@ func (m *InternalMergeRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalMergeRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalComputeChecksumRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -6919,26 +10922,32 @@ func (m *InternalMergeRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
-	n18, err := m.RequestHeader.MarshalTo(data[i:])
+	n36, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n18
-	data[i] = 0x12
-	i++
-	i = encodeVarintInternal(data, i, uint64(m.Value.Size()))
-	n19, err := m.Value.MarshalTo(data[i:])
-	if err != nil {
-		return 0, err
+	i += n36
+	if m.ChecksumID != nil {
+		data[i] = 0x12
+		i++
+		i = encodeVarintInternal(data, i, uint64(len(m.ChecksumID)))
+		i += copy(data[i:], m.ChecksumID)
+	}
+	if len(m.Boundaries) > 0 {
+		for _, b := range m.Boundaries {
+			data[i] = 0x1a
+			i++
+			i = encodeVarintInternal(data, i, uint64(len(b)))
+			i += copy(data[i:], b)
+		}
 	}
-	i += n19
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalMergeResponse) Marshal() (data []byte, err error) {
+func (m *InternalComputeChecksumResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6948,7 +10957,7 @@ func (m *InternalMergeResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalMergeResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalComputeChecksumResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -6956,18 +10965,18 @@ func (m *InternalMergeResponse) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
-	n20, err := m.ResponseHeader.MarshalTo(data[i:])
+	n37, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n20
+	i += n37
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalTruncateLogRequest) Marshal() (data []byte, err error) {
+func (m *InternalVerifyChecksumRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -6977,7 +10986,7 @@ func (m *InternalTruncateLogRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalTruncateLogRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalVerifyChecksumRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -6985,21 +10994,44 @@ func (m *InternalTruncateLogRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
-	n21, err := m.RequestHeader.MarshalTo(data[i:])
+	n38, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n21
-	data[i] = 0x10
-	i++
-	i = encodeVarintInternal(data, i, uint64(m.Index))
+	i += n38
+	if m.ChecksumID != nil {
+		data[i] = 0x12
+		i++
+		i = encodeVarintInternal(data, i, uint64(len(m.ChecksumID)))
+		i += copy(data[i:], m.ChecksumID)
+	}
+	if len(m.Checksums) > 0 {
+		for _, b := range m.Checksums {
+			data[i] = 0x1a
+			i++
+			i = encodeVarintInternal(data, i, uint64(len(b)))
+			i += copy(data[i:], b)
+		}
+	}
+	if len(m.Minority) > 0 {
+		for _, msg := range m.Minority {
+			data[i] = 0x22
+			i++
+			i = encodeVarintInternal(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalTruncateLogResponse) Marshal() (data []byte, err error) {
+func (m *InternalVerifyChecksumResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -7009,7 +11041,7 @@ func (m *InternalTruncateLogResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalTruncateLogResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalVerifyChecksumResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -7017,18 +11049,18 @@ func (m *InternalTruncateLogResponse) MarshalTo(data []byte) (n int, err error)
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
-	n22, err := m.ResponseHeader.MarshalTo(data[i:])
+	n39, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n22
+	i += n39
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalLeaderLeaseRequest) Marshal() (data []byte, err error) {
+func (m *InternalReportChecksumRequest) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -7038,7 +11070,7 @@ func (m *InternalLeaderLeaseRequest) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalLeaderLeaseRequest) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalReportChecksumRequest) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -7046,26 +11078,40 @@ func (m *InternalLeaderLeaseRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
-	n23, err := m.RequestHeader.MarshalTo(data[i:])
+	n40, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n23
-	data[i] = 0x12
+	i += n40
+	if m.ChecksumID != nil {
+		data[i] = 0x12
+		i++
+		i = encodeVarintInternal(data, i, uint64(len(m.ChecksumID)))
+		i += copy(data[i:], m.ChecksumID)
+	}
+	if len(m.Checksums) > 0 {
+		for _, b := range m.Checksums {
+			data[i] = 0x1a
+			i++
+			i = encodeVarintInternal(data, i, uint64(len(b)))
+			i += copy(data[i:], b)
+		}
+	}
+	data[i] = 0x22
 	i++
-	i = encodeVarintInternal(data, i, uint64(m.Lease.Size()))
-	n24, err := m.Lease.MarshalTo(data[i:])
+	i = encodeVarintInternal(data, i, uint64(m.Replica.Size()))
+	n41, err := m.Replica.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n24
+	i += n41
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
 	return i, nil
 }
 
-func (m *InternalLeaderLeaseResponse) Marshal() (data []byte, err error) {
+func (m *InternalReportChecksumResponse) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
 	n, err := m.MarshalTo(data)
@@ -7075,7 +11121,7 @@ func (m *InternalLeaderLeaseResponse) Marshal() (data []byte, err error) {
 	return data[:n], nil
 }
 
-func (m *InternalLeaderLeaseResponse) MarshalTo(data []byte) (n int, err error) {
+func (m *InternalReportChecksumResponse) MarshalTo(data []byte) (n int, err error) {
 	var i int
 	_ = i
 	var l int
@@ -7083,11 +11129,11 @@ func (m *InternalLeaderLeaseResponse) MarshalTo(data []byte) (n int, err error)
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
-	n25, err := m.ResponseHeader.MarshalTo(data[i:])
+	n42, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n25
+	i += n42
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
@@ -7113,81 +11159,141 @@ func (m *InternalRequestUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x12
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Get.Size()))
-		n26, err := m.Get.MarshalTo(data[i:])
+		n43, err := m.Get.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n26
+		i += n43
 	}
 	if m.Put != nil {
 		data[i] = 0x1a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Put.Size()))
-		n27, err := m.Put.MarshalTo(data[i:])
+		n44, err := m.Put.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n27
+		i += n44
 	}
 	if m.ConditionalPut != nil {
 		data[i] = 0x22
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.ConditionalPut.Size()))
-		n28, err := m.ConditionalPut.MarshalTo(data[i:])
+		n45, err := m.ConditionalPut.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n28
+		i += n45
 	}
 	if m.Increment != nil {
 		data[i] = 0x2a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Increment.Size()))
-		n29, err := m.Increment.MarshalTo(data[i:])
+		n46, err := m.Increment.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n29
+		i += n46
 	}
 	if m.Delete != nil {
 		data[i] = 0x32
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Delete.Size()))
-		n30, err := m.Delete.MarshalTo(data[i:])
+		n47, err := m.Delete.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n30
+		i += n47
 	}
 	if m.DeleteRange != nil {
 		data[i] = 0x3a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.DeleteRange.Size()))
-		n31, err := m.DeleteRange.MarshalTo(data[i:])
+		n48, err := m.DeleteRange.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n31
+		i += n48
 	}
 	if m.Scan != nil {
 		data[i] = 0x42
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Scan.Size()))
-		n32, err := m.Scan.MarshalTo(data[i:])
+		n49, err := m.Scan.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n32
+		i += n49
 	}
 	if m.EndTransaction != nil {
 		data[i] = 0x4a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.EndTransaction.Size()))
-		n33, err := m.EndTransaction.MarshalTo(data[i:])
+		n50, err := m.EndTransaction.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n50
+	}
+	if m.ReapQueue != nil {
+		data[i] = 0x52
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.ReapQueue.Size()))
+		n51, err := m.ReapQueue.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n51
+	}
+	if m.EnqueueUpdate != nil {
+		data[i] = 0x5a
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.EnqueueUpdate.Size()))
+		n52, err := m.EnqueueUpdate.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n52
+	}
+	if m.EnqueueMessage != nil {
+		data[i] = 0x62
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.EnqueueMessage.Size()))
+		n53, err := m.EnqueueMessage.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n53
+	}
+	if m.Changes != nil {
+		data[i] = 0x6a
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.Changes.Size()))
+		n54, err := m.Changes.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n54
+	}
+	if m.Import != nil {
+		data[i] = 0x72
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.Import.Size()))
+		n55, err := m.Import.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n55
+	}
+	if m.SpanStats != nil {
+		data[i] = 0x7a
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.SpanStats.Size()))
+		n56, err := m.SpanStats.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n33
+		i += n56
 	}
 	if m.InternalPushTxn != nil {
 		data[i] = 0xf2
@@ -7195,11 +11301,11 @@ func (m *InternalRequestUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x1
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalPushTxn.Size()))
-		n34, err := m.InternalPushTxn.MarshalTo(data[i:])
+		n57, err := m.InternalPushTxn.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n34
+		i += n57
 	}
 	if m.InternalResolveIntent != nil {
 		data[i] = 0xfa
@@ -7207,11 +11313,11 @@ func (m *InternalRequestUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x1
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalResolveIntent.Size()))
-		n35, err := m.InternalResolveIntent.MarshalTo(data[i:])
+		n58, err := m.InternalResolveIntent.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n35
+		i += n58
 	}
 	if m.InternalResolveIntentRange != nil {
 		data[i] = 0x82
@@ -7219,11 +11325,11 @@ func (m *InternalRequestUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x2
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalResolveIntentRange.Size()))
-		n36, err := m.InternalResolveIntentRange.MarshalTo(data[i:])
+		n59, err := m.InternalResolveIntentRange.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n36
+		i += n59
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
@@ -7250,81 +11356,141 @@ func (m *InternalResponseUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x12
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Get.Size()))
-		n37, err := m.Get.MarshalTo(data[i:])
+		n60, err := m.Get.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n37
+		i += n60
 	}
 	if m.Put != nil {
 		data[i] = 0x1a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Put.Size()))
-		n38, err := m.Put.MarshalTo(data[i:])
+		n61, err := m.Put.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n38
+		i += n61
 	}
 	if m.ConditionalPut != nil {
 		data[i] = 0x22
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.ConditionalPut.Size()))
-		n39, err := m.ConditionalPut.MarshalTo(data[i:])
+		n62, err := m.ConditionalPut.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n39
+		i += n62
 	}
 	if m.Increment != nil {
 		data[i] = 0x2a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Increment.Size()))
-		n40, err := m.Increment.MarshalTo(data[i:])
+		n63, err := m.Increment.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n40
+		i += n63
 	}
 	if m.Delete != nil {
 		data[i] = 0x32
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Delete.Size()))
-		n41, err := m.Delete.MarshalTo(data[i:])
+		n64, err := m.Delete.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n41
+		i += n64
 	}
 	if m.DeleteRange != nil {
 		data[i] = 0x3a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.DeleteRange.Size()))
-		n42, err := m.DeleteRange.MarshalTo(data[i:])
+		n65, err := m.DeleteRange.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n42
+		i += n65
 	}
 	if m.Scan != nil {
 		data[i] = 0x42
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Scan.Size()))
-		n43, err := m.Scan.MarshalTo(data[i:])
+		n66, err := m.Scan.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n43
+		i += n66
 	}
 	if m.EndTransaction != nil {
 		data[i] = 0x4a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.EndTransaction.Size()))
-		n44, err := m.EndTransaction.MarshalTo(data[i:])
+		n67, err := m.EndTransaction.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n44
+		i += n67
+	}
+	if m.ReapQueue != nil {
+		data[i] = 0x52
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.ReapQueue.Size()))
+		n68, err := m.ReapQueue.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n68
+	}
+	if m.EnqueueUpdate != nil {
+		data[i] = 0x5a
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.EnqueueUpdate.Size()))
+		n69, err := m.EnqueueUpdate.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n69
+	}
+	if m.EnqueueMessage != nil {
+		data[i] = 0x62
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.EnqueueMessage.Size()))
+		n70, err := m.EnqueueMessage.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n70
+	}
+	if m.Changes != nil {
+		data[i] = 0x6a
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.Changes.Size()))
+		n71, err := m.Changes.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n71
+	}
+	if m.Import != nil {
+		data[i] = 0x72
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.Import.Size()))
+		n72, err := m.Import.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n72
+	}
+	if m.SpanStats != nil {
+		data[i] = 0x7a
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.SpanStats.Size()))
+		n73, err := m.SpanStats.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n73
 	}
 	if m.InternalPushTxn != nil {
 		data[i] = 0xf2
@@ -7332,11 +11498,11 @@ func (m *InternalResponseUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x1
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalPushTxn.Size()))
-		n45, err := m.InternalPushTxn.MarshalTo(data[i:])
+		n74, err := m.InternalPushTxn.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n45
+		i += n74
 	}
 	if m.InternalResolveIntent != nil {
 		data[i] = 0xfa
@@ -7344,11 +11510,11 @@ func (m *InternalResponseUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x1
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalResolveIntent.Size()))
-		n46, err := m.InternalResolveIntent.MarshalTo(data[i:])
+		n75, err := m.InternalResolveIntent.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n46
+		i += n75
 	}
 	if m.InternalResolveIntentRange != nil {
 		data[i] = 0x82
@@ -7356,11 +11522,11 @@ func (m *InternalResponseUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x2
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalResolveIntentRange.Size()))
-		n47, err := m.InternalResolveIntentRange.MarshalTo(data[i:])
+		n76, err := m.InternalResolveIntentRange.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n47
+		i += n76
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
@@ -7386,11 +11552,11 @@ func (m *InternalBatchRequest) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.RequestHeader.Size()))
-	n48, err := m.RequestHeader.MarshalTo(data[i:])
+	n77, err := m.RequestHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n48
+	i += n77
 	if len(m.Requests) > 0 {
 		for _, msg := range m.Requests {
 			data[i] = 0x12
@@ -7427,11 +11593,11 @@ func (m *InternalBatchResponse) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.ResponseHeader.Size()))
-	n49, err := m.ResponseHeader.MarshalTo(data[i:])
+	n78, err := m.ResponseHeader.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n49
+	i += n78
 	if len(m.Responses) > 0 {
 		for _, msg := range m.Responses {
 			data[i] = 0x12
@@ -7469,121 +11635,151 @@ func (m *ReadWriteCmdResponse) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0xa
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Put.Size()))
-		n50, err := m.Put.MarshalTo(data[i:])
+		n79, err := m.Put.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n50
+		i += n79
 	}
 	if m.ConditionalPut != nil {
 		data[i] = 0x12
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.ConditionalPut.Size()))
-		n51, err := m.ConditionalPut.MarshalTo(data[i:])
+		n80, err := m.ConditionalPut.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n51
+		i += n80
 	}
 	if m.Increment != nil {
 		data[i] = 0x1a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Increment.Size()))
-		n52, err := m.Increment.MarshalTo(data[i:])
+		n81, err := m.Increment.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n52
+		i += n81
 	}
 	if m.Delete != nil {
 		data[i] = 0x22
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Delete.Size()))
-		n53, err := m.Delete.MarshalTo(data[i:])
+		n82, err := m.Delete.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n82
+	}
+	if m.DeleteRange != nil {
+		data[i] = 0x2a
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.DeleteRange.Size()))
+		n83, err := m.DeleteRange.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n83
+	}
+	if m.EndTransaction != nil {
+		data[i] = 0x32
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.EndTransaction.Size()))
+		n84, err := m.EndTransaction.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n84
+	}
+	if m.ReapQueue != nil {
+		data[i] = 0x3a
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.ReapQueue.Size()))
+		n85, err := m.ReapQueue.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n53
+		i += n85
 	}
-	if m.DeleteRange != nil {
-		data[i] = 0x2a
+	if m.EnqueueUpdate != nil {
+		data[i] = 0x42
 		i++
-		i = encodeVarintInternal(data, i, uint64(m.DeleteRange.Size()))
-		n54, err := m.DeleteRange.MarshalTo(data[i:])
+		i = encodeVarintInternal(data, i, uint64(m.EnqueueUpdate.Size()))
+		n86, err := m.EnqueueUpdate.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n54
+		i += n86
 	}
-	if m.EndTransaction != nil {
-		data[i] = 0x32
+	if m.EnqueueMessage != nil {
+		data[i] = 0x4a
 		i++
-		i = encodeVarintInternal(data, i, uint64(m.EndTransaction.Size()))
-		n55, err := m.EndTransaction.MarshalTo(data[i:])
+		i = encodeVarintInternal(data, i, uint64(m.EnqueueMessage.Size()))
+		n87, err := m.EnqueueMessage.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n55
+		i += n87
 	}
 	if m.InternalHeartbeatTxn != nil {
 		data[i] = 0x52
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalHeartbeatTxn.Size()))
-		n56, err := m.InternalHeartbeatTxn.MarshalTo(data[i:])
+		n88, err := m.InternalHeartbeatTxn.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n56
+		i += n88
 	}
 	if m.InternalPushTxn != nil {
 		data[i] = 0x5a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalPushTxn.Size()))
-		n57, err := m.InternalPushTxn.MarshalTo(data[i:])
+		n89, err := m.InternalPushTxn.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n57
+		i += n89
 	}
 	if m.InternalResolveIntent != nil {
 		data[i] = 0x62
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalResolveIntent.Size()))
-		n58, err := m.InternalResolveIntent.MarshalTo(data[i:])
+		n90, err := m.InternalResolveIntent.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n58
+		i += n90
 	}
 	if m.InternalResolveIntentRange != nil {
 		data[i] = 0x6a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalResolveIntentRange.Size()))
-		n59, err := m.InternalResolveIntentRange.MarshalTo(data[i:])
+		n91, err := m.InternalResolveIntentRange.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n59
+		i += n91
 	}
 	if m.InternalMerge != nil {
 		data[i] = 0x72
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalMerge.Size()))
-		n60, err := m.InternalMerge.MarshalTo(data[i:])
+		n92, err := m.InternalMerge.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n60
+		i += n92
 	}
 	if m.InternalTruncateLog != nil {
 		data[i] = 0x7a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalTruncateLog.Size()))
-		n61, err := m.InternalTruncateLog.MarshalTo(data[i:])
+		n93, err := m.InternalTruncateLog.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n61
+		i += n93
 	}
 	if m.InternalGc != nil {
 		data[i] = 0x82
@@ -7591,11 +11787,11 @@ func (m *ReadWriteCmdResponse) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x1
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalGc.Size()))
-		n62, err := m.InternalGc.MarshalTo(data[i:])
+		n94, err := m.InternalGc.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n62
+		i += n94
 	}
 	if m.InternalLeaderLease != nil {
 		data[i] = 0x8a
@@ -7603,11 +11799,83 @@ func (m *ReadWriteCmdResponse) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x1
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalLeaderLease.Size()))
-		n63, err := m.InternalLeaderLease.MarshalTo(data[i:])
+		n95, err := m.InternalLeaderLease.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n63
+		i += n95
+	}
+	if m.Import != nil {
+		data[i] = 0x92
+		i++
+		data[i] = 0x1
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.Import.Size()))
+		n96, err := m.Import.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n96
+	}
+	if m.InternalComputeChecksum != nil {
+		data[i] = 0x9a
+		i++
+		data[i] = 0x1
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.InternalComputeChecksum.Size()))
+		n97, err := m.InternalComputeChecksum.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n97
+	}
+	if m.InternalVerifyChecksum != nil {
+		data[i] = 0xa2
+		i++
+		data[i] = 0x1
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.InternalVerifyChecksum.Size()))
+		n98, err := m.InternalVerifyChecksum.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n98
+	}
+	if m.InternalTransferLeaderLease != nil {
+		data[i] = 0xaa
+		i++
+		data[i] = 0x1
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.InternalTransferLeaderLease.Size()))
+		n99, err := m.InternalTransferLeaderLease.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n99
+	}
+	if m.InternalCloseTimestamp != nil {
+		data[i] = 0xb2
+		i++
+		data[i] = 0x1
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.InternalCloseTimestamp.Size()))
+		n100, err := m.InternalCloseTimestamp.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n100
+	}
+	if m.InternalReportChecksum != nil {
+		data[i] = 0xba
+		i++
+		data[i] = 0x1
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.InternalReportChecksum.Size()))
+		n101, err := m.InternalReportChecksum.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n101
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
@@ -7634,81 +11902,141 @@ func (m *InternalRaftCommandUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x12
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Get.Size()))
-		n64, err := m.Get.MarshalTo(data[i:])
+		n102, err := m.Get.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n64
+		i += n102
 	}
 	if m.Put != nil {
 		data[i] = 0x1a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Put.Size()))
-		n65, err := m.Put.MarshalTo(data[i:])
+		n103, err := m.Put.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n65
+		i += n103
 	}
 	if m.ConditionalPut != nil {
 		data[i] = 0x22
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.ConditionalPut.Size()))
-		n66, err := m.ConditionalPut.MarshalTo(data[i:])
+		n104, err := m.ConditionalPut.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n66
+		i += n104
 	}
 	if m.Increment != nil {
 		data[i] = 0x2a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Increment.Size()))
-		n67, err := m.Increment.MarshalTo(data[i:])
+		n105, err := m.Increment.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n67
+		i += n105
 	}
 	if m.Delete != nil {
 		data[i] = 0x32
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Delete.Size()))
-		n68, err := m.Delete.MarshalTo(data[i:])
+		n106, err := m.Delete.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n68
+		i += n106
 	}
 	if m.DeleteRange != nil {
 		data[i] = 0x3a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.DeleteRange.Size()))
-		n69, err := m.DeleteRange.MarshalTo(data[i:])
+		n107, err := m.DeleteRange.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n69
+		i += n107
 	}
 	if m.Scan != nil {
 		data[i] = 0x42
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Scan.Size()))
-		n70, err := m.Scan.MarshalTo(data[i:])
+		n108, err := m.Scan.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n70
+		i += n108
 	}
 	if m.EndTransaction != nil {
 		data[i] = 0x4a
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.EndTransaction.Size()))
-		n71, err := m.EndTransaction.MarshalTo(data[i:])
+		n109, err := m.EndTransaction.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n71
+		i += n109
+	}
+	if m.ReapQueue != nil {
+		data[i] = 0x52
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.ReapQueue.Size()))
+		n110, err := m.ReapQueue.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n110
+	}
+	if m.EnqueueUpdate != nil {
+		data[i] = 0x5a
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.EnqueueUpdate.Size()))
+		n111, err := m.EnqueueUpdate.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n111
+	}
+	if m.EnqueueMessage != nil {
+		data[i] = 0x62
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.EnqueueMessage.Size()))
+		n112, err := m.EnqueueMessage.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n112
+	}
+	if m.Changes != nil {
+		data[i] = 0x6a
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.Changes.Size()))
+		n113, err := m.Changes.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n113
+	}
+	if m.Import != nil {
+		data[i] = 0x72
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.Import.Size()))
+		n114, err := m.Import.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n114
+	}
+	if m.SpanStats != nil {
+		data[i] = 0x7a
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.SpanStats.Size()))
+		n115, err := m.SpanStats.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n115
 	}
 	if m.Batch != nil {
 		data[i] = 0xf2
@@ -7716,11 +12044,11 @@ func (m *InternalRaftCommandUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x1
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.Batch.Size()))
-		n72, err := m.Batch.MarshalTo(data[i:])
+		n116, err := m.Batch.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n72
+		i += n116
 	}
 	if m.InternalRangeLookup != nil {
 		data[i] = 0xfa
@@ -7728,11 +12056,11 @@ func (m *InternalRaftCommandUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x1
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalRangeLookup.Size()))
-		n73, err := m.InternalRangeLookup.MarshalTo(data[i:])
+		n117, err := m.InternalRangeLookup.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n73
+		i += n117
 	}
 	if m.InternalHeartbeatTxn != nil {
 		data[i] = 0x82
@@ -7740,11 +12068,11 @@ func (m *InternalRaftCommandUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x2
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalHeartbeatTxn.Size()))
-		n74, err := m.InternalHeartbeatTxn.MarshalTo(data[i:])
+		n118, err := m.InternalHeartbeatTxn.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n74
+		i += n118
 	}
 	if m.InternalPushTxn != nil {
 		data[i] = 0x8a
@@ -7752,11 +12080,11 @@ func (m *InternalRaftCommandUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x2
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalPushTxn.Size()))
-		n75, err := m.InternalPushTxn.MarshalTo(data[i:])
+		n119, err := m.InternalPushTxn.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n75
+		i += n119
 	}
 	if m.InternalResolveIntent != nil {
 		data[i] = 0x92
@@ -7764,11 +12092,11 @@ func (m *InternalRaftCommandUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x2
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalResolveIntent.Size()))
-		n76, err := m.InternalResolveIntent.MarshalTo(data[i:])
+		n120, err := m.InternalResolveIntent.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n76
+		i += n120
 	}
 	if m.InternalResolveIntentRange != nil {
 		data[i] = 0x9a
@@ -7776,11 +12104,11 @@ func (m *InternalRaftCommandUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x2
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalResolveIntentRange.Size()))
-		n77, err := m.InternalResolveIntentRange.MarshalTo(data[i:])
+		n121, err := m.InternalResolveIntentRange.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n77
+		i += n121
 	}
 	if m.InternalMergeResponse != nil {
 		data[i] = 0xa2
@@ -7788,11 +12116,11 @@ func (m *InternalRaftCommandUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x2
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalMergeResponse.Size()))
-		n78, err := m.InternalMergeResponse.MarshalTo(data[i:])
+		n122, err := m.InternalMergeResponse.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n78
+		i += n122
 	}
 	if m.InternalTruncateLog != nil {
 		data[i] = 0xaa
@@ -7800,11 +12128,11 @@ func (m *InternalRaftCommandUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x2
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalTruncateLog.Size()))
-		n79, err := m.InternalTruncateLog.MarshalTo(data[i:])
+		n123, err := m.InternalTruncateLog.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n79
+		i += n123
 	}
 	if m.InternalGC != nil {
 		data[i] = 0xb2
@@ -7812,11 +12140,11 @@ func (m *InternalRaftCommandUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x2
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalGC.Size()))
-		n80, err := m.InternalGC.MarshalTo(data[i:])
+		n124, err := m.InternalGC.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n80
+		i += n124
 	}
 	if m.InternalLease != nil {
 		data[i] = 0xba
@@ -7824,11 +12152,11 @@ func (m *InternalRaftCommandUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x2
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalLease.Size()))
-		n81, err := m.InternalLease.MarshalTo(data[i:])
+		n125, err := m.InternalLease.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n81
+		i += n125
 	}
 	if m.InternalBatch != nil {
 		data[i] = 0xc2
@@ -7836,11 +12164,71 @@ func (m *InternalRaftCommandUnion) MarshalTo(data []byte) (n int, err error) {
 		data[i] = 0x2
 		i++
 		i = encodeVarintInternal(data, i, uint64(m.InternalBatch.Size()))
-		n82, err := m.InternalBatch.MarshalTo(data[i:])
+		n126, err := m.InternalBatch.MarshalTo(data[i:])
 		if err != nil {
 			return 0, err
 		}
-		i += n82
+		i += n126
+	}
+	if m.InternalComputeChecksum != nil {
+		data[i] = 0xca
+		i++
+		data[i] = 0x2
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.InternalComputeChecksum.Size()))
+		n127, err := m.InternalComputeChecksum.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n127
+	}
+	if m.InternalVerifyChecksum != nil {
+		data[i] = 0xd2
+		i++
+		data[i] = 0x2
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.InternalVerifyChecksum.Size()))
+		n128, err := m.InternalVerifyChecksum.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n128
+	}
+	if m.InternalTransferLeaderLease != nil {
+		data[i] = 0xda
+		i++
+		data[i] = 0x2
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.InternalTransferLeaderLease.Size()))
+		n129, err := m.InternalTransferLeaderLease.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n129
+	}
+	if m.InternalCloseTimestamp != nil {
+		data[i] = 0xe2
+		i++
+		data[i] = 0x2
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.InternalCloseTimestamp.Size()))
+		n130, err := m.InternalCloseTimestamp.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n130
+	}
+	if m.InternalReportChecksum != nil {
+		data[i] = 0xea
+		i++
+		data[i] = 0x2
+		i++
+		i = encodeVarintInternal(data, i, uint64(m.InternalReportChecksum.Size()))
+		n131, err := m.InternalReportChecksum.MarshalTo(data[i:])
+		if err != nil {
+			return 0, err
+		}
+		i += n131
 	}
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
@@ -7872,11 +12260,11 @@ func (m *InternalRaftCommand) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0x1a
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.Cmd.Size()))
-	n83, err := m.Cmd.MarshalTo(data[i:])
+	n132, err := m.Cmd.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n83
+	i += n132
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
@@ -7934,6 +12322,77 @@ func (m *RaftMessageResponse) MarshalTo(data []byte) (n int, err error) {
 	return i, nil
 }
 
+func (m *RaftSnapshotChunkRequest) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *RaftSnapshotChunkRequest) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0x8
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.GroupID))
+	data[i] = 0x10
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.SnapshotID))
+	data[i] = 0x18
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.Seq))
+	if m.Msg != nil {
+		data[i] = 0x22
+		i++
+		i = encodeVarintInternal(data, i, uint64(len(m.Msg)))
+		i += copy(data[i:], m.Msg)
+	}
+	if m.Data != nil {
+		data[i] = 0x2a
+		i++
+		i = encodeVarintInternal(data, i, uint64(len(m.Data)))
+		i += copy(data[i:], m.Data)
+	}
+	data[i] = 0x30
+	i++
+	if m.Last {
+		data[i] = 1
+	} else {
+		data[i] = 0
+	}
+	i++
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *RaftSnapshotChunkResponse) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *RaftSnapshotChunkResponse) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *InternalTimeSeriesData) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
@@ -8040,6 +12499,47 @@ func (m *RaftTruncatedState) MarshalTo(data []byte) (n int, err error) {
 	return i, nil
 }
 
+func (m *ChangeLogEntry) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *ChangeLogEntry) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	if m.Key != nil {
+		data[i] = 0xa
+		i++
+		i = encodeVarintInternal(data, i, uint64(len(m.Key)))
+		i += copy(data[i:], m.Key)
+	}
+	if m.EndKey != nil {
+		data[i] = 0x12
+		i++
+		i = encodeVarintInternal(data, i, uint64(len(m.EndKey)))
+		i += copy(data[i:], m.EndKey)
+	}
+	data[i] = 0x18
+	i++
+	if m.Intent {
+		data[i] = 1
+	} else {
+		data[i] = 0
+	}
+	i++
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
 func (m *RaftSnapshotData) Marshal() (data []byte, err error) {
 	size := m.Size()
 	data = make([]byte, size)
@@ -8058,11 +12558,11 @@ func (m *RaftSnapshotData) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0xa
 	i++
 	i = encodeVarintInternal(data, i, uint64(m.RangeDescriptor.Size()))
-	n84, err := m.RangeDescriptor.MarshalTo(data[i:])
+	n133, err := m.RangeDescriptor.MarshalTo(data[i:])
 	if err != nil {
 		return 0, err
 	}
-	i += n84
+	i += n133
 	if len(m.KV) > 0 {
 		for _, msg := range m.KV {
 			data[i] = 0x12
@@ -8075,6 +12575,9 @@ func (m *RaftSnapshotData) MarshalTo(data []byte) (n int, err error) {
 			i += n
 		}
 	}
+	data[i] = 0x18
+	i++
+	i = encodeVarintInternal(data, i, uint64(m.StreamID))
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
@@ -59,6 +59,17 @@ const (
 	EnqueueUpdate
 	// EnqueueMessage enqueues a message for delivery to an inbox.
 	EnqueueMessage
+	// Changes returns the committed writes to a key span within a
+	// timestamp interval, along with a resolved timestamp below which
+	// no further writes to the span can appear.
+	Changes
+	// Import writes a sorted chunk of key/value pairs in a single
+	// command per range. It is used for bulk loading data.
+	Import
+	// SpanStats returns the MVCC statistics of the keys within a span,
+	// using the stats maintained by each range wherever the span covers
+	// the range in full.
+	SpanStats
 	// Batch executes a set of commands in parallel.
 	Batch
 	// AdminSplit is called to coordinate a split of a range.
@@ -103,6 +114,21 @@ const (
 	InternalTruncateLog
 	// InternalLeaderLease requests a leader lease for a replica.
 	InternalLeaderLease
+	// InternalComputeChecksum has every replica of a range compute a
+	// checksum of its range data at the same applied index.
+	InternalComputeChecksum
+	// InternalVerifyChecksum has the replicas of a range whose checksums
+	// were outvoted by the other replicas report the diverging spans.
+	InternalVerifyChecksum
+	// InternalTransferLeaderLease hands the leader lease held by a
+	// replica over to another replica of the range.
+	InternalTransferLeaderLease
+	// InternalCloseTimestamp publishes a timestamp at or below which the
+	// holder of the leader lease no longer serves writes.
+	InternalCloseTimestamp
+	// InternalReportChecksum delivers the checksums computed by a replica
+	// of a range to the range leader.
+	InternalReportChecksum
 	// InternalBatch implements batch processing of commands. This is a
 	// superset of the Batch method.
 	InternalBatch
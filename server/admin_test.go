@@ -122,6 +122,38 @@ range_min_bytes: 67108864
 range_max_bytes: 67108864
 `, "RangeMinBytes 67108864 is greater than or equal to RangeMaxBytes 67108864"},
 		{`
+replicas:
+  - attrs: [dc1, ssd]
+range_min_bytes: 1048576
+range_max_bytes: 67108864
+range_max_qps: -1
+`, "RangeMaxQPS -1 must not be negative"},
+		{`
+replicas:
+  - attrs: [dc1, ssd]
+range_min_bytes: 1048576
+range_max_bytes: 67108864
+gc:
+  minversions: -1
+`, "GC MinVersions -1 must not be negative"},
+		{`
+replicas:
+  - attrs: [dc1, ssd]
+range_min_bytes: 1048576
+range_max_bytes: 67108864
+gc:
+  maxversions: -1
+`, "GC MaxVersions -1 must not be negative"},
+		{`
+replicas:
+  - attrs: [dc1, ssd]
+range_min_bytes: 1048576
+range_max_bytes: 67108864
+gc:
+  minversions: 3
+  maxversions: 2
+`, "GC MaxVersions 2 is less than MinVersions 3"},
+		{`
 range_min_bytes: 1048576
 range_max_bytes: 67108864
 `, "attributes for at least one replica must be specified in zone config"},
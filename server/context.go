@@ -20,6 +20,7 @@ package server
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -42,6 +43,9 @@ const (
 	defaultScanInterval     = 10 * time.Minute
 	defaultScanMaxIdleTime  = 5 * time.Second
 	defaultMetricsFrequency = 10 * time.Second
+	defaultSnapshotRate     = 8 << 20 // 8 MB/s
+	defaultStoreKeyRotation = engine.DefaultStoreKeyRotationPeriod
+	defaultStoreEngine      = "rocksdb"
 )
 
 // Context holds parameters needed to setup a server.
@@ -72,6 +76,13 @@ type Context struct {
 	// in zone configs.
 	Attrs string
 
+	// Locality specifies the failure domain of the node as a
+	// comma-separated list of key=value tiers, from the most general to
+	// the most specific, e.g. "region=us-east,datacenter=us-east-1a".
+	// The allocator spreads the replicas of a range across as many
+	// distinct tiers as possible.
+	Locality string
+
 	// Maximum clock offset for the cluster.
 	MaxOffset time.Duration
 
@@ -92,10 +103,62 @@ type Context struct {
 	// RPC client.
 	ExperimentalRPCServer bool
 
+	// QuarantineInconsistentReplicas takes replicas out of service when a
+	// consistency check finds their data to diverge from the range
+	// leader's. Otherwise the divergence is only logged.
+	QuarantineInconsistentReplicas bool
+
+	// BackpressureMaxRaftLogEntries is the number of entries in a
+	// range's Raft log beyond which writes to the range wait until the
+	// log has been truncated. Zero selects the store's default; a
+	// negative value disables backpressure on log length.
+	BackpressureMaxRaftLogEntries int64
+
+	// ClosedTimestampInterval is the interval at which range leaders
+	// close the timestamp ClosedTimestampLag ago, allowing the other
+	// replicas to serve bounded staleness reads at or below it. Zero,
+	// the default, disables the closing of timestamps, so that only
+	// leaders serve such reads.
+	ClosedTimestampInterval time.Duration
+
+	// ClosedTimestampLag is how far behind the present range leaders
+	// close timestamps. Zero selects the store's default.
+	ClosedTimestampLag time.Duration
+
 	// CacheSize is the amount of memory in bytes to use for caching data.
 	// The value is split evenly between the stores if there are more than one.
 	CacheSize int64
 
+	// SnapshotRate is the maximum number of bytes per second at which
+	// raft snapshots are sent to other nodes. Zero disables the limit.
+	SnapshotRate int64
+
+	// SnapshotDir is the directory in which snapshots received from
+	// other nodes are staged until they are complete. Defaults to the
+	// "snapshots" subdirectory of the first persistent store.
+	SnapshotDir string
+
+	// StoreKeys enables encryption at rest for persistent stores. It is
+	// a comma-separated list of store paths, each followed by '=' and the
+	// path of the file holding the key with which the keys encrypting
+	// the store's data files are wrapped. For example,
+	// -store-keys=/mnt/ssd01=/etc/cockroach/ssd01.key
+	StoreKeys string
+
+	// OldStoreKeys lists the previous key files of stores in the format
+	// of StoreKeys. It is required once after a store's key file has
+	// changed to rewrap its data keys with the new key.
+	OldStoreKeys string
+
+	// StoreKeyRotation is the period after which the key encrypting
+	// newly written data files of an encrypted store is replaced.
+	StoreKeyRotation time.Duration
+
+	// StoreEngine selects the storage engine of the stores: "rocksdb",
+	// or "golsm" for the pure Go engine, which does not support
+	// encryption.
+	StoreEngine string
+
 	// Parsed values.
 
 	// Engines is the storage instances specified by Stores.
@@ -104,6 +167,9 @@ type Context struct {
 	// NodeAttributes is the parsed representation of Attrs.
 	NodeAttributes proto.Attributes
 
+	// NodeLocality is the parsed representation of Locality.
+	NodeLocality proto.Locality
+
 	// GossipBootstrapResolvers is a list of gossip resolvers used
 	// to find bootstrap nodes for connecting to the gossip network.
 	GossipBootstrapResolvers []resolver.Resolver
@@ -132,6 +198,9 @@ func NewContext() *Context {
 		ScanInterval:     defaultScanInterval,
 		ScanMaxIdleTime:  defaultScanMaxIdleTime,
 		MetricsFrequency: defaultMetricsFrequency,
+		SnapshotRate:     defaultSnapshotRate,
+		StoreKeyRotation: defaultStoreKeyRotation,
+		StoreEngine:      defaultStoreEngine,
 	}
 	// Initializes base context defaults.
 	ctx.InitDefaults()
@@ -152,6 +221,19 @@ func (ctx *Context) Init(command string) error {
 				"did you specify --stores?", ctx.Stores)
 		}
 
+		if ctx.StoreEngine != "rocksdb" && ctx.StoreEngine != "golsm" {
+			return util.Errorf("unknown store engine %q", ctx.StoreEngine)
+		}
+
+		storeKeys, err := parseStoreKeys(ctx.StoreKeys)
+		if err != nil {
+			return util.Errorf("invalid store keys specification %q: %s", ctx.StoreKeys, err)
+		}
+		oldStoreKeys, err := parseStoreKeys(ctx.OldStoreKeys)
+		if err != nil {
+			return util.Errorf("invalid old store keys specification %q: %s", ctx.OldStoreKeys, err)
+		}
+
 		ctx.Engines = nil
 		for _, store := range storeSpecs {
 			if len(store) != 4 {
@@ -159,11 +241,29 @@ func (ctx *Context) Init(command string) error {
 			}
 			// There are two matches for each store specification: the colon-separated
 			// list of attributes and the path.
-			engine, err := ctx.initEngine(store[1], store[2])
+			var encryption *engine.EncryptionOptions
+			if keyFile, ok := storeKeys[store[2]]; ok {
+				encryption = &engine.EncryptionOptions{
+					KeyFile:        keyFile,
+					OldKeyFile:     oldStoreKeys[store[2]],
+					RotationPeriod: ctx.StoreKeyRotation,
+				}
+				delete(storeKeys, store[2])
+			} else if _, ok := oldStoreKeys[store[2]]; ok {
+				return util.Errorf("old key specified for unencrypted store %q", store[0])
+			}
+			eng, err := ctx.initEngine(store[1], store[2], encryption)
 			if err != nil {
 				return util.Errorf("unable to init engine for store %q: %s", store[0], err)
 			}
-			ctx.Engines = append(ctx.Engines, engine)
+			ctx.Engines = append(ctx.Engines, eng)
+			// Stage incoming snapshots on the first persistent store.
+			if _, err := strconv.ParseUint(store[2], 10, 64); err != nil && ctx.SnapshotDir == "" {
+				ctx.SnapshotDir = filepath.Join(store[2], "snapshots")
+			}
+		}
+		for path := range storeKeys {
+			return util.Errorf("key specified for unknown store %q", path)
 		}
 		log.Infof("initialized %d storage engine(s)", len(ctx.Engines))
 	}
@@ -171,6 +271,11 @@ func (ctx *Context) Init(command string) error {
 	if command == "start" {
 		// Initialize attributes.
 		ctx.NodeAttributes = parseAttributes(ctx.Attrs)
+		locality, err := proto.ParseLocality(ctx.Locality)
+		if err != nil {
+			return err
+		}
+		ctx.NodeLocality = locality
 
 		// Get the gossip bootstrap resolvers.
 		resolvers, err := ctx.parseGossipBootstrapResolvers()
@@ -188,20 +293,57 @@ func (ctx *Context) Init(command string) error {
 // initEngine parses the store attributes as a colon-separated list
 // and instantiates an engine based on the dir parameter. If dir parses
 // to an integer, it's taken to mean an in-memory engine; otherwise,
-// dir is treated as a path and a RocksDB engine is created.
-func (ctx *Context) initEngine(attrsStr, path string) (engine.Engine, error) {
+// dir is treated as a path and a RocksDB engine is created, which
+// encrypts its data files if encryption options are specified. If
+// StoreEngine is "golsm", GoLSM engines are created instead.
+func (ctx *Context) initEngine(attrsStr, path string, encryption *engine.EncryptionOptions) (engine.Engine, error) {
 	attrs := parseAttributes(attrsStr)
+	if ctx.StoreEngine == "golsm" && encryption != nil {
+		return nil, util.Errorf("golsm stores cannot be encrypted")
+	}
 	if size, err := strconv.ParseUint(path, 10, 64); err == nil {
 		if size == 0 {
 			return nil, util.Errorf("unable to initialize an in-memory store with capacity 0")
 		}
+		if encryption != nil {
+			return nil, util.Errorf("in-memory stores cannot be encrypted")
+		}
+		if ctx.StoreEngine == "golsm" {
+			return engine.NewGoLSM(attrs, ""), nil
+		}
 		return engine.NewInMem(attrs, int64(size)), nil
 		// TODO(spencer): should be using rocksdb for in-memory stores and
 		// relegate the InMem engine to usage only from unittests.
 	}
+	if ctx.StoreEngine == "golsm" {
+		return engine.NewGoLSM(attrs, path), nil
+	}
+	if encryption != nil {
+		return engine.NewEncryptedRocksDB(attrs, path, ctx.CacheSize, *encryption), nil
+	}
 	return engine.NewRocksDB(attrs, path, ctx.CacheSize), nil
 }
 
+// parseStoreKeys parses a comma-separated list of store paths and key
+// files separated by '=' into a map from store path to key file.
+func parseStoreKeys(spec string) (map[string]string, error) {
+	keys := map[string]string{}
+	for _, item := range strings.Split(spec, ",") {
+		if len(item) == 0 {
+			continue
+		}
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, util.Errorf("expected <store path>=<key file>; got %q", item)
+		}
+		if _, ok := keys[parts[0]]; ok {
+			return nil, util.Errorf("duplicate key for store %q", parts[0])
+		}
+		keys[parts[0]] = parts[1]
+	}
+	return keys, nil
+}
+
 // parseGossipBootstrapResolvers parses a comma-separated list of
 // gossip bootstrap resolvers.
 func (ctx *Context) parseGossipBootstrapResolvers() ([]resolver.Resolver, error) {
@@ -22,6 +22,7 @@ import (
 	"testing"
 
 	"github.com/cockroachdb/cockroach/gossip/resolver"
+	"github.com/cockroachdb/cockroach/storage/engine"
 	"github.com/cockroachdb/cockroach/util/leaktest"
 )
 
@@ -40,6 +41,25 @@ func TestParseNodeAttributes(t *testing.T) {
 	}
 }
 
+func TestParseNodeLocality(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	ctx := NewContext()
+	ctx.Locality = "region=us-east,rack=12"
+	ctx.Stores = "mem=1"
+	ctx.GossipBootstrap = "self="
+	if err := ctx.Init("start"); err != nil {
+		t.Fatalf("Failed to initialize the context: %v", err)
+	}
+	if l := ctx.NodeLocality.String(); l != ctx.Locality {
+		t.Fatalf("Unexpected locality: %s", l)
+	}
+
+	ctx.Locality = "us-east"
+	if err := ctx.Init("start"); err == nil {
+		t.Fatal("expected error parsing invalid locality")
+	}
+}
+
 // TestParseGossipBootstrapAddrs verifies that GossipBootstrap is
 // parsed correctly.
 func TestParseGossipBootstrapAddrs(t *testing.T) {
@@ -63,3 +83,38 @@ func TestParseGossipBootstrapAddrs(t *testing.T) {
 		t.Fatalf("Unexpected bootstrap addresses: %v, expected: %v", ctx.GossipBootstrapResolvers, expected)
 	}
 }
+
+// TestStoreEngine verifies that the store engine selects the type of
+// the engines.
+func TestStoreEngine(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	ctx := NewContext()
+	ctx.Stores = "mem=1,ssd=/tmp/store"
+	if err := ctx.Init("init"); err != nil {
+		t.Fatalf("Failed to initialize the context: %v", err)
+	}
+	if _, ok := ctx.Engines[1].(*engine.RocksDB); !ok {
+		t.Errorf("expected a RocksDB engine; got %T", ctx.Engines[1])
+	}
+
+	ctx.StoreEngine = "golsm"
+	if err := ctx.Init("init"); err != nil {
+		t.Fatalf("Failed to initialize the context: %v", err)
+	}
+	for _, e := range ctx.Engines {
+		if _, ok := e.(*engine.GoLSM); !ok {
+			t.Errorf("expected a GoLSM engine; got %T", e)
+		}
+	}
+
+	ctx.StoreKeys = "/tmp/store=/tmp/store.key"
+	if err := ctx.Init("init"); err == nil {
+		t.Error("expected error encrypting a golsm store")
+	}
+
+	ctx.StoreKeys = ""
+	ctx.StoreEngine = "leveldb"
+	if err := ctx.Init("init"); err == nil {
+		t.Error("expected error for an unknown store engine")
+	}
+}
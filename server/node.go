@@ -177,13 +177,14 @@ func (n *Node) context() context.Context {
 }
 
 // initDescriptor initializes the node descriptor with the server
-// address and the node attributes.
-func (n *Node) initDescriptor(addr net.Addr, attrs proto.Attributes) {
+// address, the node attributes and the node locality.
+func (n *Node) initDescriptor(addr net.Addr, attrs proto.Attributes, locality proto.Locality) {
 	n.Descriptor.Address = proto.Addr{
 		Network: addr.Network(),
 		Address: addr.String(),
 	}
 	n.Descriptor.Attrs = attrs
+	n.Descriptor.Locality = locality
 }
 
 // initNodeID updates the internal NodeDescriptor with the given ID. If zero is
@@ -227,8 +228,8 @@ func (n *Node) initNodeID(id proto.NodeID) {
 // RPC service "Node" and initializing stores for each specified
 // engine. Launches periodic store gossiping in a goroutine.
 func (n *Node) start(rpcServer *rpc.Server, engines []engine.Engine,
-	attrs proto.Attributes, stopper *stop.Stopper) error {
-	n.initDescriptor(rpcServer.Addr(), attrs)
+	attrs proto.Attributes, locality proto.Locality, stopper *stop.Stopper) error {
+	n.initDescriptor(rpcServer.Addr(), attrs, locality)
 	requests := []proto.Request{
 		&proto.GetRequest{},
 		&proto.PutRequest{},
@@ -238,6 +239,12 @@ func (n *Node) start(rpcServer *rpc.Server, engines []engine.Engine,
 		&proto.DeleteRangeRequest{},
 		&proto.ScanRequest{},
 		&proto.EndTransactionRequest{},
+		&proto.ReapQueueRequest{},
+		&proto.EnqueueUpdateRequest{},
+		&proto.EnqueueMessageRequest{},
+		&proto.ChangesRequest{},
+		&proto.ImportRequest{},
+		&proto.SpanStatsRequest{},
 		&proto.AdminSplitRequest{},
 		&proto.AdminMergeRequest{},
 		&proto.InternalRangeLookupRequest{},
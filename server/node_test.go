@@ -86,7 +86,7 @@ func createTestNode(addr net.Addr, engines []engine.Engine, gossipBS net.Addr, t
 func createAndStartTestNode(addr net.Addr, engines []engine.Engine, gossipBS net.Addr, t *testing.T) (
 	*rpc.Server, *Node, *stop.Stopper) {
 	rpcServer, _, node, stopper := createTestNode(addr, engines, gossipBS, t)
-	if err := node.start(rpcServer, engines, proto.Attributes{}, stopper); err != nil {
+	if err := node.start(rpcServer, engines, proto.Attributes{}, proto.Locality{}, stopper); err != nil {
 		t.Fatal(err)
 	}
 	return rpcServer, node, stopper
@@ -254,7 +254,7 @@ func TestCorruptedClusterID(t *testing.T) {
 
 	engines := []engine.Engine{e}
 	server, _, node, stopper := createTestNode(util.CreateTestAddr("tcp"), engines, nil, t)
-	if err := node.start(server, engines, proto.Attributes{}, stopper); err == nil {
+	if err := node.start(server, engines, proto.Attributes{}, proto.Locality{}, stopper); err == nil {
 		t.Errorf("unexpected success")
 	}
 	stopper.Stop()
@@ -0,0 +1,425 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package server
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	crand "crypto/rand"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/multiraft"
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/rpc"
+	"github.com/cockroachdb/cockroach/storage"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/log"
+	"github.com/coreos/etcd/raft/raftpb"
+	gogoproto "github.com/gogo/protobuf/proto"
+)
+
+const (
+	raftSnapshotChunkName = raftServiceName + ".RaftSnapshotChunk"
+	// Snapshots are streamed in chunks of this many bytes.
+	raftSnapshotChunkSize = 256 << 10
+	// Incoming snapshot streams which have not received a chunk for
+	// that duration are abandoned and their staged data discarded.
+	raftSnapshotStreamTimeout = time.Minute
+	// Staged snapshot data is written to files with this prefix.
+	raftSnapshotFilePrefix = "raft-snapshot-"
+)
+
+// snapshotKey identifies the snapshots of a raft group sent to a node.
+type snapshotKey struct {
+	nodeID  proto.RaftNodeID
+	groupID proto.RaftID
+}
+
+// snapshotStream is an incoming snapshot whose data is being staged
+// to disk. The staged data is encrypted with a key which is only held
+// in memory so that the data of encrypted stores is not written to
+// disk in the clear.
+type snapshotStream struct {
+	groupID   proto.RaftID
+	msg       raftpb.Message // The snapshot message, with the header of its data
+	lastChunk int64          // The time of the last chunk received; accessed atomically
+
+	mu        sync.Mutex // Protects the fields below
+	file      *os.File   // Nil once the stream has been closed
+	block     cipher.Block
+	iv        []byte
+	encrypter cipher.Stream
+	seq       int32 // The sequence number of the last chunk received
+}
+
+// close closes and removes the file staging the snapshot data. The
+// caller must hold the stream's lock.
+func (s *snapshotStream) close() {
+	if s.file != nil {
+		removeStagingFile(s.file)
+		s.file = nil
+	}
+}
+
+// stagedSnapshotData reads back and decrypts the staged data of a
+// snapshot stream. Closing it removes the staging file.
+type stagedSnapshotData struct {
+	cipher.StreamReader
+	file *os.File
+}
+
+// Close implements io.Closer.
+func (s stagedSnapshotData) Close() error {
+	removeStagingFile(s.file)
+	return nil
+}
+
+// removeStagingFile closes and removes a snapshot staging file.
+func removeStagingFile(file *os.File) {
+	if err := file.Close(); err != nil {
+		log.Warningf("could not close snapshot staging file %s: %s", file.Name(), err)
+	}
+	if err := os.Remove(file.Name()); err != nil {
+		log.Warningf("could not remove snapshot staging file %s: %s", file.Name(), err)
+	}
+}
+
+// rateLimiter paces the transmission of data to a number of bytes
+// per second. A rate of zero disables limiting.
+type rateLimiter struct {
+	rate int64
+	mu   sync.Mutex
+	next time.Time // The time at which the next bytes may be sent
+}
+
+// delay reserves the transmission of n bytes and returns the duration
+// the caller must wait before sending them.
+func (rl *rateLimiter) delay(n int) time.Duration {
+	if rl.rate <= 0 {
+		return 0
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	now := time.Now()
+	if rl.next.Before(now) {
+		rl.next = now
+	}
+	d := rl.next.Sub(now)
+	rl.next = rl.next.Add(time.Duration(int64(n) * int64(time.Second) / rl.rate))
+	return d
+}
+
+// initSnapshotStaging creates the directory in which incoming
+// snapshots are staged and removes the files of snapshots left over
+// from a previous process.
+func (t *rpcTransport) initSnapshotStaging() error {
+	if err := os.MkdirAll(t.snapshotDir, 0755); err != nil {
+		return util.Errorf("unable to create snapshot staging directory: %s", err)
+	}
+	files, err := filepath.Glob(filepath.Join(t.snapshotDir, raftSnapshotFilePrefix+"*"))
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := os.Remove(file); err != nil {
+			return util.Errorf("unable to remove stale snapshot staging file: %s", err)
+		}
+	}
+	return nil
+}
+
+// sendSnapshot starts streaming the snapshot message to its recipient
+// unless a snapshot of the same group is already being sent there. In
+// that case the message is dropped; raft sends another snapshot if the
+// one in flight does not suffice.
+func (t *rpcTransport) sendSnapshot(req *multiraft.RaftMessageRequest) error {
+	// Take the snapshot's key/value pairs from the stream registered by
+	// the range, so that they are released even if the message is
+	// dropped.
+	header, kvs, err := storage.OpenSnapshotData(req.Message.Snapshot.Data)
+	if err != nil {
+		return err
+	}
+	key := snapshotKey{proto.RaftNodeID(req.Message.To), req.GroupID}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.outgoing[key]; ok {
+		if log.V(1) {
+			log.Infof("dropping snapshot of group %d to %d: a snapshot is already in flight", key.groupID, key.nodeID)
+		}
+		return kvs.Close()
+	}
+	t.outgoing[key] = struct{}{}
+	go func() {
+		defer kvs.Close()
+		nodeID, _ := proto.DecodeRaftNodeID(key.nodeID)
+		if err := t.streamSnapshot(nodeID, req, header, kvs); err != nil {
+			log.Warningf("failed to send snapshot of group %d to node %d: %s", key.groupID, nodeID, err)
+		}
+		t.mu.Lock()
+		delete(t.outgoing, key)
+		t.mu.Unlock()
+	}()
+	return nil
+}
+
+// streamSnapshot sends the snapshot message to the given node in
+// chunks, waiting for each chunk to be acknowledged before sending the
+// next and pacing them according to the transport's rate limit. The
+// first chunk carries the message with the header of the snapshot's
+// data; the chunks carry the encoded key/value pairs read from kvs,
+// which are only read as the chunks are sent. The snapshot is streamed
+// over a client of its own so that it does not hold up the raft
+// messages sent by processQueue.
+func (t *rpcTransport) streamSnapshot(nodeID proto.NodeID, req *multiraft.RaftMessageRequest,
+	header []byte, kvs io.Reader) error {
+	addr, err := t.gossip.GetNodeIDAddress(nodeID)
+	if err != nil {
+		return err
+	}
+	client := rpc.NewClient(addr, t.rpcContext)
+	select {
+	case <-t.rpcContext.Stopper.ShouldStop():
+		return nil
+	case <-client.Closed:
+		return util.Errorf("client was closed")
+	case <-time.After(raftIdleTimeout):
+		return util.Errorf("client stuck connecting")
+	case <-client.Healthy():
+	}
+
+	msg := req.Message
+	msg.Snapshot.Data = header
+	msgData, err := msg.Marshal()
+	if err != nil {
+		return err
+	}
+	snapshotID := uint64(rand.Int63())
+	buf := make([]byte, raftSnapshotChunkSize)
+	for seq := int32(0); ; seq++ {
+		chunk := &proto.RaftSnapshotChunkRequest{
+			GroupID:    req.GroupID,
+			SnapshotID: snapshotID,
+			Seq:        seq,
+		}
+		if seq == 0 {
+			chunk.Msg = msgData
+		}
+		n, err := io.ReadFull(kvs, buf)
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			chunk.Last = true
+		} else if err != nil {
+			return util.Errorf("unable to read snapshot data: %s", err)
+		}
+		chunk.Data = buf[:n]
+
+		select {
+		case <-t.rpcContext.Stopper.ShouldStop():
+			return nil
+		case <-time.After(t.snapshotLimiter.delay(n)):
+		}
+		call := client.Go(raftSnapshotChunkName, chunk, &proto.RaftSnapshotChunkResponse{}, nil)
+		select {
+		case <-t.rpcContext.Stopper.ShouldStop():
+			return nil
+		case <-client.Closed:
+			return util.Errorf("client was closed")
+		case <-call.Done:
+			if call.Error != nil {
+				return call.Error
+			}
+		}
+		if chunk.Last {
+			return nil
+		}
+	}
+}
+
+// RaftSnapshotChunk stages the incoming chunk of a snapshot to disk.
+// Once the final chunk of the snapshot has been received, the snapshot
+// message is reassembled and proxied to the listening server
+// interface. The staged key/value pairs are not read back into the
+// message; they are decoded from the staging file as the snapshot is
+// applied.
+func (t *rpcTransport) RaftSnapshotChunk(args gogoproto.Message) (gogoproto.Message, error) {
+	req, err := t.stageSnapshotChunk(args.(*proto.RaftSnapshotChunkRequest))
+	if err != nil || req == nil {
+		return &proto.RaftSnapshotChunkResponse{}, err
+	}
+
+	t.mu.Lock()
+	server, ok := t.servers[proto.RaftNodeID(req.Message.To)]
+	t.mu.Unlock()
+	if !ok {
+		err = util.Errorf("Unable to proxy snapshot to node: %d", req.Message.To)
+	} else {
+		err = server.RaftMessage(req, &multiraft.RaftMessageResponse{})
+	}
+	if err != nil {
+		// Discard the staged data, which won't be applied.
+		storage.DiscardSnapshotData(req.Message.Snapshot.Data)
+		return nil, err
+	}
+	return &proto.RaftSnapshotChunkResponse{}, nil
+}
+
+// stageSnapshotChunk appends the chunk's data to the staging file of
+// its stream, starting a new stream on the first chunk. It returns the
+// reassembled snapshot message after the final chunk, and nil
+// otherwise. Chunks must arrive in order; the stream is abandoned
+// otherwise. Only the stream's own lock is held while its data is
+// written, so that streams are staged concurrently.
+func (t *rpcTransport) stageSnapshotChunk(chunk *proto.RaftSnapshotChunkRequest) (
+	*multiraft.RaftMessageRequest, error) {
+	stream, err := t.lookupSnapshotStream(chunk)
+	if err != nil {
+		return nil, err
+	}
+	stream.mu.Lock()
+	defer stream.mu.Unlock()
+	if stream.file == nil {
+		return nil, util.Errorf("snapshot stream %d was abandoned", chunk.SnapshotID)
+	}
+	if chunk.Seq != stream.seq+1 {
+		t.removeSnapshotStream(chunk.SnapshotID)
+		stream.close()
+		return nil, util.Errorf("unexpected chunk %d of snapshot stream %d", chunk.Seq, chunk.SnapshotID)
+	}
+	stream.seq = chunk.Seq
+	atomic.StoreInt64(&stream.lastChunk, time.Now().UnixNano())
+
+	data := make([]byte, len(chunk.Data))
+	stream.encrypter.XORKeyStream(data, chunk.Data)
+	if _, err := stream.file.Write(data); err != nil {
+		t.removeSnapshotStream(chunk.SnapshotID)
+		stream.close()
+		return nil, util.Errorf("unable to stage snapshot data: %s", err)
+	}
+	if !chunk.Last {
+		return nil, nil
+	}
+
+	// Hand the staging file over to a snapshot stream registered with
+	// the storage package, from which the snapshot is applied.
+	t.removeSnapshotStream(chunk.SnapshotID)
+	if _, err := stream.file.Seek(0, os.SEEK_SET); err != nil {
+		stream.close()
+		return nil, err
+	}
+	staged := stagedSnapshotData{
+		StreamReader: cipher.StreamReader{S: cipher.NewCTR(stream.block, stream.iv), R: stream.file},
+		file:         stream.file,
+	}
+	stream.file = nil
+	req := &multiraft.RaftMessageRequest{GroupID: stream.groupID, Message: stream.msg}
+	if req.Message.Snapshot.Data, err = storage.NewSnapshotData(stream.msg.Snapshot.Data, staged); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// lookupSnapshotStream returns the stream to which the chunk belongs,
+// starting a new stream on the first chunk.
+func (t *rpcTransport) lookupSnapshotStream(chunk *proto.RaftSnapshotChunkRequest) (*snapshotStream, error) {
+	t.mu.Lock()
+	stream, ok := t.streams[chunk.SnapshotID]
+	var expired []*snapshotStream
+	if !ok && chunk.Seq == 0 {
+		expired = t.expireSnapshotStreamsLocked()
+	}
+	t.mu.Unlock()
+	for _, s := range expired {
+		s.mu.Lock()
+		s.close()
+		s.mu.Unlock()
+	}
+	if ok {
+		return stream, nil
+	}
+	if chunk.Seq != 0 {
+		return nil, util.Errorf("unexpected chunk %d of snapshot stream %d", chunk.Seq, chunk.SnapshotID)
+	}
+	stream, err := t.newSnapshotStream(chunk)
+	if err != nil {
+		return nil, err
+	}
+	t.mu.Lock()
+	t.streams[chunk.SnapshotID] = stream
+	t.mu.Unlock()
+	return stream, nil
+}
+
+// newSnapshotStream creates the staging file for the snapshot
+// announced by the first chunk of a stream.
+func (t *rpcTransport) newSnapshotStream(chunk *proto.RaftSnapshotChunkRequest) (*snapshotStream, error) {
+	stream := &snapshotStream{groupID: chunk.GroupID, seq: -1, lastChunk: time.Now().UnixNano()}
+	if err := stream.msg.Unmarshal(chunk.Msg); err != nil {
+		return nil, err
+	}
+	if stream.msg.Type != raftpb.MsgSnap {
+		return nil, util.Errorf("snapshot stream %d carries a %s message", chunk.SnapshotID, stream.msg.Type)
+	}
+	// Generate the key and IV encrypting the staged data.
+	secret := make([]byte, 16+aes.BlockSize)
+	if _, err := crand.Read(secret); err != nil {
+		return nil, err
+	}
+	var err error
+	if stream.block, err = aes.NewCipher(secret[:16]); err != nil {
+		return nil, err
+	}
+	stream.iv = secret[16:]
+	stream.encrypter = cipher.NewCTR(stream.block, stream.iv)
+	file, err := ioutil.TempFile(t.snapshotDir, raftSnapshotFilePrefix)
+	if err != nil {
+		return nil, util.Errorf("unable to create snapshot staging file: %s", err)
+	}
+	stream.file = file
+	return stream, nil
+}
+
+// removeSnapshotStream removes the stream from the transport's
+// streams. It is closed by the caller.
+func (t *rpcTransport) removeSnapshotStream(snapshotID uint64) {
+	t.mu.Lock()
+	delete(t.streams, snapshotID)
+	t.mu.Unlock()
+}
+
+// expireSnapshotStreamsLocked removes and returns the streams which
+// have not received a chunk within raftSnapshotStreamTimeout, such as
+// those whose sender has gone away. The caller must hold t.mu and
+// close the returned streams once it has released it.
+func (t *rpcTransport) expireSnapshotStreamsLocked() []*snapshotStream {
+	var expired []*snapshotStream
+	for snapshotID, stream := range t.streams {
+		lastChunk := time.Unix(0, atomic.LoadInt64(&stream.lastChunk))
+		if time.Since(lastChunk) > raftSnapshotStreamTimeout {
+			log.Warningf("abandoning snapshot stream %d of group %d: no data received since %s",
+				snapshotID, stream.groupID, lastChunk)
+			delete(t.streams, snapshotID)
+			expired = append(expired, stream)
+		}
+	}
+	return expired
+}
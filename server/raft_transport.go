@@ -19,6 +19,7 @@
 package server
 
 import (
+	"os"
 	"sync"
 	"time"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/rpc"
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/log"
+	"github.com/coreos/etcd/raft/raftpb"
 	gogoproto "github.com/gogo/protobuf/proto"
 
 	gorpc "net/rpc"
@@ -44,32 +46,54 @@ const (
 	raftIdleTimeout = time.Minute
 )
 
-// rpcTransport handles the rpc messages for multiraft.
+// rpcTransport handles the rpc messages for multiraft. Snapshots are
+// streamed separately from other messages; see raft_snapshot.go.
 type rpcTransport struct {
-	gossip     *gossip.Gossip
-	rpcServer  *rpc.Server
-	rpcContext *rpc.Context
-	mu         sync.Mutex
-	servers    map[proto.RaftNodeID]multiraft.ServerInterface
-	queues     map[proto.RaftNodeID]chan *multiraft.RaftMessageRequest
+	gossip          *gossip.Gossip
+	rpcServer       *rpc.Server
+	rpcContext      *rpc.Context
+	snapshotLimiter *rateLimiter
+	snapshotDir     string
+	mu              sync.Mutex
+	servers         map[proto.RaftNodeID]multiraft.ServerInterface
+	queues          map[proto.RaftNodeID]chan *multiraft.RaftMessageRequest
+	outgoing        map[snapshotKey]struct{}
+	streams         map[uint64]*snapshotStream // Each stream has a lock of its own
 }
 
 // newRPCTransport creates a new rpcTransport with specified gossip and rpc server.
-func newRPCTransport(gossip *gossip.Gossip, rpcServer *rpc.Server, rpcContext *rpc.Context) (
-	multiraft.Transport, error) {
+// Outgoing snapshots are sent at no more than snapshotRate bytes per second
+// (unlimited if zero) and incoming snapshots are staged in snapshotDir, which
+// defaults to the system's temporary directory if empty.
+func newRPCTransport(gossip *gossip.Gossip, rpcServer *rpc.Server, rpcContext *rpc.Context,
+	snapshotRate int64, snapshotDir string) (multiraft.Transport, error) {
+	if snapshotDir == "" {
+		snapshotDir = os.TempDir()
+	}
 	t := &rpcTransport{
-		gossip:     gossip,
-		rpcServer:  rpcServer,
-		rpcContext: rpcContext,
-		servers:    make(map[proto.RaftNodeID]multiraft.ServerInterface),
-		queues:     make(map[proto.RaftNodeID]chan *multiraft.RaftMessageRequest),
+		gossip:          gossip,
+		rpcServer:       rpcServer,
+		rpcContext:      rpcContext,
+		snapshotLimiter: &rateLimiter{rate: snapshotRate},
+		snapshotDir:     snapshotDir,
+		servers:         make(map[proto.RaftNodeID]multiraft.ServerInterface),
+		queues:          make(map[proto.RaftNodeID]chan *multiraft.RaftMessageRequest),
+		outgoing:        make(map[snapshotKey]struct{}),
+		streams:         make(map[uint64]*snapshotStream),
 	}
 
 	if t.rpcServer != nil {
+		if err := t.initSnapshotStaging(); err != nil {
+			return nil, err
+		}
 		if err := t.rpcServer.RegisterAsync(raftMessageName, t.RaftMessage,
 			&proto.RaftMessageRequest{}); err != nil {
 			return nil, err
 		}
+		if err := t.rpcServer.Register(raftSnapshotChunkName, t.RaftSnapshotChunk,
+			&proto.RaftSnapshotChunkRequest{}); err != nil {
+			return nil, err
+		}
 	}
 
 	return t, nil
@@ -204,6 +228,9 @@ func (t *rpcTransport) processQueue(raftNodeID proto.RaftNodeID) {
 
 // Send a message to the recipient specified in the request.
 func (t *rpcTransport) Send(req *multiraft.RaftMessageRequest) error {
+	if req.Message.Type == raftpb.MsgSnap {
+		return t.sendSnapshot(req)
+	}
 	raftNodeID := proto.RaftNodeID(req.Message.To)
 	t.mu.Lock()
 	ch, ok := t.queues[raftNodeID]
@@ -222,7 +249,17 @@ func (t *rpcTransport) Send(req *multiraft.RaftMessageRequest) error {
 	return nil
 }
 
-// Close shuts down an rpcTransport.
+// Close shuts down an rpcTransport, discarding the data of incoming
+// snapshots. Client connections are shared via the global cache and
+// are left open.
 func (t *rpcTransport) Close() {
-	// No-op since we share the global cache of client connections.
+	t.mu.Lock()
+	streams := t.streams
+	t.streams = make(map[uint64]*snapshotStream)
+	t.mu.Unlock()
+	for _, stream := range streams {
+		stream.mu.Lock()
+		stream.close()
+		stream.mu.Unlock()
+	}
 }
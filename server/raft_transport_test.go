@@ -18,7 +18,12 @@
 package server
 
 import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
 	"math/rand"
+	"os"
+	"reflect"
 	"testing"
 	"time"
 
@@ -26,11 +31,13 @@ import (
 	"github.com/cockroachdb/cockroach/multiraft"
 	"github.com/cockroachdb/cockroach/proto"
 	"github.com/cockroachdb/cockroach/rpc"
+	"github.com/cockroachdb/cockroach/storage"
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/hlc"
 	"github.com/cockroachdb/cockroach/util/leaktest"
 	"github.com/cockroachdb/cockroach/util/stop"
 	"github.com/coreos/etcd/raft/raftpb"
+	gogoproto "github.com/gogo/protobuf/proto"
 )
 
 type channelServer struct {
@@ -83,7 +90,7 @@ func TestSendAndReceive(t *testing.T) {
 		}
 		defer server.Close()
 
-		transport, err := newRPCTransport(g, server, nodeRPCContext)
+		transport, err := newRPCTransport(g, server, nodeRPCContext, 0, "")
 		if err != nil {
 			t.Fatalf("Unexpected error creating transport, Error: %s", err)
 		}
@@ -179,7 +186,7 @@ func TestInOrderDelivery(t *testing.T) {
 	const numMessages = 100
 	protoNodeID := proto.NodeID(1)
 	raftNodeID := proto.MakeRaftNodeID(protoNodeID, 1)
-	serverTransport, err := newRPCTransport(g, server, nodeRPCContext)
+	serverTransport, err := newRPCTransport(g, server, nodeRPCContext, 0, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -200,7 +207,7 @@ func TestInOrderDelivery(t *testing.T) {
 	}
 
 	clientNodeID := proto.MakeRaftNodeID(2, 2)
-	clientTransport, err := newRPCTransport(g, nil, nodeRPCContext)
+	clientTransport, err := newRPCTransport(g, nil, nodeRPCContext, 0, "")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -227,3 +234,148 @@ func TestInOrderDelivery(t *testing.T) {
 		}
 	}
 }
+
+// TestSendSnapshot verifies that snapshots are streamed in chunks and
+// delivered intact, with their key/value pairs read back from the
+// staging file on the receiving node.
+func TestSendSnapshot(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	stopper := stop.NewStopper()
+	defer stopper.Stop()
+	nodeRPCContext := rpc.NewContext(nodeTestBaseContext, hlc.NewClock(hlc.UnixNano), stopper)
+	g := gossip.New(nodeRPCContext, gossip.TestInterval, gossip.TestBootstrap)
+
+	server := rpc.NewServer(util.CreateTestAddr("tcp"), nodeRPCContext)
+	if err := server.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Close()
+
+	snapshotDir, err := ioutil.TempDir("", "test-snapshots")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(snapshotDir)
+
+	protoNodeID := proto.NodeID(1)
+	raftNodeID := proto.MakeRaftNodeID(protoNodeID, 1)
+	serverTransport, err := newRPCTransport(g, server, nodeRPCContext, 0, snapshotDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer serverTransport.Close()
+	serverChannel := newChannelServer(1, 0)
+	if err := serverTransport.Listen(raftNodeID, serverChannel); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddInfo(gossip.MakeNodeIDKey(protoNodeID),
+		&proto.NodeDescriptor{
+			Address: proto.Addr{
+				Network: server.Addr().Network(),
+				Address: server.Addr().String(),
+			},
+		},
+		time.Hour); err != nil {
+		t.Fatal(err)
+	}
+
+	clientNodeID := proto.MakeRaftNodeID(2, 2)
+	clientTransport, err := newRPCTransport(g, nil, nodeRPCContext, 64<<20, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clientTransport.Close()
+
+	// Send a snapshot spanning several chunks, the last of them partial.
+	snapData := proto.RaftSnapshotData{RangeDescriptor: proto.RangeDescriptor{RaftID: 1}}
+	for i := 0; i < 4; i++ {
+		value := make([]byte, raftSnapshotChunkSize+100)
+		for j := range value {
+			value[j] = byte(rand.Int())
+		}
+		snapData.KV = append(snapData.KV, &proto.RaftSnapshotData_KeyValue{
+			Key:   []byte(fmt.Sprintf("key%d", i)),
+			Value: value,
+		})
+	}
+	data, err := gogoproto.Marshal(&snapData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expHeader, expKVs, err := storage.OpenSnapshotData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expData, err := ioutil.ReadAll(expKVs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &multiraft.RaftMessageRequest{
+		GroupID: 1,
+		Message: raftpb.Message{
+			To:   uint64(raftNodeID),
+			From: uint64(clientNodeID),
+			Type: raftpb.MsgSnap,
+			Snapshot: raftpb.Snapshot{
+				Data:     data,
+				Metadata: raftpb.SnapshotMetadata{Index: 10, Term: 2},
+			},
+		},
+	}
+	if err := clientTransport.Send(req); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case resp := <-serverChannel.ch:
+		header, kvs, err := storage.OpenSnapshotData(resp.Message.Snapshot.Data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		respData, err := ioutil.ReadAll(kvs)
+		kvs.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(header, expHeader) || !bytes.Equal(respData, expData) {
+			t.Errorf("snapshot data was not delivered intact")
+		}
+		resp.Message.Snapshot.Data = req.Message.Snapshot.Data
+		if !reflect.DeepEqual(req, resp) {
+			t.Errorf("snapshot was not delivered intact: %+v", resp.Message.Snapshot.Metadata)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for snapshot")
+	}
+
+	// The staging file must have been removed.
+	if files, err := ioutil.ReadDir(snapshotDir); err != nil {
+		t.Fatal(err)
+	} else if len(files) != 0 {
+		t.Errorf("expected snapshot staging directory to be empty; found %d files", len(files))
+	}
+}
+
+// TestRateLimiter verifies that the delays returned by the rate
+// limiter space transmissions according to its rate.
+func TestRateLimiter(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	rl := &rateLimiter{rate: 1000}
+	if d := rl.delay(500); d != 0 {
+		t.Errorf("expected first transmission to be immediate; got %s", d)
+	}
+	// The first 500 bytes take half a second at 1000 bytes per second.
+	if d := rl.delay(500); d <= 400*time.Millisecond || d > 500*time.Millisecond {
+		t.Errorf("expected delay of about 500ms; got %s", d)
+	}
+	if d := rl.delay(100); d <= 900*time.Millisecond || d > time.Second {
+		t.Errorf("expected delay of about 1s; got %s", d)
+	}
+
+	unlimited := &rateLimiter{}
+	for i := 0; i < 3; i++ {
+		if d := unlimited.delay(1 << 30); d != 0 {
+			t.Errorf("expected no delay without a rate; got %s", d)
+		}
+	}
+}
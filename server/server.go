@@ -126,7 +126,7 @@ func NewServer(ctx *Context, stopper *stop.Stopper) (*Server, error) {
 		return nil, err
 	}
 
-	s.raftTransport, err = newRPCTransport(s.gossip, s.rpc, rpcContext)
+	s.raftTransport, err = newRPCTransport(s.gossip, s.rpc, rpcContext, ctx.SnapshotRate, ctx.SnapshotDir)
 	if err != nil {
 		return nil, err
 	}
@@ -151,6 +151,11 @@ func NewServer(ctx *Context, stopper *stop.Stopper) (*Server, error) {
 		ScanMaxIdleTime: s.ctx.ScanMaxIdleTime,
 		EventFeed:       feed,
 		Tracer:          tracer,
+
+		QuarantineInconsistentReplicas: s.ctx.QuarantineInconsistentReplicas,
+		ClosedTimestampInterval:        s.ctx.ClosedTimestampInterval,
+		ClosedTimestampLag:             s.ctx.ClosedTimestampLag,
+		BackpressureMaxRaftLogEntries:  s.ctx.BackpressureMaxRaftLogEntries,
 	}
 	s.node = NewNode(nCtx)
 	s.admin = newAdminServer(s.db, s.stopper)
@@ -180,7 +185,7 @@ func (s *Server) Start(selfBootstrap bool) error {
 	}
 	s.gossip.Start(s.rpc, s.stopper)
 
-	if err := s.node.start(s.rpc, s.ctx.Engines, s.ctx.NodeAttributes, s.stopper); err != nil {
+	if err := s.node.start(s.rpc, s.ctx.Engines, s.ctx.NodeAttributes, s.ctx.NodeLocality, s.stopper); err != nil {
 		return err
 	}
 
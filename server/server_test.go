@@ -136,6 +136,37 @@ func TestInitEngines(t *testing.T) {
 	}
 }
 
+// TestInitEncryptedEngines verifies that store keys are matched with
+// the stores they are specified for.
+func TestInitEncryptedEngines(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tmp := util.CreateNTempDirs(t, "_server_test", 2)
+	defer util.CleanupDirs(tmp)
+
+	testCases := []struct {
+		storeKeys, oldStoreKeys string
+		expErr                  bool
+	}{
+		{"", "", false},
+		{fmt.Sprintf("%s=key", tmp[0]), "", false},
+		{fmt.Sprintf("%s=key,%s=key2", tmp[0], tmp[1]), fmt.Sprintf("%s=old-key", tmp[1]), false},
+		{fmt.Sprintf("%s=key", tmp[0]), fmt.Sprintf("%s=old-key", tmp[1]), true},
+		{"/no/such/store=key", "", true},
+		{"1000=key", "", true},
+		{tmp[0], "", true},
+		{fmt.Sprintf("%s=key,%s=key2", tmp[0], tmp[0]), "", true},
+	}
+	for i, test := range testCases {
+		ctx := NewContext()
+		ctx.Stores = fmt.Sprintf("mem=1000,ssd=%s,hdd=%s", tmp[0], tmp[1])
+		ctx.StoreKeys = test.storeKeys
+		ctx.OldStoreKeys = test.oldStoreKeys
+		if err := ctx.Init("init"); (err != nil) != test.expErr {
+			t.Errorf("%d: expected error %t; got %v", i, test.expErr, err)
+		}
+	}
+}
+
 // TestSelfBootstrap verifies operation when no bootstrap hosts have
 // been specified.
 func TestSelfBootstrap(t *testing.T) {
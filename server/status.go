@@ -32,6 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/proto"
 	"github.com/cockroachdb/cockroach/server/status"
 	"github.com/cockroachdb/cockroach/storage"
+	"github.com/cockroachdb/cockroach/storage/engine"
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/log"
 	"github.com/julienschmidt/httprouter"
@@ -43,6 +44,9 @@ const (
 		the local nodes response.
 
 		/_status/details/:node_id		 - specific node's details
+		/_status/encryption/:node_id     - encryption keys of a specific
+										   node's stores and the files still
+										   encrypted with old keys
 		/_status/gossip/:node_id         - specific node's gossip
 		/_status/logfiles/:node_id       - list log files
 		/_status/logfiles/:node_id/:file - returns the contents of the specific
@@ -65,6 +69,10 @@ const (
 	// statusDetailsPattern exposes a node's details.
 	statusDetailsPattern = "/_status/details/:node_id"
 
+	// statusEncryptionPattern exposes the encryption status of a node's
+	// stores.
+	statusEncryptionPattern = "/_status/encryption/:node_id"
+
 	// statusLogFilesListPattern exposes a list of log files.
 	statusLogFilesListPattern = "/_status/logfiles/:node_id"
 	// statusLogFilePattern exposes a specific file on a node.
@@ -130,6 +138,7 @@ func newStatusServer(db *client.DB, gossip *gossip.Gossip, ctx *Context) *status
 
 	server.router.GET(statusGossipPattern, server.handleGossip)
 	server.router.GET(statusDetailsPattern, server.handleDetails)
+	server.router.GET(statusEncryptionPattern, server.handleEncryption)
 	server.router.GET(statusLogFilesListPattern, server.handleLogFilesList)
 	server.router.GET(statusLogFilePattern, server.handleLogFile)
 	server.router.GET(statusLogsPattern, server.handleLogs)
@@ -272,6 +281,53 @@ func (s *statusServer) handleDetails(w http.ResponseWriter, r *http.Request, ps
 	}
 }
 
+// handleEncryptionLocal handles local requests for the encryption
+// status of the node's stores. Stores which are not encrypted are
+// listed without status.
+func (s *statusServer) handleEncryptionLocal(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	type storeEncryption struct {
+		Store  string                   `json:"store"`
+		Status *engine.EncryptionStatus `json:"status,omitempty"`
+	}
+	stores := []storeEncryption{}
+	for _, e := range s.ctx.Engines {
+		store := storeEncryption{Store: fmt.Sprint(e)}
+		if rocksdb, ok := e.(*engine.RocksDB); ok {
+			var err error
+			if store.Status, err = rocksdb.EncryptionStatus(); err != nil {
+				log.Error(err)
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+		stores = append(stores, store)
+	}
+	b, contentType, err := util.MarshalResponse(r, stores, []util.EncodingType{util.JSONEncoding})
+	if err != nil {
+		log.Error(err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set(util.ContentTypeHeader, contentType)
+	w.Write(b)
+}
+
+// handleEncryption handles GET requests for the encryption status of
+// a node's stores.
+func (s *statusServer) handleEncryption(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	nodeID, local, err := s.extractNodeID(ps)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if local {
+		s.handleEncryptionLocal(w, r, ps)
+	} else {
+		s.proxyRequest(nodeID, w, r)
+	}
+}
+
 // handleLogFilesList handles local requests for a list of available log files.
 func (s *statusServer) handleLogFilesListLocal(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	log.Flush()
@@ -39,9 +39,10 @@ type StoreStatusMonitor struct {
 	startedAt int64
 
 	// replication counts.
-	leaderRangeCount     int32
-	replicatedRangeCount int32
-	availableRangeCount  int32
+	leaderRangeCount      int32
+	replicatedRangeCount  int32
+	availableRangeCount   int32
+	quarantinedRangeCount int32
 }
 
 // NodeStatusMonitor monitors the status of a server node. Status information
@@ -195,6 +196,7 @@ func (nsm *NodeStatusMonitor) OnReplicationStatus(event *storage.ReplicationStat
 	ssm.leaderRangeCount = event.LeaderRangeCount
 	ssm.replicatedRangeCount = event.ReplicatedRangeCount
 	ssm.availableRangeCount = event.AvailableRangeCount
+	ssm.quarantinedRangeCount = event.QuarantinedRangeCount
 }
 
 // OnStartNode receives StartNodeEvents from a node event subscription. This
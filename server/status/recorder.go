@@ -167,6 +167,7 @@ func (nsr *NodeStatusRecorder) GetStatusSummaries() (*NodeStatus, []storage.Stor
 		nodeStat.LeaderRangeCount += ssm.leaderRangeCount
 		nodeStat.ReplicatedRangeCount += ssm.replicatedRangeCount
 		nodeStat.AvailableRangeCount += ssm.availableRangeCount
+		nodeStat.QuarantinedRangeCount += ssm.quarantinedRangeCount
 
 		// Its difficult to guarantee that we have the store descriptor yet; we
 		// may not have processed a StoreStatusEvent yet for this store. Just
@@ -175,15 +176,16 @@ func (nsr *NodeStatusRecorder) GetStatusSummaries() (*NodeStatus, []storage.Stor
 			return
 		}
 		status := storage.StoreStatus{
-			Desc:                 *ssm.desc,
-			NodeID:               nsr.desc.NodeID,
-			UpdatedAt:            now,
-			StartedAt:            ssm.startedAt,
-			Stats:                ssm.stats,
-			RangeCount:           int32(ssm.rangeCount),
-			LeaderRangeCount:     ssm.leaderRangeCount,
-			ReplicatedRangeCount: ssm.replicatedRangeCount,
-			AvailableRangeCount:  ssm.availableRangeCount,
+			Desc:                  *ssm.desc,
+			NodeID:                nsr.desc.NodeID,
+			UpdatedAt:             now,
+			StartedAt:             ssm.startedAt,
+			Stats:                 ssm.stats,
+			RangeCount:            int32(ssm.rangeCount),
+			LeaderRangeCount:      ssm.leaderRangeCount,
+			ReplicatedRangeCount:  ssm.replicatedRangeCount,
+			AvailableRangeCount:   ssm.availableRangeCount,
+			QuarantinedRangeCount: ssm.quarantinedRangeCount,
 		}
 		storeStats = append(storeStats, status)
 	})
@@ -102,6 +102,8 @@ func TestStatusJson(t *testing.T) {
 }`, addr.Network(), addr.String(), regexp.QuoteMeta(runtime.Version()))
 	testCases = append(testCases, TestCase{"/_status/details/local", expectedResult})
 	testCases = append(testCases, TestCase{"/_status/details/1", expectedResult})
+	// The test server's in-memory store is not encrypted.
+	testCases = append(testCases, TestCase{"/_status/encryption/local", `\[\s*{\s*"store": "[^"]*"\s*}\s*\]`})
 
 	httpClient, err := testContext.GetHTTPClient()
 	if err != nil {
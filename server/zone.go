@@ -50,6 +50,20 @@ func validateZoneConfig(config gogoproto.Message) error {
 		return util.Errorf("RangeMinBytes %d is greater than or equal to RangeMaxBytes %d",
 			zConfig.RangeMinBytes, zConfig.RangeMaxBytes)
 	}
+	if zConfig.RangeMaxQPS < 0 {
+		return util.Errorf("RangeMaxQPS %d must not be negative", zConfig.RangeMaxQPS)
+	}
+	if gc := zConfig.GC; gc != nil {
+		if gc.MinVersions < 0 {
+			return util.Errorf("GC MinVersions %d must not be negative", gc.MinVersions)
+		}
+		if gc.MaxVersions < 0 {
+			return util.Errorf("GC MaxVersions %d must not be negative", gc.MaxVersions)
+		}
+		if gc.MaxVersions != 0 && gc.MaxVersions < gc.MinVersions {
+			return util.Errorf("GC MaxVersions %d is less than MinVersions %d", gc.MaxVersions, gc.MinVersions)
+		}
+	}
 	return nil
 }
 
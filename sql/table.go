@@ -21,7 +21,9 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/client"
 	"github.com/cockroachdb/cockroach/keys"
+	"github.com/cockroachdb/cockroach/proto"
 	"github.com/cockroachdb/cockroach/sql/parser"
 	"github.com/cockroachdb/cockroach/structured"
 	"github.com/cockroachdb/cockroach/util"
@@ -124,6 +126,36 @@ func encodeIndexKeyPrefix(tableID, indexID uint32) []byte {
 	return key
 }
 
+// IndexSpan is the key span holding the data of a table index.
+type IndexSpan struct {
+	Index      string
+	Start, End proto.Key
+}
+
+// TableIndexSpans returns the key spans of the indexes of the named
+// table, in the order in which the indexes were defined.
+func TableIndexSpans(db *client.DB, database, table string) ([]IndexSpan, error) {
+	p := planner{db: db}
+	desc, err := p.getTableDesc(parser.QualifiedName{database, table})
+	if err != nil {
+		return nil, err
+	}
+	return indexSpans(desc), nil
+}
+
+func indexSpans(desc *structured.TableDescriptor) []IndexSpan {
+	spans := make([]IndexSpan, 0, len(desc.Indexes))
+	for _, index := range desc.Indexes {
+		prefix := proto.Key(encodeIndexKeyPrefix(desc.ID, index.ID))
+		spans = append(spans, IndexSpan{
+			Index: index.Name,
+			Start: prefix,
+			End:   prefix.PrefixEnd(),
+		})
+	}
+	return spans
+}
+
 func encodeIndexKey(index structured.IndexDescriptor,
 	colMap map[uint32]int, row []parser.Datum, indexKey []byte) ([]byte, error) {
 	var key []byte
@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/proto"
 	"github.com/cockroachdb/cockroach/sql/parser"
 	"github.com/cockroachdb/cockroach/structured"
 	"github.com/cockroachdb/cockroach/util/leaktest"
@@ -175,3 +176,36 @@ func TestMakeTableDescIndexes(t *testing.T) {
 		}
 	}
 }
+
+func TestIndexSpans(t *testing.T) {
+	defer leaktest.AfterTest(t)
+
+	desc := &structured.TableDescriptor{
+		ID: 51,
+		Indexes: []structured.IndexDescriptor{
+			{Name: "primary", ID: 1},
+			{Name: "c", ID: 2},
+		},
+	}
+	spans := indexSpans(desc)
+	if len(spans) != len(desc.Indexes) {
+		t.Fatalf("expected %d spans, but got %d", len(desc.Indexes), len(spans))
+	}
+	for i, span := range spans {
+		if span.Index != desc.Indexes[i].Name {
+			t.Errorf("%d: expected index %q, but got %q", i, desc.Indexes[i].Name, span.Index)
+		}
+		if !span.Start.Less(span.End) {
+			t.Errorf("%d: empty span [%q,%q)", i, span.Start, span.End)
+		}
+		// Every key of the index lies within its span.
+		key := proto.Key(encodeIndexKeyPrefix(desc.ID, desc.Indexes[i].ID))
+		key = append(key, "\xff\xff"...)
+		if key.Less(span.Start) || !key.Less(span.End) {
+			t.Errorf("%d: key %q not in span [%q,%q)", i, key, span.Start, span.End)
+		}
+		if i > 0 && span.Start.Less(spans[i-1].End) {
+			t.Errorf("%d: span [%q,%q) overlaps the previous one", i, span.Start, span.End)
+		}
+	}
+}
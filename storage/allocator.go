@@ -79,14 +79,18 @@ func (sl *storeList) Add(s *proto.StoreDescriptor) {
 // The allocator listens for gossip updates from stores and updates
 // statistics for mean of fraction of bytes used and total range count.
 //
-// When choosing a new allocation target, three candidates from
-// available stores meeting a max fraction of bytes used threshold
-// (maxFractionUsedThreshold) are chosen at random and the least
-// loaded of the three is selected in order to bias loading towards a
-// more balanced cluster, while still spreading load over all
-// available servers. "Load" is defined according to fraction of bytes
-// used, if greater than minFractionUsedThreshold; otherwise it's
-// defined according to range count.
+// When choosing a new allocation target, the available stores are
+// first narrowed to those whose nodes share the fewest locality tiers
+// (e.g. region, datacenter, rack) with the nodes of the existing
+// replicas, so that replicas are spread over as many failure domains
+// as possible. Three candidates from the remaining stores meeting a
+// max fraction of bytes used threshold (maxFractionUsedThreshold) are
+// chosen at random and the least loaded of the three is selected in
+// order to bias loading towards a more balanced cluster, while still
+// spreading load over all available servers. "Load" is defined
+// according to fraction of bytes used, if greater than
+// minFractionUsedThreshold; otherwise it's defined according to range
+// count.
 //
 // When choosing a rebalance target, a random store is selected from
 // amongst the set of stores with fraction of bytes within
@@ -173,27 +177,12 @@ func (a *allocator) allocateTargetInternal(required proto.Attributes, existing [
 	// matching here is lenient, and tries to find a target by relaxing an
 	// attribute constraint, from last attribute to first.
 	for attrs := append([]string(nil), required.Attrs...); ; attrs = attrs[:len(attrs)-1] {
-		stores, sl := a.selectRandom(3, proto.Attributes{Attrs: attrs}, existing)
+		stores, sl := a.selectRandom(3, proto.Attributes{Attrs: attrs}, existing, filter)
 
 		// Choose the store with the least fraction of bytes used.
 		var leastStore *proto.StoreDescriptor
 		for _, s := range stores {
-			// Filter store descriptor.
-			if filter != nil && !filter(s, &sl.count, &sl.used) {
-				continue
-			}
-			if leastStore == nil {
-				leastStore = s
-				continue
-			}
-			// Use counts instead of capacities if the cluster has mean
-			// fraction used below a threshold level. This is primarily useful
-			// for balancing load evenly in nascent deployments.
-			if sl.used.mean < minFractionUsedThreshold {
-				if s.Capacity.RangeCount < leastStore.Capacity.RangeCount {
-					leastStore = s
-				}
-			} else if s.Capacity.FractionUsed() < leastStore.Capacity.FractionUsed() {
+			if leastStore == nil || lessLoaded(s, leastStore, &sl.used) {
 				leastStore = s
 			}
 		}
@@ -208,6 +197,160 @@ func (a *allocator) allocateTargetInternal(required proto.Attributes, existing [
 	}
 }
 
+// lessLoaded returns whether store s is less loaded than store o.
+// Range counts are used instead of capacities if the cluster has mean
+// fraction used below a threshold level. This is primarily useful for
+// balancing load evenly in nascent deployments.
+func lessLoaded(s, o *proto.StoreDescriptor, used *stat) bool {
+	if used.mean < minFractionUsedThreshold {
+		return s.Capacity.RangeCount < o.Capacity.RangeCount
+	}
+	return s.Capacity.FractionUsed() < o.Capacity.FractionUsed()
+}
+
+// RemoveTarget returns a suitable replica to remove from amongst the
+// candidates, a subset of the existing replicas of the range. To keep
+// replicas spread across failure domains, the candidate sharing the
+// most locality tiers with another existing replica is chosen, and
+// amongst those, the one on the most loaded store, measuring load as
+// in AllocateTarget(). Replicas on stores whose capacity is no longer
+// gossiped are preferred, as their stores are likely gone. The replica
+// on the leader's store is only chosen if there is no other candidate,
+// since the leader lease must be handed over before it is removed.
+func (a *allocator) RemoveTarget(candidates, existing []proto.Replica, leader proto.StoreID) (proto.Replica, error) {
+	a.Lock()
+	defer a.Unlock()
+	sl := a.getStoreList(proto.Attributes{})
+	localities := a.getLocalities(existing)
+
+	var worst *proto.Replica
+	var worstStore *proto.StoreDescriptor
+	worstShared := 0
+	for i := range candidates {
+		replica := &candidates[i]
+		if replica.StoreID == leader {
+			if len(candidates) == 1 {
+				return *replica, nil
+			}
+			continue
+		}
+		s, err := storeDescFromGossip(gossip.MakeCapacityKey(replica.NodeID, replica.StoreID), a.gossip)
+		if err != nil {
+			return *replica, nil
+		}
+		others := map[proto.StoreID]proto.Locality{}
+		for storeID, l := range localities {
+			if storeID != replica.StoreID {
+				others[storeID] = l
+			}
+		}
+		shared := maxSharedTiers(s.Node.Locality, others)
+		if worst == nil || shared > worstShared ||
+			(shared == worstShared && lessLoaded(worstStore, s, &sl.used)) {
+			worst, worstStore, worstShared = replica, s, shared
+		}
+	}
+	if worst == nil {
+		return proto.Replica{}, util.Errorf("unable to select a replica to remove; no candidates available")
+	}
+	return *worst, nil
+}
+
+// IsQuarantined returns whether the store of the replica reports the
+// replica of the range with the given Raft ID as quarantined in its
+// gossiped descriptor.
+func (a *allocator) IsQuarantined(replica proto.Replica, raftID proto.RaftID) bool {
+	s, err := storeDescFromGossip(gossip.MakeCapacityKey(replica.NodeID, replica.StoreID), a.gossip)
+	if err != nil {
+		return false
+	}
+	return containsRaftID(s.QuarantinedRaftIDs, raftID)
+}
+
+// A constraintMatch is the result of matching the replicas of a range
+// against the attribute constraints of its zone, each of which must be
+// satisfied by a separate replica.
+type constraintMatch struct {
+	// unmet holds the constraints which no replica is assigned to.
+	unmet []proto.Attributes
+	// constraints maps the store ID of each replica to the constraint
+	// it is assigned to. Replicas assigned to none are absent.
+	constraints map[proto.StoreID]proto.Attributes
+	// removable holds the replicas which can be removed without leaving
+	// any further constraint unmet.
+	removable []proto.Replica
+}
+
+// MatchConstraints assigns the existing replicas to the required
+// attribute constraints such that as many constraints as possible are
+// met by a replica on a store with matching attributes. Replicas on
+// stores whose capacity is no longer gossiped satisfy no constraint.
+func (a *allocator) MatchConstraints(required []proto.Attributes, existing []proto.Replica) constraintMatch {
+	// satisfies[i][j] is true if replica i satisfies constraint j.
+	satisfies := make([][]bool, len(existing))
+	for i, replica := range existing {
+		satisfies[i] = make([]bool, len(required))
+		s, err := storeDescFromGossip(gossip.MakeCapacityKey(replica.NodeID, replica.StoreID), a.gossip)
+		if err != nil {
+			continue
+		}
+		for j, attrs := range required {
+			satisfies[i][j] = attrs.IsSubset(*s.CombinedAttrs())
+		}
+	}
+
+	assigned, met := matchConstraints(satisfies, len(required), -1)
+	m := constraintMatch{constraints: map[proto.StoreID]proto.Attributes{}}
+	for j, i := range assigned {
+		if i < 0 {
+			m.unmet = append(m.unmet, required[j])
+		} else {
+			m.constraints[existing[i].StoreID] = required[j]
+		}
+	}
+	for i, replica := range existing {
+		if _, ok := m.constraints[replica.StoreID]; !ok {
+			m.removable = append(m.removable, replica)
+		} else if _, n := matchConstraints(satisfies, len(required), i); n == met {
+			// The other replicas can take over the constraint.
+			m.removable = append(m.removable, replica)
+		}
+	}
+	return m
+}
+
+// matchConstraints computes a maximum matching of replicas to
+// constraints by searching for augmenting paths. Replica skip, if not
+// negative, is left out. It returns the index of the replica assigned
+// to each constraint (or -1) and the number of constraints met.
+func matchConstraints(satisfies [][]bool, constraints, skip int) ([]int, int) {
+	assigned := make([]int, constraints)
+	for j := range assigned {
+		assigned[j] = -1
+	}
+	var augment func(i int, visited []bool) bool
+	augment = func(i int, visited []bool) bool {
+		for j := range assigned {
+			if !satisfies[i][j] || visited[j] {
+				continue
+			}
+			visited[j] = true
+			if assigned[j] < 0 || augment(assigned[j], visited) {
+				assigned[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	met := 0
+	for i := range satisfies {
+		if i != skip && augment(i, make([]bool, constraints)) {
+			met++
+		}
+	}
+	return assigned, met
+}
+
 // RebalanceTarget returns a suitable store for a rebalance target
 // with required attributes. Rebalance targets are selected via the
 // same mechanism as AllocateTarget(), except the chosen target must
@@ -259,24 +402,44 @@ func (a *allocator) ShouldRebalance(s *proto.StoreDescriptor) bool {
 }
 
 // selectRandom chooses count random store descriptors which match the
-// required attributes and do not include any of the existing
-// replicas. If the supplied filter is nil, it is ignored. Returns the
-// list of matching descriptors, and the store list matching the
-// required attributes.
-func (a *allocator) selectRandom(count int, required proto.Attributes, existing []proto.Replica) (
-	[]*proto.StoreDescriptor, *storeList) {
+// required attributes, pass the supplied filter and do not include any
+// of the existing replicas. If the filter is nil, it is ignored. To
+// spread replicas across failure domains, only the stores sharing the
+// fewest locality tiers with any existing replica are chosen from; the
+// filter is applied first, so that the full stores of a diverse
+// locality don't rule out the others. Returns the list of matching
+// descriptors, and the store list matching the required attributes.
+func (a *allocator) selectRandom(count int, required proto.Attributes, existing []proto.Replica,
+	filter func(*proto.StoreDescriptor, *stat, *stat) bool) ([]*proto.StoreDescriptor, *storeList) {
 	var descs []*proto.StoreDescriptor
 	sl := a.getStoreList(required)
 	used := getUsedNodes(existing)
+	localities := a.getLocalities(existing)
 
-	// Randomly permute available stores matching the required attributes.
-	for _, idx := range a.randGen.Perm(len(sl.stores)) {
-		// Skip used nodes.
-		if _, ok := used[sl.stores[idx].Node.NodeID]; ok {
+	// Narrow the available stores to the most diverse ones, skipping
+	// used nodes and filtered stores.
+	var candidates []*proto.StoreDescriptor
+	minShared := math.MaxInt32
+	for _, s := range sl.stores {
+		if _, ok := used[s.Node.NodeID]; ok {
 			continue
 		}
+		if filter != nil && !filter(s, &sl.count, &sl.used) {
+			continue
+		}
+		shared := maxSharedTiers(s.Node.Locality, localities)
+		if shared < minShared {
+			candidates, minShared = nil, shared
+		}
+		if shared == minShared {
+			candidates = append(candidates, s)
+		}
+	}
+
+	// Randomly permute the candidate stores.
+	for _, idx := range a.randGen.Perm(len(candidates)) {
 		// Add this store; exit loop if we've satisfied count.
-		descs = append(descs, sl.stores[idx])
+		descs = append(descs, candidates[idx])
 		if len(descs) >= count {
 			break
 		}
@@ -287,6 +450,33 @@ func (a *allocator) selectRandom(count int, required proto.Attributes, existing
 	return descs, sl
 }
 
+// getLocalities returns the localities of the nodes of the existing
+// replicas, keyed by store ID. Replicas on stores whose capacity is no
+// longer gossiped are left out.
+func (a *allocator) getLocalities(existing []proto.Replica) map[proto.StoreID]proto.Locality {
+	localities := map[proto.StoreID]proto.Locality{}
+	for _, replica := range existing {
+		s, err := storeDescFromGossip(gossip.MakeCapacityKey(replica.NodeID, replica.StoreID), a.gossip)
+		if err != nil {
+			continue
+		}
+		localities[replica.StoreID] = s.Node.Locality
+	}
+	return localities
+}
+
+// maxSharedTiers returns the largest number of leading locality tiers
+// which l has in common with any of the localities.
+func maxSharedTiers(l proto.Locality, localities map[proto.StoreID]proto.Locality) int {
+	max := 0
+	for _, o := range localities {
+		if shared := l.SharedTiers(o); shared > max {
+			max = shared
+		}
+	}
+	return max
+}
+
 // getStoreList returns a store list matching the required attributes.
 // Results are cached for performance.
 //
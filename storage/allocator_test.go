@@ -530,6 +530,230 @@ func TestAllocatorRebalanceByCount(t *testing.T) {
 	}
 }
 
+// TestAllocatorRemoveTarget verifies that the replica on the most
+// loaded store other than the leader's is chosen for removal, unless
+// the leader's is the only candidate, and that replicas on stores
+// which aren't gossiped are preferred.
+func TestAllocatorRemoveTarget(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	s, _, stopper := createTestStore(t)
+	defer stopper.Stop()
+
+	stores := []*proto.StoreDescriptor{
+		{
+			StoreID:  1,
+			Node:     proto.NodeDescriptor{NodeID: 1},
+			Capacity: proto.StoreCapacity{Capacity: 100, Available: 10},
+		},
+		{
+			StoreID:  2,
+			Node:     proto.NodeDescriptor{NodeID: 2},
+			Capacity: proto.StoreCapacity{Capacity: 100, Available: 40},
+		},
+		{
+			StoreID:  3,
+			Node:     proto.NodeDescriptor{NodeID: 3},
+			Capacity: proto.StoreCapacity{Capacity: 100, Available: 60},
+		},
+	}
+	gossipStores(s.Gossip(), stores, t)
+	existing := []proto.Replica{
+		{NodeID: 1, StoreID: 1},
+		{NodeID: 2, StoreID: 2},
+		{NodeID: 3, StoreID: 3},
+	}
+
+	// Store 1 is the most loaded, but holds the leader's replica.
+	result, err := s.allocator().RemoveTarget(existing, existing, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.StoreID != 2 {
+		t.Errorf("expected replica on store 2 to be removed; got %+v", result)
+	}
+	result, err = s.allocator().RemoveTarget(existing, existing, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.StoreID != 1 {
+		t.Errorf("expected replica on store 1 to be removed; got %+v", result)
+	}
+
+	// A replica on a store which isn't gossiped is removed first.
+	existing = append(existing, proto.Replica{NodeID: 4, StoreID: 4})
+	result, err = s.allocator().RemoveTarget(existing, existing, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.StoreID != 4 {
+		t.Errorf("expected replica on store 4 to be removed; got %+v", result)
+	}
+
+	// The leader's replica is chosen if it's the only candidate.
+	result, err = s.allocator().RemoveTarget(existing[:1], existing, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.StoreID != 1 {
+		t.Errorf("expected replica on store 1 to be removed; got %+v", result)
+	}
+}
+
+// TestAllocatorLocalityDiversity verifies that replicas are allocated
+// on, and removed from, stores so as to spread them across as many
+// locality tiers as possible, even at the expense of balance.
+func TestAllocatorLocalityDiversity(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	s, _, stopper := createTestStore(t)
+	defer stopper.Stop()
+
+	locality := func(s string) proto.Locality {
+		l, err := proto.ParseLocality(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return l
+	}
+	stores := []*proto.StoreDescriptor{
+		{
+			StoreID:  1,
+			Node:     proto.NodeDescriptor{NodeID: 1, Locality: locality("region=a,rack=1")},
+			Capacity: proto.StoreCapacity{Capacity: 100, Available: 60},
+		},
+		{
+			StoreID:  2,
+			Node:     proto.NodeDescriptor{NodeID: 2, Locality: locality("region=a,rack=2")},
+			Capacity: proto.StoreCapacity{Capacity: 100, Available: 90},
+		},
+		{
+			StoreID:  3,
+			Node:     proto.NodeDescriptor{NodeID: 3, Locality: locality("region=b,rack=1")},
+			Capacity: proto.StoreCapacity{Capacity: 100, Available: 10},
+		},
+		{
+			StoreID:  4,
+			Node:     proto.NodeDescriptor{NodeID: 4, Locality: locality("region=c")},
+			Capacity: proto.StoreCapacity{Capacity: 100, Available: 10},
+		},
+	}
+	gossipStores(s.Gossip(), stores, t)
+
+	// Store 2 is the least loaded, but in the same region as store 1.
+	for i := 0; i < 10; i++ {
+		result, err := s.allocator().AllocateTarget(proto.Attributes{}, []proto.Replica{
+			{NodeID: 1, StoreID: 1},
+		}, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.StoreID != 3 && result.StoreID != 4 {
+			t.Errorf("expected store 3 or 4 to be allocated; got %d", result.StoreID)
+		}
+	}
+	result, err := s.allocator().AllocateTarget(proto.Attributes{}, []proto.Replica{
+		{NodeID: 1, StoreID: 1},
+		{NodeID: 3, StoreID: 3},
+	}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.StoreID != 4 {
+		t.Errorf("expected store 4 to be allocated; got %d", result.StoreID)
+	}
+
+	// Store 3 is the most loaded, but the only one in region b.
+	existing := []proto.Replica{
+		{NodeID: 1, StoreID: 1},
+		{NodeID: 2, StoreID: 2},
+		{NodeID: 3, StoreID: 3},
+	}
+	replica, err := s.allocator().RemoveTarget(existing, existing, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replica.StoreID != 2 {
+		t.Errorf("expected replica on store 2 to be removed; got %+v", replica)
+	}
+
+	// Stores 3 and 4 are too full to rebalance to, so store 2 is chosen
+	// despite sharing a region with store 1.
+	for i := 0; i < 10; i++ {
+		result := s.allocator().RebalanceTarget(proto.Attributes{}, []proto.Replica{
+			{NodeID: 1, StoreID: 1},
+		})
+		if result == nil {
+			t.Fatal("nil result")
+		}
+		if result.StoreID != 2 {
+			t.Errorf("%d: expected store 2; got %d", i, result.StoreID)
+		}
+	}
+}
+
+func TestAllocatorMatchConstraints(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	s, _, stopper := createTestStore(t)
+	defer stopper.Stop()
+	gossipStores(s.Gossip(), sameDCStores, t)
+
+	storeIDs := func(replicas []proto.Replica) []proto.StoreID {
+		var ids []proto.StoreID
+		for _, r := range replicas {
+			ids = append(ids, r.StoreID)
+		}
+		return ids
+	}
+
+	testCases := []struct {
+		existing  []proto.Replica
+		unmet     []proto.Attributes
+		removable []proto.StoreID
+	}{
+		// One replica satisfies each constraint.
+		{
+			existing: []proto.Replica{
+				{NodeID: 1, StoreID: 1},
+				{NodeID: 2, StoreID: 3},
+				{NodeID: 4, StoreID: 5},
+			},
+		},
+		// Both ssd replicas can satisfy the ssd constraint; nothing
+		// satisfies the mem constraint.
+		{
+			existing: []proto.Replica{
+				{NodeID: 1, StoreID: 1},
+				{NodeID: 2, StoreID: 2},
+				{NodeID: 2, StoreID: 3},
+			},
+			unmet:     multiDisksConfig.ReplicaAttrs[2:],
+			removable: []proto.StoreID{1, 2},
+		},
+		// A replica on a store which isn't gossiped satisfies nothing.
+		{
+			existing: []proto.Replica{
+				{NodeID: 1, StoreID: 1},
+				{NodeID: 2, StoreID: 3},
+				{NodeID: 3, StoreID: 4},
+				{NodeID: 4, StoreID: 5},
+				{NodeID: 5, StoreID: 6},
+			},
+			removable: []proto.StoreID{3, 4, 6},
+		},
+	}
+	for i, test := range testCases {
+		m := s.allocator().MatchConstraints(multiDisksConfig.ReplicaAttrs, test.existing)
+		if !reflect.DeepEqual(m.unmet, test.unmet) {
+			t.Errorf("%d: expected unmet constraints %v; got %v", i, test.unmet, m.unmet)
+		}
+		if ids := storeIDs(m.removable); !reflect.DeepEqual(ids, test.removable) {
+			t.Errorf("%d: expected removable replicas on stores %v; got %v", i, test.removable, ids)
+		}
+		if len(m.constraints)+len(m.unmet) != len(multiDisksConfig.ReplicaAttrs) {
+			t.Errorf("%d: expected each constraint to be met or unmet; got %+v", i, m)
+		}
+	}
+}
+
 func TestAllocatorCapacityGossipUpdate(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	s, _, stopper := createTestStore(t)
@@ -0,0 +1,285 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage
+
+import (
+	"sort"
+
+	"github.com/cockroachdb/cockroach/keys"
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/storage/engine"
+	gogoproto "github.com/gogo/protobuf/proto"
+)
+
+// A range's change log records the span of user keys written by each
+// applied command, keyed by the timestamp of the writes, so that the
+// writes since a timestamp are found without scanning the range's
+// history. Entries are written in the same batch as the writes they
+// record, and so are replicated along with them. Like the response
+// cache, the change log isn't accounted for in the range's MVCC
+// stats.
+//
+// The log is complete above its start timestamp: it doesn't cover
+// the data written at bootstrap, and entries older than the GC TTL of
+// the range's zone are discarded along with the versions they refer
+// to.
+
+// logChanges appends the writes of a successfully executed command to
+// the range's change log. Commands which don't write versioned user
+// data are ignored.
+func logChanges(batch engine.Engine, raftID proto.RaftID, args proto.Request, reply proto.Response) error {
+	header := args.Header()
+	ts := header.Timestamp
+	intent := header.Txn != nil
+	var entries []proto.ChangeLogEntry
+	switch args.(type) {
+	case *proto.PutRequest, *proto.ConditionalPutRequest, *proto.IncrementRequest, *proto.DeleteRequest:
+		entries = append(entries, proto.ChangeLogEntry{Key: header.Key, EndKey: header.Key.Next()})
+	case *proto.DeleteRangeRequest:
+		if reply.(*proto.DeleteRangeResponse).NumDeleted == 0 {
+			return nil
+		}
+		entries = append(entries, proto.ChangeLogEntry{Key: header.Key, EndKey: header.EndKey})
+	case *proto.ImportRequest:
+		if reply.(*proto.ImportResponse).NumImported == 0 {
+			return nil
+		}
+		entries = append(entries, proto.ChangeLogEntry{Key: header.Key, EndKey: header.EndKey})
+	case *proto.InternalResolveIntentRequest, *proto.InternalResolveIntentRangeRequest:
+		// A committed intent becomes a version at the commit timestamp;
+		// a pushed one moves to the pushed timestamp.
+		if header.Txn == nil {
+			return nil
+		}
+		switch header.Txn.Status {
+		case proto.COMMITTED:
+			intent = false
+		case proto.PENDING:
+			intent = true
+		default:
+			return nil
+		}
+		ts = header.Txn.Timestamp
+		endKey := header.EndKey
+		if len(endKey) == 0 {
+			endKey = header.Key.Next()
+		}
+		entries = append(entries, proto.ChangeLogEntry{Key: header.Key, EndKey: endKey})
+	case *proto.EndTransactionRequest:
+		txn := reply.(*proto.EndTransactionResponse).Txn
+		if txn == nil || txn.Status != proto.COMMITTED {
+			return nil
+		}
+		ts, intent = txn.Timestamp, false
+		for _, key := range reply.(*proto.EndTransactionResponse).Resolved {
+			entries = append(entries, proto.ChangeLogEntry{Key: key, EndKey: key.Next()})
+		}
+	default:
+		return nil
+	}
+
+	// Local keys aren't part of change feeds.
+	logged := entries[:0]
+	for _, entry := range entries {
+		if clipChangeLogEntry(&entry, keys.LocalMax, proto.KeyMax) {
+			entry.Intent = intent
+			logged = append(logged, entry)
+		}
+	}
+	return appendChangeLog(batch, raftID, ts, logged)
+}
+
+// appendChangeLog appends entries at timestamp ts to the range's
+// change log. Their sequence numbers follow that of the last entry at
+// the same timestamp, which is derived from the log contents so that
+// all replicas agree on it.
+func appendChangeLog(batch engine.Engine, raftID proto.RaftID, ts proto.Timestamp, entries []proto.ChangeLogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	var seq uint64
+	if err := iterateChangeLog(batch, raftID, keys.RangeChangeLogKey(raftID, ts, 0), keys.RangeChangeLogKey(raftID, ts.Next(), 0),
+		func(_ proto.Timestamp, lastSeq uint64, _ proto.ChangeLogEntry) error {
+			seq = lastSeq + 1
+			return nil
+		}); err != nil {
+		return err
+	}
+	for i := range entries {
+		if err := engine.MVCCPutProto(batch, nil, keys.RangeChangeLogKey(raftID, ts, seq), proto.ZeroTimestamp, nil, &entries[i]); err != nil {
+			return err
+		}
+		seq++
+	}
+	return nil
+}
+
+// iterateChangeLog calls f with the timestamp, sequence number and
+// contents of each entry of the range's change log between the
+// supplied keys, in order.
+func iterateChangeLog(e engine.Engine, raftID proto.RaftID, start, end proto.Key,
+	f func(proto.Timestamp, uint64, proto.ChangeLogEntry) error) error {
+	prefixLen := len(keys.RangeChangeLogPrefix(raftID))
+	var entry proto.ChangeLogEntry
+	_, err := engine.MVCCIterate(e, start, end, proto.ZeroTimestamp, true /* consistent */, nil /* txn */, func(kv proto.KeyValue) (bool, error) {
+		entry.Reset()
+		if err := gogoproto.Unmarshal(kv.Value.GetBytes(), &entry); err != nil {
+			return false, err
+		}
+		ts, seq := keys.DecodeQueueDetail(kv.Key[prefixLen:])
+		return false, f(ts, seq, entry)
+	})
+	return err
+}
+
+// clipChangeLogEntry restricts the span of entry to the span from key
+// to endKey, returning false if they don't overlap.
+func clipChangeLogEntry(entry *proto.ChangeLogEntry, key, endKey proto.Key) bool {
+	if entry.Key.Less(key) {
+		entry.Key = key
+	}
+	if endKey.Less(entry.EndKey) {
+		entry.EndKey = endKey
+	}
+	return entry.Key.Less(entry.EndKey)
+}
+
+// changeLogStart returns the timestamp above which the range's change
+// log is complete.
+func changeLogStart(e engine.Engine, raftID proto.RaftID) (proto.Timestamp, error) {
+	var start proto.Timestamp
+	_, err := engine.MVCCGetProto(e, keys.RangeChangeLogStartKey(raftID), proto.ZeroTimestamp, true, nil, &start)
+	return start, err
+}
+
+// forwardChangeLogStart raises the start timestamp of the range's
+// change log to start.
+func forwardChangeLogStart(batch engine.Engine, raftID proto.RaftID, start proto.Timestamp) error {
+	cur, err := changeLogStart(batch, raftID)
+	if err != nil || !cur.Less(start) {
+		return err
+	}
+	return engine.MVCCPutProto(batch, nil, keys.RangeChangeLogStartKey(raftID), proto.ZeroTimestamp, nil, &start)
+}
+
+// copyChangeLog appends the entries of the change log of range fromID
+// which overlap the span from key to endKey to that of range toID, as
+// when a range splits or merges. The destination log is only complete
+// above the start timestamps of both logs.
+func copyChangeLog(batch engine.Engine, fromID, toID proto.RaftID, key, endKey proto.Key) error {
+	prefix := keys.RangeChangeLogPrefix(fromID)
+	if err := iterateChangeLog(batch, fromID, prefix, prefix.PrefixEnd(),
+		func(ts proto.Timestamp, _ uint64, entry proto.ChangeLogEntry) error {
+			if !clipChangeLogEntry(&entry, key, endKey) {
+				return nil
+			}
+			return appendChangeLog(batch, toID, ts, []proto.ChangeLogEntry{entry})
+		}); err != nil {
+		return err
+	}
+	start, err := changeLogStart(batch, fromID)
+	if err != nil {
+		return err
+	}
+	return forwardChangeLogStart(batch, toID, start)
+}
+
+// truncateChangeLog discards the entries of the range's change log
+// below threshold.
+func truncateChangeLog(batch engine.Engine, raftID proto.RaftID, threshold proto.Timestamp) error {
+	start := engine.MVCCEncodeKey(keys.RangeChangeLogPrefix(raftID))
+	end := engine.MVCCEncodeKey(keys.RangeChangeLogKey(raftID, threshold, 0))
+	if err := batch.Iterate(start, end, func(kv proto.RawKeyValue) (bool, error) {
+		return false, batch.Clear(kv.Key)
+	}); err != nil {
+		return err
+	}
+	return forwardChangeLogStart(batch, raftID, threshold.Prev())
+}
+
+// hasChangeLogBefore returns true if the range's change log holds
+// entries below threshold.
+func hasChangeLogBefore(e engine.Engine, raftID proto.RaftID, threshold proto.Timestamp) (bool, error) {
+	var found bool
+	err := e.Iterate(engine.MVCCEncodeKey(keys.RangeChangeLogPrefix(raftID)), engine.MVCCEncodeKey(keys.RangeChangeLogKey(raftID, threshold, 0)),
+		func(proto.RawKeyValue) (bool, error) {
+			found = true
+			return true, nil
+		})
+	return found, err
+}
+
+// readChanges returns the versions written to the key range from key
+// to endKey with timestamps in the interval (startTS, endTS], as
+// found through the range's change log. Only the spans logged in the
+// interval are read. The resolved timestamp and intents are returned
+// as for engine.MVCCChanges: since writes can't be made below the
+// closed timestamp other than by resolving an intent, an intent which
+// holds back the resolved timestamp was logged above startTS.
+func readChanges(e engine.Engine, raftID proto.RaftID, key, endKey proto.Key, startTS, endTS proto.Timestamp) ([]proto.ChangeEvent, proto.Timestamp, []proto.Intent, error) {
+	var events []proto.ChangeEvent
+	var intents []proto.Intent
+	resolved := endTS
+	if err := iterateChangeLog(e, raftID, keys.RangeChangeLogKey(raftID, startTS.Next(), 0), keys.RangeChangeLogKey(raftID, endTS.Next(), 0),
+		func(ts proto.Timestamp, _ uint64, entry proto.ChangeLogEntry) error {
+			if !clipChangeLogEntry(&entry, key, endKey) {
+				return nil
+			}
+			if !entry.Intent {
+				versions, _, _, err := engine.MVCCChanges(e, entry.Key, entry.EndKey, ts.Prev(), ts)
+				events = append(events, versions...)
+				return err
+			}
+			// The intents may since have been resolved or pushed; only
+			// those still pending at or below the resolved timestamp
+			// hold it back.
+			_, r, pending, err := engine.MVCCChanges(e, entry.Key, entry.EndKey, startTS, resolved)
+			resolved = r
+			intents = append(intents, pending...)
+			return err
+		}); err != nil {
+		return nil, proto.ZeroTimestamp, nil, err
+	}
+
+	// Order the events by key and timestamp, dropping those above the
+	// resolved timestamp and those logged more than once.
+	sort.Sort(changeEvents(events))
+	filtered := events[:0]
+	for _, event := range events {
+		if resolved.Less(*event.Value.Timestamp) {
+			continue
+		}
+		if n := len(filtered); n > 0 && filtered[n-1].Key.Equal(event.Key) &&
+			filtered[n-1].Value.Timestamp.Equal(*event.Value.Timestamp) {
+			continue
+		}
+		filtered = append(filtered, event)
+	}
+	return filtered, resolved, intents, nil
+}
+
+// changeEvents implements sort.Interface, ordering events by key and,
+// for each key, by timestamp.
+type changeEvents []proto.ChangeEvent
+
+func (ce changeEvents) Len() int      { return len(ce) }
+func (ce changeEvents) Swap(i, j int) { ce[i], ce[j] = ce[j], ce[i] }
+func (ce changeEvents) Less(i, j int) bool {
+	if !ce[i].Key.Equal(ce[j].Key) {
+		return ce[i].Key.Less(ce[j].Key)
+	}
+	return ce[i].Value.Timestamp.Less(*ce[j].Value.Timestamp)
+}
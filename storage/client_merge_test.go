@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"reflect"
 	"testing"
+	"time"
 
 	"golang.org/x/net/context"
 
@@ -29,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/storage"
 	"github.com/cockroachdb/cockroach/storage/engine"
 	"github.com/cockroachdb/cockroach/testutils"
+	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/leaktest"
 	"github.com/cockroachdb/cockroach/util/log"
 )
@@ -268,3 +270,25 @@ func TestStoreRangeMergeNonConsecutive(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// TestStoreRangeMergeQueue verifies that the merge queue merges a
+// range below the min size for its zone into its right neighbor.
+func TestStoreRangeMergeQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	store, stopper := createTestStore(t)
+	defer stopper.Stop()
+
+	if _, _, err := createSplitRanges(store); err != nil {
+		t.Fatal(err)
+	}
+
+	store.ForceMergeScan(t)
+	util.SucceedsWithin(t, 5*time.Second, func() error {
+		rangeA := store.LookupRange([]byte("a"), nil)
+		rangeB := store.LookupRange([]byte("c"), nil)
+		if rangeA != rangeB {
+			return util.Errorf("ranges were not merged %+v != %+v", rangeA.Desc(), rangeB.Desc())
+		}
+		return nil
+	})
+}
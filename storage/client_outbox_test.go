@@ -0,0 +1,78 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/client"
+	"github.com/cockroachdb/cockroach/keys"
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/leaktest"
+)
+
+// TestOutboxQueueExecuteUpdate verifies that the outbox queue executes
+// an update once the transaction which enqueued it has committed, and
+// removes the update from the outbox.
+func TestOutboxQueueExecuteUpdate(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	store, stopper := createTestStore(t)
+	defer stopper.Stop()
+
+	key := proto.Key("a")
+	outbox := keys.OutboxPrefix(key)
+	if err := store.DB().Txn(func(txn *client.Txn) error {
+		b := txn.NewBatch()
+		b.EnqueueUpdate(&proto.PutRequest{
+			RequestHeader: proto.RequestHeader{Key: key},
+			Value:         proto.Value{Bytes: []byte("value")},
+		})
+		return txn.Commit(b)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := store.DB().Scan(outbox, outbox.PrefixEnd(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected one enqueued update; got %d", len(rows))
+	}
+
+	util.SucceedsWithin(t, time.Second, func() error {
+		store.ForceOutboxScan(t)
+		kv, err := store.DB().Get(key)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(kv.ValueBytes(), []byte("value")) {
+			return util.Errorf("expected enqueued update of %q to be executed", key)
+		}
+		return nil
+	})
+	// The update is removed from the outbox by the transaction which
+	// executes it.
+	rows, err = store.DB().Scan(outbox, outbox.PrefixEnd(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("expected the outbox of %q to be empty; got %d entries", key, len(rows))
+	}
+}
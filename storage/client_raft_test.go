@@ -19,6 +19,8 @@ package storage_test
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
 	"reflect"
 	"strings"
 	"sync"
@@ -39,6 +41,7 @@ import (
 	"github.com/cockroachdb/cockroach/util/log"
 	"github.com/cockroachdb/cockroach/util/stop"
 	"github.com/coreos/etcd/raft"
+	"github.com/coreos/etcd/raft/raftpb"
 )
 
 // mustGetInteger decodes an int64 value from the bytes field of the receiver
@@ -748,6 +751,19 @@ func TestReplicateAfterSplit(t *testing.T) {
 	}
 }
 
+// releaseSnapshot reads the data of the snapshot from the stream it
+// was registered with, releasing the stream.
+func releaseSnapshot(t *testing.T, snap raftpb.Snapshot) {
+	_, kvs, err := storage.OpenSnapshotData(snap.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer kvs.Close()
+	if _, err := io.Copy(ioutil.Discard, kvs); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestRangeDescriptorSnapshotRace calls Snapshot() repeatedly while
 // transactions are performed on the range descriptor.
 func TestRangeDescriptorSnapshotRace(t *testing.T) {
@@ -769,19 +785,21 @@ func TestRangeDescriptorSnapshotRace(t *testing.T) {
 				if rng == nil {
 					t.Fatal("failed to look up min range")
 				}
-				_, err := rng.Snapshot()
+				snap, err := rng.Snapshot()
 				if err != nil {
 					t.Fatalf("failed to snapshot min range: %s", err)
 				}
+				releaseSnapshot(t, snap)
 
 				rng = mtc.stores[0].LookupRange(proto.Key("Z"), nil)
 				if rng == nil {
 					t.Fatal("failed to look up max range")
 				}
-				_, err = rng.Snapshot()
+				snap, err = rng.Snapshot()
 				if err != nil {
 					t.Fatalf("failed to snapshot max range: %s", err)
 				}
+				releaseSnapshot(t, snap)
 			}
 		}
 	})
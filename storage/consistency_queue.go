@@ -0,0 +1,340 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/cockroachdb/cockroach/keys"
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/storage/engine"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/encoding"
+	"github.com/cockroachdb/cockroach/util/log"
+	"github.com/cockroachdb/cockroach/util/uuid"
+)
+
+const (
+	// consistencyQueueMaxSize is the max size of the consistency queue.
+	consistencyQueueMaxSize = 100
+	// consistencyCheckInterval is the target duration between
+	// successive cross-replica consistency checks of a range.
+	consistencyCheckInterval = 24 * time.Hour
+	// consistencyCheckSpanKeys is the approximate number of keys in each
+	// span of range data covered by a single checksum.
+	consistencyCheckSpanKeys = 10000
+	// consistencyReportTimeout is the duration for which the range
+	// leader waits for all replicas to report their checksums.
+	consistencyReportTimeout = time.Minute
+	// replicaChecksumGCInterval is the age after which checksums which
+	// were never verified are discarded.
+	replicaChecksumGCInterval = time.Hour
+)
+
+// consistencyQueue periodically checks that all replicas of a range
+// hold identical data. The range leader proposes an
+// InternalComputeChecksum command through raft, so that every replica
+// checksums its range data at the same applied index, and reports its
+// checksums back through raft. The leader then holds a vote: the
+// checksums of each span agreed upon by a majority of the replicas are
+// deemed correct, and the replicas which disagree with them are named
+// in an InternalVerifyChecksum command, upon whose application they
+// report the key spans which differ. Only those replicas may be
+// quarantined; a replica can't tell on its own whether it or the
+// leader diverges.
+type consistencyQueue struct {
+	countFn rangeCountFn
+	*baseQueue
+}
+
+// newConsistencyQueue returns a new instance of consistencyQueue.
+func newConsistencyQueue(countFn rangeCountFn) *consistencyQueue {
+	cq := &consistencyQueue{countFn: countFn}
+	cq.baseQueue = newBaseQueue("consistency", cq, consistencyQueueMaxSize)
+	return cq
+}
+
+func (cq *consistencyQueue) needsLeaderLease() bool {
+	return true
+}
+
+// shouldQueue determines whether a range should be queued for a
+// consistency check, and if so, at what priority. Returns true for
+// shouldQ in the event that it's been longer since the last check than
+// the consistency check interval.
+func (cq *consistencyQueue) shouldQueue(now proto.Timestamp, rng *Range) (shouldQ bool, priority float64) {
+	if rng.isQuarantined() {
+		return
+	}
+	lastCheck, err := rng.GetLastConsistencyCheckTimestamp()
+	if err != nil {
+		log.Errorf("unable to fetch last consistency check timestamp: %s", err)
+		return
+	}
+	checkScore := float64(now.WallTime-lastCheck.WallTime) / float64(consistencyCheckInterval.Nanoseconds())
+	if checkScore > 1 {
+		priority = checkScore
+		shouldQ = true
+	}
+	return
+}
+
+// process has all replicas of the range compute checksums of their
+// range data, and those outvoted by the others verify theirs against
+// the majority's.
+func (cq *consistencyQueue) process(now proto.Timestamp, rng *Range) error {
+	desc := rng.Desc()
+	snap := rng.rm.NewSnapshot()
+	boundaries, err := consistencyBoundaries(snap, desc, consistencyCheckSpanKeys)
+	snap.Close()
+	if err != nil {
+		return err
+	}
+
+	checksumID := []byte(uuid.NewUUID4())
+	computeArgs := &proto.InternalComputeChecksumRequest{
+		RequestHeader: proto.RequestHeader{
+			Key:       desc.StartKey,
+			Timestamp: now,
+			RaftID:    desc.RaftID,
+		},
+		ChecksumID: checksumID,
+		Boundaries: boundaries,
+	}
+	if _, err := rng.AddCmd(rng.context(), computeArgs); err != nil {
+		return err
+	}
+
+	// The command has been applied locally, so the reports of the
+	// replicas are being collected.
+	reports, err := rng.getChecksumReports(checksumID, consistencyReportTimeout)
+	if err != nil {
+		return err
+	}
+	checksums, minority, ok := voteChecksums(desc.Replicas, reports)
+	if !ok {
+		return util.Errorf("%s: no majority of replicas agrees on the checksums of consistency check %x", rng, checksumID)
+	}
+	for _, replica := range minority {
+		log.Warningf("%s: replica on store %d disagrees with the majority in consistency check %x",
+			rng, replica.StoreID, checksumID)
+	}
+	verifyArgs := &proto.InternalVerifyChecksumRequest{
+		RequestHeader: proto.RequestHeader{
+			Key:       desc.StartKey,
+			Timestamp: now,
+			RaftID:    desc.RaftID,
+		},
+		ChecksumID: checksumID,
+		Checksums:  checksums,
+		Minority:   minority,
+	}
+	if _, err := rng.AddCmd(rng.context(), verifyArgs); err != nil {
+		return err
+	}
+
+	// Store current timestamp as last consistency check for this range.
+	return rng.SetLastConsistencyCheckTimestamp(now)
+}
+
+// timer returns the duration of intervals between successive range
+// consistency checks. The durations are sized so that the full
+// complement of ranges can be checked within consistencyCheckInterval.
+func (cq *consistencyQueue) timer() time.Duration {
+	return time.Duration(consistencyCheckInterval.Nanoseconds() / int64((cq.countFn() + 1)))
+}
+
+// A replicaChecksum holds the checksums computed by a replica for a
+// single consistency check, along with those reported by all replicas.
+type replicaChecksum struct {
+	// Closed once checksums or err have been set.
+	computed  chan struct{}
+	spans     []keyRange
+	checksums [][]byte
+	err       error
+	// The checksums reported by each replica, keyed by store ID and
+	// guarded by the range's mutex. A value is sent on reported,
+	// without blocking, whenever a report is recorded.
+	reports   map[proto.StoreID][][]byte
+	reported  chan struct{}
+	createdAt time.Time
+}
+
+// voteChecksums returns the checksums of each span agreed upon by a
+// strict majority of the given replicas, along with the replicas whose
+// reported checksums differ from them. Replicas which didn't report
+// their checksums are in neither camp. Returns false if there's a span
+// whose checksums no majority agrees upon.
+func voteChecksums(replicas []proto.Replica, reports map[proto.StoreID][][]byte) ([][]byte, []proto.Replica, bool) {
+	// All replicas split their data into the same spans, so a report
+	// with a differing number of checksums can't be part of the
+	// majority.
+	counts := map[int]int{}
+	for _, checksums := range reports {
+		counts[len(checksums)]++
+	}
+	numSpans := -1
+	for n, count := range counts {
+		if count*2 > len(replicas) {
+			numSpans = n
+		}
+	}
+	if numSpans < 0 {
+		return nil, nil, false
+	}
+
+	majority := make([][]byte, numSpans)
+	for i := range majority {
+		votes := map[string]int{}
+		for _, checksums := range reports {
+			if len(checksums) == numSpans {
+				votes[string(checksums[i])]++
+			}
+		}
+		for checksum, count := range votes {
+			if count*2 > len(replicas) {
+				majority[i] = []byte(checksum)
+			}
+		}
+		if majority[i] == nil {
+			return nil, nil, false
+		}
+	}
+
+	var minority []proto.Replica
+	for _, replica := range replicas {
+		checksums, ok := reports[replica.StoreID]
+		if !ok {
+			continue
+		}
+		if len(checksums) != numSpans {
+			minority = append(minority, replica)
+			continue
+		}
+		for i, checksum := range checksums {
+			if !bytes.Equal(checksum, majority[i]) {
+				minority = append(minority, replica)
+				break
+			}
+		}
+	}
+	return majority, minority, true
+}
+
+// consistencyBoundaries returns the keys at which the range's user
+// data is split into spans of roughly spanKeys keys each for
+// checksumming.
+func consistencyBoundaries(e engine.Engine, desc *proto.RangeDescriptor, spanKeys int) ([]proto.Key, error) {
+	var boundaries []proto.Key
+	count := 0
+	if err := e.Iterate(engine.MVCCEncodeKey(dataStartKey(desc)), engine.MVCCEncodeKey(desc.EndKey),
+		func(kv proto.RawKeyValue) (bool, error) {
+			key, _, isValue := engine.MVCCDecodeKey(kv.Key)
+			// Only count metadata (or inline) entries, which occur once per
+			// key and sort before its versioned values.
+			if isValue {
+				return false, nil
+			}
+			if count++; count > spanKeys {
+				boundaries = append(boundaries, key)
+				count = 1
+			}
+			return false, nil
+		}); err != nil {
+		return nil, err
+	}
+	return boundaries, nil
+}
+
+// consistencySpans returns the spans of range data which are
+// checksummed for a consistency check: the range-local data keyed by
+// range keys (e.g. transaction records) and the outboxes of the
+// range's keys, followed by the user data split at the given
+// boundaries. Range-local data keyed by Raft ID is
+// not included, as it legitimately differs between replicas (e.g. the
+// raft state or the last verification timestamp).
+func consistencySpans(desc *proto.RangeDescriptor, boundaries []proto.Key) []keyRange {
+	spans := []keyRange{
+		{
+			start: engine.MVCCEncodeKey(keys.MakeKey(keys.LocalRangePrefix, encoding.EncodeBytes(nil, desc.StartKey))),
+			end:   engine.MVCCEncodeKey(keys.MakeKey(keys.LocalRangePrefix, encoding.EncodeBytes(nil, desc.EndKey))),
+		},
+		{
+			start: engine.MVCCEncodeKey(keys.OutboxPrefix(desc.StartKey)),
+			end:   engine.MVCCEncodeKey(keys.OutboxPrefix(desc.EndKey)),
+		},
+	}
+	start := dataStartKey(desc)
+	for _, b := range boundaries {
+		// Ignore boundaries which would leave an empty span or fall
+		// outside of the range.
+		if !start.Less(b) || !b.Less(desc.EndKey) {
+			continue
+		}
+		spans = append(spans, keyRange{start: engine.MVCCEncodeKey(start), end: engine.MVCCEncodeKey(b)})
+		start = b
+	}
+	return append(spans, keyRange{start: engine.MVCCEncodeKey(start), end: engine.MVCCEncodeKey(desc.EndKey)})
+}
+
+// computeChecksums returns a SHA-256 checksum of the key/value pairs
+// in each of the given spans.
+func computeChecksums(e engine.Engine, spans []keyRange) ([][]byte, error) {
+	checksums := make([][]byte, 0, len(spans))
+	for _, span := range spans {
+		h := sha256.New()
+		if err := e.Iterate(span.start, span.end, func(kv proto.RawKeyValue) (bool, error) {
+			// Length-prefix keys and values so that the boundaries between
+			// them are unambiguous.
+			if _, err := h.Write(encoding.EncodeUvarint(nil, uint64(len(kv.Key)))); err != nil {
+				return false, err
+			}
+			if _, err := h.Write(kv.Key); err != nil {
+				return false, err
+			}
+			if _, err := h.Write(encoding.EncodeUvarint(nil, uint64(len(kv.Value)))); err != nil {
+				return false, err
+			}
+			_, err := h.Write(kv.Value)
+			return false, err
+		}); err != nil {
+			return nil, util.Errorf("unable to checksum span %s: %s", formatKeyRange(span), err)
+		}
+		checksums = append(checksums, h.Sum(nil))
+	}
+	return checksums, nil
+}
+
+// formatKeyRange returns a human-readable representation of the
+// decoded bounds of a span of range data.
+func formatKeyRange(kr keyRange) string {
+	start, _, _ := engine.MVCCDecodeKey(kr.start)
+	end, _, _ := engine.MVCCDecodeKey(kr.end)
+	return fmt.Sprintf("[%s, %s)", start, end)
+}
+
+// dataStartKey returns the first key of the range's user data. The
+// first range in the keyspace starts at KeyMin, which includes the
+// node-local space, so its data starts at LocalMax.
+func dataStartKey(desc *proto.RangeDescriptor) proto.Key {
+	if desc.StartKey.Equal(proto.KeyMin) {
+		return keys.LocalMax
+	}
+	return desc.StartKey
+}
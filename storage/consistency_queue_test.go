@@ -0,0 +1,206 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/storage/engine"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/leaktest"
+)
+
+// TestConsistencyChecksums verifies that range data is split into
+// spans of the requested size and that only the checksums of spans
+// containing diverging data differ.
+func TestConsistencyChecksums(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	desc := &proto.RangeDescriptor{StartKey: proto.Key("a"), EndKey: proto.Key("z")}
+	eng1 := engine.NewInMem(proto.Attributes{}, 1<<20)
+	defer eng1.Close()
+	eng2 := engine.NewInMem(proto.Attributes{}, 1<<20)
+	defer eng2.Close()
+
+	for _, eng := range []engine.Engine{eng1, eng2} {
+		for _, k := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+			// Write two versions of each key; only keys are counted.
+			for _, ts := range []proto.Timestamp{makeTS(1, 0), makeTS(2, 0)} {
+				if err := engine.MVCCPut(eng, nil, proto.Key(k), ts, proto.Value{Bytes: []byte(k)}, nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+	boundaries, err := consistencyBoundaries(eng1, desc, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expBoundaries := []proto.Key{proto.Key("d"), proto.Key("g")}; !reflect.DeepEqual(boundaries, expBoundaries) {
+		t.Fatalf("expected boundaries %s; got %s", expBoundaries, boundaries)
+	}
+
+	// Boundaries which are out of order or outside of the range are
+	// ignored.
+	spans := consistencySpans(desc, append(boundaries, proto.Key("c"), proto.Key("zz")))
+	var formatted []string
+	for _, span := range spans[2:] {
+		formatted = append(formatted, formatKeyRange(span))
+	}
+	expSpans := []string{`["a", "d")`, `["d", "g")`, `["g", "z")`}
+	if !reflect.DeepEqual(formatted, expSpans) {
+		t.Fatalf("expected data spans %s; got %s", expSpans, formatted)
+	}
+
+	// Overwrite a key in the second span on one engine only.
+	if err := engine.MVCCPut(eng2, nil, proto.Key("e"), makeTS(3, 0), proto.Value{Bytes: []byte("x")}, nil); err != nil {
+		t.Fatal(err)
+	}
+	checksums1, err := computeChecksums(eng1, spans)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksums2, err := computeChecksums(eng2, spans)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range spans {
+		if equal := bytes.Equal(checksums1[i], checksums2[i]); equal != (i != 3) {
+			t.Errorf("span %d %s: expected checksums equal=%t", i, formatKeyRange(spans[i]), i != 3)
+		}
+	}
+}
+
+// TestConsistencyQueueDivergence verifies that a consistency check of a
+// healthy range records its timestamp, and that a replica found to be
+// in the minority whose checksums differ from the majority's is
+// quarantined when configured.
+func TestConsistencyQueueDivergence(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+	tc.store.ctx.QuarantineInconsistentReplicas = true
+
+	for i := 0; i < 10; i++ {
+		pArgs := putArgs([]byte(fmt.Sprintf("key%d", i)), []byte("value"), tc.rangeID, tc.store.StoreID())
+		if _, err := tc.rng.AddCmd(tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	now := tc.clock.Now()
+	cq := newConsistencyQueue(tc.store.RangeCount)
+	if err := cq.process(now, tc.rng); err != nil {
+		t.Fatal(err)
+	}
+	if ts, err := tc.rng.GetLastConsistencyCheckTimestamp(); err != nil {
+		t.Fatal(err)
+	} else if !ts.Equal(now) {
+		t.Errorf("expected last consistency check at %s; got %s", now, ts)
+	}
+	if tc.rng.isQuarantined() {
+		t.Fatal("expected consistent replica not to be quarantined")
+	}
+
+	// Compute checksums, and verify them against a corrupted copy.
+	checksumID := []byte("checksum")
+	computeArgs := &proto.InternalComputeChecksumRequest{
+		RequestHeader: proto.RequestHeader{
+			Key:    tc.rng.Desc().StartKey,
+			RaftID: tc.rangeID,
+		},
+		ChecksumID: checksumID,
+	}
+	if _, err := tc.rng.AddCmd(tc.rng.context(), computeArgs); err != nil {
+		t.Fatal(err)
+	}
+	checksums, err := tc.rng.getChecksums(checksumID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checksums[len(checksums)-1] = []byte("corrupt")
+	_, replica := tc.rng.Desc().FindReplica(tc.store.StoreID())
+	verifyArgs := &proto.InternalVerifyChecksumRequest{
+		RequestHeader: proto.RequestHeader{
+			Key:    tc.rng.Desc().StartKey,
+			RaftID: tc.rangeID,
+		},
+		ChecksumID: checksumID,
+		Checksums:  checksums,
+		Minority:   []proto.Replica{*replica},
+	}
+	if _, err := tc.rng.AddCmd(tc.rng.context(), verifyArgs); err != nil {
+		t.Fatal(err)
+	}
+	util.SucceedsWithin(t, time.Second, func() error {
+		if !tc.rng.isQuarantined() {
+			return util.Errorf("expected diverging replica to be quarantined")
+		}
+		return nil
+	})
+}
+
+// TestVoteChecksums verifies that the checksums agreed upon by a
+// majority of replicas are found, and that only the replicas which
+// disagree with them end up in the minority.
+func TestVoteChecksums(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	replicas := []proto.Replica{{StoreID: 1}, {StoreID: 2}, {StoreID: 3}}
+	a, b, c := []byte("a"), []byte("b"), []byte("c")
+	testCases := []struct {
+		reports     map[proto.StoreID][][]byte
+		expOK       bool
+		expMinority []proto.StoreID
+	}{
+		// All replicas agree.
+		{map[proto.StoreID][][]byte{1: {a, b}, 2: {a, b}, 3: {a, b}}, true, nil},
+		// The leader (or any other replica) is outvoted.
+		{map[proto.StoreID][][]byte{1: {a, c}, 2: {a, b}, 3: {a, b}}, true, []proto.StoreID{1}},
+		{map[proto.StoreID][][]byte{1: {a, b}, 2: {a, b}, 3: {c, b}}, true, []proto.StoreID{3}},
+		// A replica with a differing number of spans is outvoted.
+		{map[proto.StoreID][][]byte{1: {a, b}, 2: {a}, 3: {a, b}}, true, []proto.StoreID{2}},
+		// A missing report counts for neither side.
+		{map[proto.StoreID][][]byte{1: {a, b}, 2: {a, b}}, true, nil},
+		{map[proto.StoreID][][]byte{1: {a, b}, 2: {c, b}}, false, nil},
+		// Three different checksums: there's no majority.
+		{map[proto.StoreID][][]byte{1: {a}, 2: {b}, 3: {c}}, false, nil},
+	}
+	for i, test := range testCases {
+		majority, minority, ok := voteChecksums(replicas, test.reports)
+		if ok != test.expOK {
+			t.Errorf("%d: expected ok=%t; got %t", i, test.expOK, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if expMajority := [][]byte{a, b}; !reflect.DeepEqual(majority, expMajority) {
+			t.Errorf("%d: expected majority %q; got %q", i, expMajority, majority)
+		}
+		var storeIDs []proto.StoreID
+		for _, replica := range minority {
+			storeIDs = append(storeIDs, replica.StoreID)
+		}
+		if !reflect.DeepEqual(storeIDs, test.expMinority) {
+			t.Errorf("%d: expected minority %v; got %v", i, test.expMinority, storeIDs)
+		}
+	}
+}
@@ -0,0 +1,571 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package engine
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/log"
+	gogoproto "github.com/gogo/protobuf/proto"
+)
+
+const (
+	// DefaultStoreKeyRotationPeriod is the default age after which the
+	// key with which new files of an encrypted store are encrypted is
+	// replaced.
+	DefaultStoreKeyRotationPeriod = 7 * 24 * time.Hour
+
+	// storeKeyRegistryFile is the name of the file in the directory of
+	// an encrypted store which holds its StoreKeyRegistry.
+	storeKeyRegistryFile = "COCKROACHDB_KEYS"
+	// storeKeySize is the size of store keys, which are AES-256 keys.
+	storeKeySize = 32
+
+	// NOTE: the layout of the header of encrypted files must be kept in
+	// sync with storage/engine/rocksdb/db.cc. The header consists of the
+	// magic, the big-endian ID of the store key the file is encrypted
+	// with and the file's IV.
+	encryptedFileMagic      = "roachenc"
+	encryptedFileHeaderSize = len(encryptedFileMagic) + 8 + aes.BlockSize
+)
+
+// EncryptionOptions configures the encryption at rest of a RocksDB
+// store. The files of the store are encrypted with AES in counter mode
+// under store keys which are generated as needed and kept in the
+// store's directory, wrapped by a user-supplied key.
+type EncryptionOptions struct {
+	// KeyFile is the path to the file holding the user key, a 128, 192 or
+	// 256-bit AES key, either raw or hex-encoded.
+	KeyFile string
+	// OldKeyFile is the path to the file holding the user key which
+	// previously wrapped the store keys. It is only needed when the store
+	// is first opened after the user key is rotated, at which point the
+	// store keys are wrapped by the new user key instead.
+	OldKeyFile string
+	// RotationPeriod is the age after which the active store key is
+	// replaced by a new one. Files are rewritten under the new key as
+	// they are compacted. Defaults to DefaultStoreKeyRotationPeriod.
+	RotationPeriod time.Duration
+}
+
+// EncryptionStatus describes the keys of an encrypted store and the
+// files which have yet to be rewritten under its active key.
+type EncryptionStatus struct {
+	ActiveKeyID string           `json:"activeKeyID"`
+	Keys        []StoreKeyStatus `json:"keys"`
+	// OldFiles lists the files encrypted with keys other than the active
+	// key. The KeyID of files which are not encrypted at all is empty.
+	OldFiles []FileKeyStatus `json:"oldFiles"`
+}
+
+// StoreKeyStatus describes a key of an encrypted store.
+type StoreKeyStatus struct {
+	ID           string    `json:"id"`
+	CreationTime time.Time `json:"creationTime"`
+	Files        int       `json:"files"` // The number of files encrypted with the key
+}
+
+// FileKeyStatus describes the key with which a file is encrypted.
+type FileKeyStatus struct {
+	Name  string `json:"name"`
+	KeyID string `json:"keyID"`
+	Size  int64  `json:"size"`
+}
+
+// formatKeyID formats the ID of a key for display. The zero ID, which
+// denotes plaintext, is formatted as the empty string.
+func formatKeyID(id uint64) string {
+	if id == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%016x", id)
+}
+
+// userKey is the key with which the keys of a store are wrapped.
+type userKey struct {
+	id   uint64 // Derived from a hash of the key
+	aead cipher.AEAD
+}
+
+// loadUserKey reads the user key from the given file.
+func loadUserKey(path string) (*userKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, util.Errorf("unable to read key file: %s", err)
+	}
+	key := data
+	if decoded, err := hex.DecodeString(string(bytes.TrimSpace(data))); err == nil {
+		key = decoded
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, util.Errorf("invalid key in %s: %s", path, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(key)
+	return &userKey{id: binary.BigEndian.Uint64(sum[:8]), aead: aead}, nil
+}
+
+// wrap seals the store key with the given ID. The ID is authenticated
+// along with the key so that wrapped keys cannot be swapped.
+func (uk *userKey) wrap(id uint64, key []byte) ([]byte, error) {
+	nonce := make([]byte, uk.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return uk.aead.Seal(nonce, nonce, key, keyIDBytes(id)), nil
+}
+
+// unwrap opens the wrapped store key with the given ID.
+func (uk *userKey) unwrap(id uint64, wrapped []byte) ([]byte, error) {
+	n := uk.aead.NonceSize()
+	if len(wrapped) < n {
+		return nil, util.Errorf("wrapped store key %s is truncated", formatKeyID(id))
+	}
+	key, err := uk.aead.Open(nil, wrapped[:n], wrapped[n:], keyIDBytes(id))
+	if err != nil {
+		return nil, util.Errorf("unable to unwrap store key %s: %s", formatKeyID(id), err)
+	}
+	return key, nil
+}
+
+func keyIDBytes(id uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], id)
+	return b[:]
+}
+
+// storeKeyring holds the keys of an encrypted store and persists them
+// in the store's key registry file.
+type storeKeyring struct {
+	dir            string
+	userKey        *userKey
+	rotationPeriod time.Duration
+	mu             sync.RWMutex
+	registry       StoreKeyRegistry
+	ciphers        map[uint64]cipher.Block // Unwrapped keys by ID
+}
+
+// openStoreKeyring loads the key registry of the store located in dir,
+// creating it if necessary. Store keys wrapped by the old user key are
+// rewrapped by the current one, keys which no file is encrypted with
+// any longer are discarded and the active key is rotated if it has
+// expired. This must be called before the store is opened.
+func openStoreKeyring(dir string, opts EncryptionOptions) (*storeKeyring, error) {
+	if opts.KeyFile == "" {
+		return nil, util.Errorf("no key file specified to encrypt store %s", dir)
+	}
+	uk, err := loadUserKey(opts.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	var oldKey *userKey
+	if opts.OldKeyFile != "" {
+		if oldKey, err = loadUserKey(opts.OldKeyFile); err != nil {
+			return nil, err
+		}
+	}
+	kr := &storeKeyring{
+		dir:            dir,
+		userKey:        uk,
+		rotationPeriod: opts.RotationPeriod,
+		ciphers:        map[uint64]cipher.Block{},
+	}
+	if kr.rotationPeriod <= 0 {
+		kr.rotationPeriod = DefaultStoreKeyRotationPeriod
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(kr.registryPath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, util.Errorf("unable to read store key registry: %s", err)
+	}
+	if err := gogoproto.Unmarshal(data, &kr.registry); err != nil {
+		return nil, util.Errorf("unable to decode store key registry: %s", err)
+	}
+
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	dirty := false
+	for i := range kr.registry.Keys {
+		sk := &kr.registry.Keys[i]
+		wrapper := uk
+		if sk.WrappingKeyID != uk.id {
+			if oldKey == nil || sk.WrappingKeyID != oldKey.id {
+				return nil, util.Errorf("store key %s of %s is wrapped by unknown key %s; "+
+					"specify the previous key to rotate keys", formatKeyID(sk.ID), dir,
+					formatKeyID(sk.WrappingKeyID))
+			}
+			wrapper = oldKey
+		}
+		key, err := wrapper.unwrap(sk.ID, sk.WrappedKey)
+		if err != nil {
+			return nil, err
+		}
+		if wrapper != uk {
+			if sk.WrappedKey, err = uk.wrap(sk.ID, key); err != nil {
+				return nil, err
+			}
+			sk.WrappingKeyID = uk.id
+			dirty = true
+		}
+		if kr.ciphers[sk.ID], err = aes.NewCipher(key); err != nil {
+			return nil, err
+		}
+	}
+	if dirty {
+		log.Infof("rewrapped store keys of %s with key %s", dir, formatKeyID(uk.id))
+	}
+
+	// Discard the keys which no file is encrypted with any longer.
+	files, err := kr.fileKeys()
+	if err != nil {
+		return nil, err
+	}
+	used := map[uint64]bool{}
+	for _, f := range files {
+		if f.keyID != 0 && kr.ciphers[f.keyID] == nil {
+			return nil, util.Errorf("file %s is encrypted with unknown store key %s", f.name, formatKeyID(f.keyID))
+		}
+		used[f.keyID] = true
+	}
+	keys := kr.registry.Keys[:0]
+	for _, sk := range kr.registry.Keys {
+		if sk.ID != kr.registry.ActiveKeyID && !used[sk.ID] {
+			delete(kr.ciphers, sk.ID)
+			dirty = true
+			continue
+		}
+		keys = append(keys, sk)
+	}
+	kr.registry.Keys = keys
+
+	if dirty {
+		if err := kr.writeRegistryLocked(); err != nil {
+			return nil, err
+		}
+	}
+	if err := kr.maybeRotateLocked(); err != nil {
+		return nil, err
+	}
+	return kr, nil
+}
+
+func (kr *storeKeyring) registryPath() string {
+	return filepath.Join(kr.dir, storeKeyRegistryFile)
+}
+
+// writeRegistryLocked atomically replaces the key registry file with
+// the current registry. The caller must hold mu.
+func (kr *storeKeyring) writeRegistryLocked() error {
+	data, err := gogoproto.Marshal(&kr.registry)
+	if err != nil {
+		return err
+	}
+	tmpPath := kr.registryPath() + ".tmp"
+	f, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return util.Errorf("unable to write store key registry: %s", err)
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, kr.registryPath())
+	}
+	if err != nil {
+		return util.Errorf("unable to write store key registry: %s", err)
+	}
+	// Sync the directory to persist the rename.
+	d, err := os.Open(kr.dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// maybeRotateLocked replaces the active store key with a new one if
+// there is none or it is older than the rotation period. The caller
+// must hold mu.
+func (kr *storeKeyring) maybeRotateLocked() error {
+	for _, sk := range kr.registry.Keys {
+		if sk.ID == kr.registry.ActiveKeyID {
+			if time.Since(time.Unix(0, sk.CreationTime)) < kr.rotationPeriod {
+				return nil
+			}
+			break
+		}
+	}
+
+	key := make([]byte, storeKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	var id uint64
+	for id == 0 || kr.ciphers[id] != nil {
+		if err := binary.Read(rand.Reader, binary.BigEndian, &id); err != nil {
+			return err
+		}
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	wrapped, err := kr.userKey.wrap(id, key)
+	if err != nil {
+		return err
+	}
+
+	prevKeys, prevActiveKeyID := kr.registry.Keys, kr.registry.ActiveKeyID
+	kr.registry.Keys = append(kr.registry.Keys[:len(kr.registry.Keys):len(kr.registry.Keys)], StoreKey{
+		ID:            id,
+		WrappedKey:    wrapped,
+		WrappingKeyID: kr.userKey.id,
+		CreationTime:  time.Now().UnixNano(),
+	})
+	kr.registry.ActiveKeyID = id
+	if err := kr.writeRegistryLocked(); err != nil {
+		kr.registry.Keys, kr.registry.ActiveKeyID = prevKeys, prevActiveKeyID
+		return err
+	}
+	kr.ciphers[id] = block
+	log.Infof("rotated store key of %s to %s", kr.dir, formatKeyID(id))
+	return nil
+}
+
+// newFile fills iv with a random initialization vector for a new file
+// and returns the ID of the key with which to encrypt it, rotating the
+// active key first if it has expired.
+func (kr *storeKeyring) newFile(iv []byte) (uint64, error) {
+	kr.mu.Lock()
+	defer kr.mu.Unlock()
+	if err := kr.maybeRotateLocked(); err != nil {
+		return 0, err
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return 0, err
+	}
+	return kr.registry.ActiveKeyID, nil
+}
+
+// xorKeyStream encrypts or decrypts the data located at offset in the
+// contents of a file encrypted with the given key and IV.
+func (kr *storeKeyring) xorKeyStream(keyID uint64, iv []byte, offset int64, data []byte) error {
+	kr.mu.RLock()
+	block := kr.ciphers[keyID]
+	kr.mu.RUnlock()
+	if block == nil {
+		return util.Errorf("unknown store key %s", formatKeyID(keyID))
+	}
+	if len(iv) != aes.BlockSize {
+		return util.Errorf("invalid IV length %d", len(iv))
+	}
+	xorKeyStreamAt(block, iv, offset, data)
+	return nil
+}
+
+// xorKeyStreamAt XORs data with the AES-CTR key stream of the block
+// cipher and IV, starting at the given offset of the stream. The IV is
+// the initial value of the 128-bit big-endian counter.
+func xorKeyStreamAt(block cipher.Block, iv []byte, offset int64, data []byte) {
+	var ctr [aes.BlockSize]byte
+	hi, lo := binary.BigEndian.Uint64(iv[:8]), binary.BigEndian.Uint64(iv[8:])
+	newLo := lo + uint64(offset/aes.BlockSize)
+	if newLo < lo {
+		hi++
+	}
+	binary.BigEndian.PutUint64(ctr[:8], hi)
+	binary.BigEndian.PutUint64(ctr[8:], newLo)
+	stream := cipher.NewCTR(block, ctr[:])
+	if skip := offset % aes.BlockSize; skip > 0 {
+		var discard [aes.BlockSize]byte
+		stream.XORKeyStream(discard[:skip], discard[:skip])
+	}
+	stream.XORKeyStream(data, data)
+}
+
+// fileKey is the key with which a file of the store is encrypted.
+type fileKey struct {
+	name  string
+	keyID uint64 // Zero if the file is not encrypted
+	size  int64
+}
+
+// fileKeys reads the headers of the files of the store to determine
+// the keys with which they are encrypted.
+func (kr *storeKeyring) fileKeys() ([]fileKey, error) {
+	infos, err := ioutil.ReadDir(kr.dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []fileKey
+	for _, info := range infos {
+		name := info.Name()
+		// The lock file and the key registry are not written by RocksDB
+		// through the encrypted environment.
+		if !info.Mode().IsRegular() || name == "LOCK" || name == storeKeyRegistryFile ||
+			name == storeKeyRegistryFile+".tmp" {
+			continue
+		}
+		keyID, err := readFileKeyID(filepath.Join(kr.dir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				// The file was deleted in the meantime.
+				continue
+			}
+			return nil, err
+		}
+		files = append(files, fileKey{name: name, keyID: keyID, size: info.Size()})
+	}
+	return files, nil
+}
+
+// readFileKeyID returns the ID of the key with which the file is
+// encrypted, or zero if it is not encrypted.
+func readFileKeyID(path string) (uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	var header [encryptedFileHeaderSize]byte
+	if _, err := io.ReadFull(f, header[:]); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if string(header[:len(encryptedFileMagic)]) != encryptedFileMagic {
+		return 0, nil
+	}
+	return binary.BigEndian.Uint64(header[len(encryptedFileMagic):]), nil
+}
+
+// status returns the keys of the store and the files which are not
+// encrypted with the active key.
+func (kr *storeKeyring) status() (*EncryptionStatus, error) {
+	files, err := kr.fileKeys()
+	if err != nil {
+		return nil, err
+	}
+	kr.mu.RLock()
+	defer kr.mu.RUnlock()
+	counts := map[uint64]int{}
+	status := &EncryptionStatus{ActiveKeyID: formatKeyID(kr.registry.ActiveKeyID)}
+	for _, f := range files {
+		counts[f.keyID]++
+		if f.keyID != kr.registry.ActiveKeyID {
+			status.OldFiles = append(status.OldFiles, FileKeyStatus{
+				Name:  f.name,
+				KeyID: formatKeyID(f.keyID),
+				Size:  f.size,
+			})
+		}
+	}
+	for _, sk := range kr.registry.Keys {
+		status.Keys = append(status.Keys, StoreKeyStatus{
+			ID:           formatKeyID(sk.ID),
+			CreationTime: time.Unix(0, sk.CreationTime),
+			Files:        counts[sk.ID],
+		})
+	}
+	sort.Sort(storeKeyStatusByCreation(status.Keys))
+	return status, nil
+}
+
+type storeKeyStatusByCreation []StoreKeyStatus
+
+func (s storeKeyStatusByCreation) Len() int      { return len(s) }
+func (s storeKeyStatusByCreation) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s storeKeyStatusByCreation) Less(i, j int) bool {
+	return s[i].CreationTime.Before(s[j].CreationTime)
+}
+
+// keyrings maps the handles passed to RocksDB to the keyrings of the
+// encrypted stores. The handles are used by the encryption callbacks
+// invoked from C++, which cannot hold references to Go memory.
+var keyrings = struct {
+	sync.RWMutex
+	next int
+	m    map[int]*storeKeyring
+}{m: map[int]*storeKeyring{}}
+
+// registerKeyring returns a new, non-zero handle for the keyring.
+func registerKeyring(kr *storeKeyring) int {
+	keyrings.Lock()
+	defer keyrings.Unlock()
+	keyrings.next++
+	keyrings.m[keyrings.next] = kr
+	return keyrings.next
+}
+
+func unregisterKeyring(handle int) {
+	keyrings.Lock()
+	defer keyrings.Unlock()
+	delete(keyrings.m, handle)
+}
+
+func lookupKeyring(handle int) (*storeKeyring, error) {
+	keyrings.RLock()
+	defer keyrings.RUnlock()
+	kr, ok := keyrings.m[handle]
+	if !ok {
+		return nil, util.Errorf("unknown keyring %d", handle)
+	}
+	return kr, nil
+}
+
+// newFileKey implements rocksdb.NewFileKey.
+func newFileKey(handle int, iv []byte) (uint64, error) {
+	kr, err := lookupKeyring(handle)
+	if err != nil {
+		return 0, err
+	}
+	return kr.newFile(iv)
+}
+
+// xorKeyStream implements rocksdb.XORKeyStream.
+func xorKeyStream(handle int, keyID uint64, iv []byte, offset int64, data []byte) error {
+	kr, err := lookupKeyring(handle)
+	if err != nil {
+		return err
+	}
+	return kr.xorKeyStream(keyID, iv, offset, data)
+}
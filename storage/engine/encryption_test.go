@@ -0,0 +1,205 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package engine
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/util/leaktest"
+)
+
+// writeTestKeyFile writes a random hex-encoded AES-256 key to a file
+// in dir and returns its path.
+func writeTestKeyFile(t testing.TB, dir, name string) string {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = byte(rand.Int())
+	}
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// writeTestFile writes a file encrypted with the given key, according
+// to its header, to the store directory.
+func writeTestFile(t *testing.T, dir, name string, keyID uint64) {
+	header := append([]byte(encryptedFileMagic), keyIDBytes(keyID)...)
+	header = append(header, make([]byte, aes.BlockSize)...)
+	if err := ioutil.WriteFile(filepath.Join(dir, name), header, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestXORKeyStreamAt verifies that encrypting the pieces of a stream at
+// their offsets is equivalent to encrypting the stream as a whole,
+// including when the counter wraps around its lower half.
+func TestXORKeyStreamAt(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	block, err := aes.NewCipher(make([]byte, 32))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, iv := range [][]byte{
+		make([]byte, aes.BlockSize),
+		{0, 0, 0, 0, 0, 0, 0, 1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xfe},
+	} {
+		plaintext := make([]byte, 1000)
+		for i := range plaintext {
+			plaintext[i] = byte(i)
+		}
+		expected := make([]byte, len(plaintext))
+		cipher.NewCTR(block, iv).XORKeyStream(expected, plaintext)
+
+		for _, piece := range []int{1, 7, 16, 33, 500} {
+			data := append([]byte(nil), plaintext...)
+			for offset := 0; offset < len(data); offset += piece {
+				end := offset + piece
+				if end > len(data) {
+					end = len(data)
+				}
+				xorKeyStreamAt(block, iv, int64(offset), data[offset:end])
+			}
+			if !bytes.Equal(data, expected) {
+				t.Errorf("iv %x, pieces of %d: ciphertext differs from that of the whole stream", iv, piece)
+			}
+		}
+	}
+}
+
+// TestStoreKeyringRotation verifies that the active store key is
+// rotated once it expires, and that old keys are retained only while
+// files are encrypted with them.
+func TestStoreKeyringRotation(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	dir, err := ioutil.TempDir("", "test-keyring")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	opts := EncryptionOptions{KeyFile: writeTestKeyFile(t, dir, "key")}
+	storeDir := filepath.Join(dir, "store")
+
+	kr, err := openStoreKeyring(storeDir, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iv := make([]byte, aes.BlockSize)
+	firstKeyID, err := kr.newFile(iv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firstKeyID == 0 {
+		t.Fatal("expected a store key to be created")
+	}
+	writeTestFile(t, storeDir, "000001.sst", firstKeyID)
+	if err := ioutil.WriteFile(filepath.Join(storeDir, "CURRENT"), []byte("MANIFEST-000001\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reopening the store keeps using the active key.
+	if kr, err = openStoreKeyring(storeDir, opts); err != nil {
+		t.Fatal(err)
+	}
+	if keyID, err := kr.newFile(iv); err != nil || keyID != firstKeyID {
+		t.Fatalf("expected key %x to remain active; got %x, %v", firstKeyID, keyID, err)
+	}
+
+	// Once expired, the key is replaced but retained while a file is
+	// encrypted with it.
+	opts.RotationPeriod = time.Nanosecond
+	if kr, err = openStoreKeyring(storeDir, opts); err != nil {
+		t.Fatal(err)
+	}
+	secondKeyID := kr.registry.ActiveKeyID
+	if secondKeyID == firstKeyID {
+		t.Fatal("expected the store key to be rotated")
+	}
+	status, err := kr.status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(status.Keys) != 2 {
+		t.Errorf("expected 2 keys; got %+v", status.Keys)
+	}
+	expOldFiles := map[string]string{"000001.sst": formatKeyID(firstKeyID), "CURRENT": ""}
+	if len(status.OldFiles) != len(expOldFiles) {
+		t.Errorf("expected old files %v; got %+v", expOldFiles, status.OldFiles)
+	}
+	for _, f := range status.OldFiles {
+		if keyID, ok := expOldFiles[f.Name]; !ok || keyID != f.KeyID {
+			t.Errorf("unexpected old file %+v", f)
+		}
+	}
+
+	// Once the file is rewritten, the first key is discarded.
+	writeTestFile(t, storeDir, "000001.sst", secondKeyID)
+	opts.RotationPeriod = 0
+	if kr, err = openStoreKeyring(storeDir, opts); err != nil {
+		t.Fatal(err)
+	}
+	if len(kr.registry.Keys) != 1 || kr.registry.Keys[0].ID != secondKeyID {
+		t.Errorf("expected only key %x to remain; got %+v", secondKeyID, kr.registry.Keys)
+	}
+}
+
+// TestStoreKeyringUserKeyRotation verifies that the store keys are
+// rewrapped when the user key is rotated, which requires the previous
+// user key to be specified.
+func TestStoreKeyringUserKeyRotation(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	dir, err := ioutil.TempDir("", "test-keyring")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	oldKeyFile := writeTestKeyFile(t, dir, "old-key")
+	newKeyFile := writeTestKeyFile(t, dir, "new-key")
+	storeDir := filepath.Join(dir, "store")
+
+	kr, err := openStoreKeyring(storeDir, EncryptionOptions{KeyFile: oldKeyFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID := kr.registry.ActiveKeyID
+
+	if _, err := openStoreKeyring(storeDir, EncryptionOptions{KeyFile: newKeyFile}); err == nil {
+		t.Fatal("expected opening the store with a different key to fail")
+	}
+	if _, err := openStoreKeyring(storeDir, EncryptionOptions{KeyFile: newKeyFile, OldKeyFile: oldKeyFile}); err != nil {
+		t.Fatal(err)
+	}
+	// The old key is no longer needed, and no longer works.
+	if kr, err = openStoreKeyring(storeDir, EncryptionOptions{KeyFile: newKeyFile}); err != nil {
+		t.Fatal(err)
+	}
+	if kr.registry.ActiveKeyID != keyID {
+		t.Errorf("expected rotating the user key to retain store key %x; got %x", keyID, kr.registry.ActiveKeyID)
+	}
+	if _, err := openStoreKeyring(storeDir, EncryptionOptions{KeyFile: oldKeyFile}); err == nil {
+		t.Fatal("expected opening the store with the old key to fail")
+	}
+}
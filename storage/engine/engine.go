@@ -21,6 +21,7 @@ package engine
 
 import (
 	"sync"
+	"syscall"
 
 	"github.com/cockroachdb/cockroach/proto"
 	"github.com/cockroachdb/cockroach/util"
@@ -98,6 +99,14 @@ type Engine interface {
 	//
 	// The logic for merges is written in db.cc in order to be compatible with RocksDB.
 	Merge(key proto.EncodedKey, value []byte) error
+	// PutSorted writes the key/value pairs, which must be sorted by key
+	// and must not overlap with any key present in the engine. GoLSM
+	// writes them to a table of their own instead of through its
+	// write-ahead log and memtable; RocksDB puts them one by one. Pairs
+	// written to a batch are applied atomically along with the rest of
+	// the batch on Commit, and needn't be visible to reads from the
+	// batch before then.
+	PutSorted(kvs []proto.RawKeyValue) error
 	// Capacity returns capacity details for the engine's available storage.
 	Capacity() (proto.StoreCapacity, error)
 	// SetGCTimeouts sets timeout values for GC of transaction and
@@ -138,6 +147,10 @@ type Engine interface {
 	Defer(fn func())
 }
 
+func emptyKeyError() error {
+	return util.ErrorSkipFrames(1, "attempted access to empty key")
+}
+
 var bufferPool = sync.Pool{
 	New: func() interface{} {
 		return gogoproto.NewBuffer(nil)
@@ -245,3 +258,19 @@ func ClearRange(engine Engine, start, end proto.EncodedKey) (int, error) {
 	}
 	return count, b.Commit()
 }
+
+// fsCapacity queries the file system holding dir for capacity
+// information. The temporary directory is queried if dir is empty.
+func fsCapacity(dir string) (proto.StoreCapacity, error) {
+	var fs syscall.Statfs_t
+	var capacity proto.StoreCapacity
+	if dir == "" {
+		dir = "/tmp"
+	}
+	if err := syscall.Statfs(dir, &fs); err != nil {
+		return capacity, err
+	}
+	capacity.Capacity = int64(fs.Bsize) * int64(fs.Blocks)
+	capacity.Available = int64(fs.Bsize) * int64(fs.Bavail)
+	return capacity, nil
+}
@@ -21,8 +21,10 @@ package engine
 import (
 	"bytes"
 	"fmt"
+	"io/ioutil"
 	"math"
 	"math/rand"
+	"os"
 	"reflect"
 	"sort"
 	"strconv"
@@ -54,12 +56,63 @@ var (
 	inMemAttrs = proto.Attributes{Attrs: []string{"mem"}}
 )
 
+const testCacheSize = 1 << 30 // GB.
+
+// encodePutResponse creates a put response using the specified
+// timestamp and encodes it using gogoprotobuf.
+func encodePutResponse(timestamp proto.Timestamp, t *testing.T) []byte {
+	rwCmd := &proto.ReadWriteCmdResponse{
+		Put: &proto.PutResponse{
+			ResponseHeader: proto.ResponseHeader{
+				Timestamp: timestamp,
+			},
+		},
+	}
+	data, err := gogoproto.Marshal(rwCmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// encodeTransaction creates a transaction using the specified
+// timestamp and encodes it using gogoprotobuf.
+func encodeTransaction(timestamp proto.Timestamp, t *testing.T) []byte {
+	txn := &proto.Transaction{
+		Timestamp: timestamp,
+	}
+	data, err := gogoproto.Marshal(txn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
 // runWithAllEngines creates a new engine of each supported type and
 // invokes the supplied test func with each instance.
 func runWithAllEngines(test func(e Engine, t *testing.T), t *testing.T) {
 	inMem := NewInMem(inMemAttrs, testCacheSize)
 	defer inMem.Close()
 	test(inMem, t)
+
+	goLSMInMem := NewGoLSM(inMemAttrs, "")
+	if err := goLSMInMem.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer goLSMInMem.Close()
+	test(goLSMInMem, t)
+
+	dir, err := ioutil.TempDir("", "test-golsm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	goLSM := NewGoLSM(inMemAttrs, dir)
+	if err := goLSM.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer goLSM.Close()
+	test(goLSM, t)
 }
 
 // TestEngineBatchCommit writes a batch containing 10K rows (all the
@@ -633,7 +686,7 @@ func TestSnapshotMethods(t *testing.T) {
 		// Verify Attrs.
 		var attrs proto.Attributes
 		switch engine.(type) {
-		case *InMem:
+		case *InMem, *GoLSM:
 			attrs = inMemAttrs
 		}
 		if !reflect.DeepEqual(engine.Attrs(), attrs) {
@@ -18,26 +18,38 @@
 package engine
 
 import (
+	"time"
+
 	"github.com/cockroachdb/cockroach/proto"
 	"github.com/cockroachdb/cockroach/util/log"
 	gogoproto "github.com/gogo/protobuf/proto"
 )
 
 // GarbageCollector GCs MVCC key/values using a zone-specific GC
-// policy allows either the union or intersection of maximum # of
-// versions and maximum age.
+// policy which combines the maximum age of versions with minimum and
+// maximum numbers of versions: the policy's MinVersions most recent
+// versions are retained regardless of age (union), while versions
+// beyond MaxVersions are GC'd even if they are young enough to be
+// retained according to the TTL (intersection). Versions beyond
+// MaxVersions are only GC'd once they have been shadowed by a newer
+// version for longer than a minimum age, so that reads within it still
+// find them.
 type GarbageCollector struct {
-	expiration proto.Timestamp
-	policy     proto.GCPolicy
+	expiration        proto.Timestamp
+	versionExpiration proto.Timestamp
+	policy            proto.GCPolicy
 }
 
 // NewGarbageCollector allocates and returns a new GC, with expiration
-// computed based on current time and policy.TTLSeconds.
-func NewGarbageCollector(now proto.Timestamp, policy proto.GCPolicy) *GarbageCollector {
+// computed based on current time and policy.TTLSeconds. Versions
+// beyond policy.MaxVersions are retained as long as they have been
+// shadowed for less than minVersionAge.
+func NewGarbageCollector(now proto.Timestamp, policy proto.GCPolicy, minVersionAge time.Duration) *GarbageCollector {
 	ttlNanos := int64(policy.TTLSeconds) * 1E9
 	return &GarbageCollector{
-		expiration: proto.Timestamp{WallTime: now.WallTime - ttlNanos},
-		policy:     policy,
+		expiration:        proto.Timestamp{WallTime: now.WallTime - ttlNanos},
+		versionExpiration: proto.Timestamp{WallTime: now.WallTime - minVersionAge.Nanoseconds()},
+		policy:            policy,
 	}
 }
 
@@ -47,7 +59,7 @@ func NewGarbageCollector(now proto.Timestamp, policy proto.GCPolicy) *GarbageCol
 // be garbage collected. If no values should be GC'd, returns
 // proto.ZeroTimestamp.
 func (gc *GarbageCollector) Filter(keys []proto.EncodedKey, values [][]byte) proto.Timestamp {
-	if gc.policy.TTLSeconds <= 0 {
+	if gc.policy.TTLSeconds <= 0 && gc.policy.MaxVersions <= 0 {
 		return proto.ZeroTimestamp
 	}
 	if len(keys) == 0 {
@@ -57,6 +69,7 @@ func (gc *GarbageCollector) Filter(keys []proto.EncodedKey, values [][]byte) pro
 	// Loop over values. All should be MVCC versions.
 	delTS := proto.ZeroTimestamp
 	survivors := false
+	var newer proto.Timestamp
 	for i, key := range keys {
 		_, ts, isValue := MVCCDecodeKey(key)
 		if !isValue {
@@ -73,16 +86,19 @@ func (gc *GarbageCollector) Filter(keys []proto.EncodedKey, values [][]byte) pro
 			// it for GC. It should always survive if non-deleted.
 			if !mvccVal.Deleted {
 				survivors = true
+				newer = ts
 				continue
 			}
 		}
-		// If we encounter a version older than our GC timestamp, mark for deletion.
-		if ts.Less(gc.expiration) {
+		// If we encounter a version which the policy doesn't retain, mark
+		// it and all older versions for deletion.
+		if !gc.retain(i, ts, newer) {
 			delTS = ts
 			break
 		} else if !mvccVal.Deleted {
 			survivors = true
 		}
+		newer = ts
 	}
 	// If there are no non-deleted survivors, return timestamp of first key
 	// to delete all entries.
@@ -92,3 +108,17 @@ func (gc *GarbageCollector) Filter(keys []proto.EncodedKey, values [][]byte) pro
 	}
 	return delTS
 }
+
+// retain returns whether the policy retains the version at index i in
+// the list of versions of a key, ordered from newest to oldest, which
+// was written at the specified timestamp and shadowed by the version
+// written at newer.
+func (gc *GarbageCollector) retain(i int, ts, newer proto.Timestamp) bool {
+	if i < int(gc.policy.MinVersions) {
+		return true
+	}
+	if gc.policy.MaxVersions > 0 && i >= int(gc.policy.MaxVersions) && newer.Less(gc.versionExpiration) {
+		return false
+	}
+	return gc.policy.TTLSeconds <= 0 || !ts.Less(gc.expiration)
+}
@@ -19,6 +19,7 @@ package engine
 
 import (
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/cockroach/proto"
 	"github.com/cockroachdb/cockroach/util/leaktest"
@@ -51,8 +52,8 @@ func serializedMVCCValue(deleted bool, t *testing.T) []byte {
 // different sorts of MVCC keys.
 func TestGarbageCollectorFilter(t *testing.T) {
 	defer leaktest.AfterTest(t)
-	gcA := NewGarbageCollector(makeTS(0, 0), proto.GCPolicy{TTLSeconds: 1})
-	gcB := NewGarbageCollector(makeTS(0, 0), proto.GCPolicy{TTLSeconds: 2})
+	gcA := NewGarbageCollector(makeTS(0, 0), proto.GCPolicy{TTLSeconds: 1}, 0)
+	gcB := NewGarbageCollector(makeTS(0, 0), proto.GCPolicy{TTLSeconds: 2}, 0)
 	n := serializedMVCCValue(false, t)
 	d := serializedMVCCValue(true, t)
 	testData := []struct {
@@ -89,3 +90,63 @@ func TestGarbageCollectorFilter(t *testing.T) {
 		}
 	}
 }
+
+// TestGarbageCollectorFilterVersions verifies the filter policies
+// which limit the number of versions of keys, alone or combined with
+// a TTL.
+func TestGarbageCollectorFilterVersions(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	cKey := proto.Key("c")
+	cKeys := []proto.EncodedKey{
+		MVCCEncodeVersionKey(cKey, makeTS(4E9, 0)),
+		MVCCEncodeVersionKey(cKey, makeTS(3E9, 0)),
+		MVCCEncodeVersionKey(cKey, makeTS(2E9, 0)),
+		MVCCEncodeVersionKey(cKey, makeTS(1E9, 0)),
+	}
+	n := serializedMVCCValue(false, t)
+	d := serializedMVCCValue(true, t)
+	testData := []struct {
+		policy   proto.GCPolicy
+		minAge   time.Duration
+		time     proto.Timestamp
+		values   [][]byte
+		expDelTS proto.Timestamp
+	}{
+		// Without a TTL or maximum number of versions, nothing is GC'd.
+		{proto.GCPolicy{MinVersions: 2}, 0, makeTS(5E9, 0), [][]byte{n, n, n, n}, proto.ZeroTimestamp},
+		{proto.GCPolicy{MinVersions: 2}, 0, makeTS(5E9, 0), [][]byte{d, d, d, d}, proto.ZeroTimestamp},
+		// Maximum number of versions only.
+		{proto.GCPolicy{MaxVersions: 4}, 0, makeTS(5E9, 0), [][]byte{n, n, n, n}, proto.ZeroTimestamp},
+		{proto.GCPolicy{MaxVersions: 2}, 0, makeTS(5E9, 0), [][]byte{n, n, n, n}, makeTS(2E9, 0)},
+		{proto.GCPolicy{MaxVersions: 1}, 0, makeTS(5E9, 0), [][]byte{n, n, n, n}, makeTS(3E9, 0)},
+		{proto.GCPolicy{MaxVersions: 2}, 0, makeTS(5E9, 0), [][]byte{d, n, n, n}, makeTS(2E9, 0)},
+		{proto.GCPolicy{MaxVersions: 1}, 0, makeTS(5E9, 0), [][]byte{d, n, n, n}, makeTS(4E9, 0)},
+		// Minimum number of versions and TTL.
+		{proto.GCPolicy{TTLSeconds: 2}, 0, makeTS(5E9, 0), [][]byte{n, n, n, n}, makeTS(2E9, 0)},
+		{proto.GCPolicy{TTLSeconds: 2, MinVersions: 3}, 0, makeTS(5E9, 0), [][]byte{n, n, n, n}, makeTS(1E9, 0)},
+		{proto.GCPolicy{TTLSeconds: 2, MinVersions: 4}, 0, makeTS(5E9, 0), [][]byte{n, n, n, n}, proto.ZeroTimestamp},
+		{proto.GCPolicy{TTLSeconds: 2, MinVersions: 2}, 0, makeTS(10E9, 0), [][]byte{n, n, n, n}, makeTS(2E9, 0)},
+		// Maximum number of versions and TTL.
+		{proto.GCPolicy{TTLSeconds: 2, MaxVersions: 1}, 0, makeTS(5E9, 0), [][]byte{n, n, n, n}, makeTS(3E9, 0)},
+		{proto.GCPolicy{TTLSeconds: 10, MaxVersions: 3}, 0, makeTS(5E9, 0), [][]byte{n, n, n, n}, makeTS(1E9, 0)},
+		{proto.GCPolicy{TTLSeconds: 2, MaxVersions: 3}, 0, makeTS(10E9, 0), [][]byte{n, n, n, n}, makeTS(3E9, 0)},
+		// All three.
+		{proto.GCPolicy{TTLSeconds: 2, MinVersions: 1, MaxVersions: 3}, 0, makeTS(10E9, 0), [][]byte{n, n, n, n}, makeTS(3E9, 0)},
+		{proto.GCPolicy{TTLSeconds: 10, MinVersions: 1, MaxVersions: 3}, 0, makeTS(5E9, 0), [][]byte{d, n, n, n}, makeTS(1E9, 0)},
+		// Versions beyond the maximum number which haven't been shadowed
+		// for the minimum age are retained.
+		{proto.GCPolicy{MaxVersions: 1}, 2 * time.Second, makeTS(5E9, 0), [][]byte{n, n, n, n}, makeTS(1E9, 0)},
+		{proto.GCPolicy{MaxVersions: 1}, 2 * time.Second, makeTS(6E9, 0), [][]byte{n, n, n, n}, makeTS(2E9, 0)},
+		{proto.GCPolicy{MaxVersions: 1}, 2 * time.Second, makeTS(7E9, 0), [][]byte{n, n, n, n}, makeTS(3E9, 0)},
+		{proto.GCPolicy{MaxVersions: 1}, 10 * time.Second, makeTS(5E9, 0), [][]byte{n, n, n, n}, proto.ZeroTimestamp},
+		{proto.GCPolicy{MaxVersions: 2}, 2 * time.Second, makeTS(5E9, 0), [][]byte{d, n, n, n}, makeTS(1E9, 0)},
+		{proto.GCPolicy{TTLSeconds: 10, MaxVersions: 1}, 2 * time.Second, makeTS(6E9, 0), [][]byte{n, n, n, n}, makeTS(2E9, 0)},
+	}
+	for i, test := range testData {
+		gc := NewGarbageCollector(test.time, test.policy, test.minAge)
+		delTS := gc.Filter(cKeys, test.values)
+		if !delTS.Equal(test.expDelTS) {
+			t.Errorf("%d: expected deletion timestamp %s; got %s", i, test.expDelTS, delTS)
+		}
+	}
+}
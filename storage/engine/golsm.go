@@ -0,0 +1,1347 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/cockroachdb/cockroach/keys"
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/encoding"
+	"github.com/cockroachdb/cockroach/util/log"
+	gogoproto "github.com/gogo/protobuf/proto"
+)
+
+const (
+	// defaultMemtableSize is the approximate size in bytes above which
+	// the memtable of a GoLSM engine is flushed to a table.
+	defaultMemtableSize = 8 << 20
+	// compactionTrigger is the number of tables at which the newest
+	// tables of a GoLSM engine are compacted.
+	compactionTrigger = 4
+	// maxTables is the number of tables at which all tables of a
+	// GoLSM engine are compacted.
+	maxTables = 16
+)
+
+// GoLSM is a log-structured merge tree implemented in pure Go, for
+// use where RocksDB and its cgo toolchain are not available. Writes
+// are logged to a write-ahead log and applied to a sorted in-memory
+// table, which is written to an immutable table file once it exceeds
+// its size limit. Tables are merged by a background compaction, which
+// also garbage collects response cache entries and transaction records
+// according to SetGCTimeouts. An engine without a directory keeps its
+// tables in memory.
+//
+// Merges are resolved when they are written rather than when they are
+// read, using the same semantics as the RocksDB merge operator. Unlike
+// with RocksDB, a merge of incompatible values fails with an error.
+type GoLSM struct {
+	attrs        proto.Attributes // Attributes for this engine
+	dir          string           // The data directory; empty for in-memory engines
+	memtableSize int64
+	refcount     int32
+
+	// writeMu serializes writes, flushes and the installation of
+	// compacted tables, and protects the fields below.
+	writeMu     sync.Mutex
+	lock        *os.File // locks the data directory
+	log         *os.File
+	logNum      uint64
+	nextFileNum uint64
+
+	// mu protects mem and tables, which are only modified while also
+	// holding writeMu.
+	mu     sync.RWMutex
+	mem    *memTable
+	tables *tableSet
+
+	gcMu        sync.Mutex // protects minTxnTS and minRCacheTS
+	minTxnTS    int64
+	minRCacheTS int64
+
+	compactMu sync.Mutex // serializes compactions
+	compactC  chan struct{}
+	stopper   chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewGoLSM allocates and returns a new GoLSM object. If dir is empty,
+// the engine is kept in memory.
+func NewGoLSM(attrs proto.Attributes, dir string) *GoLSM {
+	return &GoLSM{
+		attrs:        attrs,
+		dir:          dir,
+		memtableSize: defaultMemtableSize,
+	}
+}
+
+// String formatter.
+func (r *GoLSM) String() string {
+	return fmt.Sprintf("%s=%s", r.attrs.Attrs, r.dir)
+}
+
+// Open opens the engine, recovering the contents of its directory if
+// it is persistent. As with RocksDB, the Open and Close methods are
+// reference counted.
+func (r *GoLSM) Open() error {
+	if r.mem != nil {
+		atomic.AddInt32(&r.refcount, 1)
+		return nil
+	}
+	r.mem = &memTable{}
+	r.tables = newTableSet(nil)
+	r.nextFileNum = 1
+	if len(r.dir) == 0 {
+		log.Infof("opening in-memory golsm instance")
+	} else {
+		log.Infof("opening golsm instance at %q", r.dir)
+		if err := r.recover(); err != nil {
+			r.closeFiles()
+			r.mem, r.tables = nil, nil
+			return util.Errorf("could not open golsm instance: %s", err)
+		}
+	}
+	r.compactC = make(chan struct{}, 1)
+	r.stopper = make(chan struct{})
+	r.wg.Add(1)
+	go r.compactLoop()
+	r.maybeScheduleCompaction()
+
+	atomic.AddInt32(&r.refcount, 1)
+	return nil
+}
+
+// recover locks the data directory and loads its tables, replaying
+// the write-ahead logs which have not been flushed yet. The replayed
+// entries are flushed to a new table.
+func (r *GoLSM) recover() error {
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return err
+	}
+	lock, err := os.Create(filepath.Join(r.dir, goLSMLockFile))
+	if err != nil {
+		return err
+	}
+	r.lock = lock
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		return util.Errorf("could not lock %s: %s", r.dir, err)
+	}
+
+	m := &goLSMManifest{nextFileNum: 1}
+	if data, err := ioutil.ReadFile(filepath.Join(r.dir, goLSMManifestFile)); err == nil {
+		if m, err = decodeGoLSMManifest(data); err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+	var tables []*lsmTable
+	for _, num := range m.tableNums {
+		t, err := r.openTableFile(num)
+		if err != nil {
+			newTableSet(tables).unref()
+			return err
+		}
+		tables = append(tables, t)
+	}
+	r.tables = newTableSet(tables)
+	r.nextFileNum, r.logNum = m.nextFileNum, m.logNum
+
+	infos, err := ioutil.ReadDir(r.dir)
+	if err != nil {
+		return err
+	}
+	var logNums []uint64
+	for _, info := range infos {
+		num, ext, ok := parseGoLSMFileName(info.Name())
+		if !ok {
+			continue
+		}
+		if num >= r.nextFileNum {
+			r.nextFileNum = num + 1
+		}
+		if ext == goLSMLogExt && num >= r.logNum {
+			logNums = append(logNums, num)
+		}
+	}
+	sort.Sort(uint64Slice(logNums))
+	for _, num := range logNums {
+		if err := r.replayLog(num); err != nil {
+			return err
+		}
+	}
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	if err := r.flushLocked(r.mem, nil); err != nil {
+		return err
+	}
+	return r.removeObsoleteFiles()
+}
+
+// Close closes the engine. Snapshots and iterators must be closed
+// beforehand.
+func (r *GoLSM) Close() {
+	if atomic.AddInt32(&r.refcount, -1) > 0 {
+		return
+	}
+	if len(r.dir) == 0 {
+		log.Infof("closing in-memory golsm instance")
+	} else {
+		log.Infof("closing golsm instance at %q", r.dir)
+	}
+	if r.mem == nil {
+		return
+	}
+	close(r.stopper)
+	r.wg.Wait()
+	r.closeFiles()
+	r.mem, r.tables = nil, nil
+}
+
+// closeFiles releases the tables, the write-ahead log and the lock
+// of the data directory.
+func (r *GoLSM) closeFiles() {
+	if r.tables != nil {
+		r.tables.unref()
+	}
+	if r.log != nil {
+		if err := r.log.Close(); err != nil {
+			log.Warningf("closing log %d: %s", r.logNum, err)
+		}
+		r.log = nil
+	}
+	if r.lock != nil {
+		_ = r.lock.Close()
+		r.lock = nil
+	}
+}
+
+// Destroy destroys the underlying filesystem data associated with the
+// database. The engine must be closed.
+func (r *GoLSM) Destroy() error {
+	if len(r.dir) == 0 {
+		return nil
+	}
+	infos, err := ioutil.ReadDir(r.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, info := range infos {
+		name := info.Name()
+		if _, _, ok := parseGoLSMFileName(name); !ok && name != goLSMManifestFile &&
+			name != goLSMManifestFile+".tmp" && name != goLSMLockFile {
+			continue
+		}
+		if err := os.Remove(filepath.Join(r.dir, name)); err != nil {
+			return err
+		}
+	}
+	// Like RocksDB, leave the directory in place if it holds other files.
+	_ = os.Remove(r.dir)
+	return nil
+}
+
+// Attrs returns the list of attributes describing this engine.
+func (r *GoLSM) Attrs() proto.Attributes {
+	return r.attrs
+}
+
+// Put sets the given key to the value provided.
+func (r *GoLSM) Put(key proto.EncodedKey, value []byte) error {
+	if len(key) == 0 {
+		return emptyKeyError()
+	}
+	return r.write([]lsmEntry{{key: copyBytes(key), value: copyBytes(value), kind: kindPut}}, nil)
+}
+
+// Merge merges the given value into the value of key. See the
+// documentation of mergeValues for details.
+func (r *GoLSM) Merge(key proto.EncodedKey, value []byte) error {
+	if len(key) == 0 {
+		return emptyKeyError()
+	}
+	return r.write([]lsmEntry{{key: copyBytes(key), kind: kindMerge, operands: [][]byte{copyBytes(value)}}}, nil)
+}
+
+// PutSorted writes the sorted key/value pairs to a new table, which is
+// installed as the newest table of the engine.
+func (r *GoLSM) PutSorted(kvs []proto.RawKeyValue) error {
+	b := newGoLSMBatch(r)
+	if err := b.PutSorted(kvs); err != nil {
+		return err
+	}
+	return b.Commit()
+}
+
+// Get returns the value for the given key.
+func (r *GoLSM) Get(key proto.EncodedKey) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, emptyKeyError()
+	}
+	v := r.view()
+	defer v.release()
+	return v.get(key)
+}
+
+// GetProto fetches the value at the specified key and unmarshals it.
+func (r *GoLSM) GetProto(key proto.EncodedKey, msg gogoproto.Message) (
+	ok bool, keyBytes, valBytes int64, err error) {
+	return getProto(r, key, msg)
+}
+
+// Clear removes the item from the db with the given key.
+func (r *GoLSM) Clear(key proto.EncodedKey) error {
+	if len(key) == 0 {
+		return emptyKeyError()
+	}
+	return r.write([]lsmEntry{{key: copyBytes(key), kind: kindDelete}}, nil)
+}
+
+// Iterate iterates from start to end keys, invoking f on each
+// key/value pair. See engine.Iterate for details.
+func (r *GoLSM) Iterate(start, end proto.EncodedKey, f func(proto.RawKeyValue) (bool, error)) error {
+	return iterate(r, start, end, f)
+}
+
+// Capacity queries the underlying file system for disk capacity information.
+func (r *GoLSM) Capacity() (proto.StoreCapacity, error) {
+	return fsCapacity(r.dir)
+}
+
+// SetGCTimeouts sets the timeouts used to garbage collect transaction
+// records and response cache entries during compactions.
+func (r *GoLSM) SetGCTimeouts(minTxnTS, minRCacheTS int64) {
+	r.gcMu.Lock()
+	defer r.gcMu.Unlock()
+	r.minTxnTS, r.minRCacheTS = minTxnTS, minRCacheTS
+}
+
+// CompactRange compacts all tables, including the memtable, into a
+// single table. The key range is currently ignored.
+func (r *GoLSM) CompactRange(start, end proto.EncodedKey) {
+	if err := r.Flush(); err != nil {
+		log.Warningf("compact range: %s", err)
+		return
+	}
+	r.compactMu.Lock()
+	defer r.compactMu.Unlock()
+	v := r.view()
+	defer v.release()
+	if len(v.tables.tables) == 0 {
+		return
+	}
+	if err := r.compact(v.tables.tables, true); err != nil {
+		log.Warningf("compact range: %s", err)
+	}
+}
+
+// ApproximateSize returns the approximate number of bytes the engine
+// is using to store data for the given range of keys.
+func (r *GoLSM) ApproximateSize(start, end proto.EncodedKey) (uint64, error) {
+	v := r.view()
+	defer v.release()
+	size := v.mem.approximateSize(start, end)
+	for _, t := range v.tables.tables {
+		if s, e := t.offset(start), t.offset(end); e > s {
+			size += e - s
+		}
+	}
+	return size, nil
+}
+
+// Flush writes the memtable to a table immediately.
+func (r *GoLSM) Flush() error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	return r.flushLocked(r.mem, nil)
+}
+
+// NewIterator returns an iterator over this engine.
+func (r *GoLSM) NewIterator() Iterator {
+	v := r.view()
+	return newGoLSMIterator(v, nil, v.release)
+}
+
+// NewSnapshot returns a read-only snapshot of the engine.
+func (r *GoLSM) NewSnapshot() Engine {
+	if r.mem == nil {
+		panic("GoLSM is not initialized yet")
+	}
+	return &goLSMSnapshot{
+		parent: r,
+		view:   r.view(),
+	}
+}
+
+// NewBatch returns a new batch wrapping this engine.
+func (r *GoLSM) NewBatch() Engine {
+	return newGoLSMBatch(r)
+}
+
+// Commit is a noop for GoLSM engine.
+func (r *GoLSM) Commit() error {
+	return nil
+}
+
+// Defer is not implemented for GoLSM engine.
+func (r *GoLSM) Defer(func()) {
+	panic("only implemented for goLSMBatch")
+}
+
+// write atomically applies the entries to the engine, along with the
+// ingested table if not nil. Merge entries are resolved against the
+// current values of their keys.
+//
+// An ingested table holds keys which aren't present in the engine, but
+// which may have been deleted by entries of the memtable. The memtable
+// is therefore flushed along with the entries, and the table installed
+// above the flushed table by the same update of the manifest. The
+// entries aren't written to the write-ahead log in that case, as the
+// manifest update persists them along with the ingested table.
+func (r *GoLSM) write(entries []lsmEntry, ingested *lsmTable) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	v := lsmView{mem: r.mem, tables: r.tables}
+	for i := range entries {
+		e := &entries[i]
+		if e.kind == kindMerge {
+			base, err := v.get(e.key)
+			if err != nil {
+				return err
+			}
+			value, err := e.resolve(base)
+			if err != nil {
+				return err
+			}
+			*e = lsmEntry{key: e.key, value: value, kind: kindPut}
+		}
+		v.mem = v.mem.set(*e)
+	}
+	if ingested != nil {
+		return r.flushLocked(v.mem, ingested)
+	}
+	if r.log != nil {
+		if _, err := r.log.Write(encodeLogRecord(entries)); err != nil {
+			return err
+		}
+	}
+	r.mu.Lock()
+	r.mem = v.mem
+	r.mu.Unlock()
+	if r.mem.size >= r.memtableSize {
+		return r.flushLocked(r.mem, nil)
+	}
+	return nil
+}
+
+// flushLocked writes mem, which is the memtable or a successor of it,
+// to a new table and replaces the memtable with an empty one. The
+// ingested table, if not nil, is installed above the new table. For
+// persistent engines, a new write-ahead log is started and the
+// manifest is updated. The caller must hold writeMu.
+func (r *GoLSM) flushLocked(mem *memTable, ingested *lsmTable) error {
+	if mem.root == nil && ingested == nil && (r.dir == "" || r.log != nil) {
+		return nil
+	}
+	tables := r.tables.tables
+	var flushed *lsmTable
+	if mem.root != nil {
+		num := r.nextFileNum
+		r.nextFileNum++
+		// Deletions need not be retained if there are no older tables.
+		t, err := r.writeTable(num, newMemIterator(mem), len(tables) == 0, nil)
+		if err != nil {
+			return err
+		}
+		if t != nil {
+			flushed = t
+			tables = append([]*lsmTable{t}, tables...)
+		}
+	}
+	if ingested != nil {
+		tables = append([]*lsmTable{ingested}, tables...)
+	}
+	oldLog, oldLogNum := r.log, r.logNum
+	if r.dir != "" {
+		logNum := r.nextFileNum
+		r.nextFileNum++
+		f, err := os.Create(filepath.Join(r.dir, goLSMFileName(logNum, goLSMLogExt)))
+		if err == nil {
+			err = r.writeManifest(&goLSMManifest{
+				nextFileNum: r.nextFileNum,
+				logNum:      logNum,
+				tableNums:   tableNums(tables),
+			})
+		}
+		if err != nil {
+			if f != nil {
+				_ = f.Close()
+			}
+			if flushed != nil {
+				discardTable(flushed)
+			}
+			return err
+		}
+		r.log, r.logNum = f, logNum
+	}
+
+	r.mu.Lock()
+	oldTables := r.tables
+	r.mem, r.tables = &memTable{}, newTableSet(tables)
+	r.mu.Unlock()
+	oldTables.unref()
+
+	if oldLog != nil {
+		if err := oldLog.Close(); err != nil {
+			log.Warningf("closing log %d: %s", oldLogNum, err)
+		}
+		if err := os.Remove(filepath.Join(r.dir, goLSMFileName(oldLogNum, goLSMLogExt))); err != nil {
+			log.Warningf("removing log %d: %s", oldLogNum, err)
+		}
+	}
+	r.maybeScheduleCompaction()
+	return nil
+}
+
+// writeTable writes the entries of the iterator to a new table with
+// the given number. Deletions are dropped if dropDeletes is true; put
+// entries for which gc returns true are turned into deletions. No
+// table is created if there are no entries to write.
+func (r *GoLSM) writeTable(num uint64, it entryIterator, dropDeletes bool,
+	gc func(*lsmEntry) bool) (*lsmTable, error) {
+	f, finish, abort, err := r.createTableFile(num)
+	if err != nil {
+		return nil, err
+	}
+	bw := bufio.NewWriterSize(f, 64<<10)
+	w := newTableWriter(bw)
+	for it.seek(nil); it.valid(); it.next() {
+		e := it.entry()
+		if e.kind == kindPut && gc != nil && gc(e) {
+			e = &lsmEntry{key: e.key, kind: kindDelete}
+		}
+		if e.kind == kindDelete && dropDeletes {
+			continue
+		}
+		if err := w.add(e); err != nil {
+			abort()
+			return nil, err
+		}
+	}
+	if err := it.status(); err != nil {
+		abort()
+		return nil, err
+	}
+	if w.empty() {
+		abort()
+		return nil, nil
+	}
+	if err := w.finish(); err != nil {
+		abort()
+		return nil, err
+	}
+	if err := bw.Flush(); err != nil {
+		abort()
+		return nil, err
+	}
+	t, err := finish()
+	if err != nil {
+		abort()
+		return nil, err
+	}
+	return t, nil
+}
+
+// discardTable releases a table which was not installed, removing its
+// file.
+func discardTable(t *lsmTable) {
+	atomic.StoreInt32(&t.obsolete, 1)
+	t.ref()
+	t.unref()
+}
+
+func tableNums(tables []*lsmTable) []uint64 {
+	nums := make([]uint64, len(tables))
+	for i, t := range tables {
+		nums[i] = t.num
+	}
+	return nums
+}
+
+// maybeScheduleCompaction signals the compaction loop.
+func (r *GoLSM) maybeScheduleCompaction() {
+	if r.compactC == nil {
+		return
+	}
+	select {
+	case r.compactC <- struct{}{}:
+	default:
+	}
+}
+
+// compactLoop compacts the tables of the engine in the background
+// whenever signaled, until the engine is closed.
+func (r *GoLSM) compactLoop() {
+	defer r.wg.Done()
+	for {
+		select {
+		case <-r.compactC:
+			r.compactMu.Lock()
+			for {
+				v := r.view()
+				tables := pickCompaction(v.tables.tables)
+				var err error
+				if tables != nil {
+					err = r.compact(tables, len(tables) == len(v.tables.tables))
+				}
+				v.release()
+				if err != nil {
+					log.Warningf("compaction failed: %s", err)
+				}
+				if tables == nil || err != nil {
+					break
+				}
+			}
+			r.compactMu.Unlock()
+		case <-r.stopper:
+			return
+		}
+	}
+}
+
+// pickCompaction returns the tables to compact, if any. Tables are
+// compacted once there are compactionTrigger of them, starting from
+// the newest and including older tables as long as they are no larger
+// than twice the size of the tables included so far, which bounds the
+// number of times data is rewritten. All tables are compacted once
+// there are maxTables of them.
+func pickCompaction(tables []*lsmTable) []*lsmTable {
+	if len(tables) >= maxTables {
+		return tables
+	}
+	if len(tables) < compactionTrigger {
+		return nil
+	}
+	var n int
+	var size uint64
+	for n < len(tables) && (n == 0 || tables[n].size <= 2*size) {
+		size += tables[n].size
+		n++
+	}
+	if n < 2 {
+		return nil
+	}
+	return tables[:n]
+}
+
+// compact merges the given run of current tables into a single table.
+// Deletions are dropped if the run includes the oldest table, as
+// indicated by bottom. The caller must hold compactMu.
+func (r *GoLSM) compact(tables []*lsmTable, bottom bool) error {
+	r.writeMu.Lock()
+	num := r.nextFileNum
+	r.nextFileNum++
+	r.writeMu.Unlock()
+
+	r.gcMu.Lock()
+	minTxnTS, minRCacheTS := r.minTxnTS, r.minRCacheTS
+	r.gcMu.Unlock()
+
+	iters := make([]entryIterator, len(tables))
+	for i, t := range tables {
+		iters[i] = newTableIterator(t)
+	}
+	t, err := r.writeTable(num, &mergingIterator{iters: iters}, bottom, func(e *lsmEntry) bool {
+		return isGCable(e.key, e.value, minTxnTS, minRCacheTS)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+	// Flushes may have added newer tables since the compaction started.
+	current := r.tables.tables
+	i := 0
+	for i < len(current) && current[i] != tables[0] {
+		i++
+	}
+	if i+len(tables) > len(current) {
+		panic("compacted tables are no longer current")
+	}
+	var newTables []*lsmTable
+	newTables = append(newTables, current[:i]...)
+	if t != nil {
+		newTables = append(newTables, t)
+	}
+	newTables = append(newTables, current[i+len(tables):]...)
+	if r.dir != "" {
+		if err := r.writeManifest(&goLSMManifest{
+			nextFileNum: r.nextFileNum,
+			logNum:      r.logNum,
+			tableNums:   tableNums(newTables),
+		}); err != nil {
+			if t != nil {
+				discardTable(t)
+			}
+			return err
+		}
+	}
+	for _, t := range tables {
+		atomic.StoreInt32(&t.obsolete, 1)
+	}
+	r.mu.Lock()
+	oldTables := r.tables
+	r.tables = newTableSet(newTables)
+	r.mu.Unlock()
+	oldTables.unref()
+	return nil
+}
+
+// isGCable returns true if the key and value are those of a response
+// cache entry or a transaction record whose timestamp is not after
+// the corresponding GC timeout. This mirrors the compaction filter in
+// db.cc.
+func isGCable(encKey, value []byte, minTxnTS, minRCacheTS int64) (gc bool) {
+	// Keys which are not valid MVCC keys are never garbage collected.
+	defer func() {
+		if recover() != nil {
+			gc = false
+		}
+	}()
+	key, _, isValue := MVCCDecodeKey(encKey)
+	if isValue {
+		return false
+	}
+	var isRCache, isTxn bool
+	if bytes.HasPrefix(key, keys.LocalRangeIDPrefix) {
+		b, _ := encoding.DecodeUvarint(key[len(keys.LocalRangeIDPrefix):])
+		isRCache = bytes.HasPrefix(b, keys.LocalResponseCacheSuffix)
+	} else if bytes.HasPrefix(key, keys.LocalRangePrefix) {
+		_, suffix, _ := keys.DecodeRangeKey(key)
+		isTxn = bytes.Equal(suffix, keys.LocalTransactionSuffix)
+	}
+	if !isRCache && !isTxn {
+		return false
+	}
+	var meta MVCCMetadata
+	if err := gogoproto.Unmarshal(value, &meta); err != nil || meta.Value == nil {
+		return false
+	}
+	if isRCache {
+		var rwResp proto.ReadWriteCmdResponse
+		if err := gogoproto.Unmarshal(meta.Value.Bytes, &rwResp); err != nil {
+			return false
+		}
+		resp, ok := rwResp.GetValue().(proto.Response)
+		return ok && resp.Header().Timestamp.WallTime <= minRCacheTS
+	}
+	var txn proto.Transaction
+	if err := gogoproto.Unmarshal(meta.Value.Bytes, &txn); err != nil {
+		return false
+	}
+	return txn.Timestamp.WallTime <= minTxnTS
+}
+
+// view returns a consistent view of the current contents of the
+// engine, which must be released when done.
+func (r *GoLSM) view() lsmView {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	r.tables.ref()
+	return lsmView{mem: r.mem, tables: r.tables}
+}
+
+// lsmView is a consistent, read-only view of the contents of a GoLSM
+// engine.
+type lsmView struct {
+	mem    *memTable
+	tables *tableSet
+}
+
+func (v lsmView) release() {
+	v.tables.unref()
+}
+
+// get returns the value for the given key, or nil if there is none.
+func (v lsmView) get(key []byte) ([]byte, error) {
+	if e := v.mem.get(key); e != nil {
+		if e.kind == kindDelete {
+			return nil, nil
+		}
+		return copyBytes(e.value), nil
+	}
+	for _, t := range v.tables.tables {
+		e, err := t.get(key)
+		if err != nil {
+			return nil, err
+		}
+		if e != nil {
+			if e.kind == kindDelete {
+				return nil, nil
+			}
+			return e.value, nil
+		}
+	}
+	return nil, nil
+}
+
+// newIterator returns an iterator over the entries of the view,
+// beneath those of the overlay memtable if non-nil.
+func (v lsmView) newIterator(overlay *memTable) *mergingIterator {
+	var iters []entryIterator
+	if overlay != nil {
+		iters = append(iters, newMemIterator(overlay))
+	}
+	iters = append(iters, newMemIterator(v.mem))
+	for _, t := range v.tables.tables {
+		iters = append(iters, newTableIterator(t))
+	}
+	return &mergingIterator{iters: iters}
+}
+
+// entryIterator iterates over the entries of a layer of a GoLSM
+// engine in key order, including deletions.
+type entryIterator interface {
+	// seek positions the iterator at the first entry whose key is
+	// greater than or equal to key.
+	seek(key []byte)
+	valid() bool
+	next()
+	// entry returns the current entry, which must not be modified.
+	entry() *lsmEntry
+	// status returns the error, if any, encountered by the iterator.
+	status() error
+}
+
+// mergingIterator merges entry iterators over layers ordered from the
+// newest to the oldest. For each key, it yields the entry of the
+// newest layer, with merge entries resolved against older layers.
+type mergingIterator struct {
+	iters []entryIterator
+	cur   lsmEntry
+	ok    bool
+	err   error
+}
+
+func (m *mergingIterator) seek(key []byte) {
+	for _, it := range m.iters {
+		it.seek(key)
+	}
+	m.findNext()
+}
+
+func (m *mergingIterator) valid() bool {
+	return m.ok
+}
+
+func (m *mergingIterator) next() {
+	key := m.cur.key
+	for _, it := range m.iters {
+		if it.valid() && bytes.Equal(it.entry().key, key) {
+			it.next()
+		}
+	}
+	m.findNext()
+}
+
+// findNext positions the iterator at the smallest key of the layers.
+func (m *mergingIterator) findNext() {
+	m.ok = false
+	var key []byte
+	found := false
+	for _, it := range m.iters {
+		if err := it.status(); err != nil {
+			m.err = err
+			return
+		}
+		if it.valid() && (!found || bytes.Compare(it.entry().key, key) < 0) {
+			key, found = it.entry().key, true
+		}
+	}
+	if !found {
+		return
+	}
+	// Collect the merge entries for the key down to the newest layer
+	// with a put or a deletion, which provides the base value.
+	var merges []*lsmEntry
+	var base []byte
+	for _, it := range m.iters {
+		if !it.valid() || !bytes.Equal(it.entry().key, key) {
+			continue
+		}
+		e := it.entry()
+		if e.kind == kindMerge {
+			merges = append(merges, e)
+			continue
+		}
+		if len(merges) == 0 {
+			m.cur, m.ok = *e, true
+			return
+		}
+		if e.kind == kindPut {
+			base = e.value
+		}
+		break
+	}
+	for i := len(merges) - 1; i >= 0; i-- {
+		var err error
+		if base, err = merges[i].resolve(base); err != nil {
+			m.err = err
+			return
+		}
+	}
+	m.cur, m.ok = lsmEntry{key: key, value: base, kind: kindPut}, true
+}
+
+func (m *mergingIterator) entry() *lsmEntry {
+	return &m.cur
+}
+
+func (m *mergingIterator) status() error {
+	return m.err
+}
+
+// goLSMIterator implements the Iterator interface over a view of a
+// GoLSM engine, skipping deletions.
+type goLSMIterator struct {
+	iter    *mergingIterator
+	release func()
+}
+
+// newGoLSMIterator returns an iterator over the view beneath the
+// overlay memtable if non-nil. release is invoked when the iterator
+// is closed.
+func newGoLSMIterator(v lsmView, overlay *memTable, release func()) *goLSMIterator {
+	return &goLSMIterator{
+		iter:    v.newIterator(overlay),
+		release: release,
+	}
+}
+
+// The following methods implement the Iterator interface.
+func (r *goLSMIterator) Close() {
+	if r.release != nil {
+		r.release()
+		r.release = nil
+	}
+}
+
+func (r *goLSMIterator) Seek(key []byte) {
+	r.iter.seek(key)
+	r.skipDeletions()
+}
+
+func (r *goLSMIterator) Valid() bool {
+	return r.iter.valid()
+}
+
+func (r *goLSMIterator) Next() {
+	r.iter.next()
+	r.skipDeletions()
+}
+
+func (r *goLSMIterator) skipDeletions() {
+	for r.iter.valid() && r.iter.entry().kind == kindDelete {
+		r.iter.next()
+	}
+}
+
+func (r *goLSMIterator) Key() proto.EncodedKey {
+	return copyBytes(r.iter.entry().key)
+}
+
+func (r *goLSMIterator) Value() []byte {
+	return copyBytes(r.iter.entry().value)
+}
+
+func (r *goLSMIterator) ValueProto(msg gogoproto.Message) error {
+	value := r.iter.entry().value
+	if len(value) == 0 {
+		return nil
+	}
+	return gogoproto.Unmarshal(value, msg)
+}
+
+func (r *goLSMIterator) Error() error {
+	return r.iter.status()
+}
+
+type goLSMSnapshot struct {
+	parent *GoLSM
+	view   lsmView
+}
+
+// Open is a noop.
+func (r *goLSMSnapshot) Open() error {
+	return nil
+}
+
+// Close releases the snapshot.
+func (r *goLSMSnapshot) Close() {
+	r.view.release()
+}
+
+// Attrs returns the engine/store attributes.
+func (r *goLSMSnapshot) Attrs() proto.Attributes {
+	return r.parent.Attrs()
+}
+
+// Put is illegal for snapshot and returns an error.
+func (r *goLSMSnapshot) Put(key proto.EncodedKey, value []byte) error {
+	return util.Errorf("cannot Put to a snapshot")
+}
+
+// PutSorted is illegal for snapshot and returns an error.
+func (r *goLSMSnapshot) PutSorted(kvs []proto.RawKeyValue) error {
+	return util.Errorf("cannot PutSorted to a snapshot")
+}
+
+// Get returns the value for the given key, nil otherwise, as of the
+// snapshot.
+func (r *goLSMSnapshot) Get(key proto.EncodedKey) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, emptyKeyError()
+	}
+	return r.view.get(key)
+}
+
+func (r *goLSMSnapshot) GetProto(key proto.EncodedKey, msg gogoproto.Message) (
+	ok bool, keyBytes, valBytes int64, err error) {
+	return getProto(r, key, msg)
+}
+
+// Iterate iterates over the keys between start inclusive and end
+// exclusive as of the snapshot, invoking f() on each key/value pair.
+func (r *goLSMSnapshot) Iterate(start, end proto.EncodedKey, f func(proto.RawKeyValue) (bool, error)) error {
+	return iterate(r, start, end, f)
+}
+
+// Clear is illegal for snapshot and returns an error.
+func (r *goLSMSnapshot) Clear(key proto.EncodedKey) error {
+	return util.Errorf("cannot Clear from a snapshot")
+}
+
+// Merge is illegal for snapshot and returns an error.
+func (r *goLSMSnapshot) Merge(key proto.EncodedKey, value []byte) error {
+	return util.Errorf("cannot Merge to a snapshot")
+}
+
+// Capacity returns capacity details for the engine's available storage.
+func (r *goLSMSnapshot) Capacity() (proto.StoreCapacity, error) {
+	return r.parent.Capacity()
+}
+
+// SetGCTimeouts is a noop for a snapshot.
+func (r *goLSMSnapshot) SetGCTimeouts(minTxnTS, minRCacheTS int64) {
+}
+
+// ApproximateSize returns the approximate number of bytes the engine is
+// using to store data for the given range of keys.
+func (r *goLSMSnapshot) ApproximateSize(start, end proto.EncodedKey) (uint64, error) {
+	return r.parent.ApproximateSize(start, end)
+}
+
+// Flush is a no-op for snapshots.
+func (r *goLSMSnapshot) Flush() error {
+	return nil
+}
+
+// NewIterator returns a new instance of an Iterator over the
+// engine as of the snapshot.
+func (r *goLSMSnapshot) NewIterator() Iterator {
+	r.view.tables.ref()
+	return newGoLSMIterator(r.view, nil, r.view.release)
+}
+
+// NewSnapshot is illegal for snapshot.
+func (r *goLSMSnapshot) NewSnapshot() Engine {
+	panic("cannot create a NewSnapshot from a snapshot")
+}
+
+// NewBatch is illegal for snapshot.
+func (r *goLSMSnapshot) NewBatch() Engine {
+	panic("cannot create a NewBatch from a snapshot")
+}
+
+// Commit is illegal for snapshot and returns an error.
+func (r *goLSMSnapshot) Commit() error {
+	return util.Errorf("cannot Commit to a snapshot")
+}
+
+// Defer is not implemented for goLSMSnapshot.
+func (r *goLSMSnapshot) Defer(func()) {
+	panic("only implemented for goLSMBatch")
+}
+
+// goLSMBatch accumulates updates in a memtable of its own, which
+// overlays the engine for reads, and applies them atomically to the
+// engine on Commit. Merges into keys which have not been written by
+// the batch are kept as merge entries, which are resolved against the
+// engine when read or committed. Pairs written by PutSorted are kept
+// apart, and ingested as a table of their own on Commit.
+type goLSMBatch struct {
+	parent   *GoLSM
+	mem      *memTable
+	ingested *memTable
+	defers   []func()
+}
+
+func newGoLSMBatch(r *GoLSM) *goLSMBatch {
+	return &goLSMBatch{
+		parent: r,
+		mem:    &memTable{},
+	}
+}
+
+func (r *goLSMBatch) Open() error {
+	return util.Errorf("cannot open a batch")
+}
+
+func (r *goLSMBatch) Close() {
+}
+
+// Attrs returns the engine/store attributes.
+func (r *goLSMBatch) Attrs() proto.Attributes {
+	return r.parent.Attrs()
+}
+
+func (r *goLSMBatch) Put(key proto.EncodedKey, value []byte) error {
+	if len(key) == 0 {
+		return emptyKeyError()
+	}
+	r.mem = r.mem.set(lsmEntry{key: copyBytes(key), value: copyBytes(value), kind: kindPut})
+	return nil
+}
+
+func (r *goLSMBatch) Merge(key proto.EncodedKey, value []byte) error {
+	if len(key) == 0 {
+		return emptyKeyError()
+	}
+	e := lsmEntry{key: copyBytes(key), kind: kindMerge, operands: [][]byte{copyBytes(value)}}
+	if old := r.mem.get(key); old != nil {
+		if old.kind == kindMerge {
+			e.operands = append(append([][]byte(nil), old.operands...), e.operands[0])
+		} else {
+			base, err := old.resolve(nil)
+			if err != nil {
+				return err
+			}
+			if e.value, err = e.resolve(base); err != nil {
+				return err
+			}
+			e.kind, e.operands = kindPut, nil
+		}
+	}
+	r.mem = r.mem.set(e)
+	return nil
+}
+
+func (r *goLSMBatch) PutSorted(kvs []proto.RawKeyValue) error {
+	if r.ingested == nil {
+		r.ingested = &memTable{}
+	}
+	for _, kv := range kvs {
+		if len(kv.Key) == 0 {
+			return emptyKeyError()
+		}
+		r.ingested = r.ingested.set(lsmEntry{key: copyBytes(kv.Key), value: copyBytes(kv.Value), kind: kindPut})
+	}
+	return nil
+}
+
+func (r *goLSMBatch) Get(key proto.EncodedKey) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, emptyKeyError()
+	}
+	e := r.mem.get(key)
+	if e == nil {
+		return r.parent.Get(key)
+	}
+	var base []byte
+	if e.kind == kindMerge {
+		var err error
+		if base, err = r.parent.Get(key); err != nil {
+			return nil, err
+		}
+	}
+	value, err := e.resolve(base)
+	return copyBytes(value), err
+}
+
+func (r *goLSMBatch) GetProto(key proto.EncodedKey, msg gogoproto.Message) (
+	ok bool, keyBytes, valBytes int64, err error) {
+	return getProto(r, key, msg)
+}
+
+func (r *goLSMBatch) Iterate(start, end proto.EncodedKey, f func(proto.RawKeyValue) (bool, error)) error {
+	return iterate(r, start, end, f)
+}
+
+func (r *goLSMBatch) Clear(key proto.EncodedKey) error {
+	if len(key) == 0 {
+		return emptyKeyError()
+	}
+	r.mem = r.mem.set(lsmEntry{key: copyBytes(key), kind: kindDelete})
+	return nil
+}
+
+func (r *goLSMBatch) Capacity() (proto.StoreCapacity, error) {
+	return r.parent.Capacity()
+}
+
+func (r *goLSMBatch) SetGCTimeouts(minTxnTS, minRCacheTS int64) {
+	// no-op
+}
+
+func (r *goLSMBatch) ApproximateSize(start, end proto.EncodedKey) (uint64, error) {
+	return r.parent.ApproximateSize(start, end)
+}
+
+func (r *goLSMBatch) Flush() error {
+	return util.Errorf("cannot flush a batch")
+}
+
+func (r *goLSMBatch) NewIterator() Iterator {
+	v := r.parent.view()
+	return newGoLSMIterator(v, r.mem, v.release)
+}
+
+func (r *goLSMBatch) NewSnapshot() Engine {
+	panic("cannot create a NewSnapshot from a batch")
+}
+
+func (r *goLSMBatch) NewBatch() Engine {
+	return newGoLSMBatch(r.parent)
+}
+
+func (r *goLSMBatch) Commit() error {
+	if r.mem == nil {
+		panic("this batch was already committed")
+	}
+	var entries []lsmEntry
+	it := newMemIterator(r.mem)
+	for it.seek(nil); it.valid(); it.next() {
+		entries = append(entries, *it.entry())
+	}
+	var ingested *lsmTable
+	if r.ingested != nil && r.ingested.root != nil {
+		// The table is written outside of the engine's locks; it's only
+		// referenced once installed.
+		r.parent.writeMu.Lock()
+		num := r.parent.nextFileNum
+		r.parent.nextFileNum++
+		r.parent.writeMu.Unlock()
+		var err error
+		if ingested, err = r.parent.writeTable(num, newMemIterator(r.ingested), false, nil); err != nil {
+			return err
+		}
+	}
+	if len(entries) > 0 || ingested != nil {
+		if err := r.parent.write(entries, ingested); err != nil {
+			if ingested != nil {
+				discardTable(ingested)
+			}
+			return err
+		}
+	}
+	r.mem, r.ingested = nil, nil
+
+	// On success, run the deferred functions in reverse order.
+	for i := len(r.defers) - 1; i >= 0; i-- {
+		r.defers[i]()
+	}
+	r.defers = nil
+
+	return nil
+}
+
+func (r *goLSMBatch) Defer(fn func()) {
+	r.defers = append(r.defers, fn)
+}
+
+// getProto fetches the value at the specified key of the engine and
+// unmarshals it.
+func getProto(engine Engine, key proto.EncodedKey, msg gogoproto.Message) (
+	ok bool, keyBytes, valBytes int64, err error) {
+	var value []byte
+	if value, err = engine.Get(key); err != nil {
+		return
+	}
+	if len(value) == 0 {
+		if msg != nil {
+			msg.Reset()
+		}
+		return
+	}
+	ok = true
+	if msg != nil {
+		err = gogoproto.Unmarshal(value, msg)
+	}
+	keyBytes = int64(len(key))
+	valBytes = int64(len(value))
+	return
+}
+
+// iterate iterates over the keys of the engine between start inclusive
+// and end exclusive, invoking f on each key/value pair.
+func iterate(engine Engine, start, end proto.EncodedKey, f func(proto.RawKeyValue) (bool, error)) error {
+	if bytes.Compare(start, end) >= 0 {
+		return nil
+	}
+	it := engine.NewIterator()
+	defer it.Close()
+
+	it.Seek(start)
+	for ; it.Valid(); it.Next() {
+		k := it.Key()
+		if !k.Less(end) {
+			break
+		}
+		if done, err := f(proto.RawKeyValue{Key: k, Value: it.Value()}); done || err != nil {
+			return err
+		}
+	}
+	// Check for any errors during iteration.
+	return it.Error()
+}
+
+func copyBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
+
+type uint64Slice []uint64
+
+func (s uint64Slice) Len() int           { return len(s) }
+func (s uint64Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s uint64Slice) Less(i, j int) bool { return s[i] < s[j] }
@@ -0,0 +1,47 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+// +build !cgo
+
+package engine
+
+import "github.com/cockroachdb/cockroach/proto"
+
+// In builds without cgo, RocksDB is not available and GoLSM takes its
+// place: the InMem engine is backed by an in-memory GoLSM instance and
+// merges are resolved by the Go merge operator.
+
+// InMem wraps an in-memory GoLSM engine.
+type InMem struct {
+	*GoLSM
+}
+
+// NewInMem allocates and returns a new, opened InMem engine. The cache
+// size is ignored.
+func NewInMem(attrs proto.Attributes, cacheSize int64) *InMem {
+	db := &InMem{
+		GoLSM: NewGoLSM(attrs, ""),
+	}
+	if err := db.Open(); err != nil {
+		panic(err)
+	}
+	return db
+}
+
+// goMerge merges update into existing using the Go equivalent of the
+// RocksDB merge operator.
+func goMerge(existing, update []byte) ([]byte, error) {
+	return mergeValues(existing, update)
+}
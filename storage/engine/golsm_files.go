@@ -0,0 +1,293 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package engine
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/log"
+)
+
+// The directory of a persistent GoLSM engine holds its tables, its
+// write-ahead logs and a manifest listing the tables and the first
+// log which has not been flushed to a table yet. Files are numbered
+// from a counter which is also recorded in the manifest.
+const (
+	goLSMManifestFile = "MANIFEST"
+	goLSMLockFile     = "LOCK"
+	goLSMTableExt     = ".sst"
+	goLSMLogExt       = ".log"
+)
+
+func goLSMFileName(num uint64, ext string) string {
+	return fmt.Sprintf("%06d%s", num, ext)
+}
+
+// parseGoLSMFileName returns the number and the extension of a table
+// or log file name.
+func parseGoLSMFileName(name string) (uint64, string, bool) {
+	ext := filepath.Ext(name)
+	if ext != goLSMTableExt && ext != goLSMLogExt {
+		return 0, "", false
+	}
+	num, err := strconv.ParseUint(strings.TrimSuffix(name, ext), 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return num, ext, true
+}
+
+// goLSMManifest is the persistent state of a GoLSM engine.
+type goLSMManifest struct {
+	nextFileNum uint64
+	logNum      uint64
+	tableNums   []uint64 // from the newest to the oldest
+}
+
+// encode encodes the manifest as a list of uvarints followed by their
+// CRC-32 (Castagnoli).
+func (m *goLSMManifest) encode() []byte {
+	b := appendUvarint(nil, m.nextFileNum)
+	b = appendUvarint(b, m.logNum)
+	b = appendUvarint(b, uint64(len(m.tableNums)))
+	for _, num := range m.tableNums {
+		b = appendUvarint(b, num)
+	}
+	var crc [4]byte
+	binary.LittleEndian.PutUint32(crc[:], crc32.Checksum(b, crcTable))
+	return append(b, crc[:]...)
+}
+
+func decodeGoLSMManifest(b []byte) (*goLSMManifest, error) {
+	if len(b) < 4 || crc32.Checksum(b[:len(b)-4], crcTable) != binary.LittleEndian.Uint32(b[len(b)-4:]) {
+		return nil, util.Errorf("corrupted manifest")
+	}
+	b = b[:len(b)-4]
+	var vals []uint64
+	for len(b) > 0 {
+		v, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, util.Errorf("corrupted manifest")
+		}
+		vals, b = append(vals, v), b[n:]
+	}
+	if len(vals) < 3 || uint64(len(vals)-3) != vals[2] {
+		return nil, util.Errorf("corrupted manifest")
+	}
+	return &goLSMManifest{
+		nextFileNum: vals[0],
+		logNum:      vals[1],
+		tableNums:   vals[3:],
+	}, nil
+}
+
+// writeManifest atomically replaces the manifest of the engine.
+func (r *GoLSM) writeManifest(m *goLSMManifest) error {
+	tmp := filepath.Join(r.dir, goLSMManifestFile+".tmp")
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(m.encode()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(r.dir, goLSMManifestFile)); err != nil {
+		return err
+	}
+	return syncDir(r.dir)
+}
+
+// syncDir syncs the directory, making the creation, removal and
+// renaming of the files within it durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	err = d.Sync()
+	if closeErr := d.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// A write-ahead log consists of records holding the entries of a
+// single write. Each record is prefixed with the CRC-32 (Castagnoli)
+// of its payload and the length of its payload; the entries are
+// encoded as in tables.
+const goLSMLogHeaderSize = 8
+
+// encodeLogRecord encodes the put and delete entries as a log record.
+func encodeLogRecord(entries []lsmEntry) []byte {
+	b := make([]byte, goLSMLogHeaderSize)
+	for i := range entries {
+		b = appendEntry(b, &entries[i])
+	}
+	payload := b[goLSMLogHeaderSize:]
+	binary.LittleEndian.PutUint32(b[0:], crc32.Checksum(payload, crcTable))
+	binary.LittleEndian.PutUint32(b[4:], uint32(len(payload)))
+	return b
+}
+
+// replayLog applies the records of the log to the memtable. A torn or
+// corrupted record ends the log, as it can only result from a write
+// which was interrupted by a crash.
+func (r *GoLSM) replayLog(num uint64) error {
+	data, err := ioutil.ReadFile(filepath.Join(r.dir, goLSMFileName(num, goLSMLogExt)))
+	if err != nil {
+		return err
+	}
+	for len(data) > 0 {
+		if len(data) < goLSMLogHeaderSize {
+			break
+		}
+		length := binary.LittleEndian.Uint32(data[4:])
+		if uint64(len(data)-goLSMLogHeaderSize) < uint64(length) {
+			break
+		}
+		payload := data[goLSMLogHeaderSize : goLSMLogHeaderSize+length]
+		if crc32.Checksum(payload, crcTable) != binary.LittleEndian.Uint32(data[0:]) {
+			break
+		}
+		for len(payload) > 0 {
+			var e lsmEntry
+			if e, payload, err = decodeEntry(payload); err != nil {
+				return util.Errorf("log %d: %s", num, err)
+			}
+			r.mem = r.mem.set(e)
+		}
+		data = data[goLSMLogHeaderSize+length:]
+	}
+	if len(data) > 0 {
+		log.Warningf("ignoring %d bytes of torn or corrupted records at the end of log %d", len(data), num)
+	}
+	return nil
+}
+
+// openTableFile opens the table with the given number.
+func (r *GoLSM) openTableFile(num uint64) (*lsmTable, error) {
+	f, err := os.Open(filepath.Join(r.dir, goLSMFileName(num, goLSMTableExt)))
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	t, err := openTable(num, f, uint64(info.Size()))
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// createTableFile returns a writer for the contents of a new table
+// with the given number, and a function which completes the table and
+// opens it. For in-memory engines, the table is kept in a buffer.
+func (r *GoLSM) createTableFile(num uint64) (io.Writer, func() (*lsmTable, error), func(), error) {
+	if r.dir == "" {
+		buf := &memTableFile{}
+		finish := func() (*lsmTable, error) {
+			return openTable(num, buf, uint64(len(buf.data)))
+		}
+		return buf, finish, func() {}, nil
+	}
+	path := filepath.Join(r.dir, goLSMFileName(num, goLSMTableExt))
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	abort := func() {
+		_ = f.Close()
+		if err := os.Remove(path); err != nil {
+			log.Warningf("removing table %d: %s", num, err)
+		}
+	}
+	finish := func() (*lsmTable, error) {
+		if err := f.Sync(); err != nil {
+			return nil, err
+		}
+		if err := f.Close(); err != nil {
+			return nil, err
+		}
+		return r.openTableFile(num)
+	}
+	return f, finish, abort, nil
+}
+
+// memTableFile holds the contents of a table of an in-memory engine.
+type memTableFile struct {
+	data []byte
+}
+
+func (f *memTableFile) Write(b []byte) (int, error) {
+	f.data = append(f.data, b...)
+	return len(b), nil
+}
+
+func (f *memTableFile) ReadAt(b []byte, off int64) (int, error) {
+	if off >= int64(len(f.data)) {
+		return 0, io.EOF
+	}
+	n := copy(b, f.data[off:])
+	if n < len(b) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// removeObsoleteFiles removes the logs which have been flushed and the
+// tables which are not part of the engine, such as the outputs of
+// interrupted flushes and compactions.
+func (r *GoLSM) removeObsoleteFiles() error {
+	live := map[uint64]bool{}
+	for _, t := range r.tables.tables {
+		live[t.num] = true
+	}
+	infos, err := ioutil.ReadDir(r.dir)
+	if err != nil {
+		return err
+	}
+	for _, info := range infos {
+		num, ext, ok := parseGoLSMFileName(info.Name())
+		if !ok || (ext == goLSMLogExt && num >= r.logNum) || (ext == goLSMTableExt && live[num]) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(r.dir, info.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package engine
+
+import (
+	"bytes"
+	"hash/fnv"
+)
+
+// entryKind is the kind of an entry in a GoLSM engine.
+type entryKind byte
+
+const (
+	// kindPut entries set the value of a key.
+	kindPut entryKind = iota
+	// kindDelete entries are tombstones, which shadow the values of
+	// the key in older layers.
+	kindDelete
+	// kindMerge entries hold merge operands which are yet to be
+	// merged into the value of the key in older layers. They only
+	// occur in batches; merges are resolved when committed.
+	kindMerge
+)
+
+// lsmEntry is a key/value entry in one of the layers of a GoLSM
+// engine. Entries are immutable once added to a layer.
+type lsmEntry struct {
+	key      []byte
+	value    []byte
+	kind     entryKind
+	operands [][]byte
+}
+
+// size returns the approximate number of bytes used by the entry.
+func (e *lsmEntry) size() int64 {
+	size := int64(len(e.key) + len(e.value) + 2)
+	for _, op := range e.operands {
+		size += int64(len(op))
+	}
+	return size
+}
+
+// resolve returns the value of the entry given the value of its key
+// in the older layers, which is only consulted by merge entries. A
+// nil value indicates that the key is deleted.
+func (e *lsmEntry) resolve(base []byte) ([]byte, error) {
+	switch e.kind {
+	case kindPut:
+		return e.value, nil
+	case kindDelete:
+		return nil, nil
+	}
+	value := base
+	for _, op := range e.operands {
+		var err error
+		if value, err = mergeValues(value, op); err != nil {
+			return nil, err
+		}
+	}
+	return value, nil
+}
+
+// memNode is a node of the treap holding the entries of a memTable.
+// Nodes are copied rather than modified on insertion, so that a root
+// node remains a consistent view of the entries which can be read
+// without locking.
+type memNode struct {
+	lsmEntry
+	priority    uint32
+	left, right *memNode
+}
+
+// memTable is an immutable, sorted in-memory table of entries.
+// Updating a memTable returns a new memTable which shares all
+// unmodified nodes with the original.
+type memTable struct {
+	root *memNode
+	size int64 // approximate number of bytes used by the entries
+}
+
+// get returns the entry for key, or nil if there is none.
+func (m *memTable) get(key []byte) *lsmEntry {
+	for n := m.root; n != nil; {
+		switch c := bytes.Compare(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return &n.lsmEntry
+		}
+	}
+	return nil
+}
+
+// set returns a new memTable in which the entry replaces any entry
+// for the same key.
+func (m *memTable) set(e lsmEntry) *memTable {
+	size := m.size + e.size()
+	if old := m.get(e.key); old != nil {
+		size -= old.size()
+	}
+	h := fnv.New32a()
+	_, _ = h.Write(e.key)
+	n := &memNode{lsmEntry: e, priority: h.Sum32()}
+	return &memTable{root: memInsert(m.root, n), size: size}
+}
+
+// memInsert inserts the leaf node n into the treap rooted at root,
+// copying the nodes on its path, and returns the new root.
+func memInsert(root, n *memNode) *memNode {
+	if root == nil {
+		return n
+	}
+	c := bytes.Compare(n.key, root.key)
+	if c == 0 {
+		n.left, n.right = root.left, root.right
+		return n
+	}
+	// The copy of root and the node returned by memInsert are both
+	// new, so they may be rotated in place.
+	newRoot := *root
+	if c < 0 {
+		newRoot.left = memInsert(root.left, n)
+		if l := newRoot.left; l.priority > newRoot.priority {
+			newRoot.left, l.right = l.right, &newRoot
+			return l
+		}
+	} else {
+		newRoot.right = memInsert(root.right, n)
+		if r := newRoot.right; r.priority > newRoot.priority {
+			newRoot.right, r.left = r.left, &newRoot
+			return r
+		}
+	}
+	return &newRoot
+}
+
+// approximateSize returns the approximate number of bytes used by the
+// entries with keys between start and end.
+func (m *memTable) approximateSize(start, end []byte) uint64 {
+	var size int64
+	it := newMemIterator(m)
+	for it.seek(start); it.valid() && bytes.Compare(it.entry().key, end) < 0; it.next() {
+		size += it.entry().size()
+	}
+	return uint64(size)
+}
+
+// memIterator iterates over the entries of a memTable in key order.
+type memIterator struct {
+	root *memNode
+	// stack holds the nodes whose keys have yet to be visited and whose
+	// left subtrees have been visited, with the current node on top.
+	stack []*memNode
+}
+
+func newMemIterator(m *memTable) *memIterator {
+	return &memIterator{root: m.root}
+}
+
+func (it *memIterator) seek(key []byte) {
+	it.stack = it.stack[:0]
+	for n := it.root; n != nil; {
+		if bytes.Compare(n.key, key) >= 0 {
+			it.stack = append(it.stack, n)
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+}
+
+func (it *memIterator) valid() bool {
+	return len(it.stack) > 0
+}
+
+func (it *memIterator) next() {
+	n := it.stack[len(it.stack)-1]
+	it.stack = it.stack[:len(it.stack)-1]
+	for n = n.right; n != nil; n = n.left {
+		it.stack = append(it.stack, n)
+	}
+}
+
+func (it *memIterator) entry() *lsmEntry {
+	return &it.stack[len(it.stack)-1].lsmEntry
+}
+
+func (it *memIterator) status() error {
+	return nil
+}
@@ -0,0 +1,412 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package engine
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+	"sync/atomic"
+
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/log"
+)
+
+// A table is an immutable, sorted file of entries. It consists of
+// blocks of entries, each followed by the CRC-32 (Castagnoli) of its
+// contents, an index block in the same format holding the last key
+// and the location of each block, and a fixed-size footer. Entries
+// are encoded as the uvarint length of the key, the uvarint length of
+// the value shifted left by one with the lowest bit set for
+// deletions, the key and the value.
+const (
+	tableBlockSize  = 4 << 10
+	tableFooterSize = 24
+	tableMagic      = 0x7461626c65676f31 // "tablego1"
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// blockHandle locates a block of a table.
+type blockHandle struct {
+	lastKey []byte // the last key in the block
+	offset  uint64
+	length  uint64 // excluding the checksum
+}
+
+// tableWriter writes a table to an underlying writer. Entries must
+// be added in increasing key order.
+type tableWriter struct {
+	w       io.Writer
+	offset  uint64
+	block   []byte
+	lastKey []byte
+	index   []blockHandle
+	scratch [binary.MaxVarintLen64]byte
+}
+
+func newTableWriter(w io.Writer) *tableWriter {
+	return &tableWriter{w: w}
+}
+
+// appendUvarint appends the uvarint encoding of v to b.
+func appendUvarint(b []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+// appendEntry appends the encoding of a put or delete entry to b.
+func appendEntry(b []byte, e *lsmEntry) []byte {
+	valLen := uint64(len(e.value)) << 1
+	if e.kind == kindDelete {
+		valLen = 1
+	}
+	b = appendUvarint(b, uint64(len(e.key)))
+	b = appendUvarint(b, valLen)
+	b = append(b, e.key...)
+	if e.kind != kindDelete {
+		b = append(b, e.value...)
+	}
+	return b
+}
+
+// decodeEntry decodes an entry encoded by appendEntry from the front
+// of b, returning the remainder of b. The key and value of the entry
+// refer to b.
+func decodeEntry(b []byte) (lsmEntry, []byte, error) {
+	keyLen, n := binary.Uvarint(b)
+	if n <= 0 {
+		return lsmEntry{}, nil, util.Errorf("corrupted entry")
+	}
+	b = b[n:]
+	valLen, n := binary.Uvarint(b)
+	if n <= 0 {
+		return lsmEntry{}, nil, util.Errorf("corrupted entry")
+	}
+	b = b[n:]
+	e := lsmEntry{kind: kindPut}
+	if valLen&1 != 0 {
+		e.kind = kindDelete
+	}
+	valLen >>= 1
+	if keyLen > uint64(len(b)) || valLen > uint64(len(b))-keyLen {
+		return lsmEntry{}, nil, util.Errorf("corrupted entry")
+	}
+	e.key = b[:keyLen:keyLen]
+	if e.kind == kindPut {
+		e.value = b[keyLen : keyLen+valLen : keyLen+valLen]
+	}
+	return e, b[keyLen+valLen:], nil
+}
+
+// add adds an entry, which must be a put or a delete, to the table.
+func (w *tableWriter) add(e *lsmEntry) error {
+	w.block = appendEntry(w.block, e)
+	w.lastKey = append(w.lastKey[:0], e.key...)
+	if len(w.block) >= tableBlockSize {
+		return w.finishBlock()
+	}
+	return nil
+}
+
+// writeBlock writes the block followed by its checksum.
+func (w *tableWriter) writeBlock(block []byte) (blockHandle, error) {
+	h := blockHandle{offset: w.offset, length: uint64(len(block))}
+	binary.LittleEndian.PutUint32(w.scratch[:4], crc32.Checksum(block, crcTable))
+	if _, err := w.w.Write(block); err != nil {
+		return h, err
+	}
+	if _, err := w.w.Write(w.scratch[:4]); err != nil {
+		return h, err
+	}
+	w.offset += uint64(len(block)) + 4
+	return h, nil
+}
+
+func (w *tableWriter) finishBlock() error {
+	if len(w.block) == 0 {
+		return nil
+	}
+	h, err := w.writeBlock(w.block)
+	if err != nil {
+		return err
+	}
+	h.lastKey = append([]byte(nil), w.lastKey...)
+	w.index = append(w.index, h)
+	w.block = w.block[:0]
+	return nil
+}
+
+// empty returns true if no entries have been added to the table.
+func (w *tableWriter) empty() bool {
+	return len(w.index) == 0 && len(w.block) == 0
+}
+
+// finish writes the index block and the footer of the table.
+func (w *tableWriter) finish() error {
+	if err := w.finishBlock(); err != nil {
+		return err
+	}
+	var index []byte
+	for _, h := range w.index {
+		index = appendUvarint(index, uint64(len(h.lastKey)))
+		index = append(index, h.lastKey...)
+		index = appendUvarint(index, h.offset)
+		index = appendUvarint(index, h.length)
+	}
+	indexHandle, err := w.writeBlock(index)
+	if err != nil {
+		return err
+	}
+	var footer [tableFooterSize]byte
+	binary.LittleEndian.PutUint64(footer[0:], indexHandle.offset)
+	binary.LittleEndian.PutUint64(footer[8:], indexHandle.length)
+	binary.LittleEndian.PutUint64(footer[16:], tableMagic)
+	_, err = w.w.Write(footer[:])
+	return err
+}
+
+// lsmTable is an open table. Tables are reference counted by the
+// tableSets which include them; the resources of a table are released
+// once it is no longer referenced, and its file is removed if it has
+// been marked obsolete by a compaction.
+type lsmTable struct {
+	num      uint64
+	size     uint64
+	dataSize uint64 // offset of the index block
+	r        io.ReaderAt
+	index    []blockHandle
+	refs     int32
+	obsolete int32
+}
+
+// openTable reads the index of the table of the given size from r.
+func openTable(num uint64, r io.ReaderAt, size uint64) (*lsmTable, error) {
+	if size < tableFooterSize {
+		return nil, util.Errorf("table %d: file too short", num)
+	}
+	var footer [tableFooterSize]byte
+	if _, err := r.ReadAt(footer[:], int64(size-tableFooterSize)); err != nil {
+		return nil, err
+	}
+	if binary.LittleEndian.Uint64(footer[16:]) != tableMagic {
+		return nil, util.Errorf("table %d: bad magic number", num)
+	}
+	t := &lsmTable{
+		num:      num,
+		size:     size,
+		dataSize: binary.LittleEndian.Uint64(footer[0:]),
+		r:        r,
+	}
+	index, err := t.readBlock(blockHandle{
+		offset: t.dataSize,
+		length: binary.LittleEndian.Uint64(footer[8:]),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for len(index) > 0 {
+		var h blockHandle
+		keyLen, n := binary.Uvarint(index)
+		if n <= 0 || uint64(len(index)-n) < keyLen {
+			return nil, util.Errorf("table %d: corrupted index", num)
+		}
+		h.lastKey, index = index[n:n+int(keyLen)], index[n+int(keyLen):]
+		if h.offset, n = binary.Uvarint(index); n <= 0 {
+			return nil, util.Errorf("table %d: corrupted index", num)
+		}
+		index = index[n:]
+		if h.length, n = binary.Uvarint(index); n <= 0 {
+			return nil, util.Errorf("table %d: corrupted index", num)
+		}
+		index = index[n:]
+		t.index = append(t.index, h)
+	}
+	return t, nil
+}
+
+// readBlock reads the block and verifies its checksum.
+func (t *lsmTable) readBlock(h blockHandle) ([]byte, error) {
+	if h.offset+h.length+4 > t.size {
+		return nil, util.Errorf("table %d: block out of bounds", t.num)
+	}
+	buf := make([]byte, h.length+4)
+	if _, err := t.r.ReadAt(buf, int64(h.offset)); err != nil {
+		return nil, err
+	}
+	block := buf[:h.length]
+	if crc32.Checksum(block, crcTable) != binary.LittleEndian.Uint32(buf[h.length:]) {
+		return nil, util.Errorf("table %d: checksum mismatch in block at offset %d", t.num, h.offset)
+	}
+	return block, nil
+}
+
+// readEntries reads and decodes the entries of the i-th block.
+func (t *lsmTable) readEntries(i int) ([]lsmEntry, error) {
+	block, err := t.readBlock(t.index[i])
+	if err != nil {
+		return nil, err
+	}
+	var entries []lsmEntry
+	for len(block) > 0 {
+		var e lsmEntry
+		if e, block, err = decodeEntry(block); err != nil {
+			return nil, util.Errorf("table %d: %s", t.num, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// findBlock returns the index of the first block which may contain
+// keys greater than or equal to key.
+func (t *lsmTable) findBlock(key []byte) int {
+	return sort.Search(len(t.index), func(i int) bool {
+		return bytes.Compare(t.index[i].lastKey, key) >= 0
+	})
+}
+
+// get returns the entry for key, or nil if there is none.
+func (t *lsmTable) get(key []byte) (*lsmEntry, error) {
+	i := t.findBlock(key)
+	if i == len(t.index) {
+		return nil, nil
+	}
+	entries, err := t.readEntries(i)
+	if err != nil {
+		return nil, err
+	}
+	j := sort.Search(len(entries), func(j int) bool {
+		return bytes.Compare(entries[j].key, key) >= 0
+	})
+	if j < len(entries) && bytes.Equal(entries[j].key, key) {
+		return &entries[j], nil
+	}
+	return nil, nil
+}
+
+// offset returns the approximate offset of key in the table.
+func (t *lsmTable) offset(key []byte) uint64 {
+	if i := t.findBlock(key); i < len(t.index) {
+		return t.index[i].offset
+	}
+	return t.dataSize
+}
+
+func (t *lsmTable) ref() {
+	atomic.AddInt32(&t.refs, 1)
+}
+
+// unref releases a reference to the table, closing it and removing
+// its file if obsolete once it is no longer referenced.
+func (t *lsmTable) unref() {
+	if atomic.AddInt32(&t.refs, -1) > 0 {
+		return
+	}
+	f, ok := t.r.(*os.File)
+	if !ok {
+		return
+	}
+	if err := f.Close(); err != nil {
+		log.Warningf("closing table %d: %s", t.num, err)
+	}
+	if atomic.LoadInt32(&t.obsolete) != 0 {
+		if err := os.Remove(f.Name()); err != nil {
+			log.Warningf("removing table %d: %s", t.num, err)
+		}
+	}
+}
+
+// tableSet is an immutable, reference counted list of tables, ordered
+// from the newest to the oldest.
+type tableSet struct {
+	tables []*lsmTable
+	refs   int32
+}
+
+// newTableSet returns a tableSet holding a reference to each of the
+// tables, and a single reference of its own.
+func newTableSet(tables []*lsmTable) *tableSet {
+	for _, t := range tables {
+		t.ref()
+	}
+	return &tableSet{tables: tables, refs: 1}
+}
+
+func (s *tableSet) ref() {
+	atomic.AddInt32(&s.refs, 1)
+}
+
+func (s *tableSet) unref() {
+	if atomic.AddInt32(&s.refs, -1) > 0 {
+		return
+	}
+	for _, t := range s.tables {
+		t.unref()
+	}
+}
+
+// tableIterator iterates over the entries of a table.
+type tableIterator struct {
+	t       *lsmTable
+	block   int // index of the current block
+	entries []lsmEntry
+	pos     int
+	err     error
+}
+
+func newTableIterator(t *lsmTable) *tableIterator {
+	return &tableIterator{t: t, block: len(t.index)}
+}
+
+// loadBlock positions the iterator at the start of the i-th block.
+func (it *tableIterator) loadBlock(i int) {
+	it.block, it.entries, it.pos = i, nil, 0
+	if i < len(it.t.index) {
+		it.entries, it.err = it.t.readEntries(i)
+		if it.err != nil {
+			it.block = len(it.t.index)
+		}
+	}
+}
+
+func (it *tableIterator) seek(key []byte) {
+	it.loadBlock(it.t.findBlock(key))
+	it.pos = sort.Search(len(it.entries), func(j int) bool {
+		return bytes.Compare(it.entries[j].key, key) >= 0
+	})
+}
+
+func (it *tableIterator) valid() bool {
+	return it.pos < len(it.entries)
+}
+
+func (it *tableIterator) next() {
+	if it.pos++; it.pos == len(it.entries) {
+		it.loadBlock(it.block + 1)
+	}
+}
+
+func (it *tableIterator) entry() *lsmEntry {
+	return &it.entries[it.pos]
+}
+
+func (it *tableIterator) status() error {
+	return it.err
+}
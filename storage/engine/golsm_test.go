@@ -0,0 +1,428 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package engine
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/keys"
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util/leaktest"
+	"github.com/cockroachdb/cockroach/util/randutil"
+	"github.com/cockroachdb/cockroach/util/uuid"
+)
+
+// openTestGoLSM opens a GoLSM engine in dir with a small memtable, so
+// that tests exercise flushes and compactions.
+func openTestGoLSM(t *testing.T, dir string) *GoLSM {
+	e := NewGoLSM(inMemAttrs, dir)
+	e.memtableSize = 1 << 10
+	if err := e.Open(); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+// verifyGoLSM verifies that the contents of the engine match the model.
+func verifyGoLSM(t *testing.T, e Engine, model map[string][]byte) {
+	for k, v := range model {
+		val, err := e.Get(proto.EncodedKey(k))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(val, v) {
+			t.Fatalf("key %q: expected %q, got %q", k, v, val)
+		}
+	}
+	var sortedKeys []string
+	for k := range model {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+	kvs, err := Scan(e, proto.EncodedKey(proto.KeyMin), proto.EncodedKey(proto.KeyMax), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kvs) != len(sortedKeys) {
+		t.Fatalf("expected %d keys, scanned %d", len(sortedKeys), len(kvs))
+	}
+	for i, kv := range kvs {
+		if string(kv.Key) != sortedKeys[i] || !bytes.Equal(kv.Value, model[sortedKeys[i]]) {
+			t.Fatalf("%d: expected %q=%q, scanned %q=%q", i, sortedKeys[i], model[sortedKeys[i]], kv.Key, kv.Value)
+		}
+	}
+}
+
+// TestGoLSMRandomOperations applies random puts, merges and deletions
+// directly and in batches, verifying the contents of the engine against
+// a model, including after the engine is reopened.
+func TestGoLSMRandomOperations(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	dir, err := ioutil.TempDir("", "test-golsm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	rand, _ := randutil.NewPseudoRand()
+
+	e := openTestGoLSM(t, dir)
+	model := map[string][]byte{}
+	apply := func(eng Engine, model map[string][]byte) {
+		key := fmt.Sprintf("key%04d", rand.Intn(200))
+		switch rand.Intn(4) {
+		case 0:
+			if err := eng.Clear(proto.EncodedKey(key)); err != nil {
+				t.Fatal(err)
+			}
+			delete(model, key)
+		case 1:
+			value := appender(string(randutil.RandBytes(rand, 8)))
+			if err := eng.Merge(proto.EncodedKey(key), value); err != nil {
+				t.Fatal(err)
+			}
+			merged, err := mergeValues(model[key], value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			model[key] = merged
+		default:
+			value := appender(string(randutil.RandBytes(rand, rand.Intn(50))))
+			if err := eng.Put(proto.EncodedKey(key), value); err != nil {
+				t.Fatal(err)
+			}
+			model[key] = value
+		}
+	}
+
+	for i := 0; i < 20; i++ {
+		for j := 0; j < 200; j++ {
+			apply(e, model)
+		}
+		// Apply some operations in a batch, whose reads reflect both
+		// the batch and the engine.
+		b := e.NewBatch()
+		batchModel := map[string][]byte{}
+		for k, v := range model {
+			batchModel[k] = v
+		}
+		for j := 0; j < 50; j++ {
+			apply(b, batchModel)
+		}
+		verifyGoLSM(t, b, batchModel)
+		if err := b.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		model = batchModel
+		verifyGoLSM(t, e, model)
+
+		if i%5 == 4 {
+			e.Close()
+			e = openTestGoLSM(t, dir)
+			verifyGoLSM(t, e, model)
+		}
+	}
+	e.CompactRange(nil, nil)
+	verifyGoLSM(t, e, model)
+	if n := len(e.tables.tables); n > 1 {
+		t.Errorf("expected at most one table after compacting; got %d", n)
+	}
+	e.Close()
+}
+
+// TestGoLSMRecovery verifies that unflushed writes are recovered from
+// the write-ahead log, and that a torn record at its end is ignored.
+func TestGoLSMRecovery(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	dir, err := ioutil.TempDir("", "test-golsm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	e := NewGoLSM(inMemAttrs, dir)
+	if err := e.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Put(proto.EncodedKey("a"), []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Put(proto.EncodedKey("b"), []byte("2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Clear(proto.EncodedKey("a")); err != nil {
+		t.Fatal(err)
+	}
+	logPath := filepath.Join(dir, goLSMFileName(e.logNum, goLSMLogExt))
+
+	// Opening the directory a second time fails.
+	if err := NewGoLSM(inMemAttrs, dir).Open(); err == nil {
+		t.Fatal("expected opening a locked directory to fail")
+	}
+	e.Close()
+
+	// Append a torn record to the log.
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	record := encodeLogRecord([]lsmEntry{{key: []byte("c"), value: []byte("3"), kind: kindPut}})
+	if _, err := f.Write(record[:len(record)-1]); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	e = NewGoLSM(inMemAttrs, dir)
+	if err := e.Open(); err != nil {
+		t.Fatal(err)
+	}
+	verifyGoLSM(t, e, map[string][]byte{"b": []byte("2")})
+	if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+		t.Errorf("expected the recovered log to be removed; got %v", err)
+	}
+	e.Close()
+
+	if err := e.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("expected the data directory to be removed; got %v", err)
+	}
+}
+
+// TestGoLSMPutSorted verifies that sorted pairs are installed above
+// the deletions of the memtable along with the other writes of their
+// batch, only once the batch is committed, and that they survive the
+// engine being reopened.
+func TestGoLSMPutSorted(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	dir, err := ioutil.TempDir("", "test-golsm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	e := openTestGoLSM(t, dir)
+	for _, k := range []string{"a", "b", "c"} {
+		if err := e.Put(proto.EncodedKey(k), []byte("1")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := e.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	// Leave deletions of "b" and "c" in the memtable.
+	for _, k := range []string{"b", "c"} {
+		if err := e.Clear(proto.EncodedKey(k)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	kvs := []proto.RawKeyValue{
+		{Key: proto.EncodedKey("b"), Value: []byte("2")},
+		{Key: proto.EncodedKey("c"), Value: []byte("2")},
+	}
+
+	// Nothing is written by a batch which isn't committed.
+	b := e.NewBatch()
+	if err := b.PutSorted(kvs); err != nil {
+		t.Fatal(err)
+	}
+	b.Close()
+	verifyGoLSM(t, e, map[string][]byte{"a": []byte("1")})
+
+	b = e.NewBatch()
+	if err := b.Put(proto.EncodedKey("d"), []byte("2")); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.PutSorted(kvs); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	b.Close()
+	model := map[string][]byte{"a": []byte("1"), "b": []byte("2"), "c": []byte("2"), "d": []byte("2")}
+	verifyGoLSM(t, e, model)
+
+	e.Close()
+	e = openTestGoLSM(t, dir)
+	defer e.Close()
+	verifyGoLSM(t, e, model)
+}
+
+// TestGoLSMSnapshotCompaction verifies that snapshots are unaffected by
+// compactions, and that the files of compacted tables are removed once
+// no snapshot refers to them.
+func TestGoLSMSnapshotCompaction(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	dir, err := ioutil.TempDir("", "test-golsm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	e := openTestGoLSM(t, dir)
+	defer e.Close()
+
+	model := map[string][]byte{}
+	for i := 0; i < 100; i++ {
+		key, value := fmt.Sprintf("key%04d", i), []byte(fmt.Sprintf("value%d", i))
+		if err := e.Put(proto.EncodedKey(key), value); err != nil {
+			t.Fatal(err)
+		}
+		model[key] = value
+	}
+	snap := e.NewSnapshot()
+	snapTables := e.tables.tables
+	if len(snapTables) == 0 {
+		t.Fatal("expected tables to have been flushed")
+	}
+	for i := 0; i < 100; i += 2 {
+		if err := e.Clear(proto.EncodedKey(fmt.Sprintf("key%04d", i))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	e.CompactRange(nil, nil)
+
+	verifyGoLSM(t, snap, model)
+	tablePath := filepath.Join(dir, goLSMFileName(snapTables[0].num, goLSMTableExt))
+	if _, err := os.Stat(tablePath); err != nil {
+		t.Fatalf("expected table referenced by snapshot to exist: %s", err)
+	}
+	snap.Close()
+	if _, err := os.Stat(tablePath); !os.IsNotExist(err) {
+		t.Errorf("expected compacted table to be removed; got %v", err)
+	}
+
+	for i := 0; i < 100; i += 2 {
+		delete(model, fmt.Sprintf("key%04d", i))
+	}
+	verifyGoLSM(t, e, model)
+}
+
+// TestGoLSMCompactionGC verifies that compactions garbage collect
+// response cache entries and transaction records according to the
+// GC timeouts.
+func TestGoLSMCompactionGC(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	e := NewGoLSM(inMemAttrs, "")
+	if err := e.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+	e.SetGCTimeouts(1, 2)
+
+	cmdID := &proto.ClientCmdID{WallTime: 1, Random: 1}
+	kvs := []proto.KeyValue{
+		{
+			Key:   keys.ResponseCacheKey(1, cmdID),
+			Value: proto.Value{Bytes: encodePutResponse(makeTS(2, 0), t)},
+		},
+		{
+			Key:   keys.ResponseCacheKey(2, cmdID),
+			Value: proto.Value{Bytes: encodePutResponse(makeTS(3, 0), t)},
+		},
+		{
+			Key:   keys.TransactionKey(proto.Key("a"), proto.Key(uuid.NewUUID4())),
+			Value: proto.Value{Bytes: encodeTransaction(makeTS(1, 0), t)},
+		},
+		{
+			Key:   keys.TransactionKey(proto.Key("b"), proto.Key(uuid.NewUUID4())),
+			Value: proto.Value{Bytes: encodeTransaction(makeTS(2, 0), t)},
+		},
+	}
+	for _, kv := range kvs {
+		if err := MVCCPut(e, nil, kv.Key, proto.ZeroTimestamp, kv.Value, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	e.CompactRange(nil, nil)
+	actualKVs, _, err := MVCCScan(e, proto.KeyMin, proto.KeyMax, 0, proto.ZeroTimestamp, true, nil)
+	if err != nil {
+		t.Fatalf("could not run scan: %v", err)
+	}
+	var keys []proto.Key
+	for _, kv := range actualKVs {
+		keys = append(keys, kv.Key)
+	}
+	expKeys := []proto.Key{
+		kvs[1].Key,
+		kvs[3].Key,
+	}
+	if !reflect.DeepEqual(expKeys, keys) {
+		t.Errorf("expected keys %+v, got keys %+v", expKeys, keys)
+	}
+}
+
+// TestGoLSMMergeTimeSeries verifies that time series merged into the
+// engine, directly and through a batch, are combined as by goMerge.
+func TestGoLSMMergeTimeSeries(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	e := NewGoLSM(inMemAttrs, "")
+	if err := e.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	key := proto.EncodedKey("ts")
+	values := [][]byte{
+		timeSeries(testtime, 1000, tsSample{2, 1, 5, 5, 5}, tsSample{1, 1, 5, 5, 5}),
+		timeSeries(testtime, 1000, tsSample{1, 1, 100, 100, 100}),
+		timeSeries(testtime, 1000, tsSample{3, 1, 5, 5, 5}, tsSample{2, 1, 5, 5, 5}),
+	}
+	expected := timeSeries(testtime, 1000,
+		tsSample{1, 2, 105, 100, 5}, tsSample{2, 2, 10, 5, 5}, tsSample{3, 1, 5, 5, 5})
+
+	if err := e.Merge(key, values[0]); err != nil {
+		t.Fatal(err)
+	}
+	b := e.NewBatch()
+	for _, value := range values[1:] {
+		if err := b.Merge(key, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, eng := range []Engine{b, e} {
+		if eng == e {
+			if err := b.Commit(); err != nil {
+				t.Fatal(err)
+			}
+		}
+		result, err := eng.Get(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a, e := unmarshalTimeSeries(t, result), unmarshalTimeSeries(t, expected); !reflect.DeepEqual(a, e) {
+			t.Errorf("expected %v, got %v", e, a)
+		}
+	}
+
+	// Merging values of different types fails.
+	if err := e.Merge(key, appender("a")); err == nil {
+		t.Error("expected merging bytes into a time series to fail")
+	}
+}
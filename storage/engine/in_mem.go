@@ -15,6 +15,8 @@
 //
 // Author: Peter Mattis (peter@cockroachlabs.com)
 
+// +build cgo
+
 package engine
 
 import "github.com/cockroachdb/cockroach/proto"
@@ -18,7 +18,11 @@
 package engine
 
 import (
+	"math"
+	"sort"
+
 	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util"
 	gogoproto "github.com/gogo/protobuf/proto"
 )
 
@@ -67,3 +71,169 @@ func MergeInternalTimeSeriesData(sources ...*proto.InternalTimeSeriesData) (
 	}
 	return mergedTS, nil
 }
+
+// mergeValues is the Go equivalent of the merge operator in db.cc, for
+// use by engines which are not backed by RocksDB. It merges the value
+// of the MVCCMetadata encoded in update into that encoded in existing
+// (which may be nil) and returns the resulting encoded MVCCMetadata.
+// As with goMerge, the checksum of the merged value is cleared.
+func mergeValues(existing, update []byte) ([]byte, error) {
+	var meta, updateMeta MVCCMetadata
+	if err := gogoproto.Unmarshal(existing, &meta); err != nil {
+		return nil, util.Errorf("corrupted existing value: %s", err)
+	}
+	if err := gogoproto.Unmarshal(update, &updateMeta); err != nil {
+		return nil, util.Errorf("corrupted update value: %s", err)
+	}
+	if meta.Value == nil {
+		meta.Value = &proto.Value{}
+	}
+	right := updateMeta.Value
+	if right == nil {
+		right = &proto.Value{}
+	}
+	if err := mergeValue(meta.Value, right); err != nil {
+		return nil, err
+	}
+	meta.Value.Checksum = nil
+	return gogoproto.Marshal(&meta)
+}
+
+// mergeValue merges right into left. Byte values are concatenated,
+// while time series values are combined sample by sample. A value
+// merged into an empty value replaces it.
+func mergeValue(left, right *proto.Value) error {
+	if left.Bytes == nil {
+		*left = *right
+		if left.IsTimeSeriesData() {
+			return mergeTimeSeriesValues(left, nil)
+		}
+		return nil
+	}
+	if right.Bytes == nil {
+		return util.Errorf("inconsistent value types for merge (left = bytes, right = ?)")
+	}
+	if left.IsTimeSeriesData() || right.IsTimeSeriesData() {
+		if !left.IsTimeSeriesData() || !right.IsTimeSeriesData() {
+			return util.Errorf("inconsistent value types for merging time series data (type(left) != type(right))")
+		}
+		return mergeTimeSeriesValues(left, right)
+	}
+	left.Bytes = append(left.Bytes, right.Bytes...)
+	return nil
+}
+
+// mergeTimeSeriesValues merges the samples of the time series in right,
+// which may be nil, into those of the time series in left, combining
+// samples with the same offset. The samples of left are assumed to be
+// sorted already. The time series must have the same start timestamp
+// and sample duration.
+func mergeTimeSeriesValues(left, right *proto.Value) error {
+	leftTS, err := proto.InternalTimeSeriesDataFromValue(left)
+	if err != nil {
+		return err
+	}
+	var rightSamples []*proto.InternalTimeSeriesSample
+	if right == nil {
+		// Consolidate the samples of left.
+		rightSamples, leftTS.Samples = leftTS.Samples, nil
+	} else {
+		rightTS, err := proto.InternalTimeSeriesDataFromValue(right)
+		if err != nil {
+			return err
+		}
+		if leftTS.StartTimestampNanos != rightTS.StartTimestampNanos {
+			return util.Errorf("time series merge failed due to mismatched start timestamps")
+		}
+		if leftTS.SampleDurationNanos != rightTS.SampleDurationNanos {
+			return util.Errorf("time series merge failed due to mismatched sample durations")
+		}
+		rightSamples = rightTS.Samples
+	}
+	sort.Stable(sampleSlice(rightSamples))
+
+	mergedTS := &proto.InternalTimeSeriesData{
+		StartTimestampNanos: leftTS.StartTimestampNanos,
+		SampleDurationNanos: leftTS.SampleDurationNanos,
+	}
+	leftSamples := leftTS.Samples
+	for len(leftSamples) > 0 || len(rightSamples) > 0 {
+		// Accumulate the samples at the lowest offset from either side,
+		// each of which may have duplicated offsets.
+		var offset int32
+		switch {
+		case len(leftSamples) == 0:
+			offset = rightSamples[0].Offset
+		case len(rightSamples) == 0:
+			offset = leftSamples[0].Offset
+		default:
+			offset = leftSamples[0].Offset
+			if rightSamples[0].Offset < offset {
+				offset = rightSamples[0].Offset
+			}
+		}
+		sample := &proto.InternalTimeSeriesSample{Offset: offset}
+		for len(leftSamples) > 0 && leftSamples[0].Offset == offset {
+			accumulateSample(sample, leftSamples[0])
+			leftSamples = leftSamples[1:]
+		}
+		for len(rightSamples) > 0 && rightSamples[0].Offset == offset {
+			accumulateSample(sample, rightSamples[0])
+			rightSamples = rightSamples[1:]
+		}
+		mergedTS.Samples = append(mergedTS.Samples, sample)
+	}
+	merged, err := mergedTS.ToValue()
+	if err != nil {
+		return err
+	}
+	left.Bytes = merged.Bytes
+	return nil
+}
+
+// accumulateSample accumulates src into dest, which have the same
+// offset. Samples without measurements do not affect the maximum and
+// minimum.
+func accumulateSample(dest, src *proto.InternalTimeSeriesSample) {
+	count := dest.Count + src.Count
+	if count > 1 {
+		var max, min float64
+		switch {
+		case dest.Count == 0:
+			max, min = sampleBounds(src)
+		case src.Count == 0:
+			max, min = sampleBounds(dest)
+		default:
+			srcMax, srcMin := sampleBounds(src)
+			destMax, destMin := sampleBounds(dest)
+			max, min = math.Max(srcMax, destMax), math.Min(srcMin, destMin)
+		}
+		dest.Max, dest.Min = gogoproto.Float64(max), gogoproto.Float64(min)
+	}
+	if count > 0 {
+		dest.Sum += src.Sum
+	}
+	dest.Count = count
+}
+
+// sampleBounds returns the maximum and minimum measurements of the
+// sample, which are only recorded explicitly for samples of more than
+// one measurement.
+func sampleBounds(s *proto.InternalTimeSeriesSample) (max, min float64) {
+	max, min = s.Sum, s.Sum
+	if s.Max != nil {
+		max = *s.Max
+	}
+	if s.Min != nil {
+		min = *s.Min
+	}
+	return max, min
+}
+
+// sampleSlice implements sort.Interface for time series samples,
+// ordering them by offset.
+type sampleSlice []*proto.InternalTimeSeriesSample
+
+func (s sampleSlice) Len() int           { return len(s) }
+func (s sampleSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s sampleSlice) Less(i, j int) bool { return s[i].Offset < s[j].Offset }
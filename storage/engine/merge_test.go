@@ -94,6 +94,17 @@ func timeSeries(start int64, duration int64, samples ...tsSample) []byte {
 // the storage engines. For that, see the engine tests.
 func TestGoMerge(t *testing.T) {
 	defer leaktest.AfterTest(t)
+	testMerge(t, "goMerge", goMerge)
+}
+
+// TestMergeValues tests the Go implementation of the merge operator,
+// mergeValues, against the same cases as goMerge.
+func TestMergeValues(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	testMerge(t, "mergeValues", mergeValues)
+}
+
+func testMerge(t *testing.T, name string, merge func(existing, update []byte) ([]byte, error)) {
 	// Let's start with stuff that should go wrong.
 	badCombinations := []struct {
 		existing, update []byte
@@ -135,9 +146,9 @@ func TestGoMerge(t *testing.T) {
 		},
 	}
 	for i, c := range badCombinations {
-		_, err := goMerge(c.existing, c.update)
+		_, err := merge(c.existing, c.update)
 		if err == nil {
-			t.Errorf("goMerge: %d: expected error", i)
+			t.Errorf("%s: %d: expected error", name, i)
 		}
 	}
 
@@ -155,9 +166,9 @@ func TestGoMerge(t *testing.T) {
 	}
 
 	for i, c := range testCasesAppender {
-		result, err := goMerge(c.existing, c.update)
+		result, err := merge(c.existing, c.update)
 		if err != nil {
-			t.Errorf("goMerge error: %d: %v", i, err)
+			t.Errorf("%s error: %d: %v", name, i, err)
 			continue
 		}
 		var resultV, expectedV MVCCMetadata
@@ -168,7 +179,7 @@ func TestGoMerge(t *testing.T) {
 			t.Fatal(err)
 		}
 		if !reflect.DeepEqual(resultV, expectedV) {
-			t.Errorf("goMerge error: %d: want %+v, got %+v", i, expectedV, resultV)
+			t.Errorf("%s error: %d: want %+v, got %+v", name, i, expectedV, resultV)
 		}
 	}
 
@@ -258,16 +269,16 @@ func TestGoMerge(t *testing.T) {
 		existingTS := unmarshalTimeSeries(t, c.existing)
 		updateTS := unmarshalTimeSeries(t, c.update)
 
-		// Directly test the C++ implementation of merging using goMerge.  goMerge
-		// operates directly on marshalled bytes.
-		result, err := goMerge(c.existing, c.update)
+		// Directly test the implementation of merging, which operates
+		// directly on marshalled bytes.
+		result, err := merge(c.existing, c.update)
 		if err != nil {
-			t.Errorf("goMerge error on case %d: %s", i, err.Error())
+			t.Errorf("%s error on case %d: %s", name, i, err.Error())
 			continue
 		}
 		resultTS := unmarshalTimeSeries(t, result)
 		if a, e := resultTS, expectedTS; !reflect.DeepEqual(a, e) {
-			t.Errorf("goMerge returned wrong result on case %d: expected %v, returned %v", i, e, a)
+			t.Errorf("%s returned wrong result on case %d: expected %v, returned %v", name, i, e, a)
 		}
 
 		// Test the MergeInternalTimeSeriesData method separately.
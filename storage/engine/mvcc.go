@@ -60,6 +60,18 @@ func (meta MVCCMetadata) IsIntentOf(txn *proto.Transaction) bool {
 	return meta.Txn != nil && txn != nil && bytes.Equal(meta.Txn.ID, txn.ID)
 }
 
+// liveIntentVersion returns the most recent entry of the intent history
+// whose write has not been rolled back to a savepoint of txn, or nil if
+// there is none.
+func (meta MVCCMetadata) liveIntentVersion(txn *proto.Transaction) *MVCCIntentVersion {
+	for i := len(meta.IntentHistory) - 1; i >= 0; i-- {
+		if !txn.IsRolledBack(meta.IntentHistory[i].Sequence) {
+			return &meta.IntentHistory[i]
+		}
+	}
+	return nil
+}
+
 // Delta returns the difference between two MVCCStats structures.
 func (ms *MVCCStats) Delta(oms *MVCCStats) MVCCStats {
 	result := *ms
@@ -539,6 +551,16 @@ func mvccGetInternal(engine Engine, key proto.Key, metaKey proto.EncodedKey,
 					txn.Epoch, meta.Txn.Epoch)
 			}
 			valueKey, err = getValue(engine, latestKey.Next(), MVCCEncodeKey(key.Next()), value)
+		} else if ownIntent && txn.IsRolledBack(meta.Txn.Sequence) {
+			// The latest write to our own intent was rolled back to a
+			// savepoint. Read the most recent earlier write which wasn't,
+			// or the value beneath the intent if there is none.
+			if version := meta.liveIntentVersion(txn); version != nil {
+				*value = version.Value
+				valueKey = latestKey
+			} else {
+				valueKey, err = getValue(engine, latestKey.Next(), MVCCEncodeKey(key.Next()), value)
+			}
 		} else {
 			var ok bool
 			ok, _, _, err = engine.GetProto(latestKey, value)
@@ -729,6 +751,7 @@ func mvccPutInternal(engine Engine, ms *MVCCStats, key proto.Key, timestamp prot
 
 	var meta *MVCCMetadata
 	var origAgeSeconds int64
+	var history []MVCCIntentVersion
 	if ok {
 		// There is existing metadata for this key; ensure our write is permitted.
 		meta = &buf.meta
@@ -746,9 +769,17 @@ func mvccPutInternal(engine Engine, ms *MVCCStats, key proto.Key, timestamp prot
 					txn.Epoch, meta.Txn.Epoch, txn.ID)
 			}
 
-			// We are replacing our own older write intent. If we are
-			// writing at the same timestamp we can simply overwrite it;
-			// otherwise we must explicitly delete the obsolete intent.
+			// We are replacing our own older write intent. Within the
+			// same epoch, its value is kept in the intent history in case
+			// our write is rolled back to a savepoint.
+			if txn.Epoch == meta.Txn.Epoch {
+				if history, err = intentHistory(engine, metaKey, meta, txn); err != nil {
+					return err
+				}
+			}
+			// If we are writing at the same timestamp we can simply
+			// overwrite the intent; otherwise we must explicitly delete
+			// the obsolete intent.
 			if !timestamp.Equal(meta.Timestamp) {
 				versionKey := mvccEncodeTimestamp(metaKey, meta.Timestamp)
 				if err := engine.Clear(versionKey); err != nil {
@@ -769,7 +800,7 @@ func mvccPutInternal(engine Engine, ms *MVCCStats, key proto.Key, timestamp prot
 			return nil
 		}
 	}
-	buf.newMeta = MVCCMetadata{Txn: txn, Timestamp: timestamp}
+	buf.newMeta = MVCCMetadata{Txn: txn, Timestamp: timestamp, IntentHistory: history}
 	newMeta := &buf.newMeta
 
 	// Make sure to zero the redundant timestamp (timestamp is encoded
@@ -800,6 +831,62 @@ func mvccPutInternal(engine Engine, ms *MVCCStats, key proto.Key, timestamp prot
 	return nil
 }
 
+// intentHistory returns the intent history to keep when txn replaces
+// its own intent described by meta within the same epoch. Entries
+// rolled back to a savepoint are dropped, and the replaced value is
+// appended unless it was rolled back or written at the same sequence
+// number as its replacement. The client only advances the sequence
+// number past a savepoint, so the history holds at most one entry per
+// savepoint a rollback could return to.
+func intentHistory(engine Engine, metaKey proto.EncodedKey, meta *MVCCMetadata,
+	txn *proto.Transaction) ([]MVCCIntentVersion, error) {
+	var history []MVCCIntentVersion
+	for _, version := range meta.IntentHistory {
+		if !txn.IsRolledBack(version.Sequence) {
+			history = append(history, version)
+		}
+	}
+	if meta.Txn.Sequence == txn.Sequence || txn.IsRolledBack(meta.Txn.Sequence) {
+		return history, nil
+	}
+	version := MVCCIntentVersion{Sequence: meta.Txn.Sequence}
+	versionKey := mvccEncodeTimestamp(metaKey, meta.Timestamp)
+	ok, _, _, err := engine.GetProto(versionKey, &version.Value)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, util.Errorf("unable to fetch intent value for key %q", versionKey)
+	}
+	return append(history, version), nil
+}
+
+// restoreIntentVersion replaces the value of the intent described by
+// meta with the given version from its intent history, keeping the
+// intent's timestamp. The new metadata and its encoded sizes are
+// returned.
+func restoreIntentVersion(engine Engine, ms *MVCCStats, key proto.Key, meta *MVCCMetadata,
+	version *MVCCIntentVersion, origMetaKeySize, origMetaValSize int64) (*MVCCMetadata, int64, int64, error) {
+	metaKey := MVCCEncodeKey(key)
+	_, valueSize, err := PutProto(engine, mvccEncodeTimestamp(metaKey, meta.Timestamp), &version.Value)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	newMeta := &MVCCMetadata{
+		Txn:       meta.Txn,
+		Timestamp: meta.Timestamp,
+		Deleted:   version.Value.Deleted,
+		KeyBytes:  mvccVersionTimestampSize,
+		ValBytes:  valueSize,
+	}
+	metaKeySize, metaValSize, err := PutProto(engine, metaKey, newMeta)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	updateStatsOnPut(ms, key, origMetaKeySize, origMetaValSize, metaKeySize, metaValSize, meta, newMeta, 0)
+	return newMeta, metaKeySize, metaValSize, nil
+}
+
 // MVCCIncrement fetches the value for key, and assuming the value is
 // an "integer" type, increments it by inc and stores the new
 // value. The newly incremented value is returned.
@@ -902,6 +989,67 @@ func MVCCMerge(engine Engine, ms *MVCCStats, key proto.Key, value proto.Value) e
 	return nil
 }
 
+// MVCCPutSorted writes the given rows, which must be sorted by key, as
+// new versions at the given timestamp, handing the encoded versions and
+// their metadata to Engine.PutSorted in a single chunk. Since there are no
+// existing versions or intents to check the writes against, none of the
+// keys between the first and the last row may be present; if one is,
+// false is returned and nothing is written.
+func MVCCPutSorted(engine Engine, ms *MVCCStats, rows []proto.KeyValue, timestamp proto.Timestamp) (bool, error) {
+	if len(rows) == 0 {
+		return true, nil
+	}
+	if timestamp.Equal(proto.ZeroTimestamp) {
+		return false, util.Errorf("cannot put sorted inline values")
+	}
+	empty := true
+	if err := engine.Iterate(MVCCEncodeKey(rows[0].Key), MVCCEncodeKey(rows[len(rows)-1].Key.Next()),
+		func(proto.RawKeyValue) (bool, error) {
+			empty = false
+			return true, nil
+		}); err != nil || !empty {
+		return false, err
+	}
+
+	kvs := make([]proto.RawKeyValue, 0, 2*len(rows))
+	for i := range rows {
+		row := &rows[i]
+		if len(row.Key) == 0 {
+			return false, emptyKeyError()
+		}
+		if i > 0 && !rows[i-1].Key.Less(row.Key) {
+			return false, util.Errorf("rows to put are not sorted: %q follows %q", row.Key, rows[i-1].Key)
+		}
+		if row.Value.Timestamp != nil && !row.Value.Timestamp.Equal(timestamp) {
+			return false, util.Errorf(
+				"the timestamp %+v provided in value does not match the timestamp %+v in request",
+				row.Value.Timestamp, timestamp)
+		}
+		if err := row.Value.Verify(row.Key); err != nil {
+			return false, err
+		}
+		// As for MVCCPut, the timestamp is encoded into the version key
+		// only.
+		value := row.Value
+		value.Timestamp = nil
+		valueBytes, err := gogoproto.Marshal(&MVCCValue{Value: &value})
+		if err != nil {
+			return false, err
+		}
+		meta := MVCCMetadata{Timestamp: timestamp, KeyBytes: mvccVersionTimestampSize, ValBytes: int64(len(valueBytes))}
+		metaBytes, err := gogoproto.Marshal(&meta)
+		if err != nil {
+			return false, err
+		}
+		metaKey := MVCCEncodeKey(row.Key)
+		kvs = append(kvs,
+			proto.RawKeyValue{Key: metaKey, Value: metaBytes},
+			proto.RawKeyValue{Key: mvccEncodeTimestamp(metaKey, timestamp), Value: valueBytes})
+		updateStatsOnPut(ms, row.Key, 0, 0, int64(len(metaKey)), int64(len(metaBytes)), nil, &meta, 0)
+	}
+	return true, engine.PutSorted(kvs)
+}
+
 // MVCCDeleteRange deletes the range of key/value pairs specified by
 // start and end keys. Specify max=0 for unbounded deletes.
 func MVCCDeleteRange(engine Engine, ms *MVCCStats, key, endKey proto.Key, max int64, timestamp proto.Timestamp, txn *proto.Transaction) (int64, error) {
@@ -1041,6 +1189,86 @@ func MVCCIterate(engine Engine, startKey, endKey proto.Key, timestamp proto.Time
 	return intents, wiErr
 }
 
+// MVCCChanges returns the versions written to the key range from key
+// to endKey with timestamps in the interval (startTS, endTS], ordered
+// by key and, for each key, by ascending timestamp. Inline values are
+// not versioned and are never returned.
+//
+// The returned resolved timestamp is endTS, lowered to just below the
+// earliest unresolved intent at or below endTS, since such an intent
+// may still commit. Events above the resolved timestamp are omitted;
+// the resolved timestamp is never lowered below startTS. The intents
+// which held back the resolved timestamp are returned so that the
+// caller may attempt to resolve them.
+func MVCCChanges(engine Engine, key, endKey proto.Key, startTS, endTS proto.Timestamp) ([]proto.ChangeEvent, proto.Timestamp, []proto.Intent, error) {
+	if len(endKey) == 0 {
+		return nil, proto.ZeroTimestamp, nil, emptyKeyError()
+	}
+
+	var events, versions []proto.ChangeEvent
+	var intents []proto.Intent
+	resolved := endTS
+	// Versions of a key are stored newest first; gather them and append
+	// them to events in reverse order once the next key is reached.
+	flush := func() {
+		for i := len(versions) - 1; i >= 0; i-- {
+			events = append(events, versions[i])
+		}
+		versions = versions[:0]
+	}
+	meta := &MVCCMetadata{}
+	err := engine.Iterate(MVCCEncodeKey(key), MVCCEncodeKey(endKey), func(kv proto.RawKeyValue) (bool, error) {
+		key, ts, isValue := MVCCDecodeKey(kv.Key)
+		if !isValue {
+			flush()
+			if err := gogoproto.Unmarshal(kv.Value, meta); err != nil {
+				return false, util.Errorf("unable to unmarshal MVCC metadata %q: %s", kv.Key, err)
+			}
+			if meta.Txn != nil && !endTS.Less(meta.Timestamp) {
+				intents = append(intents, proto.Intent{Key: key, Txn: *meta.Txn})
+				if prev := meta.Timestamp.Prev(); prev.Less(resolved) {
+					resolved = prev
+				}
+			}
+			return false, nil
+		}
+		// Skip the provisional value of an intent as well as versions
+		// outside of the requested interval.
+		if (meta.Txn != nil && ts.Equal(meta.Timestamp)) || !startTS.Less(ts) || endTS.Less(ts) {
+			return false, nil
+		}
+		value := MVCCValue{}
+		if err := gogoproto.Unmarshal(kv.Value, &value); err != nil {
+			return false, util.Errorf("unable to unmarshal MVCC value %q: %s", kv.Key, err)
+		}
+		event := proto.ChangeEvent{Key: key, Deleted: value.Deleted}
+		if value.Value != nil {
+			event.Value = *value.Value
+		}
+		event.Value.Timestamp = &ts
+		versions = append(versions, event)
+		return false, nil
+	})
+	if err != nil {
+		return nil, proto.ZeroTimestamp, nil, err
+	}
+	flush()
+
+	if resolved.Less(startTS) {
+		resolved = startTS
+	}
+	if resolved.Less(endTS) {
+		filtered := events[:0]
+		for _, event := range events {
+			if !resolved.Less(*event.Value.Timestamp) {
+				filtered = append(filtered, event)
+			}
+		}
+		events = filtered
+	}
+	return events, resolved, intents, nil
+}
+
 // MVCCResolveWriteIntent either commits or aborts (rolls back) an
 // extant write intent for a given txn according to commit parameter.
 // ResolveWriteIntent will skip write intents of other txns.
@@ -1090,14 +1318,32 @@ func MVCCResolveWriteIntent(engine Engine, ms *MVCCStats, key proto.Key, timesta
 	// timestamp-encoded key) if timestamp changed.
 	commit := txn.Status == proto.COMMITTED
 	pushed := txn.Status == proto.PENDING && meta.Txn.Timestamp.Less(txn.Timestamp)
+	if commit && meta.Txn.Epoch == txn.Epoch && txn.IsRolledBack(meta.Txn.Sequence) {
+		// The latest write to the intent was rolled back to a savepoint.
+		// Commit the most recent earlier write which wasn't, or discard
+		// the intent if there is none.
+		if version := meta.liveIntentVersion(txn); version != nil {
+			meta, origMetaKeySize, origMetaValSize, err = restoreIntentVersion(engine, ms, key, meta, version, origMetaKeySize, origMetaValSize)
+			if err != nil {
+				return err
+			}
+		} else {
+			commit = false
+		}
+	}
 	if (commit || pushed) && meta.Txn.Epoch == txn.Epoch {
 		origTimestamp := meta.Timestamp
 		newMeta := *meta
 		newMeta.Timestamp = txn.Timestamp
 		if pushed { // keep intent if we're pushing timestamp
-			newMeta.Txn = txn
+			// The pushed transaction may not reflect the sequence number
+			// at which the intent was written.
+			pushedTxn := *txn
+			pushedTxn.Sequence = meta.Txn.Sequence
+			newMeta.Txn = &pushedTxn
 		} else {
 			newMeta.Txn = nil
+			newMeta.IntentHistory = nil
 		}
 		metaKeySize, metaValSize, err := PutProto(engine, metaKey, &newMeta)
 		if err != nil {
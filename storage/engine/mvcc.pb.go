@@ -7,11 +7,15 @@
 
 	It is generated from these files:
 		cockroach/storage/engine/mvcc.proto
+		cockroach/storage/engine/store_key.proto
 
 	It has these top-level messages:
 		MVCCValue
 		MVCCMetadata
+		MVCCIntentVersion
 		MVCCStats
+		StoreKey
+		StoreKeyRegistry
 */
 package engine
 
@@ -73,8 +77,13 @@ type MVCCMetadata struct {
 	// and subsequent version rows. If timestamp == (0, 0), then there
 	// is only a single MVCC metadata row with value inlined, and with
 	// empty timestamp, key_bytes, and val_bytes.
-	Value            *cockroach_proto1.Value `protobuf:"bytes,6,opt,name=value" json:"value,omitempty"`
-	XXX_unrecognized []byte                  `json:"-"`
+	Value *cockroach_proto1.Value `protobuf:"bytes,6,opt,name=value" json:"value,omitempty"`
+	// The values previously written to the intent by its transaction in
+	// the current epoch, in increasing sequence order. They are retained
+	// so that the intent can be restored to an earlier value if later
+	// writes are rolled back to a savepoint.
+	IntentHistory    []MVCCIntentVersion `protobuf:"bytes,7,rep,name=intent_history" json:"intent_history"`
+	XXX_unrecognized []byte              `json:"-"`
 }
 
 func (m *MVCCMetadata) Reset()         { *m = MVCCMetadata{} }
@@ -123,6 +132,39 @@ func (m *MVCCMetadata) GetValue() *cockroach_proto1.Value {
 	return nil
 }
 
+func (m *MVCCMetadata) GetIntentHistory() []MVCCIntentVersion {
+	if m != nil {
+		return m.IntentHistory
+	}
+	return nil
+}
+
+// MVCCIntentVersion is a value written to an intent at the given
+// transaction sequence number.
+type MVCCIntentVersion struct {
+	Sequence         int32     `protobuf:"varint,1,opt,name=sequence" json:"sequence"`
+	Value            MVCCValue `protobuf:"bytes,2,opt,name=value" json:"value"`
+	XXX_unrecognized []byte    `json:"-"`
+}
+
+func (m *MVCCIntentVersion) Reset()         { *m = MVCCIntentVersion{} }
+func (m *MVCCIntentVersion) String() string { return proto.CompactTextString(m) }
+func (*MVCCIntentVersion) ProtoMessage()    {}
+
+func (m *MVCCIntentVersion) GetSequence() int32 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *MVCCIntentVersion) GetValue() MVCCValue {
+	if m != nil {
+		return m.Value
+	}
+	return MVCCValue{}
+}
+
 // MVCCStats tracks byte and instance counts for:
 //  - Live key/values (i.e. what a scan at current time will reveal;
 //    note that this includes intent keys and values, but not keys and
@@ -478,6 +520,114 @@ func (m *MVCCMetadata) Unmarshal(data []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntentHistory", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IntentHistory = append(m.IntentHistory, MVCCIntentVersion{})
+			if err := m.IntentHistory[len(m.IntentHistory)-1].Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			var sizeOfWire int
+			for {
+				sizeOfWire++
+				wire >>= 7
+				if wire == 0 {
+					break
+				}
+			}
+			iNdEx -= sizeOfWire
+			skippy, err := skipMvcc(data[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, data[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	return nil
+}
+
+func (m *MVCCIntentVersion) Unmarshal(data []byte) error {
+	l := len(data)
+	iNdEx := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := data[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.Sequence |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				msglen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			postIndex := iNdEx + msglen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Value.Unmarshal(data[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			var sizeOfWire int
 			for {
@@ -854,6 +1004,24 @@ func (m *MVCCMetadata) Size() (n int) {
 		l = m.Value.Size()
 		n += 1 + l + sovMvcc(uint64(l))
 	}
+	if len(m.IntentHistory) > 0 {
+		for _, e := range m.IntentHistory {
+			l = e.Size()
+			n += 1 + l + sovMvcc(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *MVCCIntentVersion) Size() (n int) {
+	var l int
+	_ = l
+	n += 1 + sovMvcc(uint64(m.Sequence))
+	l = m.Value.Size()
+	n += 1 + l + sovMvcc(uint64(l))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -991,6 +1159,50 @@ func (m *MVCCMetadata) MarshalTo(data []byte) (n int, err error) {
 		}
 		i += n4
 	}
+	if len(m.IntentHistory) > 0 {
+		for _, msg := range m.IntentHistory {
+			data[i] = 0x3a
+			i++
+			i = encodeVarintMvcc(data, i, uint64(msg.Size()))
+			n, err := msg.MarshalTo(data[i:])
+			if err != nil {
+				return 0, err
+			}
+			i += n
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		i += copy(data[i:], m.XXX_unrecognized)
+	}
+	return i, nil
+}
+
+func (m *MVCCIntentVersion) Marshal() (data []byte, err error) {
+	size := m.Size()
+	data = make([]byte, size)
+	n, err := m.MarshalTo(data)
+	if err != nil {
+		return nil, err
+	}
+	return data[:n], nil
+}
+
+func (m *MVCCIntentVersion) MarshalTo(data []byte) (n int, err error) {
+	var i int
+	_ = i
+	var l int
+	_ = l
+	data[i] = 0x8
+	i++
+	i = encodeVarintMvcc(data, i, uint64(m.Sequence))
+	data[i] = 0x12
+	i++
+	i = encodeVarintMvcc(data, i, uint64(m.Value.Size()))
+	n901, err := m.Value.MarshalTo(data[i:])
+	if err != nil {
+		return 0, err
+	}
+	i += n901
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
@@ -237,6 +237,41 @@ func TestMVCCPutOutOfOrder(t *testing.T) {
 	}
 }
 
+// TestMVCCPutSortedChecksum verifies that sorted puts verify the
+// checksums of their values and write nothing on a mismatch.
+func TestMVCCPutSortedChecksum(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	engine := createTestEngine()
+	defer engine.Close()
+
+	rows := []proto.KeyValue{
+		{Key: testKey1, Value: value1},
+		{Key: testKey2, Value: value2},
+	}
+	for i := range rows {
+		rows[i].Value.InitChecksum(rows[i].Key)
+	}
+	rows[1].Value.Bytes = value3.Bytes
+	if _, err := MVCCPutSorted(engine, nil, rows, makeTS(1, 0)); err == nil || !strings.Contains(err.Error(), "invalid checksum") {
+		t.Fatalf("expected checksum error; got %v", err)
+	}
+	if value, _, err := MVCCGet(engine, testKey1, makeTS(2, 0), true, nil); err != nil || value != nil {
+		t.Fatalf("expected nothing to be written; got %+v, %v", value, err)
+	}
+
+	rows[1].Value.Bytes = value2.Bytes
+	if sorted, err := MVCCPutSorted(engine, nil, rows, makeTS(1, 0)); err != nil || !sorted {
+		t.Fatalf("expected sorted put to succeed; got %t, %v", sorted, err)
+	}
+	value, _, err := MVCCGet(engine, testKey2, makeTS(2, 0), true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value == nil || !bytes.Equal(value.Bytes, value2.Bytes) {
+		t.Fatalf("the value should be %s, but got %+v", value2.Bytes, value)
+	}
+}
+
 // TestMVCCIncrement verifies increment behavior. In particular,
 // incrementing a non-existent key by 0 will create the value.
 func TestMVCCIncrement(t *testing.T) {
@@ -1538,6 +1573,131 @@ func TestMVCCResolveWithDiffEpochs(t *testing.T) {
 	}
 }
 
+// TestMVCCSavepointRollback verifies that a transaction's writes which
+// were rolled back to a savepoint are ignored when it reads its own
+// intents and discarded when the intents are committed.
+func TestMVCCSavepointRollback(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	engine := createTestEngine()
+	defer engine.Close()
+	ms := &MVCCStats{}
+
+	if err := MVCCPut(engine, ms, testKey1, makeTS(0, 1), value1, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ts := makeTS(0, 2)
+	txn := makeTxn(txn1, ts)
+	put := func(key proto.Key, value proto.Value) {
+		txn.Sequence++
+		if err := MVCCPut(engine, ms, key, ts, value, txn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectValue := func(key proto.Key, txn *proto.Transaction, expected []byte) {
+		value, _, err := MVCCGet(engine, key, ts, true, txn)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected == nil {
+			if value != nil {
+				t.Errorf("%q: expected no value; got %q", key, value.Bytes)
+			}
+		} else if value == nil || !bytes.Equal(value.Bytes, expected) {
+			t.Errorf("%q: expected value %q; got %+v", key, expected, value)
+		}
+	}
+
+	put(testKey1, value2) // sequence 1
+	put(testKey2, value2) // sequence 2
+	put(testKey1, value3) // sequence 3
+	expectValue(testKey1, txn, value3.Bytes)
+	expectValue(testKey2, txn, value2.Bytes)
+
+	// Roll back to a savepoint taken after the first write.
+	txn.RolledBack = append(txn.RolledBack, proto.SequenceRange{Start: 2, End: 3})
+	expectValue(testKey1, txn, value2.Bytes)
+	expectValue(testKey2, txn, nil)
+
+	// Overwrite the intent, then roll the new write back as well.
+	put(testKey1, value4) // sequence 4
+	expectValue(testKey1, txn, value4.Bytes)
+	txn.RolledBack = append(txn.RolledBack, proto.SequenceRange{Start: 4, End: 4})
+	expectValue(testKey1, txn, value2.Bytes)
+
+	// Commit: only the write of the first sequence remains.
+	commitTxn := *txn
+	commitTxn.Status = proto.COMMITTED
+	for _, key := range []proto.Key{testKey1, testKey2} {
+		if err := MVCCResolveWriteIntent(engine, ms, key, ts, &commitTxn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectValue(testKey1, nil, value2.Bytes)
+	expectValue(testKey2, nil, nil)
+	value, _, err := MVCCGet(engine, testKey1, makeTS(0, 1), true, nil)
+	if err != nil || value == nil || !bytes.Equal(value.Bytes, value1.Bytes) {
+		t.Errorf("expected value %q, err nil; got %+v, %v", value1.Bytes, value, err)
+	}
+
+	// Verify aggregated stats match computed stats.
+	iter := engine.NewIterator()
+	iter.Seek(proto.KeyMin)
+	expMS, err := MVCCComputeStats(iter, 0)
+	iter.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifyStats("verification", ms, &expMS, t)
+}
+
+// TestMVCCIntentHistorySavepoints verifies that a transaction replacing
+// its own intent only records the replaced value in the intent history
+// when a savepoint separates the two writes.
+func TestMVCCIntentHistorySavepoints(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	engine := createTestEngine()
+	defer engine.Close()
+
+	ts := makeTS(0, 1)
+	txn := makeTxn(txn1, ts)
+	expectHistory := func(expLen int) {
+		meta := &MVCCMetadata{}
+		if ok, _, _, err := engine.GetProto(MVCCEncodeKey(testKey1), meta); err != nil || !ok {
+			t.Fatalf("unable to read intent: ok=%t, err=%v", ok, err)
+		}
+		if len(meta.IntentHistory) != expLen {
+			t.Fatalf("expected %d intent history entries; got %+v", expLen, meta.IntentHistory)
+		}
+	}
+
+	// The writes made without an intervening savepoint share a
+	// sequence number and replace each other.
+	for i := 0; i < 10; i++ {
+		if err := MVCCPut(engine, nil, testKey1, ts, value1, txn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := MVCCPut(engine, nil, testKey1, ts, value2, txn); err != nil {
+		t.Fatal(err)
+	}
+	expectHistory(0)
+
+	// A write after a savepoint records the value a rollback restores.
+	txn.Sequence++
+	for _, value := range []proto.Value{value3, value4} {
+		if err := MVCCPut(engine, nil, testKey1, ts, value, txn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectHistory(1)
+	txn.RolledBack = append(txn.RolledBack, proto.SequenceRange{Start: 1, End: 1})
+	value, _, err := MVCCGet(engine, testKey1, ts, true, txn)
+	if err != nil || value == nil || !bytes.Equal(value.Bytes, value2.Bytes) {
+		t.Errorf("expected value %q, err nil; got %+v, %v", value2.Bytes, value, err)
+	}
+}
+
 func TestMVCCResolveWithUpdatedTimestamp(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	engine := createTestEngine()
@@ -1689,6 +1849,97 @@ func TestMVCCResolveTxnRange(t *testing.T) {
 	}
 }
 
+// TestMVCCChanges verifies that committed versions are returned in
+// key and timestamp order, and that unresolved intents hold back the
+// resolved timestamp.
+func TestMVCCChanges(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	engine := createTestEngine()
+	defer engine.Close()
+
+	if err := MVCCPut(engine, nil, testKey1, makeTS(1, 0), value1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := MVCCPut(engine, nil, testKey2, makeTS(2, 0), value2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := MVCCPut(engine, nil, testKey1, makeTS(3, 0), value2, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := MVCCPut(engine, nil, testKey3, makeTS(4, 0), value3, makeTxn(txn2, makeTS(4, 0))); err != nil {
+		t.Fatal(err)
+	}
+	if err := MVCCDelete(engine, nil, testKey1, makeTS(5, 0), nil); err != nil {
+		t.Fatal(err)
+	}
+	// Inline values are not versioned and never show up as changes.
+	if err := MVCCPut(engine, nil, testKey4, proto.ZeroTimestamp, value4, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	type change struct {
+		key     proto.Key
+		ts      proto.Timestamp
+		value   []byte
+		deleted bool
+	}
+	testCases := []struct {
+		start, end proto.Timestamp
+		intents    int
+		resolved   proto.Timestamp
+		expChanges []change
+	}{
+		// The intent at t=4 holds back the resolved timestamp.
+		{makeTS(0, 0), makeTS(6, 0), 1, makeTS(3, math.MaxInt32), []change{
+			{testKey1, makeTS(1, 0), value1.Bytes, false},
+			{testKey1, makeTS(3, 0), value2.Bytes, false},
+			{testKey2, makeTS(2, 0), value2.Bytes, false},
+		}},
+		// The intent is above the end timestamp and doesn't matter.
+		{makeTS(1, 0), makeTS(2, 0), 0, makeTS(2, 0), []change{
+			{testKey2, makeTS(2, 0), value2.Bytes, false},
+		}},
+		// The resolved timestamp is never lowered below the start.
+		{makeTS(4, 0), makeTS(6, 0), 1, makeTS(4, 0), nil},
+	}
+	check := func(i int, start, end proto.Timestamp, expIntents int, expResolved proto.Timestamp, expChanges []change) {
+		events, resolved, intents, err := MVCCChanges(engine, testKey1, testKey4.Next(), start, end)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if len(intents) != expIntents {
+			t.Errorf("%d: expected %d intents; got %+v", i, expIntents, intents)
+		}
+		if !resolved.Equal(expResolved) {
+			t.Errorf("%d: expected resolved timestamp %s; got %s", i, expResolved, resolved)
+		}
+		if len(events) != len(expChanges) {
+			t.Fatalf("%d: expected %d events; got %+v", i, len(expChanges), events)
+		}
+		for j, exp := range expChanges {
+			e := events[j]
+			if !e.Key.Equal(exp.key) || !e.Value.Timestamp.Equal(exp.ts) ||
+				!bytes.Equal(e.Value.Bytes, exp.value) || e.Deleted != exp.deleted {
+				t.Errorf("%d: expected event %d to be %+v; got %+v", i, j, exp, e)
+			}
+		}
+	}
+	for i, test := range testCases {
+		check(i, test.start, test.end, test.intents, test.resolved, test.expChanges)
+	}
+
+	// Once the intent commits, its value and everything above it is
+	// returned.
+	if err := MVCCResolveWriteIntent(engine, nil, testKey3, makeTS(4, 0), makeTxn(txn2Commit, makeTS(4, 0))); err != nil {
+		t.Fatal(err)
+	}
+	check(len(testCases), makeTS(2, 0), makeTS(6, 0), 0, makeTS(6, 0), []change{
+		{testKey1, makeTS(3, 0), value2.Bytes, false},
+		{testKey1, makeTS(5, 0), nil, true},
+		{testKey3, makeTS(4, 0), value3.Bytes, false},
+	})
+}
+
 func TestValidSplitKeys(t *testing.T) {
 	defer leaktest.AfterTest(t)
 	testCases := []struct {
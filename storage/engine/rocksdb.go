@@ -18,14 +18,17 @@
 // Author: Tobias Schottdorf (tobias.schottdorf@gmail.com)
 // Author: Jiang-Ming Yang (jiangming.yang@gmail.com)
 
+// +build cgo
+
 package engine
 
 import (
 	"bytes"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync/atomic"
-	"syscall"
 	"unsafe"
 
 	"github.com/cockroachdb/cockroach/storage/engine/rocksdb"
@@ -46,6 +49,8 @@ import "C"
 
 func init() {
 	rocksdb.Logger = log.Infof
+	rocksdb.NewFileKey = newFileKey
+	rocksdb.XORKeyStream = xorKeyStream
 }
 
 // RocksDB is a wrapper around a RocksDB database instance.
@@ -55,6 +60,10 @@ type RocksDB struct {
 	attrs     proto.Attributes // Attributes for this engine
 	dir       string           // The data directory
 	cacheSize int64            // Memory to use to cache values.
+
+	encryption *EncryptionOptions // Nil if the data is not encrypted
+	keyring    *storeKeyring
+	keyringID  int // The handle of keyring passed to RocksDB
 }
 
 // NewRocksDB allocates and returns a new RocksDB object.
@@ -69,6 +78,15 @@ func NewRocksDB(attrs proto.Attributes, dir string, cacheSize int64) *RocksDB {
 	}
 }
 
+// NewEncryptedRocksDB allocates and returns a new RocksDB object whose
+// files are encrypted according to the given options.
+func NewEncryptedRocksDB(attrs proto.Attributes, dir string, cacheSize int64,
+	encryption EncryptionOptions) *RocksDB {
+	r := NewRocksDB(attrs, dir, cacheSize)
+	r.encryption = &encryption
+	return r
+}
+
 func newMemRocksDB(attrs proto.Attributes, cacheSize int64) *RocksDB {
 	return &RocksDB{
 		attrs: attrs,
@@ -100,14 +118,19 @@ func (r *RocksDB) Open() error {
 	} else {
 		log.Infof("opening rocksdb instance at %q", r.dir)
 	}
+	if err := r.openKeyring(); err != nil {
+		return util.Errorf("could not open rocksdb instance: %s", err)
+	}
 	status := C.DBOpen(&r.rdb, goToCSlice([]byte(r.dir)),
 		C.DBOptions{
 			cache_size:      C.int64_t(r.cacheSize),
 			allow_os_buffer: C.bool(true),
 			logging_enabled: C.bool(log.V(3)),
+			encryption:      C.int(r.keyringID),
 		})
 	err := statusToError(status)
 	if err != nil {
+		r.closeKeyring()
 		return util.Errorf("could not open rocksdb instance: %s", err)
 	}
 
@@ -129,6 +152,48 @@ func (r *RocksDB) Close() {
 		C.DBClose(r.rdb)
 		r.rdb = nil
 	}
+	r.closeKeyring()
+}
+
+// openKeyring loads the keys of an encrypted database and registers
+// them for use by RocksDB. Opening an encrypted database without its
+// keys is refused.
+func (r *RocksDB) openKeyring() error {
+	if r.encryption == nil {
+		if len(r.dir) != 0 {
+			if _, err := os.Stat(filepath.Join(r.dir, storeKeyRegistryFile)); err == nil {
+				return util.Errorf("store at %q is encrypted; its key file must be specified", r.dir)
+			}
+		}
+		return nil
+	}
+	if len(r.dir) == 0 {
+		return util.Errorf("in-memory stores cannot be encrypted")
+	}
+	keyring, err := openStoreKeyring(r.dir, *r.encryption)
+	if err != nil {
+		return err
+	}
+	r.keyring = keyring
+	r.keyringID = registerKeyring(keyring)
+	return nil
+}
+
+func (r *RocksDB) closeKeyring() {
+	if r.keyring != nil {
+		unregisterKeyring(r.keyringID)
+		r.keyring, r.keyringID = nil, 0
+	}
+}
+
+// EncryptionStatus returns the keys with which the files of the
+// database are encrypted and the files which are not encrypted with
+// the current key, or nil if the database is not encrypted.
+func (r *RocksDB) EncryptionStatus() (*EncryptionStatus, error) {
+	if r.keyring == nil {
+		return nil, nil
+	}
+	return r.keyring.status()
 }
 
 // Attrs returns the list of attributes describing this engine. This
@@ -139,10 +204,6 @@ func (r *RocksDB) Attrs() proto.Attributes {
 	return r.attrs
 }
 
-func emptyKeyError() error {
-	return util.ErrorSkipFrames(1, "attempted access to empty key")
-}
-
 // Put sets the given key to the value provided.
 //
 // The key and value byte slices may be reused safely. put takes a copy of
@@ -177,6 +238,19 @@ func (r *RocksDB) Merge(key proto.EncodedKey, value []byte) error {
 	return statusToError(C.DBMerge(r.rdb, goToCSlice(key), goToCSlice(value)))
 }
 
+// PutSorted puts the sorted key/value pairs through a batch. The
+// version of RocksDB in use can neither build table files outside of
+// the database nor add them to it, so unlike GoLSM, it writes the
+// pairs just as it would individual puts.
+func (r *RocksDB) PutSorted(kvs []proto.RawKeyValue) error {
+	b := r.NewBatch()
+	defer b.Close()
+	if err := b.PutSorted(kvs); err != nil {
+		return err
+	}
+	return b.Commit()
+}
+
 // Get returns the value for the given key.
 func (r *RocksDB) Get(key proto.EncodedKey) ([]byte, error) {
 	return r.getInternal(key, nil)
@@ -267,18 +341,7 @@ func (r *RocksDB) iterateInternal(start, end proto.EncodedKey, f func(proto.RawK
 
 // Capacity queries the underlying file system for disk capacity information.
 func (r *RocksDB) Capacity() (proto.StoreCapacity, error) {
-	var fs syscall.Statfs_t
-	var capacity proto.StoreCapacity
-	dir := r.dir
-	if dir == "" {
-		dir = "/tmp"
-	}
-	if err := syscall.Statfs(dir, &fs); err != nil {
-		return capacity, err
-	}
-	capacity.Capacity = int64(fs.Bsize) * int64(fs.Blocks)
-	capacity.Available = int64(fs.Bsize) * int64(fs.Bavail)
-	return capacity, nil
+	return fsCapacity(r.dir)
 }
 
 // SetGCTimeouts calls through to the DBEngine's SetGCTimeouts method.
@@ -312,7 +375,16 @@ func (r *RocksDB) CompactRange(start, end proto.EncodedKey) {
 
 // Destroy destroys the underlying filesystem data associated with the database.
 func (r *RocksDB) Destroy() error {
-	return statusToError(C.DBDestroy(goToCSlice([]byte(r.dir))))
+	if err := statusToError(C.DBDestroy(goToCSlice([]byte(r.dir)))); err != nil {
+		return err
+	}
+	if len(r.dir) == 0 {
+		return nil
+	}
+	if err := os.Remove(filepath.Join(r.dir, storeKeyRegistryFile)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
 }
 
 // ApproximateSize returns the approximate number of bytes on disk that RocksDB
@@ -458,6 +530,11 @@ func (r *rocksDBSnapshot) Put(key proto.EncodedKey, value []byte) error {
 	return util.Errorf("cannot Put to a snapshot")
 }
 
+// PutSorted is illegal for snapshot and returns an error.
+func (r *rocksDBSnapshot) PutSorted(kvs []proto.RawKeyValue) error {
+	return util.Errorf("cannot PutSorted to a snapshot")
+}
+
 // Get returns the value for the given key, nil otherwise using
 // the snapshot handle.
 func (r *rocksDBSnapshot) Get(key proto.EncodedKey) ([]byte, error) {
@@ -576,6 +653,15 @@ func (r *rocksDBBatch) Merge(key proto.EncodedKey, value []byte) error {
 	return nil
 }
 
+func (r *rocksDBBatch) PutSorted(kvs []proto.RawKeyValue) error {
+	for _, kv := range kvs {
+		if err := r.Put(kv.Key, kv.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (r *rocksDBBatch) Get(key proto.EncodedKey) ([]byte, error) {
 	if len(key) == 0 {
 		return nil, emptyKeyError()
@@ -49,6 +49,7 @@ struct DBBatch {
 struct DBEngine {
   rocksdb::DB* rep;
   rocksdb::Env* memenv;
+  rocksdb::Env* encenv;
 };
 
 struct DBIterator {
@@ -714,6 +715,345 @@ class DBLogger : public rocksdb::Logger {
   const bool enabled_;
 };
 
+// NOTE: the layout of the header of encrypted files must be kept in
+// sync with storage/engine/encryption.go. The header consists of the
+// magic, the big-endian ID of the store key the file is encrypted
+// with and the file's IV.
+const rocksdb::Slice kEncryptedFileMagic("roachenc", 8);
+const size_t kEncryptionIVSize = 16;
+const size_t kEncryptedFileHeaderSize = 8 + 8 + kEncryptionIVSize;
+
+// FromGoError converts an error returned by an exported Go function
+// into a status, freeing the error.
+rocksdb::Status FromGoError(char* err) {
+  if (err == NULL) {
+    return rocksdb::Status::OK();
+  }
+  rocksdb::Status status = rocksdb::Status::IOError(err);
+  free(err);
+  return status;
+}
+
+std::string EncodeEncryptionHeader(uint64_t key_id, const char* iv) {
+  std::string header(kEncryptedFileMagic.data(), kEncryptedFileMagic.size());
+  for (int i = 7; i >= 0; i--) {
+    header.push_back(static_cast<char>(key_id >> (8 * i)));
+  }
+  header.append(iv, kEncryptionIVSize);
+  return header;
+}
+
+// DecodeEncryptionHeader returns true if data holds the header of an
+// encrypted file, storing the ID of its key and its IV.
+bool DecodeEncryptionHeader(const rocksdb::Slice& data, uint64_t* key_id, char* iv) {
+  if (data.size() < kEncryptedFileHeaderSize || !data.starts_with(kEncryptedFileMagic)) {
+    return false;
+  }
+  const unsigned char* p =
+      reinterpret_cast<const unsigned char*>(data.data()) + kEncryptedFileMagic.size();
+  *key_id = 0;
+  for (int i = 0; i < 8; i++) {
+    *key_id = (*key_id << 8) | p[i];
+  }
+  memcpy(iv, p + 8, kEncryptionIVSize);
+  return true;
+}
+
+// FileCipher encrypts and decrypts the contents of a file by calling
+// into Go, which holds the store's keys. Encryption uses AES in
+// counter mode, so the same operation decrypts data and the contents
+// can be processed at any offset.
+class FileCipher {
+ public:
+  FileCipher(int handle, uint64_t key_id, const char* iv)
+      : handle_(handle),
+        key_id_(key_id) {
+    memcpy(iv_, iv, kEncryptionIVSize);
+  }
+
+  // XOR encrypts or decrypts the n bytes of data in place, which are
+  // located at offset in the contents of the file.
+  rocksdb::Status XOR(uint64_t offset, char* data, size_t n) const {
+    if (n == 0) {
+      return rocksdb::Status::OK();
+    }
+    return FromGoError(rocksDBEncryptionXOR(
+        handle_, key_id_, const_cast<char*>(iv_), kEncryptionIVSize, offset, data, n));
+  }
+
+ private:
+  const int handle_;
+  const uint64_t key_id_;
+  char iv_[kEncryptionIVSize];
+};
+
+class EncryptedSequentialFile : public rocksdb::SequentialFile {
+ public:
+  EncryptedSequentialFile(rocksdb::SequentialFile* base, const FileCipher& cipher)
+      : base_(base),
+        cipher_(cipher),
+        offset_(0) {
+  }
+
+  virtual rocksdb::Status Read(size_t n, rocksdb::Slice* result, char* scratch) {
+    rocksdb::Status status = base_->Read(n, result, scratch);
+    if (!status.ok()) {
+      return status;
+    }
+    if (result->data() != scratch) {
+      memmove(scratch, result->data(), result->size());
+    }
+    *result = rocksdb::Slice(scratch, result->size());
+    status = cipher_.XOR(offset_, scratch, result->size());
+    offset_ += result->size();
+    return status;
+  }
+
+  virtual rocksdb::Status Skip(uint64_t n) {
+    rocksdb::Status status = base_->Skip(n);
+    if (status.ok()) {
+      offset_ += n;
+    }
+    return status;
+  }
+
+  virtual rocksdb::Status InvalidateCache(size_t offset, size_t length) {
+    return base_->InvalidateCache(offset + kEncryptedFileHeaderSize, length);
+  }
+
+ private:
+  std::unique_ptr<rocksdb::SequentialFile> base_;
+  const FileCipher cipher_;
+  uint64_t offset_;
+};
+
+class EncryptedRandomAccessFile : public rocksdb::RandomAccessFile {
+ public:
+  EncryptedRandomAccessFile(rocksdb::RandomAccessFile* base, const FileCipher& cipher)
+      : base_(base),
+        cipher_(cipher) {
+  }
+
+  virtual rocksdb::Status Read(uint64_t offset, size_t n, rocksdb::Slice* result,
+                               char* scratch) const {
+    rocksdb::Status status = base_->Read(offset + kEncryptedFileHeaderSize, n, result, scratch);
+    if (!status.ok()) {
+      return status;
+    }
+    if (result->data() != scratch) {
+      memmove(scratch, result->data(), result->size());
+    }
+    *result = rocksdb::Slice(scratch, result->size());
+    return cipher_.XOR(offset, scratch, result->size());
+  }
+
+  virtual size_t GetUniqueId(char* id, size_t max_size) const {
+    return base_->GetUniqueId(id, max_size);
+  }
+
+  virtual void Hint(AccessPattern pattern) {
+    base_->Hint(pattern);
+  }
+
+  virtual rocksdb::Status InvalidateCache(size_t offset, size_t length) {
+    return base_->InvalidateCache(offset + kEncryptedFileHeaderSize, length);
+  }
+
+ private:
+  std::unique_ptr<rocksdb::RandomAccessFile> base_;
+  const FileCipher cipher_;
+};
+
+// EncryptedWritableFile encrypts appended data into a buffer before
+// passing it on to the underlying file. Preallocation is not
+// supported as the underlying file's Allocate method is inaccessible.
+class EncryptedWritableFile : public rocksdb::WritableFile {
+ public:
+  EncryptedWritableFile(rocksdb::WritableFile* base, const FileCipher& cipher)
+      : base_(base),
+        cipher_(cipher),
+        offset_(0) {
+  }
+
+  virtual rocksdb::Status Append(const rocksdb::Slice& data) {
+    buf_.assign(data.data(), data.size());
+    rocksdb::Status status = cipher_.XOR(offset_, &buf_[0], buf_.size());
+    if (!status.ok()) {
+      return status;
+    }
+    status = base_->Append(buf_);
+    if (status.ok()) {
+      offset_ += data.size();
+    }
+    return status;
+  }
+
+  virtual rocksdb::Status Close() {
+    return base_->Close();
+  }
+
+  virtual rocksdb::Status Flush() {
+    return base_->Flush();
+  }
+
+  virtual rocksdb::Status Sync() {
+    return base_->Sync();
+  }
+
+  virtual rocksdb::Status Fsync() {
+    return base_->Fsync();
+  }
+
+  virtual void SetIOPriority(rocksdb::Env::IOPriority pri) {
+    base_->SetIOPriority(pri);
+  }
+
+  virtual uint64_t GetFileSize() {
+    return offset_;
+  }
+
+  virtual size_t GetUniqueId(char* id, size_t max_size) const {
+    return base_->GetUniqueId(id, max_size);
+  }
+
+  virtual rocksdb::Status InvalidateCache(size_t offset, size_t length) {
+    return base_->InvalidateCache(offset + kEncryptedFileHeaderSize, length);
+  }
+
+ private:
+  std::unique_ptr<rocksdb::WritableFile> base_;
+  const FileCipher cipher_;
+  uint64_t offset_;
+  std::string buf_;
+};
+
+// EncryptedEnv encrypts the files of a store with the keys held by the
+// Go keyring identified by handle. Files which do not start with an
+// encryption header are read as plaintext, which allows encryption to
+// be enabled for an existing store: its files are encrypted as they
+// are rewritten by compactions.
+class EncryptedEnv : public rocksdb::EnvWrapper {
+ public:
+  EncryptedEnv(rocksdb::Env* base, int handle)
+      : rocksdb::EnvWrapper(base),
+        handle_(handle) {
+  }
+
+  virtual rocksdb::Status NewSequentialFile(const std::string& fname,
+                                            std::unique_ptr<rocksdb::SequentialFile>* result,
+                                            const rocksdb::EnvOptions& options) {
+    uint64_t key_id;
+    char iv[kEncryptionIVSize];
+    bool encrypted;
+    rocksdb::Status status = ReadHeader(fname, &encrypted, &key_id, iv);
+    if (!status.ok()) {
+      return status;
+    }
+    status = target()->NewSequentialFile(fname, result, options);
+    if (!status.ok() || !encrypted) {
+      return status;
+    }
+    status = (*result)->Skip(kEncryptedFileHeaderSize);
+    if (!status.ok()) {
+      result->reset();
+      return status;
+    }
+    result->reset(new EncryptedSequentialFile(result->release(),
+                                              FileCipher(handle_, key_id, iv)));
+    return status;
+  }
+
+  virtual rocksdb::Status NewRandomAccessFile(const std::string& fname,
+                                              std::unique_ptr<rocksdb::RandomAccessFile>* result,
+                                              const rocksdb::EnvOptions& options) {
+    rocksdb::Status status = target()->NewRandomAccessFile(fname, result, options);
+    if (!status.ok()) {
+      return status;
+    }
+    char scratch[kEncryptedFileHeaderSize];
+    rocksdb::Slice header;
+    status = (*result)->Read(0, kEncryptedFileHeaderSize, &header, scratch);
+    if (!status.ok()) {
+      result->reset();
+      return status;
+    }
+    uint64_t key_id;
+    char iv[kEncryptionIVSize];
+    if (DecodeEncryptionHeader(header, &key_id, iv)) {
+      result->reset(new EncryptedRandomAccessFile(result->release(),
+                                                  FileCipher(handle_, key_id, iv)));
+    }
+    return status;
+  }
+
+  virtual rocksdb::Status NewWritableFile(const std::string& fname,
+                                          std::unique_ptr<rocksdb::WritableFile>* result,
+                                          const rocksdb::EnvOptions& options) {
+    uint64_t key_id;
+    char iv[kEncryptionIVSize];
+    rocksdb::Status status = FromGoError(
+        rocksDBEncryptionNewFile(handle_, &key_id, iv, kEncryptionIVSize));
+    if (!status.ok()) {
+      return status;
+    }
+    status = target()->NewWritableFile(fname, result, options);
+    if (!status.ok()) {
+      return status;
+    }
+    status = (*result)->Append(EncodeEncryptionHeader(key_id, iv));
+    if (!status.ok()) {
+      result->reset();
+      return status;
+    }
+    result->reset(new EncryptedWritableFile(result->release(),
+                                            FileCipher(handle_, key_id, iv)));
+    return status;
+  }
+
+  virtual rocksdb::Status NewRandomRWFile(const std::string& fname,
+                                          std::unique_ptr<rocksdb::RandomRWFile>* result,
+                                          const rocksdb::EnvOptions& options) {
+    return rocksdb::Status::NotSupported("random read/write files cannot be encrypted");
+  }
+
+  virtual rocksdb::Status GetFileSize(const std::string& fname, uint64_t* file_size) {
+    rocksdb::Status status = target()->GetFileSize(fname, file_size);
+    if (!status.ok()) {
+      return status;
+    }
+    uint64_t key_id;
+    char iv[kEncryptionIVSize];
+    bool encrypted;
+    status = ReadHeader(fname, &encrypted, &key_id, iv);
+    if (status.ok() && encrypted) {
+      *file_size -= kEncryptedFileHeaderSize;
+    }
+    return status;
+  }
+
+ private:
+  // ReadHeader determines whether the file is encrypted and, if so,
+  // reads the ID of its key and its IV.
+  rocksdb::Status ReadHeader(const std::string& fname, bool* encrypted,
+                             uint64_t* key_id, char* iv) {
+    std::unique_ptr<rocksdb::SequentialFile> file;
+    rocksdb::Status status = target()->NewSequentialFile(fname, &file, rocksdb::EnvOptions());
+    if (!status.ok()) {
+      return status;
+    }
+    char scratch[kEncryptedFileHeaderSize];
+    rocksdb::Slice header;
+    status = file->Read(kEncryptedFileHeaderSize, &header, scratch);
+    if (!status.ok()) {
+      return status;
+    }
+    *encrypted = DecodeEncryptionHeader(header, key_id, iv);
+    return status;
+  }
+
+  const int handle_;
+};
+
 // Getter defines an interface for retrieving a value from either an
 // iterator or an engine. It is used by ProcessDeltaKey to abstract
 // whether the "base" layer is an iterator or an engine.
@@ -1081,19 +1421,25 @@ DBStatus DBOpen(DBEngine **db, DBSlice dir, DBOptions db_opts) {
   options.max_bytes_for_level_base = 512 << 20;   // 512 MB
 
   rocksdb::Env* memenv = NULL;
+  rocksdb::Env* encenv = NULL;
   if (dir.len == 0) {
     memenv = rocksdb::NewMemEnv(rocksdb::Env::Default());
     options.env = memenv;
+  } else if (db_opts.encryption != 0) {
+    encenv = new EncryptedEnv(rocksdb::Env::Default(), db_opts.encryption);
+    options.env = encenv;
   }
 
   rocksdb::DB *db_ptr;
   rocksdb::Status status = rocksdb::DB::Open(options, ToString(dir), &db_ptr);
   if (!status.ok()) {
+    delete encenv;
     return ToDBStatus(status);
   }
   *db = new DBEngine;
   (*db)->rep = db_ptr;
   (*db)->memenv = memenv;
+  (*db)->encenv = encenv;
   return kSuccess;
 }
 
@@ -1105,6 +1451,7 @@ DBStatus DBDestroy(DBSlice dir) {
 void DBClose(DBEngine* db) {
   delete db->rep;
   delete db->memenv;
+  delete db->encenv;
   delete db;
 }
 
@@ -53,6 +53,9 @@ typedef struct {
   int64_t cache_size;
   bool allow_os_buffer;
   bool logging_enabled;
+  // The handle of the Go keyring holding the keys with which the files
+  // of the database are encrypted, or 0 if they are not encrypted.
+  int encryption;
 } DBOptions;
 
 // Opens the database located in "dir", creating it if it doesn't
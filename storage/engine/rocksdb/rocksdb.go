@@ -18,6 +18,9 @@
 package rocksdb
 
 import (
+	"errors"
+	"unsafe"
+
 	// Link against the protobuf, rocksdb, and snappy libaries. This is
 	// explicit because these Go libraries do not export any Go symbols.
 	_ "github.com/cockroachdb/c-protobuf"
@@ -30,6 +33,9 @@ import (
 // #cgo darwin LDFLAGS: -Wl,-undefined -Wl,dynamic_lookup
 // #cgo !darwin LDFLAGS: -Wl,-unresolved-symbols=ignore-all
 // #cgo linux LDFLAGS: -lrt
+//
+// #include <stdint.h>
+// #include <stdlib.h>
 import "C"
 
 // Logger is a logging function to be set by the importing package. Its
@@ -42,3 +48,56 @@ func rocksDBLog(s *C.char, n C.int) {
 	// when RocksDB.Open() is called.
 	Logger("%s", C.GoStringN(s, n))
 }
+
+// NewFileKey is called when RocksDB creates a file in an encrypted
+// database. It is to be set by the importing package, which holds the
+// keys of the database identified by handle. It must fill iv with the
+// file's initialization vector and return the ID of the key with which
+// to encrypt the file.
+var NewFileKey = func(handle int, iv []byte) (uint64, error) {
+	return 0, errors.New("encryption is not supported")
+}
+
+// XORKeyStream is called to encrypt or decrypt the data located at
+// offset in the contents of a file of an encrypted database. It is to
+// be set by the importing package and must XOR the data in place with
+// the key stream of the given key and initialization vector.
+var XORKeyStream = func(handle int, keyID uint64, iv []byte, offset int64, data []byte) error {
+	return errors.New("encryption is not supported")
+}
+
+//export rocksDBEncryptionNewFile
+func rocksDBEncryptionNewFile(handle C.int, keyID *C.uint64_t, iv *C.char, ivLen C.size_t) *C.char {
+	id, err := NewFileKey(int(handle), cBytes(iv, ivLen))
+	if err != nil {
+		return C.CString(err.Error())
+	}
+	*keyID = C.uint64_t(id)
+	return nil
+}
+
+//export rocksDBEncryptionXOR
+func rocksDBEncryptionXOR(handle C.int, keyID C.uint64_t, iv *C.char, ivLen C.size_t,
+	offset C.uint64_t, data *C.char, n C.size_t) *C.char {
+	if n > maxCBytes {
+		return C.CString("unable to encrypt more than 2GB at once")
+	}
+	if err := XORKeyStream(int(handle), uint64(keyID), cBytes(iv, ivLen), int64(offset),
+		cBytes(data, n)); err != nil {
+		return C.CString(err.Error())
+	}
+	return nil
+}
+
+// maxCBytes is the largest number of bytes of C memory which can be
+// referenced by a Go slice.
+const maxCBytes = 0x7fffffff
+
+// cBytes returns a slice referencing the n bytes of C memory at p
+// without copying them. n must not exceed maxCBytes.
+func cBytes(p *C.char, n C.size_t) []byte {
+	if n == 0 {
+		return nil
+	}
+	return (*[maxCBytes]byte)(unsafe.Pointer(p))[:n:n]
+}
@@ -103,9 +103,10 @@ type ReplicationStatusEvent struct {
 	// periodically polling the ranges of each store.
 	// TODO(mrtracy): See if this information could be computed incrementally
 	// from other events.
-	LeaderRangeCount     int32
-	ReplicatedRangeCount int32
-	AvailableRangeCount  int32
+	LeaderRangeCount      int32
+	ReplicatedRangeCount  int32
+	AvailableRangeCount   int32
+	QuarantinedRangeCount int32
 }
 
 // BeginScanRangesEvent occurs when the store is about to scan over all ranges.
@@ -208,15 +209,16 @@ func (sef StoreEventFeed) storeStatus(desc *proto.StoreDescriptor) {
 }
 
 // replicationStatus publishes a ReplicationStatusEvent to this feed.
-func (sef StoreEventFeed) replicationStatus(leaders, replicated, available int32) {
+func (sef StoreEventFeed) replicationStatus(leaders, replicated, available, quarantined int32) {
 	if sef.f == nil {
 		return
 	}
 	sef.f.Publish(&ReplicationStatusEvent{
-		StoreID:              sef.id,
-		LeaderRangeCount:     leaders,
-		ReplicatedRangeCount: replicated,
-		AvailableRangeCount:  available,
+		StoreID:               sef.id,
+		LeaderRangeCount:      leaders,
+		ReplicatedRangeCount:  replicated,
+		AvailableRangeCount:   available,
+		QuarantinedRangeCount: quarantined,
 	})
 }
 
@@ -298,13 +298,14 @@ func TestStoreEventFeed(t *testing.T) {
 		{
 			"ReplicationStatus",
 			func(feed StoreEventFeed) {
-				feed.replicationStatus(3, 2, 1)
+				feed.replicationStatus(3, 2, 1, 4)
 			},
 			&ReplicationStatusEvent{
-				StoreID:              proto.StoreID(1),
-				LeaderRangeCount:     3,
-				ReplicatedRangeCount: 2,
-				AvailableRangeCount:  1,
+				StoreID:               proto.StoreID(1),
+				LeaderRangeCount:      3,
+				ReplicatedRangeCount:  2,
+				AvailableRangeCount:   1,
+				QuarantinedRangeCount: 4,
 			},
 		},
 		{
@@ -52,8 +52,8 @@ const (
 // entirety using the MVCC versions iterator. The gc queue manages the
 // following tasks:
 //
-//  - GC of version data via TTL expiration (and more complex schemes
-//    as implemented going forward).
+//  - GC of version data via TTL expiration and limits on the number
+//    of versions of each key.
 //  - Resolve extant write intents and determine oldest non-resolvable
 //    intent.
 //
@@ -87,7 +87,26 @@ func (gcq *gcQueue) shouldQueue(now proto.Timestamp, rng *Range) (shouldQ bool,
 	}
 
 	// GC score is the total GC'able bytes age normalized by 1 MB * the range's TTL in seconds.
-	gcScore := float64(rng.stats.GetGCBytesAge(now.WallTime)) / float64(policy.TTLSeconds) / float64(gcByteCountNormalization)
+	var gcScore float64
+	if policy.TTLSeconds > 0 {
+		gcScore = float64(rng.stats.GetGCBytesAge(now.WallTime)) / float64(policy.TTLSeconds) / float64(gcByteCountNormalization)
+	}
+	// Versions beyond the maximum number are GC'able regardless of their
+	// age. The stats don't track the number of versions of keys, but the
+	// non-live bytes retained by the last GC are within the limit, so
+	// the GC'able bytes are estimated as the non-live bytes written
+	// since.
+	if policy.MaxVersions > 0 {
+		gcMeta, err := rng.GetGCMetadata()
+		if err != nil {
+			log.Errorf("GC metadata: %s", err)
+			return
+		}
+		versionScore := float64(rng.stats.GetGCBytes()-gcMeta.GetRetainedGCBytes()) / float64(gcByteCountNormalization)
+		if versionScore > gcScore {
+			gcScore = versionScore
+		}
+	}
 
 	// Intent score. This computes the average age of outstanding intents
 	// and normalizes.
@@ -121,7 +140,9 @@ func (gcq *gcQueue) process(now proto.Timestamp, rng *Range) error {
 	}
 
 	gcMeta := proto.NewGCMetadata(now.WallTime)
-	gc := engine.NewGarbageCollector(now, policy)
+	// Versions beyond the maximum number are retained for as long as
+	// transactions may still read below their successors.
+	gc := engine.NewGarbageCollector(now, policy, intentAgeThreshold)
 
 	// Compute intent expiration (intent age at which we attempt to resolve).
 	intentExp := now
@@ -133,6 +154,11 @@ func (gcq *gcQueue) process(now proto.Timestamp, rng *Range) error {
 			RaftID:    rng.Desc().RaftID,
 		},
 	}
+	// Change log entries are kept as long as the versions they refer to.
+	if policy.TTLSeconds > 0 {
+		gcArgs.ChangeLogThreshold = now
+		gcArgs.ChangeLogThreshold.WallTime -= int64(policy.TTLSeconds) * 1E9
+	}
 	var mu sync.Mutex
 	var oldestIntentNanos int64 = math.MaxInt64
 	var wg sync.WaitGroup
@@ -213,7 +239,19 @@ func (gcq *gcQueue) process(now proto.Timestamp, rng *Range) error {
 	// Set start and end keys.
 	switch len(gcArgs.Keys) {
 	case 0:
-		return nil
+		// Only send the request to discard old change log entries or, if
+		// the number of versions is limited, to record the non-live bytes
+		// which were retained.
+		if policy.MaxVersions <= 0 {
+			if gcArgs.ChangeLogThreshold.Equal(proto.ZeroTimestamp) {
+				return nil
+			}
+			if ok, err := hasChangeLogBefore(snap, rng.Desc().RaftID, gcArgs.ChangeLogThreshold); err != nil || !ok {
+				return err
+			}
+		}
+		gcArgs.Key = rng.Desc().StartKey
+		gcArgs.EndKey = gcArgs.Key.Next()
 	case 1:
 		gcArgs.Key = gcArgs.Keys[0].Key
 		gcArgs.EndKey = gcArgs.Key.Next()
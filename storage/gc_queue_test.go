@@ -28,6 +28,7 @@ import (
 	"github.com/cockroachdb/cockroach/storage/engine"
 	"github.com/cockroachdb/cockroach/util/leaktest"
 	"github.com/cockroachdb/cockroach/util/log"
+	gogoproto "github.com/gogo/protobuf/proto"
 )
 
 // makeTS creates a new hybrid logical timestamp.
@@ -116,6 +117,70 @@ func TestGCQueueShouldQueue(t *testing.T) {
 	}
 }
 
+// TestGCQueueShouldQueueMaxVersions verifies that ranges of zones
+// whose GC policy limits the number of versions of keys are queued
+// based on the non-live bytes written since the last GC, regardless
+// of their age.
+func TestGCQueueShouldQueueMaxVersions(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	zoneConfig := proto.ZoneConfig{
+		ReplicaAttrs:  []proto.Attributes{},
+		RangeMinBytes: 1 << 10,
+		RangeMaxBytes: 1 << 18,
+		GC: &proto.GCPolicy{
+			MaxVersions: 2,
+		},
+	}
+	pcc, err := NewPrefixConfigMap([]*PrefixConfig{{proto.KeyMin, nil, &zoneConfig}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tc.rng.rm.Gossip().AddInfo(gossip.KeyConfigZone, pcc, 0*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	bc := int64(gcByteCountNormalization)
+	testCases := []struct {
+		gcBytes       int64
+		retainedBytes int64
+		shouldQ       bool
+		priority      float64
+	}{
+		// No GC'able bytes.
+		{0, 0, false, 0},
+		// Non-live bytes which were all retained by the last GC.
+		{6 * bc, 6 * bc, false, 0},
+		// Few non-live bytes written since the last GC.
+		{6 * bc, 5 * bc, false, 0},
+		// Enough non-live bytes written since the last GC.
+		{6 * bc, 3 * bc, true, 3},
+	}
+
+	gcQ := newGCQueue()
+
+	for i, test := range testCases {
+		stats := engine.MVCCStats{KeyBytes: test.gcBytes}
+		if err := tc.rng.stats.SetMVCCStats(tc.rng.rm.Engine(), stats); err != nil {
+			t.Fatal(err)
+		}
+		gcMeta := &proto.GCMetadata{RetainedGCBytes: gogoproto.Int64(test.retainedBytes)}
+		if err := engine.MVCCPutProto(tc.rng.rm.Engine(), nil, keys.RangeGCMetadataKey(tc.rng.Desc().RaftID), proto.ZeroTimestamp, nil, gcMeta); err != nil {
+			t.Fatal(err)
+		}
+		shouldQ, priority := gcQ.shouldQueue(makeTS(0, 0), tc.rng)
+		if shouldQ != test.shouldQ {
+			t.Errorf("%d: should queue expected %t; got %t", i, test.shouldQ, shouldQ)
+		}
+		if math.Abs(priority-test.priority) > 0.00001 {
+			t.Errorf("%d: priority expected %f; got %f", i, test.priority, priority)
+		}
+	}
+}
+
 // TestGCQueueProcess creates test data in the range over various time
 // scales and verifies that scan queue process properly GCs test data.
 func TestGCQueueProcess(t *testing.T) {
@@ -278,6 +343,9 @@ func TestGCQueueProcess(t *testing.T) {
 	if *gcMeta.OldestIntentNanos != ts4.WallTime {
 		t.Errorf("expected oldest intent nanos=%d; got %d", ts4.WallTime, gcMeta.OldestIntentNanos)
 	}
+	if retained := tc.rng.stats.GetGCBytes(); gcMeta.GetRetainedGCBytes() != retained {
+		t.Errorf("expected retained GC bytes=%d; got %d", retained, gcMeta.GetRetainedGCBytes())
+	}
 
 	// Verify that the last verification timestamp was updated as whole range was scanned.
 	ts, err := tc.rng.GetLastVerificationTimestamp()
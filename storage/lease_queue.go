@@ -0,0 +1,286 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/cockroach/gossip"
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util/log"
+)
+
+const (
+	// leaseQueueMaxSize is the max size of the lease queue.
+	leaseQueueMaxSize = 100
+	// leaseQueueTimerDuration is the duration between lease transfers of
+	// queued ranges.
+	leaseQueueTimerDuration = 1 * time.Second
+	// leaseRebalanceThreshold is the fraction by which the number of
+	// leases held by a store must exceed the mean of the stores holding
+	// replicas of a range for the store to give up the range's lease.
+	leaseRebalanceThreshold = 0.1
+	// leaseLocalityMinRequests is the minimum number of requests served
+	// by a range over the last load window for the location of its
+	// clients to be taken into account.
+	leaseLocalityMinRequests = 100
+	// leaseLocalityThreshold is the minimum number of node attributes by
+	// which the average request must come closer to a replica for the
+	// lease to be transferred to it.
+	leaseLocalityThreshold = 0.5
+)
+
+// leaseQueue manages a queue of ranges whose leader lease should be
+// transferred to another replica. The lease follows the range's
+// clients: a replica whose node shares a longer prefix of attributes
+// with the gateway nodes through which most requests are submitted is
+// preferred. Otherwise, leases are moved away from stores holding more
+// than their share of leases, according to the lease counts gossiped
+// by the stores holding replicas of the range.
+//
+// The gossiped lease counts only reflect the transfers made since the
+// stores last gossiped their descriptors. Until they do, the leases
+// transferred to a store are added to its gossiped count, so that a
+// store which has just been chosen as the target of some transfers
+// does not remain the target of all of them.
+type leaseQueue struct {
+	*baseQueue
+	gossip    *gossip.Gossip
+	countFn   rangeCountFn
+	mu        sync.Mutex                       // Protects transfers
+	transfers map[proto.StoreID]leaseTransfers // Leases transferred by store
+}
+
+// leaseTransfers counts the leases transferred to a store since its
+// lease count was last gossiped.
+type leaseTransfers struct {
+	gossiped int32 // The store's gossiped lease count at the first transfer
+	count    int32 // The number of leases transferred since
+}
+
+// newLeaseQueue returns a new instance of leaseQueue. countFn returns
+// the number of leases held by the local store.
+func newLeaseQueue(gossip *gossip.Gossip, countFn rangeCountFn) *leaseQueue {
+	lq := &leaseQueue{
+		gossip:    gossip,
+		countFn:   countFn,
+		transfers: map[proto.StoreID]leaseTransfers{},
+	}
+	lq.baseQueue = newBaseQueue("lease", lq, leaseQueueMaxSize)
+	return lq
+}
+
+func (lq *leaseQueue) needsLeaderLease() bool {
+	return true
+}
+
+// shouldQueue determines whether the range's leader lease should be
+// transferred to another replica.
+func (lq *leaseQueue) shouldQueue(now proto.Timestamp, rng *Range) (shouldQ bool, priority float64) {
+	target, priority := lq.leaseTarget(now, rng)
+	return target != nil, priority
+}
+
+// process transfers the range's leader lease to the chosen replica.
+func (lq *leaseQueue) process(now proto.Timestamp, rng *Range) error {
+	target, _ := lq.leaseTarget(now, rng)
+	if target == nil {
+		// Something changed between shouldQueue and process.
+		return nil
+	}
+	log.Infof("transferring leader lease of %s to store %d", rng, target.replica.StoreID)
+	if err := rng.transferLeaderLease(target.replica); err != nil {
+		return err
+	}
+	lq.recordTransfer(target)
+	return nil
+}
+
+// recordTransfer counts a lease transferred to the target's store.
+func (lq *leaseQueue) recordTransfer(target *leaseCandidate) {
+	lq.mu.Lock()
+	defer lq.mu.Unlock()
+	t, ok := lq.transfers[target.replica.StoreID]
+	if !ok {
+		t.gossiped = target.gossipedCount
+	}
+	t.count++
+	lq.transfers[target.replica.StoreID] = t
+}
+
+// leaseCount returns the number of leases held by the store, given its
+// gossiped lease count: the leases transferred to the store are added
+// until a change of the gossiped count shows that the store has
+// gossiped its descriptor since.
+func (lq *leaseQueue) leaseCount(storeID proto.StoreID, gossiped int32) int32 {
+	lq.mu.Lock()
+	defer lq.mu.Unlock()
+	t, ok := lq.transfers[storeID]
+	if !ok {
+		return gossiped
+	}
+	if t.gossiped != gossiped {
+		delete(lq.transfers, storeID)
+		return gossiped
+	}
+	return gossiped + t.count
+}
+
+// timer returns interval between processing successive queued lease
+// transfers.
+func (lq *leaseQueue) timer() time.Duration {
+	return leaseQueueTimerDuration
+}
+
+// leaseCandidate is a replica considered to hold a range's leader lease.
+type leaseCandidate struct {
+	replica       proto.Replica
+	attrs         proto.Attributes // Attributes of the replica's node
+	leaseCount    int32            // Leases held by the replica's store
+	gossipedCount int32            // Leases held by the store as last gossiped
+}
+
+// gatewayLoad is the number of requests submitted to a range through
+// a gateway node with the given attributes.
+type gatewayLoad struct {
+	attrs proto.Attributes
+	count int64
+}
+
+// leaseTarget returns the replica to which the range's leader lease
+// should be transferred along with the priority of the transfer, or
+// nil if the lease should stay put.
+func (lq *leaseQueue) leaseTarget(now proto.Timestamp, rng *Range) (*leaseCandidate, float64) {
+	if rng.isQuarantined() {
+		return nil, 0
+	}
+	desc := rng.Desc()
+	local := leaseCandidate{leaseCount: int32(lq.countFn())}
+	var others []leaseCandidate
+	for _, replica := range desc.Replicas {
+		storeDesc, err := storeDescFromGossip(gossip.MakeCapacityKey(replica.NodeID, replica.StoreID), lq.gossip)
+		if replica.StoreID == rng.rm.StoreID() {
+			local.replica = replica
+			if err == nil {
+				local.attrs = storeDesc.Node.Attrs
+			}
+			continue
+		}
+		// Skip replicas on stores which aren't known to be alive or which
+		// have quarantined their replica of the range.
+		if err != nil || containsRaftID(storeDesc.QuarantinedRaftIDs, desc.RaftID) {
+			continue
+		}
+		gossiped := storeDesc.Capacity.LeaseCount
+		others = append(others, leaseCandidate{
+			replica:       replica,
+			attrs:         storeDesc.Node.Attrs,
+			leaseCount:    lq.leaseCount(replica.StoreID, gossiped),
+			gossipedCount: gossiped,
+		})
+	}
+	if len(others) == 0 {
+		return nil, 0
+	}
+
+	var gateways []gatewayLoad
+	for nodeID, count := range rng.load.gatewayCounts(now.WallTime) {
+		info, err := lq.gossip.GetInfo(gossip.MakeNodeIDKey(nodeID))
+		if err != nil {
+			continue
+		}
+		gateways = append(gateways, gatewayLoad{attrs: info.(*proto.NodeDescriptor).Attrs, count: count})
+	}
+	return chooseLeaseTarget(local, others, gateways)
+}
+
+// chooseLeaseTarget returns the candidate to which the lease held by
+// the local replica should be transferred along with the priority of
+// the transfer, or nil if the lease should stay put. If enough requests
+// were served to locate the range's clients, the candidate closest to
+// them is chosen if it is substantially closer than the local replica.
+// Otherwise, if the local store holds more than its share of leases,
+// the candidate with the fewest leases is chosen among those which are
+// no farther from the clients.
+func chooseLeaseTarget(local leaseCandidate, others []leaseCandidate, gateways []gatewayLoad) (*leaseCandidate, float64) {
+	var total int64
+	for _, g := range gateways {
+		total += g.count
+	}
+	useLocality := total >= leaseLocalityMinRequests
+	// distance returns the negated average number of node attributes
+	// which the candidate shares with the gateways of the requests.
+	distance := func(c *leaseCandidate) float64 {
+		var shared int64
+		for _, g := range gateways {
+			shared += g.count * int64(c.attrs.CommonPrefix(g.attrs))
+		}
+		return -float64(shared) / float64(total)
+	}
+
+	var localDistance float64
+	if useLocality {
+		localDistance = distance(&local)
+		var target *leaseCandidate
+		targetDistance := localDistance - leaseLocalityThreshold
+		for i := range others {
+			if d := distance(&others[i]); d < targetDistance {
+				target, targetDistance = &others[i], d
+			}
+		}
+		if target != nil {
+			return target, 1 + localDistance - targetDistance
+		}
+	}
+
+	mean := float64(local.leaseCount)
+	for _, c := range others {
+		mean += float64(c.leaseCount)
+	}
+	mean /= float64(len(others) + 1)
+	if float64(local.leaseCount) <= mean*(1+leaseRebalanceThreshold) {
+		return nil, 0
+	}
+	var target *leaseCandidate
+	for i := range others {
+		c := &others[i]
+		if useLocality && distance(c) > localDistance {
+			continue
+		}
+		// Moving the lease must not simply reverse the imbalance.
+		if float64(c.leaseCount) >= mean || local.leaseCount-c.leaseCount < 2 {
+			continue
+		}
+		if target == nil || c.leaseCount < target.leaseCount {
+			target = c
+		}
+	}
+	if target == nil {
+		return nil, 0
+	}
+	return target, (float64(local.leaseCount) - mean) / mean
+}
+
+// containsRaftID returns whether raftID is in the list.
+func containsRaftID(raftIDs []proto.RaftID, raftID proto.RaftID) bool {
+	for _, id := range raftIDs {
+		if id == raftID {
+			return true
+		}
+	}
+	return false
+}
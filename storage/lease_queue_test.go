@@ -0,0 +1,107 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util/leaktest"
+)
+
+// TestChooseLeaseTarget verifies that leases are moved towards the
+// clients of a range and away from stores holding more than their
+// share of leases.
+func TestChooseLeaseTarget(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	attrs := func(a ...string) proto.Attributes { return proto.Attributes{Attrs: a} }
+	east, west := attrs("us-east", "x"), attrs("us-west", "x")
+
+	testCases := []struct {
+		counts   [3]int32 // Leases held by stores 1 (local), 2 and 3.
+		gateways []gatewayLoad
+		target   proto.StoreID
+		priority float64
+	}{
+		// Balanced lease counts, no requests.
+		{[3]int32{10, 10, 10}, nil, 0, 0},
+		// Local store within the threshold above the mean.
+		{[3]int32{11, 10, 9}, nil, 0, 0},
+		// Local store overloaded; the least loaded store is chosen.
+		{[3]int32{18, 10, 8}, nil, 3, 0.5},
+		// Requests come from the west.
+		{[3]int32{10, 10, 10}, []gatewayLoad{{west, 100}}, 3, 2},
+		// Requests come from the west, but too few of them.
+		{[3]int32{10, 10, 10}, []gatewayLoad{{west, 10}}, 0, 0},
+		// Requests come from both sides equally.
+		{[3]int32{10, 10, 10}, []gatewayLoad{{west, 50}, {east, 50}}, 0, 0},
+		// Requests come from the east; leases don't move farther away
+		// from clients to balance lease counts.
+		{[3]int32{18, 10, 8}, []gatewayLoad{{east, 100}}, 2, 0.5},
+	}
+
+	for i, test := range testCases {
+		local := leaseCandidate{
+			replica:    proto.Replica{NodeID: 1, StoreID: 1},
+			attrs:      attrs("us-east", "a"),
+			leaseCount: test.counts[0],
+		}
+		others := []leaseCandidate{
+			{replica: proto.Replica{NodeID: 2, StoreID: 2}, attrs: attrs("us-east", "b"), leaseCount: test.counts[1]},
+			{replica: proto.Replica{NodeID: 3, StoreID: 3}, attrs: attrs("us-west", "c"), leaseCount: test.counts[2]},
+		}
+		target, priority := chooseLeaseTarget(local, others, test.gateways)
+		var storeID proto.StoreID
+		if target != nil {
+			storeID = target.replica.StoreID
+		}
+		if storeID != test.target {
+			t.Errorf("%d: expected target store %d; got %d", i, test.target, storeID)
+		}
+		if math.Abs(priority-test.priority) > 0.00001 {
+			t.Errorf("%d: expected priority %f; got %f", i, test.priority, priority)
+		}
+	}
+}
+
+// TestLeaseQueueTransferCounts verifies that the leases transferred to
+// a store are added to its gossiped lease count until the store
+// gossips a new count.
+func TestLeaseQueueTransferCounts(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	lq := newLeaseQueue(nil, func() int { return 0 })
+	target := &leaseCandidate{replica: proto.Replica{NodeID: 2, StoreID: 2}, gossipedCount: 10}
+
+	if count := lq.leaseCount(2, 10); count != 10 {
+		t.Errorf("expected gossiped count 10; got %d", count)
+	}
+	lq.recordTransfer(target)
+	lq.recordTransfer(target)
+	if count := lq.leaseCount(2, 10); count != 12 {
+		t.Errorf("expected count 12 after two transfers; got %d", count)
+	}
+	if count := lq.leaseCount(3, 10); count != 10 {
+		t.Errorf("expected count of other store to be unaffected; got %d", count)
+	}
+	// Once the store gossips its count, the transfers are accounted for.
+	if count := lq.leaseCount(2, 12); count != 12 {
+		t.Errorf("expected newly gossiped count 12; got %d", count)
+	}
+	if count := lq.leaseCount(2, 10); count != 10 {
+		t.Errorf("expected transfers to be forgotten; got %d", count)
+	}
+}
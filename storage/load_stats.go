@@ -0,0 +1,170 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/storage/engine"
+)
+
+const (
+	// loadStatsWindow is the duration over which a range's request
+	// rate is measured.
+	loadStatsWindow = 10 * time.Second
+	// loadSampleSize is the number of request keys retained in a range's
+	// sample of accessed keys.
+	loadSampleSize = 100
+)
+
+// loadStats tracks the rate of requests served by a range along with a
+// uniform (reservoir) sample of the keys they accessed, from which a
+// split key dividing the load evenly can be estimated, and the number
+// of requests submitted through each gateway node, from which the
+// replica closest to the range's clients can be determined. Requests
+// are counted in consecutive windows of loadStatsWindow; the figures
+// reported are those of the last complete window.
+type loadStats struct {
+	sync.Mutex
+	randGen      *rand.Rand
+	start        int64                  // Start of the first window, in nanoseconds
+	windowStart  int64                  // Start of the current window, in nanoseconds
+	count        int64                  // Requests in the current window
+	samples      []proto.Key            // Sample of keys accessed in the current window
+	gateways     map[proto.NodeID]int64 // Requests per gateway node in the current window
+	qps          float64                // Request rate of the last complete window
+	lastSamples  []proto.Key            // Sample of keys accessed in the last complete window
+	lastGateways map[proto.NodeID]int64 // Requests per gateway node in the last complete window
+}
+
+// newLoadStats returns a new loadStats whose first window starts at
+// now, in nanoseconds.
+func newLoadStats(now int64) *loadStats {
+	return &loadStats{
+		randGen:     rand.New(rand.NewSource(rand.Int63())),
+		start:       now,
+		windowStart: now,
+	}
+}
+
+// record counts a request accessing key at time now, submitted through
+// the given gateway node (zero if unknown). Returns true if the request
+// started a new window.
+func (ls *loadStats) record(now int64, key proto.Key, gateway proto.NodeID) bool {
+	ls.Lock()
+	defer ls.Unlock()
+	rotated := ls.maybeRotateLocked(now)
+	ls.count++
+	if len(ls.samples) < loadSampleSize {
+		ls.samples = append(ls.samples, key)
+	} else if idx := ls.randGen.Int63n(ls.count); idx < loadSampleSize {
+		ls.samples[idx] = key
+	}
+	if gateway != 0 {
+		if ls.gateways == nil {
+			ls.gateways = map[proto.NodeID]int64{}
+		}
+		ls.gateways[gateway]++
+	}
+	return rotated
+}
+
+// maybeRotateLocked completes the current window if it has lasted at
+// least loadStatsWindow at time now. Returns true if the window was
+// completed.
+func (ls *loadStats) maybeRotateLocked(now int64) bool {
+	elapsed := now - ls.windowStart
+	if elapsed < loadStatsWindow.Nanoseconds() {
+		return false
+	}
+	ls.qps = float64(ls.count) / time.Duration(elapsed).Seconds()
+	ls.lastSamples, ls.samples = ls.samples, nil
+	ls.lastGateways, ls.gateways = ls.gateways, nil
+	ls.count = 0
+	ls.windowStart = now
+	return true
+}
+
+// QPS returns the request rate, in requests per second, measured over
+// the last complete window as of now.
+func (ls *loadStats) QPS(now int64) float64 {
+	ls.Lock()
+	defer ls.Unlock()
+	ls.maybeRotateLocked(now)
+	return ls.qps
+}
+
+// complete returns whether a full window has elapsed as of now since
+// the measurements started, i.e. whether QPS reports a measured rate.
+func (ls *loadStats) complete(now int64) bool {
+	ls.Lock()
+	defer ls.Unlock()
+	return now-ls.start >= loadStatsWindow.Nanoseconds()
+}
+
+// gatewayCounts returns the number of requests submitted through each
+// gateway node in the last complete window as of now.
+func (ls *loadStats) gatewayCounts(now int64) map[proto.NodeID]int64 {
+	ls.Lock()
+	defer ls.Unlock()
+	ls.maybeRotateLocked(now)
+	counts := make(map[proto.NodeID]int64, len(ls.lastGateways))
+	for nodeID, count := range ls.lastGateways {
+		counts[nodeID] = count
+	}
+	return counts
+}
+
+// splitKey returns the median of the keys sampled in the last complete
+// window, which divides the requests roughly in half. Returns nil if
+// there is no such key which is a valid split key after start, e.g.
+// because all requests accessed the same key.
+func (ls *loadStats) splitKey(start proto.Key) proto.Key {
+	ls.Lock()
+	samples := append(proto.KeySlice(nil), ls.lastSamples...)
+	ls.Unlock()
+	if len(samples) == 0 {
+		return nil
+	}
+	sort.Sort(samples)
+	key := samples[len(samples)/2]
+	if !start.Less(key) || !engine.IsValidSplitKey(key) {
+		return nil
+	}
+	return key
+}
+
+// reset discards all measurements, starting a new window at now. It is
+// invoked after a range has been split, as the measurements no longer
+// reflect the load of its narrowed key span, and when a replica
+// acquires the leader lease, as a replica only sees the range's
+// requests while it holds the lease.
+func (ls *loadStats) reset(now int64) {
+	ls.Lock()
+	defer ls.Unlock()
+	ls.start = now
+	ls.windowStart = now
+	ls.count = 0
+	ls.samples = nil
+	ls.gateways = nil
+	ls.qps = 0
+	ls.lastSamples = nil
+	ls.lastGateways = nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util/leaktest"
+)
+
+// TestLoadStats verifies the request rate, split key and gateway counts
+// computed from the requests of the last complete window.
+func TestLoadStats(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	window := loadStatsWindow.Nanoseconds()
+	ls := newLoadStats(0)
+
+	// Write to 50 distinct keys in reverse order during the first window,
+	// alternating between two gateway nodes.
+	for i := 49; i >= 0; i-- {
+		if ls.record(int64(i), proto.Key(fmt.Sprintf("k%02d", i)), proto.NodeID(i%2+1)) {
+			t.Fatal("unexpected completion of window")
+		}
+	}
+	// No window has completed yet.
+	if qps := ls.QPS(window - 1); qps != 0 {
+		t.Errorf("expected 0 qps before the first window completes; got %f", qps)
+	}
+	if key := ls.splitKey(proto.KeyMin); key != nil {
+		t.Errorf("expected no split key before the first window completes; got %s", key)
+	}
+
+	// A request in the next window completes the first one.
+	if !ls.record(window, proto.Key("k00"), 0) {
+		t.Fatal("expected request to complete the first window")
+	}
+	if qps := ls.QPS(window); qps != 5 {
+		t.Errorf("expected 5 qps; got %f", qps)
+	}
+	if key := ls.splitKey(proto.KeyMin); !key.Equal(proto.Key("k25")) {
+		t.Errorf("expected split key k25; got %s", key)
+	}
+	if counts, expCounts := ls.gatewayCounts(window), map[proto.NodeID]int64{1: 25, 2: 25}; !reflect.DeepEqual(counts, expCounts) {
+		t.Errorf("expected gateway counts %v; got %v", expCounts, counts)
+	}
+	// The split key must fall after the start of the range.
+	if key := ls.splitKey(proto.Key("k25")); key != nil {
+		t.Errorf("expected no split key after k25; got %s", key)
+	}
+
+	// Only a single key is accessed in the second window.
+	for i := 0; i < 2*loadSampleSize; i++ {
+		ls.record(window, proto.Key("k00"), 0)
+	}
+	if qps := ls.QPS(2 * window); qps != float64(2*loadSampleSize+1)/loadStatsWindow.Seconds() {
+		t.Errorf("unexpected qps %f", qps)
+	}
+	if key := ls.splitKey(proto.Key("k00")); key != nil {
+		t.Errorf("expected no split key for a single hot key; got %s", key)
+	}
+
+	ls.reset(2 * window)
+	if qps := ls.QPS(3 * window); qps != 0 {
+		t.Errorf("expected 0 qps after reset; got %f", qps)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/gossip"
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util/log"
+)
+
+const (
+	// mergeQueueMaxSize is the max size of the merge queue.
+	mergeQueueMaxSize = 100
+	// mergeQueueTimerDuration is the duration between merges of queued ranges.
+	mergeQueueTimerDuration = 1 * time.Second
+)
+
+// mergeQueue manages a queue of ranges slated to be merged into their
+// right neighbor because their size has dropped below the minimum for
+// their zone, e.g. after a large deletion.
+//
+// Merging requires both ranges to be replicated on the same stores. If
+// they are not, the queue first co-locates them by changing the left
+// range's replicas, one at a time, to match those of its neighbor. This
+// requires the store holding the left range's leader lease to hold a
+// replica of the right range as well; ranges for which this is not the
+// case are skipped until the lease moves.
+type mergeQueue struct {
+	*baseQueue
+	gossip *gossip.Gossip
+}
+
+// newMergeQueue returns a new instance of mergeQueue.
+func newMergeQueue(gossip *gossip.Gossip) *mergeQueue {
+	mq := &mergeQueue{gossip: gossip}
+	mq.baseQueue = newBaseQueue("merge", mq, mergeQueueMaxSize)
+	return mq
+}
+
+func (mq *mergeQueue) needsLeaderLease() bool {
+	return true
+}
+
+// shouldQueue determines whether a range should be queued for merging
+// with its right neighbor. This is true if the range's size is below
+// the minimum for its zone and its right neighbor is governed by the
+// same accounting and zone configs, so that the merged range would not
+// be split again along a config boundary. Smaller ranges are merged
+// first.
+func (mq *mergeQueue) shouldQueue(now proto.Timestamp, rng *Range) (shouldQ bool, priority float64) {
+	desc := rng.Desc()
+	if desc.EndKey.Equal(proto.KeyMax) || rng.isQuarantined() {
+		return
+	}
+	zone, err := lookupZoneConfig(mq.gossip, rng)
+	if err != nil {
+		log.Error(err)
+		return
+	}
+	if zone.RangeMinBytes <= 0 {
+		return
+	}
+	size := rng.stats.GetSize()
+	if size >= zone.RangeMinBytes {
+		return
+	}
+	if !sameConfigs(mq.gossip, desc.StartKey, desc.EndKey) {
+		return
+	}
+	if zone.RangeMaxQPS > 0 {
+		// The merged range's load can only be checked against the zone's
+		// maximum if the load of both ranges is known here. This avoids
+		// merging ranges which were just split by load.
+		if !loadKnown(rng, now) {
+			return
+		}
+		if subsumedRng := rng.rm.LookupRange(desc.EndKey, nil); subsumedRng == nil || !loadKnown(subsumedRng, now) {
+			return
+		}
+	}
+	return true, 1 - float64(size)/float64(zone.RangeMinBytes)
+}
+
+// process co-locates the replicas of the range with those of its right
+// neighbor if necessary, and merges the two ranges once they are.
+func (mq *mergeQueue) process(now proto.Timestamp, rng *Range) error {
+	if shouldQ, _ := mq.shouldQueue(now, rng); !shouldQ {
+		// Something changed since the range was queued.
+		return nil
+	}
+	desc := rng.Desc()
+	subsumedRng := rng.rm.LookupRange(desc.EndKey, nil)
+	if subsumedRng == nil {
+		if log.V(1) {
+			log.Infof("unable to merge %s: store holds no replica of its right neighbor", rng)
+		}
+		return nil
+	}
+	subsumedDesc := subsumedRng.Desc()
+
+	// Avoid merges which would immediately be undone by the split queue,
+	// leaving some headroom below the zone's limits.
+	zone, err := lookupZoneConfig(mq.gossip, rng)
+	if err != nil {
+		return err
+	}
+	if size := rng.stats.GetSize() + subsumedRng.stats.GetSize(); size >= zone.RangeMaxBytes/2 {
+		if log.V(1) {
+			log.Infof("not merging %s into %s: merged size %d would approach max %d",
+				subsumedRng, rng, size, zone.RangeMaxBytes)
+		}
+		return nil
+	}
+	if zone.RangeMaxQPS > 0 {
+		if qps := rng.load.QPS(now.WallTime) + subsumedRng.load.QPS(now.WallTime); qps >= float64(zone.RangeMaxQPS)/2 {
+			if log.V(1) {
+				log.Infof("not merging %s into %s: merged qps %.1f would approach max %d",
+					subsumedRng, rng, qps, zone.RangeMaxQPS)
+			}
+			return nil
+		}
+	}
+
+	if !replicaSetsEqual(desc.Replicas, subsumedDesc.Replicas) {
+		if err := mq.colocate(rng, desc, subsumedDesc); err != nil {
+			return err
+		}
+		// Enqueue this range again to make further changes or merge.
+		go mq.MaybeAdd(rng, rng.rm.Clock().Now())
+		return nil
+	}
+
+	log.Infof("merging %s into %s size=%d min=%d", subsumedRng, rng, rng.stats.GetSize(), zone.RangeMinBytes)
+	_, err = rng.AddCmd(rng.context(), &proto.AdminMergeRequest{
+		RequestHeader: proto.RequestHeader{Key: desc.StartKey},
+	})
+	return err
+}
+
+// colocate makes a single change to the replicas of the range towards
+// matching those of the subsumed range. Missing replicas are added
+// before superfluous ones are removed, so that the range's replication
+// is never reduced in the meantime.
+func (mq *mergeQueue) colocate(rng *Range, desc, subsumedDesc *proto.RangeDescriptor) error {
+	for _, replica := range subsumedDesc.Replicas {
+		if !containsStore(desc.Replicas, replica.StoreID) {
+			log.Infof("adding replica on store %d to %s to co-locate it with its right neighbor", replica.StoreID, rng)
+			return rng.ChangeReplicas(proto.ADD_REPLICA, proto.Replica{
+				NodeID:  replica.NodeID,
+				StoreID: replica.StoreID,
+			})
+		}
+	}
+	for _, replica := range desc.Replicas {
+		if !containsStore(subsumedDesc.Replicas, replica.StoreID) {
+			log.Infof("removing replica on store %d from %s to co-locate it with its right neighbor", replica.StoreID, rng)
+			return rng.ChangeReplicas(proto.REMOVE_REPLICA, replica)
+		}
+	}
+	return nil
+}
+
+// timer returns interval between processing successive queued merges.
+func (mq *mergeQueue) timer() time.Duration {
+	return mergeQueueTimerDuration
+}
+
+// containsStore returns whether any of the replicas is located on the
+// given store.
+func containsStore(replicas []proto.Replica, storeID proto.StoreID) bool {
+	for _, replica := range replicas {
+		if replica.StoreID == storeID {
+			return true
+		}
+	}
+	return false
+}
+
+// loadKnown returns whether the load measured by the local replica of
+// the range as of now is that of the range: the replica must hold the
+// leader lease, through which all requests are served, and must have
+// measured a complete window since it acquired the lease.
+func loadKnown(rng *Range, now proto.Timestamp) bool {
+	lease := rng.getLease()
+	return lease.OwnedBy(rng.rm.RaftNodeID()) && lease.Covers(now) && rng.load.complete(now.WallTime)
+}
+
+// sameConfigs returns whether keys a and b are matched by the same
+// entries of both the accounting and zone config maps.
+func sameConfigs(g *gossip.Gossip, a, b proto.Key) bool {
+	for _, configKey := range []string{gossip.KeyConfigAccounting, gossip.KeyConfigZone} {
+		info, err := g.GetInfo(configKey)
+		if err != nil {
+			log.Errorf("unable to fetch %s config from gossip: %s", configKey, err)
+			return false
+		}
+		configMap := info.(PrefixConfigMap)
+		if !configMap.MatchByPrefix(a).Prefix.Equal(configMap.MatchByPrefix(b).Prefix) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,175 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/gossip"
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/storage/engine"
+	"github.com/cockroachdb/cockroach/util/leaktest"
+)
+
+// TestMergeQueueShouldQueue verifies that shouldQueue queues ranges
+// below the min size for their zone, unless their right neighbor is
+// governed by different accounting or zone configs.
+func TestMergeQueueShouldQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	// Set accounting and zone configs.
+	acctMap, err := NewPrefixConfigMap([]*PrefixConfig{
+		{proto.KeyMin, nil, 1},
+		{proto.Key("/dbA"), nil, 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tc.gossip.AddInfo(gossip.KeyConfigAccounting, acctMap, 0*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	zoneMap, err := NewPrefixConfigMap([]*PrefixConfig{
+		{proto.KeyMin, nil, &proto.ZoneConfig{RangeMinBytes: 1 << 20, RangeMaxBytes: 64 << 20}},
+		{proto.Key("/dbB"), nil, &proto.ZoneConfig{RangeMaxBytes: 64 << 20}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tc.gossip.AddInfo(gossip.KeyConfigZone, zoneMap, 0*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		start, end proto.Key
+		bytes      int64
+		shouldQ    bool
+		priority   float64
+	}{
+		// Same configs, no bytes.
+		{proto.KeyMin, proto.Key("/"), 0, true, 1},
+		// Same configs, half of min bytes.
+		{proto.KeyMin, proto.Key("/"), 1 << 19, true, 0.5},
+		// Same configs, min bytes.
+		{proto.KeyMin, proto.Key("/"), 1 << 20, false, 0},
+		// Last range.
+		{proto.Key("/"), proto.KeyMax, 0, false, 0},
+		// Neighbor in different accounting config.
+		{proto.Key("/"), proto.Key("/dbA"), 0, false, 0},
+		// Same configs within a nested prefix.
+		{proto.Key("/dbA"), proto.Key("/dbA1"), 0, true, 1},
+		// Zone without a min size.
+		{proto.Key("/dbB"), proto.Key("/dbB1"), 0, false, 0},
+	}
+
+	mergeQ := newMergeQueue(tc.gossip)
+
+	for i, test := range testCases {
+		if err := tc.rng.stats.SetMVCCStats(tc.rng.rm.Engine(), engine.MVCCStats{KeyBytes: test.bytes}); err != nil {
+			t.Fatal(err)
+		}
+		copy := *tc.rng.Desc()
+		copy.StartKey = test.start
+		copy.EndKey = test.end
+		if err := tc.rng.setDesc(&copy); err != nil {
+			t.Fatal(err)
+		}
+		shouldQ, priority := mergeQ.shouldQueue(proto.ZeroTimestamp, tc.rng)
+		if shouldQ != test.shouldQ {
+			t.Errorf("%d: should queue expected %t; got %t", i, test.shouldQ, shouldQ)
+		}
+		if math.Abs(priority-test.priority) > 0.00001 {
+			t.Errorf("%d: priority expected %f; got %f", i, test.priority, priority)
+		}
+	}
+}
+
+// TestMergeQueueShouldQueueAfterLoadSplit verifies that the ranges
+// resulting from a split by load aren't queued for merging until the
+// load of both has been measured over a complete window by their
+// leader lease holders.
+func TestMergeQueueShouldQueueAfterLoadSplit(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	acctMap, err := NewPrefixConfigMap([]*PrefixConfig{
+		{proto.KeyMin, nil, 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tc.gossip.AddInfo(gossip.KeyConfigAccounting, acctMap, 0*time.Second); err != nil {
+		t.Fatal(err)
+	}
+	zoneMap, err := NewPrefixConfigMap([]*PrefixConfig{
+		{proto.KeyMin, nil, &proto.ZoneConfig{RangeMinBytes: 1 << 20, RangeMaxBytes: 64 << 20, RangeMaxQPS: 100}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tc.gossip.AddInfo(gossip.KeyConfigZone, zoneMap, 0*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	// Load the range at twice the zone's max request rate over a window
+	// and split it where the split queue would.
+	window := loadStatsWindow.Nanoseconds()
+	tc.rng.load.reset(0)
+	for i := 0; i < 2000; i++ {
+		tc.rng.load.record(0, proto.Key(fmt.Sprintf("k%03d", i%100)), 0)
+	}
+	tc.manualClock.Set(window)
+	splitQ := newSplitQueue(nil, tc.gossip)
+	if shouldQ, _ := splitQ.shouldQueue(tc.clock.Now(), tc.rng); !shouldQ {
+		t.Fatal("expected range to be queued for a split by load")
+	}
+	splitKey := tc.rng.load.splitKey(tc.rng.Desc().StartKey)
+	if splitKey == nil {
+		t.Fatal("expected a split key")
+	}
+	newRng := splitTestRange(tc.store, proto.KeyMin, splitKey, t)
+
+	// This replica holds the leader lease of both ranges, acquiring that
+	// of the new range.
+	now := tc.clock.Now()
+	for _, rng := range []*Range{tc.rng, newRng} {
+		setLeaderLease(t, rng, &proto.Lease{
+			Start:      now,
+			Expiration: now.Add(3*window, 0),
+			RaftNodeID: tc.store.RaftNodeID(),
+		})
+	}
+
+	// The load of neither range has been measured since the split.
+	mergeQ := newMergeQueue(tc.gossip)
+	if shouldQ, _ := mergeQ.shouldQueue(tc.clock.Now(), tc.rng); shouldQ {
+		t.Error("expected no merge right after a split by load")
+	}
+
+	// Once a window has passed without load, the ranges are merged.
+	tc.manualClock.Increment(window)
+	if shouldQ, _ := mergeQ.shouldQueue(tc.clock.Now(), tc.rng); !shouldQ {
+		t.Error("expected merge once the load of both ranges is known")
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage
+
+import (
+	"time"
+
+	"github.com/cockroachdb/cockroach/client"
+	"github.com/cockroachdb/cockroach/keys"
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/storage/engine"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/log"
+	gogoproto "github.com/gogo/protobuf/proto"
+)
+
+const (
+	// outboxQueueMaxSize is the max size of the outbox queue.
+	outboxQueueMaxSize = 100
+	// outboxQueueTimerDuration is the duration between processing of
+	// queued ranges.
+	outboxQueueTimerDuration = 0 * time.Second // zero duration to process greedily.
+)
+
+// outboxQueue manages a queue of ranges with updates pending in the
+// executable outboxes of their keys (see EnqueueUpdate). Each update
+// is executed and removed from its outbox in a single distributed
+// transaction, so that it is applied exactly once.
+type outboxQueue struct {
+	*baseQueue
+	db *client.DB
+}
+
+// newOutboxQueue returns a new instance of outboxQueue.
+func newOutboxQueue(db *client.DB) *outboxQueue {
+	oq := &outboxQueue{
+		db: db,
+	}
+	oq.baseQueue = newBaseQueue("outbox", oq, outboxQueueMaxSize)
+	return oq
+}
+
+func (oq *outboxQueue) needsLeaderLease() bool {
+	return true
+}
+
+// shouldQueue determines whether a range should be queued for
+// executing pending updates. This is true if the outbox of any key in
+// the range holds a committed update.
+func (oq *outboxQueue) shouldQueue(now proto.Timestamp, rng *Range) (bool, float64) {
+	entries, err := outboxEntries(rng.rm.Engine(), rng.Desc(), now, 1)
+	if err != nil {
+		log.Errorf("unable to scan outboxes of range %s: %s", rng, err)
+		return false, 0
+	}
+	return len(entries) > 0, 1
+}
+
+// process executes the pending updates of the range in order.
+func (oq *outboxQueue) process(now proto.Timestamp, rng *Range) error {
+	snap := rng.rm.Engine().NewSnapshot()
+	defer snap.Close()
+	entries, err := outboxEntries(snap, rng.Desc(), now, 0)
+	if err != nil {
+		return err
+	}
+	for _, key := range entries {
+		if err := oq.db.Txn(func(txn *client.Txn) error {
+			return executeOutboxEntry(txn, key)
+		}); err != nil {
+			return util.Errorf("unable to execute update %q: %s", key, err)
+		}
+	}
+	return nil
+}
+
+// timer returns interval between processing successive queued ranges.
+func (oq *outboxQueue) timer() time.Duration {
+	return outboxQueueTimerDuration
+}
+
+// outboxEntries returns the keys of committed outbox entries of the
+// keys of the specified range, up to max entries if max is non-zero.
+func outboxEntries(e engine.Engine, desc *proto.RangeDescriptor, now proto.Timestamp, max int) ([]proto.Key, error) {
+	var entries []proto.Key
+	// Uncommitted entries are skipped by the inconsistent scan; they
+	// are picked up once their transaction has committed.
+	_, err := engine.MVCCIterate(e, keys.OutboxPrefix(desc.StartKey), keys.OutboxPrefix(desc.EndKey), now, false, nil, func(kv proto.KeyValue) (bool, error) {
+		entries = append(entries, kv.Key)
+		return max > 0 && len(entries) == max, nil
+	})
+	return entries, err
+}
+
+// executeOutboxEntry executes the update stored in the outbox entry at
+// key and deletes the entry as part of txn. Entries which no longer
+// exist have already been executed and are skipped.
+func executeOutboxEntry(txn *client.Txn, key proto.Key) error {
+	kv, err := txn.Get(key)
+	if err != nil || !kv.Exists() {
+		return err
+	}
+	var update proto.RequestUnion
+	if err := gogoproto.Unmarshal(kv.ValueBytes(), &update); err != nil {
+		return err
+	}
+	args, ok := update.GetValue().(proto.Request)
+	if !ok {
+		return util.Errorf("outbox entry %q does not contain an update", key)
+	}
+	// Only the key range of the enqueued request is retained; the
+	// transaction supplies everything else.
+	header := args.Header()
+	*header = proto.RequestHeader{Key: header.Key, EndKey: header.EndKey}
+	b := txn.NewBatch()
+	b.InternalAddCall(proto.Call{Args: args, Reply: args.CreateReply()})
+	b.Del(key)
+	return txn.Commit(b)
+}
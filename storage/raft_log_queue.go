@@ -0,0 +1,185 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/log"
+	"github.com/coreos/etcd/raft"
+)
+
+const (
+	// raftLogQueueMaxSize is the max size of the raft log queue.
+	raftLogQueueMaxSize = 100
+	// raftLogQueueTimerDuration is the duration between truncations of
+	// queued ranges.
+	raftLogQueueTimerDuration = 0 // zero duration to process truncations greedily.
+	// raftLogMaxEntries is the number of entries beyond which a range's
+	// raft log is truncated.
+	raftLogMaxEntries = 100
+	// raftLogMaxBytes is the size in bytes beyond which a range's raft
+	// log is truncated.
+	raftLogMaxBytes = 1 << 20 // 1 MB
+	// raftLogLaggardEntries is the number of entries beyond which the
+	// raft log is truncated past followers which have not caught up;
+	// such followers are then sent a snapshot.
+	raftLogLaggardEntries = 10000
+)
+
+// raftStatusFn should return the raft status of the specified range,
+// or nil if it is not available.
+type raftStatusFn func(proto.RaftID) *raft.Status
+
+// raftLogQueue manages a queue of ranges whose raft logs are to be
+// truncated. A log is truncated once it holds more than
+// raftLogMaxEntries entries or raftLogMaxBytes bytes, up to the
+// lowest index which all replicas have acknowledged and which has been
+// applied locally. Followers which have fallen more than
+// raftLogLaggardEntries behind are not waited for.
+//
+// Only the raft leader knows how far the followers have caught up, so
+// the queue processes a range only on its raft leader, which proposes
+// the truncation itself whether or not it holds the leader lease.
+type raftLogQueue struct {
+	*baseQueue
+	statusFn raftStatusFn
+}
+
+// newRaftLogQueue returns a new instance of raftLogQueue. statusFn
+// returns the raft status of a range, which is only complete on the
+// range's raft leader.
+func newRaftLogQueue(statusFn raftStatusFn) *raftLogQueue {
+	rlq := &raftLogQueue{statusFn: statusFn}
+	rlq.baseQueue = newBaseQueue("raftlog", rlq, raftLogQueueMaxSize)
+	return rlq
+}
+
+func (rlq *raftLogQueue) needsLeaderLease() bool {
+	return false
+}
+
+// shouldQueue determines whether the range's raft log should be
+// truncated, and if so, at what priority, which grows with the number
+// of entries which can be truncated.
+func (rlq *raftLogQueue) shouldQueue(now proto.Timestamp, rng *Range) (shouldQ bool, priority float64) {
+	if !rlq.isRaftLeader(rng) {
+		return
+	}
+	firstIndex, truncatableIndex, err := rlq.truncatableIndex(rng)
+	if err != nil {
+		log.Warning(err)
+		return
+	}
+	if truncatableIndex < firstIndex {
+		return
+	}
+	entries := truncatableIndex + 1 - firstIndex
+	if entries > raftLogMaxEntries {
+		return true, float64(entries) / raftLogMaxEntries
+	}
+	if size := atomic.LoadInt64(&rng.raftLogSize); size > raftLogMaxBytes {
+		return true, float64(size) / raftLogMaxBytes
+	}
+	return
+}
+
+// process truncates the raft log of the range up to and including the
+// truncatable index.
+func (rlq *raftLogQueue) process(now proto.Timestamp, rng *Range) error {
+	if !rlq.isRaftLeader(rng) {
+		// Leadership moved since the range was queued.
+		return nil
+	}
+	firstIndex, truncatableIndex, err := rlq.truncatableIndex(rng)
+	if err != nil {
+		return err
+	}
+	if truncatableIndex < firstIndex {
+		return nil
+	}
+	if log.V(1) {
+		log.Infof("truncating raft log of %s from %d to %d", rng, firstIndex, truncatableIndex+1)
+	}
+	desc := rng.Desc()
+	args := &proto.InternalTruncateLogRequest{
+		RequestHeader: proto.RequestHeader{
+			Key:       desc.StartKey,
+			Timestamp: now,
+			RaftID:    desc.RaftID,
+		},
+		Index: truncatableIndex + 1,
+	}
+	// Propose the truncation directly: like InternalReportChecksum, it
+	// is applied regardless of which replica holds the leader lease.
+	errChan, pendingCmd := rng.proposeRaftCommand(rng.context(), args)
+	if err := <-errChan; err != nil {
+		return err
+	}
+	select {
+	case respWithErr := <-pendingCmd.done:
+		return respWithErr.Err
+	case <-rng.rm.Stopper().ShouldStop():
+		return nil
+	}
+}
+
+// timer returns the duration between truncations.
+func (rlq *raftLogQueue) timer() time.Duration {
+	return raftLogQueueTimerDuration
+}
+
+// isRaftLeader returns whether the local replica of the range is its
+// raft leader.
+func (rlq *raftLogQueue) isRaftLeader(rng *Range) bool {
+	status := rlq.statusFn(rng.Desc().RaftID)
+	return status != nil && status.SoftState.RaftState == raft.StateLeader
+}
+
+// truncatableIndex returns the first index of the range's raft log and
+// the highest index up to which the log may be truncated. This is the
+// lowest index acknowledged by all replicas, ignoring those which lag
+// more than raftLogLaggardEntries behind, capped at the index applied
+// by the local replica. A newly elected leader knows nothing of the
+// followers' progress until they reply, during which their matched
+// index is zero; such followers are not taken for laggards but hold
+// back truncation. The raft status is only complete on the raft
+// leader; on other replicas, an error is returned.
+func (rlq *raftLogQueue) truncatableIndex(rng *Range) (uint64, uint64, error) {
+	raftID := rng.Desc().RaftID
+	status := rlq.statusFn(raftID)
+	if status == nil || status.SoftState.RaftState != raft.StateLeader {
+		return 0, 0, util.Errorf("%s: raft status unavailable on non-leader replica", rng)
+	}
+	firstIndex, err := rng.FirstIndex()
+	if err != nil {
+		return 0, 0, util.Errorf("%s: unable to read first raft log index: %s", rng, err)
+	}
+	truncatableIndex := status.Applied
+	for _, progress := range status.Progress {
+		if progress.Match != 0 && progress.Match+raftLogLaggardEntries < status.Applied {
+			// Leave the laggard behind; it will be sent a snapshot.
+			continue
+		}
+		if progress.Match < truncatableIndex {
+			truncatableIndex = progress.Match
+		}
+	}
+	return firstIndex, truncatableIndex, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/leaktest"
+	"github.com/coreos/etcd/raft"
+)
+
+// TestRaftLogQueueTruncatableIndex verifies that the raft log is only
+// truncated up to the lowest index acknowledged by all followers which
+// have not fallen too far behind, and only on the raft leader.
+func TestRaftLogQueueTruncatableIndex(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	const applied = 2 * raftLogLaggardEntries
+	testCases := []struct {
+		state    raft.StateType
+		matches  []uint64
+		expIndex uint64
+		expErr   bool
+	}{
+		// All followers caught up.
+		{raft.StateLeader, []uint64{applied, applied, applied}, applied, false},
+		// A follower lagging behind holds up truncation.
+		{raft.StateLeader, []uint64{applied, applied - 10, applied}, applied - 10, false},
+		// A follower lagging too far behind is not waited for.
+		{raft.StateLeader, []uint64{applied, applied - 10, 1}, applied - 10, false},
+		// A follower whose progress is unknown, as after an election,
+		// holds up truncation.
+		{raft.StateLeader, []uint64{applied, applied - 10, 0}, 0, false},
+		// No truncation beyond the locally applied index.
+		{raft.StateLeader, []uint64{applied + 10, applied + 10}, applied, false},
+		// Followers cannot truncate.
+		{raft.StateFollower, nil, 0, true},
+	}
+	for i, test := range testCases {
+		status := &raft.Status{
+			SoftState: raft.SoftState{RaftState: test.state},
+			Applied:   applied,
+			Progress:  map[uint64]raft.Progress{},
+		}
+		for j, match := range test.matches {
+			status.Progress[uint64(j+1)] = raft.Progress{Match: match}
+		}
+		rlq := newRaftLogQueue(func(proto.RaftID) *raft.Status { return status })
+		_, index, err := rlq.truncatableIndex(tc.rng)
+		if test.expErr != (err != nil) {
+			t.Errorf("%d: expected error %t; got %v", i, test.expErr, err)
+			continue
+		}
+		if index != test.expIndex {
+			t.Errorf("%d: expected truncatable index %d; got %d", i, test.expIndex, index)
+		}
+		if test.state != raft.StateLeader {
+			if shouldQ, _ := rlq.shouldQueue(tc.clock.Now(), tc.rng); shouldQ {
+				t.Errorf("%d: expected range not to be queued on a follower", i)
+			}
+		}
+	}
+}
+
+// TestRaftLogQueueTruncate verifies that a range with a long raft log
+// is queued, that processing it truncates the log, and that the size
+// of the log is tracked as it grows and is truncated.
+func TestRaftLogQueueTruncate(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+	verifyLogSize := func() {
+		size, err := loadRaftLogSize(tc.store.Engine(), tc.rng.Desc().RaftID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tracked := atomic.LoadInt64(&tc.rng.raftLogSize); tracked != size {
+			t.Errorf("expected tracked raft log size %d; got %d", size, tracked)
+		}
+	}
+
+	// Keep the writes below from queueing the range.
+	rlq := tc.store.raftLogQueue()
+	rlq.disabled = true
+	if shouldQ, _ := rlq.shouldQueue(tc.clock.Now(), tc.rng); shouldQ {
+		t.Fatal("expected a new range not to be queued for raft log truncation")
+	}
+
+	for i := 0; i < raftLogMaxEntries+10; i++ {
+		args := incrementArgs([]byte("a"), 1, 1, tc.store.StoreID())
+		if _, err := tc.rng.AddCmd(tc.rng.context(), &args); err != nil {
+			t.Fatal(err)
+		}
+	}
+	verifyLogSize()
+	oldFirstIndex, err := tc.rng.FirstIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shouldQ, priority := rlq.shouldQueue(tc.clock.Now(), tc.rng); !shouldQ || priority < 1 {
+		t.Fatalf("expected range to be queued for raft log truncation; got %t at priority %f", shouldQ, priority)
+	}
+	if err := rlq.process(tc.clock.Now(), tc.rng); err != nil {
+		t.Fatal(err)
+	}
+	newFirstIndex, err := tc.rng.FirstIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newFirstIndex < oldFirstIndex+raftLogMaxEntries {
+		t.Errorf("expected first index to advance by at least %d from %d; got %d",
+			raftLogMaxEntries, oldFirstIndex, newFirstIndex)
+	}
+	verifyLogSize()
+	if shouldQ, _ := rlq.shouldQueue(tc.clock.Now(), tc.rng); shouldQ {
+		t.Error("expected truncated range not to be queued for raft log truncation")
+	}
+}
+
+// TestRaftLogQueueTruncateOnWrite verifies that writes which grow the
+// raft log beyond raftLogMaxEntries queue the range for truncation.
+func TestRaftLogQueueTruncateOnWrite(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	oldFirstIndex, err := tc.rng.FirstIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < raftLogMaxEntries+10; i++ {
+		args := incrementArgs([]byte("a"), 1, 1, tc.store.StoreID())
+		if _, err := tc.rng.AddCmd(tc.rng.context(), &args); err != nil {
+			t.Fatal(err)
+		}
+	}
+	util.SucceedsWithin(t, time.Second, func() error {
+		firstIndex, err := tc.rng.FirstIndex()
+		if err != nil {
+			return err
+		}
+		if firstIndex <= oldFirstIndex {
+			return util.Errorf("expected raft log to be truncated past %d", oldFirstIndex)
+		}
+		return nil
+	})
+}
@@ -99,6 +99,10 @@ const (
 
 	// DefaultLeaderLeaseDuration is the default duration of the leader lease.
 	DefaultLeaderLeaseDuration = time.Second
+
+	// maxTSCacheSummaryEntries is the maximum number of timestamp cache
+	// entries handed over to the new holder of a transferred leader lease.
+	maxTSCacheSummaryEntries = 1000
 )
 
 // configDescriptor describes administrative configuration maps
@@ -128,6 +132,7 @@ var tsCacheMethods = [...]bool{
 	proto.Scan:                       true,
 	proto.Delete:                     true,
 	proto.DeleteRange:                true,
+	proto.Import:                     true,
 	proto.InternalResolveIntent:      true,
 	proto.InternalResolveIntentRange: true,
 }
@@ -163,6 +168,8 @@ type rangeManager interface {
 	allocator() *allocator
 	Gossip() *gossip.Gossip
 	splitQueue() *splitQueue
+	raftLogQueue() *raftLogQueue
+	leaseOwnershipChanged(owned bool)
 	Stopper() *stop.Stopper
 	EventFeed() StoreEventFeed
 	Context(context.Context) context.Context
@@ -174,6 +181,8 @@ type rangeManager interface {
 	NewRangeDescriptor(start, end proto.Key, replicas []proto.Replica) (*proto.RangeDescriptor, error)
 	NewSnapshot() engine.Engine
 	ProposeRaftCommand(cmdIDKey, proto.InternalRaftCommand) <-chan error
+	QuarantineRange(rng *Range)
+	ReportInconsistency(rng *Range, spans []string)
 	RemoveRange(rng *Range) error
 	Tracer() *tracer.Tracer
 	SplitRange(origRng, newRng *Range) error
@@ -191,13 +200,37 @@ type Range struct {
 	rm       rangeManager   // Makes some store methods available
 	stats    *rangeStats    // Range statistics
 	maxBytes int64          // Max bytes before split.
+	// The max bytes before the zone's limit last shrunk, while the range
+	// has yet to be split down to the new limit. Updated atomically.
+	prevMaxBytes int64
+	// Set while the split queue finds no key to split the range at by
+	// size. Updated atomically.
+	noSplitKey int32
+	maxQPS   int64          // Max requests per second before split.
+	load     *loadStats     // Request rate and key sample
 	// Held while a split, merge, or replica change is underway.
 	metaLock sync.Mutex // TODO(bdarnell): Revisit the metaLock.
 	// Last index persisted to the raft log (not necessarily committed).
 	// Updated atomically.
 	lastIndex uint64
+	// Index of the last entry truncated from the raft log; see
+	// raftTruncatedState. Updated atomically.
+	truncatedIndex uint64
 	// Last index applied to the state machine. Updated atomically.
 	appliedIndex uint64
+	// Size in bytes of the raft log, maintained as entries are appended
+	// and truncated. Updated atomically.
+	raftLogSize int64
+	// Set while this replica closes a timestamp. Updated atomically.
+	closing int32
+	// The wall time at which this replica last served a bounded
+	// staleness read or change feed request. Only ranges which serve
+	// them close timestamps. Updated atomically.
+	closedTSRequested int64
+	// Set once the replica's data has been found to be corrupt. Updated
+	// atomically.
+	quarantined int32
+
 	configHashes map[int][]byte // Config map sha256 hashes @ last gossip
 	lease        unsafe.Pointer // Information for leader lease, updated atomically
 	llMu         sync.Mutex     // Synchronizes readers' requests for leader lease
@@ -207,6 +240,9 @@ type Range struct {
 	cmdQ         *CommandQueue   // Enforce at most one command is running per key(s)
 	tsCache      *TimestampCache // Most recent timestamps for keys / key ranges
 	pendingCmds  map[cmdIDKey]*pendingCmd
+	checksums    map[string]*replicaChecksum     // Consistency checksums by checksum ID
+	closed       proto.Timestamp                 // No writes are served at or below this timestamp
+	inflight     map[interface{}]proto.Timestamp // Timestamps of proposed writes by command key
 }
 
 // NewRange initializes the range using the given metadata.
@@ -217,6 +253,9 @@ func NewRange(desc *proto.RangeDescriptor, rm rangeManager) (*Range, error) {
 		tsCache:     NewTimestampCache(rm.Clock()),
 		respCache:   NewResponseCache(desc.RaftID),
 		pendingCmds: map[cmdIDKey]*pendingCmd{},
+		checksums:   map[string]*replicaChecksum{},
+		inflight:    map[interface{}]proto.Timestamp{},
+		load:        newLoadStats(rm.Clock().PhysicalNow()),
 	}
 	r.setDescWithoutProcessUpdate(desc)
 
@@ -226,6 +265,18 @@ func NewRange(desc *proto.RangeDescriptor, rm rangeManager) (*Range, error) {
 	}
 	atomic.StoreUint64(&r.lastIndex, lastIndex)
 
+	truncatedState, err := r.raftTruncatedState()
+	if err != nil {
+		return nil, err
+	}
+	atomic.StoreUint64(&r.truncatedIndex, truncatedState.Index)
+
+	raftLogSize, err := loadRaftLogSize(r.rm.Engine(), desc.RaftID)
+	if err != nil {
+		return nil, err
+	}
+	atomic.StoreInt64(&r.raftLogSize, raftLogSize)
+
 	appliedIndex, err := r.loadAppliedIndex(r.rm.Engine())
 	if err != nil {
 		return nil, err
@@ -238,6 +289,30 @@ func NewRange(desc *proto.RangeDescriptor, rm rangeManager) (*Range, error) {
 	}
 	atomic.StorePointer(&r.lease, unsafe.Pointer(lease))
 
+	if _, err := engine.MVCCGetProto(r.rm.Engine(), keys.RangeClosedTimestampKey(desc.RaftID),
+		proto.ZeroTimestamp, true, nil, &r.closed); err != nil {
+		return nil, err
+	}
+
+	var quarantined proto.Timestamp
+	if ok, err := engine.MVCCGetProto(r.rm.Engine(), keys.RangeQuarantinedKey(desc.RaftID),
+		proto.ZeroTimestamp, true, nil, &quarantined); err != nil {
+		return nil, err
+	} else if ok {
+		r.quarantined = 1
+	}
+
+	// An initialized replica which was interrupted while applying a
+	// snapshot holds part of the snapshot's data; see ApplySnapshot.
+	if r.isInitialized() {
+		if ok, err := engine.MVCCGetProto(r.rm.Engine(), keys.RangeSnapshotApplyKey(desc.RaftID),
+			proto.ZeroTimestamp, true, nil, nil); err != nil {
+			return nil, err
+		} else if ok && r.quarantine() {
+			log.Warningf("%s: quarantined after applying a raft snapshot was interrupted", r)
+		}
+	}
+
 	// Gossip configs as they might not be gossiped until configs
 	// are updated or a leader lease is acquired/extended.
 	r.maybeGossipConfigs(func(configPrefix proto.Key) bool {
@@ -282,9 +357,44 @@ func (r *Range) GetMaxBytes() int64 {
 }
 
 // SetMaxBytes atomically sets the maximum byte limit before
-// split. This value is cached by the range for efficiency.
+// split. This value is cached by the range for efficiency. When the
+// limit shrinks, the previous one is kept for backpressureMaxBytes.
 func (r *Range) SetMaxBytes(maxBytes int64) {
-	atomic.StoreInt64(&r.maxBytes, maxBytes)
+	oldMaxBytes := atomic.SwapInt64(&r.maxBytes, maxBytes)
+	if prevMaxBytes := atomic.LoadInt64(&r.prevMaxBytes); maxBytes < oldMaxBytes && oldMaxBytes > prevMaxBytes {
+		atomic.StoreInt64(&r.prevMaxBytes, oldMaxBytes)
+	} else if maxBytes >= prevMaxBytes {
+		atomic.StoreInt64(&r.prevMaxBytes, 0)
+	}
+}
+
+// backpressureMaxBytes returns the max bytes against which writes to
+// the range are backpressured. Right after the zone's limit has shrunk
+// the range may be far over the new one through no fault of its
+// writers, so the previous limit applies until splits have brought the
+// range within the given multiple of the new one.
+func (r *Range) backpressureMaxBytes(multiple float64) int64 {
+	maxBytes := r.GetMaxBytes()
+	prevMaxBytes := atomic.LoadInt64(&r.prevMaxBytes)
+	if prevMaxBytes == 0 {
+		return maxBytes
+	}
+	if float64(r.stats.GetSize()) <= multiple*float64(maxBytes) {
+		atomic.CompareAndSwapInt64(&r.prevMaxBytes, prevMaxBytes, 0)
+		return maxBytes
+	}
+	return prevMaxBytes
+}
+
+// GetMaxQPS atomically gets the range maximum request rate.
+func (r *Range) GetMaxQPS() int64 {
+	return atomic.LoadInt64(&r.maxQPS)
+}
+
+// SetMaxQPS atomically sets the maximum request rate, in requests per
+// second, before split. Zero disables load-based splitting.
+func (r *Range) SetMaxQPS(maxQPS int64) {
+	atomic.StoreInt64(&r.maxQPS, maxQPS)
 }
 
 // IsFirstRange returns true if this is the first range.
@@ -305,6 +415,16 @@ func (r *Range) getLease() *proto.Lease {
 	return (*proto.Lease)(atomic.LoadPointer(&r.lease))
 }
 
+// setLease atomically replaces the range's leader lease, letting the
+// range manager know when this replica gains or loses the lease.
+func (r *Range) setLease(lease *proto.Lease) {
+	prevLease := (*proto.Lease)(atomic.SwapPointer(&r.lease, unsafe.Pointer(lease)))
+	raftNodeID := r.rm.RaftNodeID()
+	if owned := lease.OwnedBy(raftNodeID); owned != prevLease.OwnedBy(raftNodeID) {
+		r.rm.leaseOwnershipChanged(owned)
+	}
+}
+
 // newNotLeaderError returns a NotLeaderError intialized with the
 // replica for the holder (if any) of the given lease.
 func (r *Range) newNotLeaderError(l *proto.Lease, originNode proto.RaftNodeID) error {
@@ -354,6 +474,48 @@ func (r *Range) requestLeaderLease(timestamp proto.Timestamp) error {
 	return (<-pendingCmd.done).Err
 }
 
+// transferLeaderLease hands the leader lease held by this replica over
+// to the target replica, with a new lease starting now. The request
+// carries a summary of the timestamp cache, which the target uses in
+// place of the expiration of this replica's lease to seed its own.
+//
+// For the summary to account for all commands served under this
+// replica's lease, the request spans the entire range in the command
+// queue: it waits for the commands in progress to complete and update
+// the timestamp cache, and holds back new ones until it has been
+// applied, after which they are redirected to the target.
+func (r *Range) transferLeaderLease(target proto.Replica) error {
+	now := r.rm.Clock().Now()
+	desc := r.Desc()
+	args := &proto.InternalTransferLeaderLeaseRequest{
+		RequestHeader: proto.RequestHeader{
+			Key:       desc.StartKey,
+			EndKey:    desc.EndKey,
+			Timestamp: now,
+			RaftID:    desc.RaftID,
+		},
+		Lease: proto.Lease{
+			Start:      now,
+			Expiration: now.Add(int64(DefaultLeaderLeaseDuration), 0),
+			RaftNodeID: proto.MakeRaftNodeID(target.NodeID, target.StoreID),
+		},
+	}
+	ctx := r.context()
+	cmdKey := r.beginCmd(&args.RequestHeader, false)
+	err := r.redirectOnOrAcquireLeaderLease(tracer.FromCtx(ctx), now)
+	if err == nil {
+		r.Lock()
+		args.TimestampCache = r.tsCache.Summary(maxTSCacheSummaryEntries)
+		r.Unlock()
+		errChan, pendingCmd := r.proposeRaftCommand(ctx, args)
+		if err = <-errChan; err == nil {
+			err = (<-pendingCmd.done).Err
+		}
+	}
+	r.endCmd(cmdKey, args, err, false /* !readOnly */)
+	return err
+}
+
 // redirectOnOrAcquireLeaderLease checks whether this replica has the
 // leader lease at the specified timestamp. If it does, returns
 // success. If another replica currently holds the lease, redirects by
@@ -376,6 +538,11 @@ func (r *Range) redirectOnOrAcquireLeaderLease(trace *tracer.Trace, timestamp pr
 
 	raftNodeID := r.rm.RaftNodeID()
 
+	if r.isQuarantined() {
+		// A quarantined replica must neither use nor acquire the lease.
+		return r.newNotLeaderError(nil, raftNodeID)
+	}
+
 	if lease := r.getLease(); lease.Covers(timestamp) {
 		if lease.OwnedBy(raftNodeID) {
 			// Happy path: We have an active lease, nothing to do.
@@ -400,6 +567,56 @@ func (r *Range) redirectOnOrAcquireLeaderLease(trace *tracer.Trace, timestamp pr
 	return err
 }
 
+// isFreshAt returns whether this replica has applied the closing of a
+// timestamp at or above the specified one. The leader serves no more
+// writes at or below a closed timestamp, and it proposes the closing
+// after all writes it served below it, so such a replica has applied
+// every write a read at the timestamp may observe. Only the commit of
+// intents already present may still change the result of the read.
+func (r *Range) isFreshAt(timestamp proto.Timestamp) bool {
+	r.RLock()
+	defer r.RUnlock()
+	return !r.closed.Less(timestamp)
+}
+
+// closeTimestamp has the holder of the leader lease promise not to
+// serve writes at or below the specified timestamp, lowered to just
+// below the timestamps of any writes which are still underway, and
+// publishes the promise to the range's replicas through Raft. The
+// timestamp cache's low water mark makes later writes move their
+// timestamps above the closed timestamp.
+func (r *Range) closeTimestamp(closed proto.Timestamp) error {
+	if !atomic.CompareAndSwapInt32(&r.closing, 0, 1) {
+		return nil
+	}
+	defer atomic.StoreInt32(&r.closing, 0)
+
+	r.Lock()
+	r.tsCache.SetLowWater(closed)
+	for _, timestamp := range r.inflight {
+		if !closed.Less(timestamp) {
+			closed = timestamp.Prev()
+		}
+	}
+	prevClosed := r.closed
+	r.Unlock()
+	if !prevClosed.Less(closed) {
+		return nil
+	}
+
+	desc := r.Desc()
+	args := &proto.InternalCloseTimestampRequest{
+		RequestHeader: proto.RequestHeader{
+			Key:       desc.StartKey,
+			Timestamp: r.rm.Clock().Now(),
+			RaftID:    desc.RaftID,
+		},
+		Closed: closed,
+	}
+	_, err := r.AddCmd(r.context(), args)
+	return err
+}
+
 // WaitForLeaderLease is used from unittests to wait until this range
 // has the leader lease.
 func (r *Range) WaitForLeaderLease(t util.Tester) {
@@ -416,6 +633,28 @@ func (r *Range) isInitialized() bool {
 	return len(r.Desc().EndKey) > 0
 }
 
+// quarantine marks the replica as quarantined, returning false if it
+// already was. The time of the quarantine is persisted in a marker
+// which is loaded by NewRange, so that the replica stays out of
+// service across restarts until it is destroyed.
+func (r *Range) quarantine() bool {
+	if !atomic.CompareAndSwapInt32(&r.quarantined, 0, 1) {
+		return false
+	}
+	now := r.rm.Clock().Now()
+	if err := engine.MVCCPutProto(r.rm.Engine(), nil, keys.RangeQuarantinedKey(r.Desc().RaftID), proto.ZeroTimestamp, nil, &now); err != nil {
+		log.Errorf("%s: unable to persist quarantine: %s", r, err)
+	}
+	return true
+}
+
+// isQuarantined returns whether the replica has been quarantined. A
+// quarantined replica no longer serves commands; see
+// Store.QuarantineRange.
+func (r *Range) isQuarantined() bool {
+	return atomic.LoadInt32(&r.quarantined) != 0
+}
+
 // Desc atomically returns the range's descriptor.
 func (r *Range) Desc() *proto.RangeDescriptor {
 	return (*proto.RangeDescriptor)(atomic.LoadPointer(&r.desc))
@@ -492,6 +731,103 @@ func (r *Range) SetLastVerificationTimestamp(timestamp proto.Timestamp) error {
 	return engine.MVCCPutProto(r.rm.Engine(), nil, key, proto.ZeroTimestamp, nil, &timestamp)
 }
 
+// GetLastConsistencyCheckTimestamp reads the timestamp at which the
+// range's replicas were last checked for consistency.
+func (r *Range) GetLastConsistencyCheckTimestamp() (proto.Timestamp, error) {
+	key := keys.RangeLastConsistencyCheckTimestampKey(r.Desc().RaftID)
+	timestamp := proto.Timestamp{}
+	_, err := engine.MVCCGetProto(r.rm.Engine(), key, proto.ZeroTimestamp, true, nil, &timestamp)
+	if err != nil {
+		return proto.ZeroTimestamp, err
+	}
+	return timestamp, nil
+}
+
+// SetLastConsistencyCheckTimestamp writes the timestamp at which the
+// range's replicas were last checked for consistency.
+func (r *Range) SetLastConsistencyCheckTimestamp(timestamp proto.Timestamp) error {
+	key := keys.RangeLastConsistencyCheckTimestampKey(r.Desc().RaftID)
+	return engine.MVCCPutProto(r.rm.Engine(), nil, key, proto.ZeroTimestamp, nil, &timestamp)
+}
+
+// getChecksums waits for this replica's checksums for the given
+// consistency check to be computed and returns them.
+func (r *Range) getChecksums(checksumID []byte) ([][]byte, error) {
+	r.RLock()
+	c, ok := r.checksums[string(checksumID)]
+	r.RUnlock()
+	if !ok {
+		return nil, util.Errorf("no checksums computed for consistency check %x", checksumID)
+	}
+	<-c.computed
+	return c.checksums, c.err
+}
+
+// reportChecksums proposes this replica's checksums for the given
+// consistency check in an InternalReportChecksum command. Unlike other
+// commands, it is proposed by replicas which don't hold the leader
+// lease.
+func (r *Range) reportChecksums(checksumID []byte, checksums [][]byte) error {
+	desc := r.Desc()
+	_, replica := desc.FindReplica(r.rm.StoreID())
+	if replica == nil {
+		return util.Errorf("replica of store %d not found in %s", r.rm.StoreID(), r)
+	}
+	args := &proto.InternalReportChecksumRequest{
+		RequestHeader: proto.RequestHeader{
+			Key:       desc.StartKey,
+			Timestamp: r.rm.Clock().Now(),
+			RaftID:    desc.RaftID,
+		},
+		ChecksumID: checksumID,
+		Checksums:  checksums,
+		Replica:    *replica,
+	}
+	errChan, pendingCmd := r.proposeRaftCommand(r.context(), args)
+	if err := <-errChan; err != nil {
+		return err
+	}
+	// The command runs as a task of the store, so don't keep the store
+	// from stopping while it waits to be applied.
+	select {
+	case respWithErr := <-pendingCmd.done:
+		return respWithErr.Err
+	case <-r.rm.Stopper().ShouldStop():
+		return util.Errorf("store is stopping")
+	}
+}
+
+// getChecksumReports waits for all replicas of the range to report
+// their checksums for the given consistency check, or for the timeout
+// to expire, and returns the checksums reported so far by store ID.
+func (r *Range) getChecksumReports(checksumID []byte, timeout time.Duration) (map[proto.StoreID][][]byte, error) {
+	r.RLock()
+	c, ok := r.checksums[string(checksumID)]
+	r.RUnlock()
+	if !ok {
+		return nil, util.Errorf("no checksums computed for consistency check %x", checksumID)
+	}
+	deadline := time.After(timeout)
+	for {
+		reports := map[proto.StoreID][][]byte{}
+		r.RLock()
+		for storeID, checksums := range c.reports {
+			reports[storeID] = checksums
+		}
+		r.RUnlock()
+		if len(reports) >= len(r.Desc().Replicas) {
+			return reports, nil
+		}
+		select {
+		case <-c.reported:
+		case <-deadline:
+			return reports, nil
+		case <-r.rm.Stopper().ShouldStop():
+			return nil, util.Errorf("store is stopping")
+		}
+	}
+}
+
 // AddCmd adds a command for execution on this range. The command's
 // affected keys are verified to be contained within the range and the
 // range's leadership is confirmed. The command is then dispatched
@@ -501,6 +837,13 @@ func (r *Range) AddCmd(ctx context.Context, args proto.Request) (proto.Response,
 	// TODO(tschottdorf) Some (internal) requests go here directly, so they
 	// won't be traced.
 	trace := tracer.FromCtx(ctx)
+	if r.isQuarantined() {
+		// Let the client retry on another replica.
+		return nil, r.newNotLeaderError(nil, r.rm.RaftNodeID())
+	}
+	if !proto.IsAdmin(args) {
+		r.recordLoad(args)
+	}
 	// Differentiate between admin, read-only and read-write.
 	var reply proto.Response
 	var err error
@@ -557,6 +900,7 @@ func (r *Range) endCmd(cmdKey interface{}, args proto.Request, err error, readOn
 		header := args.Header()
 		r.tsCache.Add(header.Key, header.EndKey, header.Timestamp, header.Txn.GetID(), readOnly)
 	}
+	delete(r.inflight, cmdKey)
 	r.cmdQ.Remove(cmdKey)
 	r.Unlock()
 }
@@ -599,8 +943,29 @@ func (r *Range) addReadOnlyCmd(ctx context.Context, args proto.Request) (proto.R
 		return nil, err
 	}
 
-	// If read-consistency is set to INCONSISTENT, run directly.
-	if header.ReadConsistency == proto.INCONSISTENT {
+	boundedStaleness := header.ReadConsistency == proto.BOUNDED_STALENESS
+	if boundedStaleness || args.Method() == proto.Changes {
+		atomic.StoreInt64(&r.closedTSRequested, r.rm.Clock().PhysicalNow())
+	}
+	if boundedStaleness {
+		if header.Txn != nil {
+			return nil, util.Error("cannot allow bounded staleness reads within a transaction")
+		}
+		if header.Timestamp.Equal(proto.ZeroTimestamp) {
+			header.Timestamp = r.rm.Clock().Now()
+		}
+		// Bounded staleness reads are run directly on any replica which
+		// has applied the closing of the read timestamp, unless they
+		// encounter intents, which may yet commit below it. Otherwise,
+		// they are served by the leader like consistent reads.
+		if r.isFreshAt(header.Timestamp) {
+			reply, intents, err := r.executeCmd(r.rm.Engine(), nil, args)
+			if err != nil || len(intents) == 0 {
+				return reply, err
+			}
+		}
+	} else if header.ReadConsistency == proto.INCONSISTENT {
+		// If read-consistency is set to INCONSISTENT, run directly.
 		// But disallow any inconsistent reads within txns.
 		if header.Txn != nil {
 			return nil, util.Error("cannot allow inconsistent reads within a transaction")
@@ -622,13 +987,23 @@ func (r *Range) addReadOnlyCmd(ctx context.Context, args proto.Request) (proto.R
 	cmdKey := r.beginCmd(header, true)
 
 	// This replica must have leader lease to process a consistent read.
-	if err := r.redirectOnOrAcquireLeaderLease(tracer.FromCtx(ctx), header.Timestamp); err != nil {
+	// The timestamp of a bounded staleness read may precede the lease.
+	leaseTimestamp := header.Timestamp
+	if boundedStaleness {
+		leaseTimestamp = r.rm.Clock().Now()
+	}
+	if err := r.redirectOnOrAcquireLeaderLease(tracer.FromCtx(ctx), leaseTimestamp); err != nil {
 		r.endCmd(cmdKey, args, err, true /* readOnly */)
 		return nil, err
 	}
 
 	// Execute read-only command.
 	reply, intents, err := r.executeCmd(r.rm.Engine(), nil, args)
+	// Bounded staleness reads skip intents; have them resolved like
+	// those encountered by consistent reads.
+	if boundedStaleness && err == nil && len(intents) > 0 {
+		err = &proto.WriteIntentError{Intents: intents}
+	}
 
 	// Only update the timestamp cache if the command succeeded.
 	r.endCmd(cmdKey, args, err, true /* readOnly */)
@@ -693,7 +1068,6 @@ func (r *Range) addWriteCmd(ctx context.Context, args proto.Request, wg *sync.Wa
 	if usesTimestampCache(args) {
 		r.Lock()
 		rTS, wTS := r.tsCache.GetMax(header.Key, header.EndKey, header.Txn.GetID())
-		r.Unlock()
 
 		// Always push the timestamp forward if there's been a read which
 		// occurred after our txn timestamp.
@@ -711,6 +1085,10 @@ func (r *Range) addWriteCmd(ctx context.Context, args proto.Request, wg *sync.Wa
 				header.Timestamp = wTS.Next()
 			}
 		}
+		// Until the write has been applied, no timestamp at or above
+		// its own may be closed.
+		r.inflight[cmdKey] = header.Timestamp
+		r.Unlock()
 	}
 
 	defer trace.Epoch("raft")()
@@ -843,6 +1221,8 @@ func (r *Range) applyRaftCommand(ctx context.Context, index uint64, originNode p
 		r.rm.EventFeed().updateRange(r, args.Method(), &ms)
 		// If the commit succeeded, potentially add range to split queue.
 		r.maybeAddToSplitQueue()
+		// Likewise to the raft log queue.
+		r.maybeAddToRaftLogQueue()
 		// Maybe update gossip configs if the command is not part of a transaction.
 		// If the command is part of an uncommitted transaction, we rely on the
 		// periodic configGossipInterval loop since we will not see the update
@@ -866,10 +1246,14 @@ func (r *Range) applyRaftCommandInBatch(ctx context.Context, index uint64, origi
 	batch := r.rm.Engine().NewBatch()
 
 	if lease := r.getLease(); args.Method() != proto.InternalLeaderLease &&
+		args.Method() != proto.InternalReportChecksum && args.Method() != proto.InternalTruncateLog &&
 		(!lease.OwnedBy(originNode) || !lease.Covers(args.Header().Timestamp)) {
 		// Verify the leader lease is held, unless this command is trying to
-		// obtain it. Any other Raft command has had the leader lease held
-		// by the replica at proposal time, but this may no longer be the case.
+		// obtain it, reports the checksums of a replica, which needn't be
+		// the leader and doesn't touch the range data, or truncates the
+		// raft log, which is proposed by the raft leader. Any other Raft
+		// command has had the leader lease held by the replica at proposal
+		// time, but this may no longer be the case.
 		// Corruption aside, the most likely reason is a leadership change (the
 		// most recent leader assumes responsibility for all past timestamps as
 		// well). In that case, it's not valid to go ahead with the execution:
@@ -1175,6 +1559,20 @@ func loadConfigMap(eng engine.Engine, keyPrefix proto.Key, configI interface{})
 	return m, sha.Sum(nil), err
 }
 
+// recordLoad records the request in the range's load statistics. Each
+// time a measurement window completes, the range is added to the split
+// queue if its request rate exceeds the max specified in the zone
+// config.
+func (r *Range) recordLoad(args proto.Request) {
+	now := r.rm.Clock().PhysicalNow()
+	if !r.load.record(now, keys.KeyAddress(args.Header().Key), args.Header().GatewayNodeID) {
+		return
+	}
+	if maxQPS := r.GetMaxQPS(); maxQPS > 0 && r.load.QPS(now) > float64(maxQPS) {
+		r.rm.splitQueue().MaybeAdd(r, r.rm.Clock().Now())
+	}
+}
+
 // maybeAddToSplitQueue checks whether the current size of the range
 // exceeds the max size specified in the zone config. If yes, the
 // range is added to the split queue.
@@ -1184,3 +1582,17 @@ func (r *Range) maybeAddToSplitQueue() {
 		r.rm.splitQueue().MaybeAdd(r, r.rm.Clock().Now())
 	}
 }
+
+// maybeAddToRaftLogQueue checks whether the range's raft log holds
+// more than raftLogMaxEntries entries. If yes, the range is added to
+// the raft log queue, which only accepts it on the raft leader.
+func (r *Range) maybeAddToRaftLogQueue() {
+	firstIndex, err := r.FirstIndex()
+	if err != nil {
+		log.Warningf("%s: unable to read first raft log index: %s", r, err)
+		return
+	}
+	if lastIndex := atomic.LoadUint64(&r.lastIndex); lastIndex >= firstIndex && lastIndex+1-firstIndex > raftLogMaxEntries {
+		r.rm.raftLogQueue().MaybeAdd(r, r.rm.Clock().Now())
+	}
+}
@@ -25,7 +25,7 @@ import (
 	"fmt"
 	"math/rand"
 	"sync/atomic"
-	"unsafe"
+	"time"
 
 	"github.com/cockroachdb/cockroach/client"
 	"github.com/cockroachdb/cockroach/keys"
@@ -87,10 +87,34 @@ func (r *Range) executeCmd(batch engine.Engine, ms *engine.MVCCStats, args proto
 		var resp proto.ScanResponse
 		resp, intents, err = r.Scan(batch, *tArgs)
 		reply = &resp
+	case *proto.ChangesRequest:
+		var resp proto.ChangesResponse
+		resp, intents, err = r.Changes(batch, *tArgs)
+		reply = &resp
+	case *proto.ImportRequest:
+		var resp proto.ImportResponse
+		resp, err = r.Import(batch, ms, *tArgs)
+		reply = &resp
+	case *proto.SpanStatsRequest:
+		var resp proto.SpanStatsResponse
+		resp, err = r.SpanStats(batch, *tArgs)
+		reply = &resp
 	case *proto.EndTransactionRequest:
 		var resp proto.EndTransactionResponse
 		resp, err = r.EndTransaction(batch, ms, *tArgs)
 		reply = &resp
+	case *proto.ReapQueueRequest:
+		var resp proto.ReapQueueResponse
+		resp, err = r.ReapQueue(batch, ms, *tArgs)
+		reply = &resp
+	case *proto.EnqueueUpdateRequest:
+		var resp proto.EnqueueUpdateResponse
+		resp, err = r.EnqueueUpdate(batch, ms, *tArgs)
+		reply = &resp
+	case *proto.EnqueueMessageRequest:
+		var resp proto.EnqueueMessageResponse
+		resp, err = r.EnqueueMessage(batch, ms, *tArgs)
+		reply = &resp
 	case *proto.InternalRangeLookupRequest:
 		var resp proto.InternalRangeLookupResponse
 		resp, intents, err = r.InternalRangeLookup(batch, *tArgs)
@@ -127,10 +151,35 @@ func (r *Range) executeCmd(batch engine.Engine, ms *engine.MVCCStats, args proto
 		var resp proto.InternalLeaderLeaseResponse
 		resp, err = r.InternalLeaderLease(batch, ms, *tArgs)
 		reply = &resp
+	case *proto.InternalComputeChecksumRequest:
+		var resp proto.InternalComputeChecksumResponse
+		resp, err = r.InternalComputeChecksum(batch, ms, *tArgs)
+		reply = &resp
+	case *proto.InternalVerifyChecksumRequest:
+		var resp proto.InternalVerifyChecksumResponse
+		resp, err = r.InternalVerifyChecksum(batch, ms, *tArgs)
+		reply = &resp
+	case *proto.InternalTransferLeaderLeaseRequest:
+		var resp proto.InternalTransferLeaderLeaseResponse
+		resp, err = r.InternalTransferLeaderLease(batch, ms, *tArgs)
+		reply = &resp
+	case *proto.InternalCloseTimestampRequest:
+		var resp proto.InternalCloseTimestampResponse
+		resp, err = r.InternalCloseTimestamp(batch, ms, *tArgs)
+		reply = &resp
+	case *proto.InternalReportChecksumRequest:
+		var resp proto.InternalReportChecksumResponse
+		resp, err = r.InternalReportChecksum(batch, ms, *tArgs)
+		reply = &resp
 	default:
 		err = util.Errorf("unrecognized command %s", args.Method())
 	}
 
+	// Record the writes of the command in the range's change log.
+	if err == nil {
+		err = logChanges(batch, r.Desc().RaftID, args, reply)
+	}
+
 	if log.V(2) {
 		log.Infof("executed %s command %+v: %+v", args.Method(), args, reply)
 	}
@@ -224,9 +273,157 @@ func (r *Range) Scan(batch engine.Engine, args proto.ScanRequest) (proto.ScanRes
 
 	rows, intents, err := engine.MVCCScan(batch, args.Key, args.EndKey, args.MaxResults, args.Timestamp, args.ReadConsistency == proto.CONSISTENT, args.Txn)
 	reply.Rows = rows
+	if args.MaxResults > 0 && int64(len(rows)) == args.MaxResults {
+		reply.SetResumeKey(args.EndKey)
+	}
+	return reply, intents, err
+}
+
+// Changes returns the versions written to the key range since the
+// start timestamp and up to the resolved timestamp, which is at most
+// the request timestamp and the range's closed timestamp. No write to
+// the key range can later appear at or below the closed timestamp,
+// except for the intents returned for resolution. The writes are found
+// through the range's change log, unless it doesn't reach back to the
+// start timestamp; the key range is scanned instead in that case.
+func (r *Range) Changes(batch engine.Engine, args proto.ChangesRequest) (proto.ChangesResponse, []proto.Intent, error) {
+	var reply proto.ChangesResponse
+
+	if args.Txn != nil {
+		return reply, nil, util.Errorf("cannot read changes within a transaction")
+	}
+	desc := r.Desc()
+	key, endKey := args.Key, args.EndKey
+	if key.Less(keys.LocalMax) {
+		key = keys.LocalMax
+	}
+	if key.Less(desc.StartKey) {
+		key = desc.StartKey
+	}
+	if desc.EndKey.Less(endKey) {
+		endKey = desc.EndKey
+	}
+	r.RLock()
+	endTS := r.closed
+	r.RUnlock()
+	if args.Timestamp.Less(endTS) {
+		endTS = args.Timestamp
+	}
+	if !args.StartTimestamp.Less(endTS) {
+		reply.Resolved = args.StartTimestamp
+		return reply, nil, nil
+	}
+	if !key.Less(endKey) {
+		// The key range holds none of the range's user data.
+		reply.Resolved = endTS
+		return reply, nil, nil
+	}
+
+	logStart, err := changeLogStart(batch, desc.RaftID)
+	if err != nil {
+		return reply, nil, err
+	}
+	var events []proto.ChangeEvent
+	var resolved proto.Timestamp
+	var intents []proto.Intent
+	if args.StartTimestamp.Less(logStart) {
+		events, resolved, intents, err = engine.MVCCChanges(batch, key, endKey, args.StartTimestamp, endTS)
+	} else {
+		events, resolved, intents, err = readChanges(batch, desc.RaftID, key, endKey, args.StartTimestamp, endTS)
+	}
+	reply.Events = events
+	reply.Resolved = resolved
 	return reply, intents, err
 }
 
+// SpanStats returns the MVCC statistics of the key range. If the key
+// range covers the whole range, the range's stats are returned without
+// reading any data; only a partially covered range is scanned. As the
+// range stats include the range-local system keys, a scan does not.
+func (r *Range) SpanStats(batch engine.Engine, args proto.SpanStatsRequest) (proto.SpanStatsResponse, error) {
+	var reply proto.SpanStatsResponse
+
+	nowNanos := args.Timestamp.WallTime
+	desc := r.Desc()
+	var ms engine.MVCCStats
+	if !desc.StartKey.Less(args.Key) && !args.EndKey.Less(desc.EndKey) {
+		ms = r.stats.GetMVCC()
+		ms.GCBytesAge = r.stats.GetGCBytesAge(nowNanos)
+	} else {
+		start := args.Key
+		if start.Less(keys.LocalMax) {
+			start = keys.LocalMax
+		}
+		iter := &rangeDataIterator{
+			ranges: []keyRange{{start: engine.MVCCEncodeKey(start), end: engine.MVCCEncodeKey(args.EndKey)}},
+			iter:   batch.NewIterator(),
+		}
+		iter.Seek(iter.ranges[0].start)
+		var err error
+		ms, err = engine.MVCCComputeStats(iter, nowNanos)
+		iter.Close()
+		if err != nil {
+			return reply, err
+		}
+		reply.ScannedRangeCount = 1
+	}
+	reply.LiveBytes = ms.LiveBytes
+	reply.KeyBytes = ms.KeyBytes
+	reply.ValBytes = ms.ValBytes
+	reply.IntentBytes = ms.IntentBytes
+	reply.LiveCount = ms.LiveCount
+	reply.KeyCount = ms.KeyCount
+	reply.ValCount = ms.ValCount
+	reply.IntentCount = ms.IntentCount
+	reply.GCBytesAge = ms.GCBytesAge
+	reply.SysBytes = ms.SysBytes
+	reply.SysCount = ms.SysCount
+	reply.RangeCount = 1
+	return reply, nil
+}
+
+// Import writes those of the rows, which must be sorted by key and
+// free of duplicates, that fall within the key range of the request.
+// All rows are written at the request timestamp into the same batch,
+// so that a chunk is applied in a single Raft command. If none of
+// their keys is present yet, as is the case when loading fresh data,
+// the rows are handed to the engine as a sorted chunk of their own;
+// otherwise, they are written one by one, just as for a Put.
+func (r *Range) Import(batch engine.Engine, ms *engine.MVCCStats, args proto.ImportRequest) (proto.ImportResponse, error) {
+	var reply proto.ImportResponse
+
+	if args.Txn != nil {
+		return reply, util.Errorf("cannot import within a transaction")
+	}
+	var rows []proto.KeyValue
+	for i, row := range args.Rows {
+		if row.Key.Less(keys.LocalMax) {
+			return reply, util.Errorf("cannot import local key %q", row.Key)
+		}
+		if i > 0 && !args.Rows[i-1].Key.Less(row.Key) {
+			return reply, util.Errorf("import rows are not sorted: %q follows %q", row.Key, args.Rows[i-1].Key)
+		}
+		if row.Key.Less(args.Key) || !row.Key.Less(args.EndKey) {
+			continue
+		}
+		rows = append(rows, row)
+	}
+
+	sorted, err := engine.MVCCPutSorted(batch, ms, rows, args.Timestamp)
+	if err != nil {
+		return reply, err
+	}
+	if !sorted {
+		for _, row := range rows {
+			if err := engine.MVCCPut(batch, ms, row.Key, args.Timestamp, row.Value, nil); err != nil {
+				return reply, err
+			}
+		}
+	}
+	reply.NumImported = int64(len(rows))
+	return reply, nil
+}
+
 // EndTransaction either commits or aborts (rolls back) an extant
 // transaction according to the args.Commit parameter.
 func (r *Range) EndTransaction(batch engine.Engine, ms *engine.MVCCStats, args proto.EndTransactionRequest) (proto.EndTransactionResponse, error) {
@@ -276,6 +473,10 @@ func (r *Range) EndTransaction(batch engine.Engine, ms *engine.MVCCStats, args p
 		if reply.Txn.Priority < txn.Priority {
 			reply.Txn.Priority = txn.Priority
 		}
+		// The requester knows which of its writes were rolled back to a
+		// savepoint; the persisted record may be out of date.
+		reply.Txn.Sequence = txn.Sequence
+		reply.Txn.RolledBack = txn.RolledBack
 	} else {
 		// The transaction doesn't exist yet on disk; use the supplied version.
 		reply.Txn = &txn
@@ -296,6 +497,10 @@ func (r *Range) EndTransaction(batch engine.Engine, ms *engine.MVCCStats, args p
 		if args.Txn.Isolation == proto.SERIALIZABLE && !reply.Txn.Timestamp.Equal(args.Txn.OrigTimestamp) {
 			return reply, proto.NewTransactionRetryError(reply.Txn)
 		}
+		// The transaction can't commit at or after its deadline.
+		if args.Deadline != nil && !reply.Txn.Timestamp.Less(*args.Deadline) {
+			return reply, proto.NewTransactionStatusError(reply.Txn, "transaction deadline exceeded")
+		}
 		reply.Txn.Status = proto.COMMITTED
 	} else {
 		reply.Txn.Status = proto.ABORTED
@@ -341,6 +546,105 @@ func (r *Range) EndTransaction(batch engine.Engine, ms *engine.MVCCStats, args p
 	return reply, nil
 }
 
+// ReapQueue scans and deletes messages from a recipient message
+// queue. ReapQueueRequest invocations must be part of an extant
+// transaction or they fail. Returns the reaped queue messages, up to
+// the requested maximum. If fewer than the maximum were returned,
+// then the queue is empty.
+func (r *Range) ReapQueue(batch engine.Engine, ms *engine.MVCCStats, args proto.ReapQueueRequest) (proto.ReapQueueResponse, error) {
+	var reply proto.ReapQueueResponse
+
+	if args.Txn == nil {
+		return reply, util.Errorf("no transaction specified to ReapQueue")
+	}
+	if err := verifyQueueSpan(args.Key, args.EndKey, keys.InboxPrefix(keys.KeyAddress(args.Key))); err != nil {
+		return reply, err
+	}
+	rows, _, err := engine.MVCCScan(batch, args.Key, args.EndKey, args.MaxResults, args.Timestamp, true, args.Txn)
+	if err != nil {
+		return reply, err
+	}
+	for _, kv := range rows {
+		if err := engine.MVCCDelete(batch, ms, kv.Key, args.Timestamp, args.Txn); err != nil {
+			return reply, err
+		}
+		reply.Messages = append(reply.Messages, kv.Value)
+	}
+	return reply, nil
+}
+
+// EnqueueUpdate enqueues an update for eventual execution. The update
+// is stored in the outbox of the key it modifies and is executed by
+// the outbox queue of the leader replica.
+func (r *Range) EnqueueUpdate(batch engine.Engine, ms *engine.MVCCStats, args proto.EnqueueUpdateRequest) (proto.EnqueueUpdateResponse, error) {
+	var reply proto.EnqueueUpdateResponse
+
+	if err := verifyQueueSpan(args.Key, args.EndKey, keys.OutboxPrefix(keys.KeyAddress(args.Key))); err != nil {
+		return reply, err
+	}
+	update, ok := args.Update.GetValue().(proto.Request)
+	if !ok {
+		return reply, util.Errorf("no update specified to EnqueueUpdate")
+	}
+	switch update.(type) {
+	case *proto.PutRequest, *proto.IncrementRequest, *proto.DeleteRequest, *proto.DeleteRangeRequest:
+	default:
+		return reply, util.Errorf("%s cannot be enqueued as an update", update.Method())
+	}
+	if key := keys.KeyAddress(args.Key); !key.Equal(update.Header().Key) {
+		return reply, util.Errorf("update of key %q cannot be enqueued to the outbox of key %q", update.Header().Key, key)
+	}
+	data, err := gogoproto.Marshal(&args.Update)
+	if err != nil {
+		return reply, err
+	}
+	return reply, enqueue(batch, ms, args.RequestHeader, proto.Value{Bytes: data})
+}
+
+// EnqueueMessage enqueues a message for delivery to an inbox.
+func (r *Range) EnqueueMessage(batch engine.Engine, ms *engine.MVCCStats, args proto.EnqueueMessageRequest) (proto.EnqueueMessageResponse, error) {
+	var reply proto.EnqueueMessageResponse
+
+	if err := verifyQueueSpan(args.Key, args.EndKey, keys.InboxPrefix(keys.KeyAddress(args.Key))); err != nil {
+		return reply, err
+	}
+	return reply, enqueue(batch, ms, args.RequestHeader, args.Msg)
+}
+
+// verifyQueueSpan verifies that the key range spans exactly the
+// message queue identified by prefix.
+func verifyQueueSpan(key, endKey, prefix proto.Key) error {
+	if !key.Equal(prefix) || !endKey.Equal(prefix.PrefixEnd()) {
+		return util.Errorf("key range %q-%q does not span the %q message queue", key, endKey, prefix)
+	}
+	return nil
+}
+
+// enqueue appends value to the message queue spanned by the header's
+// key range. Entries are keyed by the header timestamp and a sequence
+// number one higher than that of the last entry at the same timestamp.
+// The sequence number is derived from the queue contents instead of
+// the command ID so that all replicas agree on it.
+func enqueue(batch engine.Engine, ms *engine.MVCCStats, header proto.RequestHeader, value proto.Value) error {
+	prefix := header.Key
+	ts := header.Timestamp
+	start := keys.MakeKey(prefix, keys.MakeQueueDetail(ts, 0))
+	end := keys.MakeKey(prefix, keys.MakeQueueDetail(ts.Next(), 0))
+	rows, _, err := engine.MVCCScan(batch, start, end, 0, ts, true, header.Txn)
+	if err != nil {
+		return err
+	}
+	var seq uint64
+	if len(rows) > 0 {
+		_, lastSeq := keys.DecodeQueueDetail(rows[len(rows)-1].Key[len(prefix):])
+		seq = lastSeq + 1
+	}
+	entryKey := keys.MakeKey(prefix, keys.MakeQueueDetail(ts, seq))
+	value.Checksum = nil
+	value.InitChecksum(entryKey)
+	return engine.MVCCPut(batch, ms, entryKey, ts, value, header.Txn)
+}
+
 // InternalRangeLookup is used to look up RangeDescriptors - a RangeDescriptor
 // is a metadata structure which describes the key range and replica locations
 // of a distinct range in the cluster.
@@ -500,7 +804,8 @@ func (r *Range) InternalHeartbeatTxn(batch engine.Engine, ms *engine.MVCCStats,
 // InternalGC iterates through the list of keys to garbage collect
 // specified in the arguments. MVCCGarbageCollect is invoked on each
 // listed key along with the expiration timestamp. The GC metadata
-// specified in the args is persisted after GC.
+// specified in the args is persisted after GC, along with the non-live
+// bytes which remain.
 func (r *Range) InternalGC(batch engine.Engine, ms *engine.MVCCStats, args proto.InternalGCRequest) (proto.InternalGCResponse, error) {
 	var reply proto.InternalGCResponse
 
@@ -509,7 +814,16 @@ func (r *Range) InternalGC(batch engine.Engine, ms *engine.MVCCStats, args proto
 		return reply, err
 	}
 
-	// Store the GC metadata for this range.
+	// Discard the change log entries which may refer to GC'd versions.
+	if !args.ChangeLogThreshold.Equal(proto.ZeroTimestamp) {
+		if err := truncateChangeLog(batch, r.Desc().RaftID, args.ChangeLogThreshold); err != nil {
+			return reply, err
+		}
+	}
+
+	// Store the GC metadata for this range. The stats of the range
+	// don't yet include the changes made by this command.
+	args.GCMeta.RetainedGCBytes = gogoproto.Int64(r.stats.GetGCBytes() + ms.KeyBytes + ms.ValBytes - ms.LiveBytes)
 	key := keys.RangeGCMetadataKey(r.Desc().RaftID)
 	if err := engine.MVCCPutProto(batch, ms, key, proto.ZeroTimestamp, nil, &args.GCMeta); err != nil {
 		return reply, err
@@ -731,7 +1045,9 @@ func (r *Range) InternalTruncateLog(batch engine.Engine, ms *engine.MVCCStats, a
 	}
 	start := keys.RaftLogKey(r.Desc().RaftID, 0)
 	end := keys.RaftLogKey(r.Desc().RaftID, args.Index)
+	var size int64
 	if err = batch.Iterate(engine.MVCCEncodeKey(start), engine.MVCCEncodeKey(end), func(kv proto.RawKeyValue) (bool, error) {
+		size += int64(len(kv.Key) + len(kv.Value))
 		return false, batch.Clear(kv.Key)
 	}); err != nil {
 		return reply, err
@@ -740,9 +1056,135 @@ func (r *Range) InternalTruncateLog(batch engine.Engine, ms *engine.MVCCStats, a
 		Index: args.Index - 1,
 		Term:  term,
 	}
+	batch.Defer(func() {
+		atomic.AddInt64(&r.raftLogSize, -size)
+		atomic.StoreUint64(&r.truncatedIndex, ts.Index)
+	})
 	return reply, engine.MVCCPutProto(batch, ms, keys.RaftTruncatedStateKey(r.Desc().RaftID), proto.ZeroTimestamp, nil, &ts)
 }
 
+// InternalComputeChecksum starts computing checksums of this replica's
+// range data as of the application of the command, with the user data
+// split into spans at the supplied boundaries. The computation runs
+// asynchronously on a snapshot of the engine; once it completes, the
+// checksums are proposed in an InternalReportChecksum command so that
+// they reach the range leader.
+func (r *Range) InternalComputeChecksum(batch engine.Engine, ms *engine.MVCCStats, args proto.InternalComputeChecksumRequest) (proto.InternalComputeChecksumResponse, error) {
+	var reply proto.InternalComputeChecksumResponse
+
+	c := &replicaChecksum{
+		computed:  make(chan struct{}),
+		spans:     consistencySpans(r.Desc(), args.Boundaries),
+		reports:   map[proto.StoreID][][]byte{},
+		reported:  make(chan struct{}, 1),
+		createdAt: time.Now(),
+	}
+	r.Lock()
+	// Discard checksums which were never verified, e.g. because the
+	// leader failed before proposing the verification.
+	for id, old := range r.checksums {
+		if c.createdAt.Sub(old.createdAt) > replicaChecksumGCInterval {
+			delete(r.checksums, id)
+		}
+	}
+	r.checksums[string(args.ChecksumID)] = c
+	r.Unlock()
+
+	snap := r.rm.NewSnapshot()
+	if !r.rm.Stopper().RunAsyncTask(func() {
+		defer snap.Close()
+		c.checksums, c.err = computeChecksums(snap, c.spans)
+		close(c.computed)
+		if c.err != nil {
+			log.Errorc(r.context(), "unable to compute checksums for consistency check %x: %s", args.ChecksumID, c.err)
+			return
+		}
+		if err := r.reportChecksums(args.ChecksumID, c.checksums); err != nil {
+			log.Warningc(r.context(), "unable to report checksums for consistency check %x: %s", args.ChecksumID, err)
+		}
+	}) {
+		snap.Close()
+		c.err = util.Errorf("store is stopping")
+		close(c.computed)
+	}
+	return reply, nil
+}
+
+// InternalReportChecksum records the checksums reported by a replica
+// for a consistency check, for the range leader to hold a vote over
+// them. Reports for checks this replica knows nothing of are ignored.
+func (r *Range) InternalReportChecksum(batch engine.Engine, ms *engine.MVCCStats, args proto.InternalReportChecksumRequest) (proto.InternalReportChecksumResponse, error) {
+	var reply proto.InternalReportChecksumResponse
+
+	r.Lock()
+	defer r.Unlock()
+	if c, ok := r.checksums[string(args.ChecksumID)]; ok {
+		c.reports[args.Replica.StoreID] = args.Checksums
+		// Wake up the leader if it's waiting for the reports.
+		select {
+		case c.reported <- struct{}{}:
+		default:
+		}
+	}
+	return reply, nil
+}
+
+// InternalVerifyChecksum has this replica compare its checksums for a
+// consistency check with the ones agreed upon by the majority of the
+// replicas, if the range leader found it to be in the minority. The
+// comparison runs asynchronously once the local checksums are
+// available. Spans whose checksums differ are reported to the store,
+// which may quarantine the replica. Replicas in the majority merely
+// discard their checksums.
+func (r *Range) InternalVerifyChecksum(batch engine.Engine, ms *engine.MVCCStats, args proto.InternalVerifyChecksumRequest) (proto.InternalVerifyChecksumResponse, error) {
+	var reply proto.InternalVerifyChecksumResponse
+
+	r.Lock()
+	c, ok := r.checksums[string(args.ChecksumID)]
+	delete(r.checksums, string(args.ChecksumID))
+	r.Unlock()
+
+	var inMinority bool
+	for _, replica := range args.Minority {
+		if replica.StoreID == r.rm.StoreID() {
+			inMinority = true
+			break
+		}
+	}
+	if !inMinority {
+		return reply, nil
+	}
+	if !ok {
+		// This replica did not apply the corresponding
+		// InternalComputeChecksum, e.g. because it was initialized from a
+		// snapshot taken afterwards.
+		log.Warningf("range %s: no checksums computed for consistency check %x", r, args.ChecksumID)
+		return reply, nil
+	}
+
+	r.rm.Stopper().RunAsyncTask(func() {
+		<-c.computed
+		if c.err != nil {
+			log.Errorc(r.context(), "unable to verify consistency check %x: %s", args.ChecksumID, c.err)
+			return
+		}
+		var spans []string
+		if len(args.Checksums) != len(c.checksums) {
+			spans = append(spans, formatKeyRange(keyRange{start: c.spans[0].start, end: c.spans[len(c.spans)-1].end}))
+		} else {
+			for i, checksum := range c.checksums {
+				if !bytes.Equal(checksum, args.Checksums[i]) {
+					spans = append(spans, formatKeyRange(c.spans[i]))
+				}
+			}
+		}
+		if len(spans) > 0 {
+			r.rm.ReportInconsistency(r, spans)
+		}
+	})
+	return reply, nil
+}
+
 // InternalLeaderLease sets the leader lease for this range. The command fails
 // only if the desired start timestamp collides with a previous lease.
 // Otherwise, the start timestamp is wound back to right after the expiration
@@ -806,28 +1248,94 @@ func (r *Range) InternalLeaderLease(batch engine.Engine, ms *engine.MVCCStats, a
 	}
 
 	args.Lease.Start = effectiveStart
+	return reply, r.setLeaseLocked(batch, ms, prevLease, args.Lease, nil)
+}
+
+// InternalTransferLeaderLease hands the leader lease over to another
+// replica of the range. The command is proposed by the lease holder
+// and, like all commands other than InternalLeaderLease, only applied
+// if its origin still holds the lease at the command's timestamp. The
+// new lease may thus start before the previous one expires, cutting it
+// short.
+func (r *Range) InternalTransferLeaderLease(batch engine.Engine, ms *engine.MVCCStats, args proto.InternalTransferLeaderLeaseRequest) (proto.InternalTransferLeaderLeaseResponse, error) {
+	var reply proto.InternalTransferLeaderLeaseResponse
+
+	r.Lock()
+	defer r.Unlock()
+
+	prevLease := r.getLease()
+	if !args.Lease.Start.Less(args.Lease.Expiration) || args.Lease.Start.Less(prevLease.Start) ||
+		args.Lease.RaftNodeID == prevLease.RaftNodeID {
+		return reply, &proto.LeaseRejectedError{
+			Existing:  *prevLease,
+			Requested: args.Lease,
+		}
+	}
+	_, storeID := proto.DecodeRaftNodeID(args.Lease.RaftNodeID)
+	if _, replica := r.Desc().FindReplica(storeID); replica == nil {
+		return reply, util.Errorf("cannot transfer leader lease of range %d to store %d, which holds no replica",
+			r.Desc().RaftID, storeID)
+	}
+	return reply, r.setLeaseLocked(batch, ms, prevLease, args.Lease, args.TimestampCache)
+}
+
+// InternalCloseTimestamp records the timestamp at or below which the
+// holder of the leader lease no longer serves writes. Once it has
+// applied the command, this replica serves bounded staleness reads at
+// or below the closed timestamp. The closed timestamp never regresses.
+func (r *Range) InternalCloseTimestamp(batch engine.Engine, ms *engine.MVCCStats, args proto.InternalCloseTimestampRequest) (proto.InternalCloseTimestampResponse, error) {
+	var reply proto.InternalCloseTimestampResponse
 
-	// Store the lease to disk & in-memory.
-	if err := engine.MVCCPutProto(batch, ms, keys.RaftLeaderLeaseKey(r.Desc().RaftID), proto.ZeroTimestamp, nil, &args.Lease); err != nil {
+	r.Lock()
+	defer r.Unlock()
+
+	if !r.closed.Less(args.Closed) {
+		return reply, nil
+	}
+	if err := engine.MVCCPutProto(batch, ms, keys.RangeClosedTimestampKey(r.Desc().RaftID), proto.ZeroTimestamp, nil, &args.Closed); err != nil {
 		return reply, err
 	}
-	atomic.StorePointer(&r.lease, unsafe.Pointer(&args.Lease))
+	r.closed = args.Closed
+	return reply, nil
+}
+
+// setLeaseLocked stores the lease to disk & in-memory, replacing
+// prevLease. tsCache, if not nil, summarizes the timestamp cache of
+// the holder of prevLease. The range lock must be held.
+func (r *Range) setLeaseLocked(batch engine.Engine, ms *engine.MVCCStats, prevLease *proto.Lease, lease proto.Lease,
+	tsCache *proto.TimestampCacheSummary) error {
+	if err := engine.MVCCPutProto(batch, ms, keys.RaftLeaderLeaseKey(r.Desc().RaftID), proto.ZeroTimestamp, nil, &lease); err != nil {
+		return err
+	}
+	r.setLease(&lease)
 
 	// If this replica is a new holder of the lease, update the
-	// low water mark in the timestamp cache. We add the maximum
-	// clock offset to account for any difference in clocks
-	// between the expiration (set by a remote node) and this
+	// timestamp cache. If the previous holder handed the lease over
+	// with a summary of its timestamp cache, which accounts for all
+	// reads it served, seed the cache with it. Otherwise, update the
+	// low water mark to the expiration of the previous lease. We add
+	// the maximum clock offset to account for any difference in
+	// clocks between the expiration (set by a remote node) and this
 	// node.
-	if r.getLease().RaftNodeID == r.rm.RaftNodeID() && prevLease.RaftNodeID != r.getLease().RaftNodeID {
-		r.tsCache.SetLowWater(prevLease.Expiration.Add(int64(r.rm.Clock().MaxOffset()), 0))
-		log.Infof("range %d: new leader lease %s", r.Desc().RaftID, args.Lease)
+	if lease.RaftNodeID == r.rm.RaftNodeID() && prevLease.RaftNodeID != lease.RaftNodeID {
+		if tsCache != nil {
+			r.tsCache.Seed(tsCache)
+		} else {
+			r.tsCache.SetLowWater(prevLease.Expiration.Add(int64(r.rm.Clock().MaxOffset()), 0))
+		}
+		// Keep the promises of the previous holders.
+		r.tsCache.SetLowWater(r.closed)
+		// Requests were served elsewhere until now; start measuring the
+		// range's load afresh.
+		r.load.reset(r.rm.Clock().PhysicalNow())
+		log.Infof("range %d: new leader lease %s", r.Desc().RaftID, lease)
 	}
 
 	// Gossip configs in the event this range contains config info.
 	r.maybeGossipConfigsLocked(func(configPrefix proto.Key) bool {
 		return r.ContainsKey(configPrefix)
 	})
-	return reply, nil
+	return nil
 }
 
 // AdminSplit divides the range into into two ranges, using either
@@ -964,6 +1472,29 @@ func (r *Range) splitTrigger(batch engine.Engine, split *proto.SplitTrigger) err
 		return util.Errorf("unable to copy last verification timestamp: %s", err)
 	}
 
+	// Copy the last consistency check timestamp.
+	checkTS, err := r.GetLastConsistencyCheckTimestamp()
+	if err != nil {
+		return util.Errorf("unable to fetch last consistency check timestamp: %s", err)
+	}
+	if err := engine.MVCCPutProto(batch, nil, keys.RangeLastConsistencyCheckTimestampKey(split.NewDesc.RaftID), proto.ZeroTimestamp, nil, &checkTS); err != nil {
+		return util.Errorf("unable to copy last consistency check timestamp: %s", err)
+	}
+
+	// Copy the closed timestamp; the new range's leader inherits the
+	// timestamp cache, which keeps writes above it.
+	r.RLock()
+	closedTS := r.closed
+	r.RUnlock()
+	if err := engine.MVCCPutProto(batch, nil, keys.RangeClosedTimestampKey(split.NewDesc.RaftID), proto.ZeroTimestamp, nil, &closedTS); err != nil {
+		return util.Errorf("unable to copy closed timestamp: %s", err)
+	}
+
+	// Copy the change log entries of the new range's key span.
+	if err := copyChangeLog(batch, r.Desc().RaftID, split.NewDesc.RaftID, split.NewDesc.StartKey, split.NewDesc.EndKey); err != nil {
+		return util.Errorf("unable to copy change log: %s", err)
+	}
+
 	// Compute stats for updated range.
 	now := r.rm.Clock().Timestamp()
 	iter := newRangeDataIterator(&split.UpdatedDesc, batch)
@@ -988,6 +1519,7 @@ func (r *Range) splitTrigger(batch engine.Engine, split *proto.SplitTrigger) err
 	if err != nil {
 		return err
 	}
+	newRng.closed = closedTS
 
 	// Compute stats for new range.
 	iter = newRangeDataIterator(&split.NewDesc, batch)
@@ -1124,6 +1656,11 @@ func (r *Range) mergeTrigger(batch engine.Engine, merge *proto.MergeTrigger) err
 		return util.Errorf("unable to copy response cache to new split range: %s", err)
 	}
 
+	// Copy the subsumed range's change log.
+	if err := copyChangeLog(batch, merge.SubsumedRaftID, r.Desc().RaftID, r.Desc().EndKey, merge.UpdatedDesc.EndKey); err != nil {
+		return util.Errorf("unable to copy change log of subsumed range: %s", err)
+	}
+
 	// Compute stats for updated range.
 	now := r.rm.Clock().Timestamp()
 	iter := newRangeDataIterator(&merge.UpdatedDesc, batch)
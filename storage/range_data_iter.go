@@ -30,6 +30,20 @@ type keyRange struct {
 	start, end proto.EncodedKey
 }
 
+// contains returns whether the key lies within the key range.
+func (kr keyRange) contains(key proto.EncodedKey) bool {
+	return !key.Less(kr.start) && key.Less(kr.end)
+}
+
+// rangeIDKeyRange returns the key range of the range-ID local data of
+// the range with the given Raft ID.
+func rangeIDKeyRange(raftID proto.RaftID) keyRange {
+	return keyRange{
+		start: engine.MVCCEncodeKey(keys.MakeKey(keys.LocalRangeIDPrefix, encoding.EncodeUvarint(nil, uint64(raftID)))),
+		end:   engine.MVCCEncodeKey(keys.MakeKey(keys.LocalRangeIDPrefix, encoding.EncodeUvarint(nil, uint64(raftID+1)))),
+	}
+}
+
 // rangeDataIterator provides a complete iteration over all key / value
 // rows in a range, including all system-local metadata and user data.
 // The ranges keyRange slice specifies the key ranges which comprise
@@ -44,25 +58,21 @@ type rangeDataIterator struct {
 }
 
 func newRangeDataIterator(d *proto.RangeDescriptor, e engine.Engine) *rangeDataIterator {
-	// The first range in the keyspace starts at KeyMin, which includes the node-local
-	// space. We need the original StartKey to find the range metadata, but the
-	// actual data starts at LocalMax.
-	dataStartKey := d.StartKey
-	if d.StartKey.Equal(proto.KeyMin) {
-		dataStartKey = keys.LocalMax
-	}
+	// We need the original StartKey to find the range metadata, but the
+	// actual data of the first range starts at LocalMax.
 	ri := &rangeDataIterator{
 		ranges: []keyRange{
-			{
-				start: engine.MVCCEncodeKey(keys.MakeKey(keys.LocalRangeIDPrefix, encoding.EncodeUvarint(nil, uint64(d.RaftID)))),
-				end:   engine.MVCCEncodeKey(keys.MakeKey(keys.LocalRangeIDPrefix, encoding.EncodeUvarint(nil, uint64(d.RaftID+1)))),
-			},
+			rangeIDKeyRange(d.RaftID),
 			{
 				start: engine.MVCCEncodeKey(keys.MakeKey(keys.LocalRangePrefix, encoding.EncodeBytes(nil, d.StartKey))),
 				end:   engine.MVCCEncodeKey(keys.MakeKey(keys.LocalRangePrefix, encoding.EncodeBytes(nil, d.EndKey))),
 			},
 			{
-				start: engine.MVCCEncodeKey(dataStartKey),
+				start: engine.MVCCEncodeKey(keys.OutboxPrefix(d.StartKey)),
+				end:   engine.MVCCEncodeKey(keys.OutboxPrefix(d.EndKey)),
+			},
+			{
+				start: engine.MVCCEncodeKey(dataStartKey(d)),
 				end:   engine.MVCCEncodeKey(d.EndKey),
 			},
 		},
@@ -62,9 +62,14 @@ func (q *rangeGCQueue) needsLeaderLease() bool {
 }
 
 // shouldQueue determins whether a range should be queued for GC,
-// and if so at what priority. Currently all inactive ranges are
-// considered for possible GC at equal priority.
+// and if so at what priority. Quarantined replicas are destroyed as
+// soon as they have been removed from the range, so they take
+// precedence; all inactive ranges are otherwise considered for
+// possible GC at equal priority.
 func (q *rangeGCQueue) shouldQueue(now proto.Timestamp, rng *Range) (bool, float64) {
+	if rng.isQuarantined() {
+		return true, 1
+	}
 	lease := rng.getLease()
 	if lease.Covers(now) {
 		// If anyone holds a non-expired lease and we know about it, we
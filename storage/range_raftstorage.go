@@ -19,7 +19,6 @@ package storage
 
 import (
 	"sync/atomic"
-	"unsafe"
 
 	"github.com/cockroachdb/cockroach/keys"
 	"github.com/cockroachdb/cockroach/multiraft"
@@ -160,11 +159,7 @@ func (r *Range) raftTruncatedState() (proto.RaftTruncatedState, error) {
 
 // FirstIndex implements the raft.Storage interface.
 func (r *Range) FirstIndex() (uint64, error) {
-	ts, err := r.raftTruncatedState()
-	if err != nil {
-		return 0, err
-	}
-	return ts.Index + 1, nil
+	return atomic.LoadUint64(&r.truncatedIndex) + 1, nil
 }
 
 // loadAppliedIndex retrieves the applied index from the supplied engine.
@@ -219,6 +214,20 @@ func (r *Range) loadLastIndex() (uint64, error) {
 	return lastIndex, nil
 }
 
+// loadRaftLogSize returns the size in bytes of the raft log of the
+// range, as found by reading the whole log. The size is maintained in
+// Range.raftLogSize as entries are appended and truncated.
+func loadRaftLogSize(eng engine.Engine, raftID proto.RaftID) (int64, error) {
+	prefix := keys.RaftLogPrefix(raftID)
+	var size int64
+	err := eng.Iterate(engine.MVCCEncodeKey(prefix), engine.MVCCEncodeKey(prefix.PrefixEnd()),
+		func(kv proto.RawKeyValue) (bool, error) {
+			size += int64(len(kv.Key) + len(kv.Value))
+			return false, nil
+		})
+	return size, err
+}
+
 // setLastIndex persists a new last index.
 func setLastIndex(eng engine.Engine, raftID proto.RaftID, lastIndex uint64) error {
 	return engine.MVCCPut(eng, nil, keys.RaftLastIndexKey(raftID),
@@ -229,9 +238,16 @@ func setLastIndex(eng engine.Engine, raftID proto.RaftID, lastIndex uint64) erro
 
 // Snapshot implements the raft.Storage interface.
 func (r *Range) Snapshot() (raftpb.Snapshot, error) {
-	// Copy all the data from a consistent RocksDB snapshot into a RaftSnapshotData.
+	// The data of the snapshot is read from a consistent RocksDB snapshot
+	// as it is streamed; see snapshot_stream.go. The RocksDB snapshot is
+	// owned by the stream from then on.
 	snap := r.rm.NewSnapshot()
-	defer snap.Close()
+	registered := false
+	defer func() {
+		if !registered {
+			snap.Close()
+		}
+	}()
 	var snapData proto.RaftSnapshotData
 
 	// Read the range metadata from the snapshot instead of the members
@@ -260,18 +276,6 @@ func (r *Range) Snapshot() (raftpb.Snapshot, error) {
 	// Store RangeDescriptor as metadata, it will be retrieved by ApplySnapshot()
 	snapData.RangeDescriptor = desc
 
-	// Iterate over all the data in the range, including local-only data like
-	// the response cache.
-	for iter := newRangeDataIterator(r.Desc(), snap); iter.Valid(); iter.Next() {
-		snapData.KV = append(snapData.KV,
-			&proto.RaftSnapshotData_KeyValue{Key: iter.Key(), Value: iter.Value()})
-	}
-
-	data, err := gogoproto.Marshal(&snapData)
-	if err != nil {
-		return raftpb.Snapshot{}, err
-	}
-
 	// Synthesize our raftpb.ConfState from desc.
 	var cs raftpb.ConfState
 	for _, rep := range desc.Replicas {
@@ -283,6 +287,15 @@ func (r *Range) Snapshot() (raftpb.Snapshot, error) {
 		return raftpb.Snapshot{}, util.Errorf("failed to fetch term of %d: %s", appliedIndex, err)
 	}
 
+	// All the data in the range, including local-only data like the
+	// response cache, is encoded by the snapshot stream as it is read.
+	snapData.StreamID = registerSnapshotStream(&snapshotKVReader{snap: snap, desc: r.Desc()}, r.rm.Engine())
+	registered = true
+	data, err := gogoproto.Marshal(&snapData)
+	if err != nil {
+		return raftpb.Snapshot{}, err
+	}
+
 	return raftpb.Snapshot{
 		Data: data,
 		Metadata: raftpb.SnapshotMetadata{
@@ -301,8 +314,10 @@ func (r *Range) Append(entries []raftpb.Entry) error {
 	batch := r.rm.Engine().NewBatch()
 	defer batch.Close()
 
+	// The system bytes of the stats are the change in the log's size.
+	var ms engine.MVCCStats
 	for _, ent := range entries {
-		err := engine.MVCCPutProto(batch, nil, keys.RaftLogKey(r.Desc().RaftID, ent.Index),
+		err := engine.MVCCPutProto(batch, &ms, keys.RaftLogKey(r.Desc().RaftID, ent.Index),
 			proto.ZeroTimestamp, nil, &ent)
 		if err != nil {
 			return err
@@ -312,7 +327,7 @@ func (r *Range) Append(entries []raftpb.Entry) error {
 	prevLastIndex := atomic.LoadUint64(&r.lastIndex)
 	// Delete any previously appended log entries which never committed.
 	for i := lastIndex + 1; i <= prevLastIndex; i++ {
-		err := engine.MVCCDelete(batch, nil,
+		err := engine.MVCCDelete(batch, &ms,
 			keys.RaftLogKey(r.Desc().RaftID, i), proto.ZeroTimestamp, nil)
 		if err != nil {
 			return err
@@ -328,6 +343,7 @@ func (r *Range) Append(entries []raftpb.Entry) error {
 	}
 
 	atomic.StoreUint64(&r.lastIndex, lastIndex)
+	atomic.AddInt64(&r.raftLogSize, ms.SysBytes)
 	return nil
 }
 
@@ -348,6 +364,7 @@ func (r *Range) updateRangeInfo() error {
 		return util.Errorf("failed to lookup zone config for Range %s: %s", r, err)
 	}
 	r.SetMaxBytes(zone.RangeMaxBytes)
+	r.SetMaxQPS(zone.RangeMaxQPS)
 
 	// No need to update configHashes. It will be set when a leader lease calls
 	// maybeGossipConfigs.
@@ -355,7 +372,19 @@ func (r *Range) updateRangeInfo() error {
 	return nil
 }
 
+// snapshotApplyBatchSize is the number of bytes of range data which
+// ApplySnapshot clears or writes per batch.
+var snapshotApplyBatchSize = 1 << 20 // 1 MB
+
 // ApplySnapshot implements the multiraft.WriteableGroupStorage interface.
+//
+// The range's data is replaced in batches of snapshotApplyBatchSize
+// bytes so that a large snapshot is never held in a single batch. The
+// range-ID local data, which holds the replica's raft state, and the
+// range descriptor are only replaced by the final batch, and a marker
+// is persisted while the batches before it are committed. A replica
+// found with the marker on startup has been interrupted while applying
+// a snapshot and is quarantined; see NewRange.
 func (r *Range) ApplySnapshot(snap raftpb.Snapshot) error {
 	snapData := proto.RaftSnapshotData{}
 	err := gogoproto.Unmarshal(snap.Data, &snapData)
@@ -363,46 +392,95 @@ func (r *Range) ApplySnapshot(snap raftpb.Snapshot) error {
 		return err
 	}
 
-	// First, save the HardState.  The HardState must not be changed
-	// because it may record a previous vote cast by this node.
-	hardStateKey := keys.RaftHardStateKey(r.Desc().RaftID)
-	hardState, _, err := engine.MVCCGet(r.rm.Engine(), hardStateKey, proto.ZeroTimestamp, true /* consistent */, nil)
-	if err != nil {
-		return err
-	}
-
 	// Extract the updated range descriptor.
 	desc := snapData.RangeDescriptor
 
-	batch := r.rm.Engine().NewBatch()
-	defer batch.Close()
+	// The HardState must not be changed because it may record a
+	// previous vote cast by this node, so it is neither cleared nor
+	// overwritten by the snapshot.
+	hardStateKey := engine.MVCCEncodeKey(keys.RaftHardStateKey(desc.RaftID))
+	applyKey := keys.RangeSnapshotApplyKey(desc.RaftID)
+	descKey := keys.RangeDescriptorKey(desc.StartKey)
+	rangeIDData := rangeIDKeyRange(desc.RaftID)
+	descData := keyRange{start: engine.MVCCEncodeKey(descKey), end: engine.MVCCEncodeKey(descKey.Next())}
+	isFinal := func(key proto.EncodedKey) bool {
+		return rangeIDData.contains(key) || descData.contains(key)
+	}
+
+	if err := engine.MVCCPutProto(r.rm.Engine(), nil, applyKey, proto.ZeroTimestamp, nil, &desc); err != nil {
+		return err
+	}
 
-	// Delete everything in the range and recreate it from the snapshot.
-	for iter := newRangeDataIterator(&desc, r.rm.Engine()); iter.Valid(); iter.Next() {
-		if err := batch.Clear(iter.Key()); err != nil {
+	// Delete everything in the range and recreate it from the snapshot,
+	// deferring the writes of the final batch.
+	engSnap := r.rm.Engine().NewSnapshot()
+	defer engSnap.Close()
+	w := &chunkedBatch{eng: r.rm.Engine()}
+	defer w.Close()
+	iter := newRangeDataIterator(&desc, engSnap)
+	for ; iter.Valid(); iter.Next() {
+		if isFinal(iter.Key()) {
+			continue
+		}
+		if err := w.Clear(iter.Key()); err != nil {
+			iter.Close()
 			return err
 		}
 	}
+	iter.Close()
 
-	// Write the snapshot into the range.
+	var final []proto.RaftSnapshotData_KeyValue
+	put := func(key proto.EncodedKey, value []byte) error {
+		if key.Equal(hardStateKey) {
+			return nil
+		}
+		if isFinal(key) {
+			final = append(final, proto.RaftSnapshotData_KeyValue{Key: key, Value: value})
+			return nil
+		}
+		return w.Put(key, value)
+	}
 	for _, kv := range snapData.KV {
-		if err := batch.Put(kv.Key, kv.Value); err != nil {
+		if err := put(kv.Key, kv.Value); err != nil {
 			return err
 		}
 	}
-
-	// Restore the saved HardState.
-	if hardState == nil {
-		err := engine.MVCCDelete(batch, nil, hardStateKey, proto.ZeroTimestamp, nil)
+	if snapData.StreamID != 0 {
+		kvs, err := takeSnapshotStream(snapData.StreamID)
 		if err != nil {
 			return err
 		}
-	} else {
-		err := engine.MVCCPut(batch, nil, hardStateKey, proto.ZeroTimestamp, *hardState, nil)
-		if err != nil {
+		defer kvs.Close()
+		if err := decodeSnapshotKVs(kvs, put); err != nil {
+			return util.Errorf("unable to read snapshot data: %s", err)
+		}
+	}
+	if err := w.Commit(); err != nil {
+		return err
+	}
+
+	// The final batch replaces the range-ID local data and the range
+	// descriptor, and clears the marker.
+	batch := r.rm.Engine().NewBatch()
+	defer batch.Close()
+	for _, kr := range []keyRange{rangeIDData, descData} {
+		if err := engSnap.Iterate(kr.start, kr.end, func(kv proto.RawKeyValue) (bool, error) {
+			if proto.EncodedKey(kv.Key).Equal(hardStateKey) {
+				return false, nil
+			}
+			return false, batch.Clear(kv.Key)
+		}); err != nil {
 			return err
 		}
 	}
+	for _, kv := range final {
+		if err := batch.Put(kv.Key, kv.Value); err != nil {
+			return err
+		}
+	}
+	if err := engine.MVCCDelete(batch, nil, applyKey, proto.ZeroTimestamp, nil); err != nil {
+		return err
+	}
 
 	// Read the leader lease.
 	lease, err := loadLeaderLease(batch, desc.RaftID)
@@ -438,20 +516,88 @@ func (r *Range) ApplySnapshot(snap raftpb.Snapshot) error {
 	// the snapshot.
 	atomic.StoreUint64(&r.lastIndex, snap.Metadata.Index)
 	atomic.StoreUint64(&r.appliedIndex, snap.Metadata.Index)
+	raftLogSize, err := loadRaftLogSize(r.rm.Engine(), desc.RaftID)
+	if err != nil {
+		return err
+	}
+	atomic.StoreInt64(&r.raftLogSize, raftLogSize)
 
 	// Atomically update the descriptor and lease.
 	if err := r.setDesc(&desc); err != nil {
 		return err
 	}
+	// The default truncated state depends on the range being
+	// initialized, so it is only read with the new descriptor.
+	truncatedState, err := r.raftTruncatedState()
+	if err != nil {
+		return err
+	}
+	atomic.StoreUint64(&r.truncatedIndex, truncatedState.Index)
 	// Update other fields which are uninitialized or need updating.
 	if err := r.updateRangeInfo(); err != nil {
 		return err
 	}
 
-	atomic.StorePointer(&r.lease, unsafe.Pointer(lease))
+	r.setLease(lease)
 	return nil
 }
 
+// chunkedBatch writes to an engine through a batch which is committed
+// whenever snapshotApplyBatchSize bytes have been cleared or written.
+type chunkedBatch struct {
+	eng   engine.Engine
+	batch engine.Engine
+	size  int
+}
+
+// Put writes the key/value pair to the current batch.
+func (b *chunkedBatch) Put(key proto.EncodedKey, value []byte) error {
+	if b.batch == nil {
+		b.batch = b.eng.NewBatch()
+	}
+	if err := b.batch.Put(key, value); err != nil {
+		return err
+	}
+	return b.maybeCommit(len(key) + len(value))
+}
+
+// Clear clears the key in the current batch.
+func (b *chunkedBatch) Clear(key proto.EncodedKey) error {
+	if b.batch == nil {
+		b.batch = b.eng.NewBatch()
+	}
+	if err := b.batch.Clear(key); err != nil {
+		return err
+	}
+	return b.maybeCommit(len(key))
+}
+
+func (b *chunkedBatch) maybeCommit(n int) error {
+	if b.size += n; b.size < snapshotApplyBatchSize {
+		return nil
+	}
+	return b.Commit()
+}
+
+// Commit commits the current batch, if any.
+func (b *chunkedBatch) Commit() error {
+	if b.batch == nil {
+		return nil
+	}
+	err := b.batch.Commit()
+	b.Close()
+	return err
+}
+
+// Close discards the current batch, if any.
+func (b *chunkedBatch) Close() {
+	if b.batch != nil {
+		b.batch.Close()
+		b.batch = nil
+		b.size = 0
+	}
+}
+
 // SetHardState implements the multiraft.WriteableGroupStorage interface.
 func (r *Range) SetHardState(st raftpb.HardState) error {
 	return engine.MVCCPutProto(r.rm.Engine(), nil, keys.RaftHardStateKey(r.Desc().RaftID),
@@ -348,6 +348,247 @@ func TestRangeReadConsistency(t *testing.T) {
 	if _, err := tc.rng.AddCmd(tc.rng.context(), &gArgs); err != nil {
 		t.Errorf("expected success reading with inconsistent: %s", err)
 	}
+
+	// BOUNDED_STALENESS reads are redirected to the leader unless the
+	// replica has applied the closing of the read timestamp.
+	gArgs.ReadConsistency = proto.BOUNDED_STALENESS
+	setClosed := func(closed proto.Timestamp) {
+		tc.rng.Lock()
+		tc.rng.closed = closed
+		tc.rng.Unlock()
+	}
+	setClosed(gArgs.Timestamp.Prev())
+
+	_, err = tc.rng.AddCmd(tc.rng.context(), &gArgs)
+	if _, ok := err.(*proto.NotLeaderError); !ok {
+		t.Errorf("expected not leader error on stale replica; got %s", err)
+	}
+
+	setClosed(gArgs.Timestamp)
+
+	if _, err := tc.rng.AddCmd(tc.rng.context(), &gArgs); err != nil {
+		t.Errorf("expected success reading with bounded staleness: %s", err)
+	}
+
+	// An intent, which may yet commit below the read timestamp, also
+	// has the read redirected to the leader.
+	txn := newTransaction("test", proto.Key("a"), 1, proto.SERIALIZABLE, tc.clock)
+	txn.Timestamp = gArgs.Timestamp.Prev()
+	if err := engine.MVCCPut(tc.engine, nil, proto.Key("a"), txn.Timestamp, proto.Value{Bytes: []byte("value")}, txn); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = tc.rng.AddCmd(tc.rng.context(), &gArgs)
+	if _, ok := err.(*proto.NotLeaderError); !ok {
+		t.Errorf("expected not leader error on intent; got %s", err)
+	}
+}
+
+// TestRangeCloseTimestamp verifies that closing a timestamp publishes
+// it to the replica, persists it, stays below the timestamps of writes
+// which are underway and moves later writes above it.
+func TestRangeCloseTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	closed := tc.clock.Now()
+	if err := tc.rng.closeTimestamp(closed); err != nil {
+		t.Fatal(err)
+	}
+	if !tc.rng.isFreshAt(closed) || tc.rng.isFreshAt(closed.Next()) {
+		t.Errorf("expected replica to be fresh up to %s", closed)
+	}
+
+	// A write below the closed timestamp is moved above it.
+	pArgs := putArgs([]byte("a"), []byte("value"), 1, tc.store.StoreID())
+	pArgs.Timestamp = closed.Prev()
+	reply, err := tc.rng.AddCmd(tc.rng.context(), &pArgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ts := reply.Header().Timestamp; !closed.Less(ts) {
+		t.Errorf("expected write to be moved above closed timestamp %s; got %s", closed, ts)
+	}
+
+	// No timestamp is closed at or above that of a write underway.
+	inflight := tc.clock.Now()
+	tc.rng.Lock()
+	tc.rng.inflight["write"] = inflight
+	tc.rng.Unlock()
+	tc.manualClock.Increment(10)
+	if err := tc.rng.closeTimestamp(tc.clock.Now()); err != nil {
+		t.Fatal(err)
+	}
+	if !tc.rng.isFreshAt(inflight.Prev()) || tc.rng.isFreshAt(inflight) {
+		t.Errorf("expected replica to be fresh up to just below %s", inflight)
+	}
+
+	// The closed timestamp survives a restart.
+	rng, err := NewRange(tc.rng.Desc(), tc.store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rng.isFreshAt(inflight.Prev()) || rng.isFreshAt(inflight) {
+		t.Errorf("expected reloaded replica to be fresh up to just below %s", inflight)
+	}
+}
+
+// TestStoreCloseTimestampsRequested verifies that only ranges which
+// have served bounded staleness reads close timestamps, and that they
+// close the timestamp the store's lag behind the present.
+func TestStoreCloseTimestampsRequested(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+	tc.store.ctx.ClosedTimestampLag = 5
+
+	// Acquire the leader lease.
+	pArgs := putArgs([]byte("a"), []byte("value"), 1, tc.store.StoreID())
+	if _, err := tc.rng.AddCmd(tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
+	tc.manualClock.Increment(10)
+	closedTimestamp := func() proto.Timestamp {
+		tc.rng.RLock()
+		defer tc.rng.RUnlock()
+		return tc.rng.closed
+	}
+
+	tc.store.closeTimestamps()
+	time.Sleep(10 * time.Millisecond)
+	if closed := closedTimestamp(); !closed.Equal(proto.ZeroTimestamp) {
+		t.Fatalf("expected no timestamp to be closed; got %s", closed)
+	}
+
+	gArgs := getArgs([]byte("a"), 1, tc.store.StoreID())
+	gArgs.ReadConsistency = proto.BOUNDED_STALENESS
+	if _, err := tc.rng.AddCmd(tc.rng.context(), &gArgs); err != nil {
+		t.Fatal(err)
+	}
+	expClosed := proto.Timestamp{WallTime: tc.clock.Now().WallTime - 5}
+	tc.store.closeTimestamps()
+	util.SucceedsWithin(t, time.Second, func() error {
+		if closed := closedTimestamp(); !closed.Equal(expClosed) {
+			return util.Errorf("expected closed timestamp %s; got %s", expClosed, closed)
+		}
+		return nil
+	})
+}
+
+// TestRangeChangeLog verifies that the writes to a range are read
+// from its change log, that a pending intent holds back the resolved
+// timestamp, and that reads from below the start of the log after it
+// has been truncated by GC fall back to scanning the range.
+func TestRangeChangeLog(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	changes := func(start proto.Timestamp) *proto.ChangesResponse {
+		args := &proto.ChangesRequest{
+			RequestHeader: proto.RequestHeader{
+				Key:       proto.Key("a"),
+				EndKey:    proto.Key("z"),
+				Timestamp: tc.clock.Now(),
+				RaftID:    tc.rng.Desc().RaftID,
+				Replica:   proto.Replica{StoreID: tc.store.StoreID()},
+			},
+			StartTimestamp: start,
+		}
+		reply, err := tc.rng.AddCmd(tc.rng.context(), args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reply.(*proto.ChangesResponse)
+	}
+	verify := func(reply *proto.ChangesResponse, expKeys ...string) {
+		if len(reply.Events) != len(expKeys) {
+			t.Fatalf("expected %d events; got %+v", len(expKeys), reply.Events)
+		}
+		for i, e := range reply.Events {
+			if !e.Key.Equal(proto.Key(expKeys[i])) {
+				t.Errorf("%d: expected key %q; got %q", i, expKeys[i], e.Key)
+			}
+		}
+	}
+	put := func(key string, txn *proto.Transaction) proto.Timestamp {
+		pArgs := putArgs([]byte(key), []byte("value"), tc.rng.Desc().RaftID, tc.store.StoreID())
+		pArgs.Timestamp = tc.clock.Now()
+		pArgs.Txn = txn
+		if txn != nil {
+			pArgs.Timestamp = txn.Timestamp
+		}
+		reply, err := tc.rng.AddCmd(tc.rng.context(), &pArgs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reply.Header().Timestamp
+	}
+
+	start := tc.clock.Now()
+	put("a", nil)
+	put("b", nil)
+	txn := newTransaction("test", proto.Key("c"), 1, proto.SERIALIZABLE, tc.clock)
+	put("c", txn)
+	dTS := put("d", nil)
+	if err := tc.rng.closeTimestamp(tc.clock.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The intent on "c" holds back the resolved timestamp, and with it
+	// the later write to "d".
+	reply := changes(start)
+	verify(reply, "a", "b")
+	if !reply.Resolved.Less(txn.Timestamp) {
+		t.Errorf("expected resolved timestamp below %s; got %s", txn.Timestamp, reply.Resolved)
+	}
+
+	// Once the intent is committed, both writes are returned.
+	txn.Status = proto.COMMITTED
+	rArgs := &proto.InternalResolveIntentRequest{
+		RequestHeader: proto.RequestHeader{
+			Timestamp: tc.clock.Now(),
+			Key:       proto.Key("c"),
+			RaftID:    tc.rng.Desc().RaftID,
+			Replica:   proto.Replica{StoreID: tc.store.StoreID()},
+			Txn:       txn,
+		},
+	}
+	if _, err := tc.rng.AddCmd(tc.rng.context(), rArgs); err != nil {
+		t.Fatal(err)
+	}
+	if err := tc.rng.closeTimestamp(tc.clock.Now()); err != nil {
+		t.Fatal(err)
+	}
+	reply = changes(reply.Resolved)
+	verify(reply, "c", "d")
+	if reply.Resolved.Less(dTS) {
+		t.Errorf("expected resolved timestamp at or above %s; got %s", dTS, reply.Resolved)
+	}
+
+	// Truncate the change log; reading from before its new start scans
+	// the range instead.
+	gcArgs := &proto.InternalGCRequest{
+		RequestHeader: proto.RequestHeader{
+			Timestamp: tc.clock.Now(),
+			Key:       tc.rng.Desc().StartKey,
+			EndKey:    tc.rng.Desc().StartKey.Next(),
+			RaftID:    tc.rng.Desc().RaftID,
+			Replica:   proto.Replica{StoreID: tc.store.StoreID()},
+		},
+		ChangeLogThreshold: reply.Resolved.Next(),
+	}
+	if _, err := tc.rng.AddCmd(tc.rng.context(), gcArgs); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := hasChangeLogBefore(tc.engine, tc.rng.Desc().RaftID, gcArgs.ChangeLogThreshold); err != nil || ok {
+		t.Fatalf("expected change log to be truncated; got %t, %v", ok, err)
+	}
+	verify(changes(start), "a", "b", "c", "d")
 }
 
 // TestApplyCmdLeaseError verifies that when during application of a Raft
@@ -603,6 +844,96 @@ func TestRangeTSCacheLowWaterOnLease(t *testing.T) {
 	}
 }
 
+// TestRangeTransferLeaderLease verifies that the holder of the leader
+// lease can hand it over to another replica of the range, but not to a
+// store which holds no replica.
+func TestRangeTransferLeaderLease(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+	tc.manualClock.Increment(100)
+
+	// Add a replica on another store to the range descriptor.
+	newDesc := *tc.rng.Desc()
+	newDesc.Replicas = append(append([]proto.Replica(nil), newDesc.Replicas...), proto.Replica{NodeID: 2, StoreID: 2})
+	if err := tc.rng.setDesc(&newDesc); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := tc.rng.transferLeaderLease(proto.Replica{NodeID: 3, StoreID: 3}); !testutils.IsError(err, "holds no replica") {
+		t.Fatalf("expected transfer to a store without a replica to fail; got %v", err)
+	}
+	if held, _ := hasLease(tc.rng, tc.clock.Now()); !held {
+		t.Fatal("expected lease to remain with the local replica")
+	}
+
+	if err := tc.rng.transferLeaderLease(proto.Replica{NodeID: 2, StoreID: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if lease := tc.rng.getLease(); lease.RaftNodeID != proto.MakeRaftNodeID(2, 2) || !lease.Covers(tc.clock.Now()) {
+		t.Errorf("expected replica on store 2 to hold the lease; got %s", lease)
+	}
+	// The local replica now redirects requests to the new holder.
+	if err := tc.rng.redirectOnOrAcquireLeaderLease(nil, tc.clock.Now()); err == nil {
+		t.Fatal("expected NotLeaderError after transferring the lease")
+	} else if _, ok := err.(*proto.NotLeaderError); !ok {
+		t.Fatalf("expected NotLeaderError; got %s", err)
+	}
+}
+
+// TestRangeTransferLeaderLeaseTSCache verifies that the new holder of
+// a transferred leader lease seeds its timestamp cache with the summary
+// carried by the transfer, rather than moving its low water mark past
+// the expiration of the previous lease.
+func TestRangeTransferLeaderLeaseTSCache(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+	tc.clock.SetMaxOffset(maxClockOffset)
+	tc.manualClock.Increment(int64(DefaultLeaderLeaseDuration + 1))
+
+	now := tc.clock.Now()
+	setLeaderLease(t, tc.rng, &proto.Lease{
+		Start:      now,
+		Expiration: now.Add(int64(DefaultLeaderLeaseDuration), 0),
+		RaftNodeID: proto.MakeRaftNodeID(2, 2),
+	})
+
+	// The replica on store 2 hands the lease back to this replica.
+	readTS := now.Add(10, 0)
+	start := now.Add(20, 0)
+	summary := &proto.TimestampCacheSummary{
+		LowWater: now,
+		Entries: []proto.TimestampCacheEntry{
+			{StartKey: proto.Key("b"), EndKey: proto.Key("c"), Timestamp: readTS, ReadOnly: true},
+		},
+	}
+	args := proto.InternalTransferLeaderLeaseRequest{
+		Lease: proto.Lease{
+			Start:      start,
+			Expiration: start.Add(int64(DefaultLeaderLeaseDuration), 0),
+			RaftNodeID: tc.store.RaftNodeID(),
+		},
+		TimestampCache: summary,
+	}
+	batch := tc.engine.NewBatch()
+	defer batch.Close()
+	if _, err := tc.rng.InternalTransferLeaderLease(batch, &engine.MVCCStats{}, args); err != nil {
+		t.Fatal(err)
+	}
+
+	if rTS, _ := tc.rng.tsCache.GetMax(proto.Key("b"), nil, nil); !rTS.Equal(readTS) {
+		t.Errorf("expected read timestamp %s for key \"b\"; got %s", readTS, rTS)
+	}
+	expiration := now.Add(int64(DefaultLeaderLeaseDuration), 0)
+	if rTS, wTS := tc.rng.tsCache.GetMax(proto.Key("a"), nil, nil); !rTS.Less(expiration) || !wTS.Less(expiration) {
+		t.Errorf("expected timestamps for key \"a\" before the expiration of the previous lease %s; got %s, %s",
+			expiration, rTS, wTS)
+	}
+}
+
 // TestRangeGossipFirstRange verifies that the first range gossips its
 // location and the cluster ID.
 func TestRangeGossipFirstRange(t *testing.T) {
@@ -1779,6 +2110,33 @@ func TestEndTransactionWithErrors(t *testing.T) {
 	}
 }
 
+// TestEndTransactionDeadline verifies that a transaction can't be
+// committed at or after its deadline.
+func TestEndTransactionDeadline(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	for i, deadlineOffset := range []int64{-1, 0, 1} {
+		txn := newTransaction("test", proto.Key(fmt.Sprintf("key-%d", i)), 1, proto.SERIALIZABLE, tc.clock)
+		args := endTxnArgs(txn, true, 1, tc.store.StoreID())
+		args.Timestamp = txn.Timestamp
+		deadline := txn.Timestamp
+		deadline.WallTime += deadlineOffset
+		args.Deadline = &deadline
+
+		_, err := tc.rng.AddCmd(tc.rng.context(), &args)
+		if deadlineOffset > 0 {
+			if err != nil {
+				t.Errorf("%d: unexpected error: %s", i, err)
+			}
+		} else if !testutils.IsError(err, "deadline exceeded") {
+			t.Errorf("%d: expected deadline exceeded error; got %v", i, err)
+		}
+	}
+}
+
 // TestInternalPushTxnBadKey verifies that args.Key equals args.PusheeTxn.ID.
 func TestInternalPushTxnBadKey(t *testing.T) {
 	defer leaktest.AfterTest(t)
@@ -2157,6 +2515,123 @@ func TestRangeResolveIntentRange(t *testing.T) {
 	}
 }
 
+// queueHeader returns a request header spanning the message queue of
+// key identified by prefix.
+func queueHeader(prefix proto.Key, txn *proto.Transaction, timestamp proto.Timestamp, storeID proto.StoreID) proto.RequestHeader {
+	return proto.RequestHeader{
+		Key:       prefix,
+		EndKey:    prefix.PrefixEnd(),
+		Timestamp: timestamp,
+		RaftID:    1,
+		Replica:   proto.Replica{StoreID: storeID},
+		Txn:       txn,
+	}
+}
+
+// TestRangeEnqueueMessageAndReapQueue verifies that messages enqueued
+// to an inbox are reaped in order and removed from the inbox.
+func TestRangeEnqueueMessageAndReapQueue(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	key := proto.Key("a")
+	txn := newTransaction("test", key, 1, proto.SERIALIZABLE, tc.clock)
+	for _, msg := range []string{"msg1", "msg2", "msg3"} {
+		eArgs := &proto.EnqueueMessageRequest{
+			RequestHeader: queueHeader(keys.InboxPrefix(key), txn, txn.Timestamp, tc.store.StoreID()),
+			Msg:           proto.Value{Bytes: []byte(msg)},
+		}
+		if _, err := tc.rng.AddCmd(tc.rng.context(), eArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, expMsgs := range [][]string{{"msg1", "msg2"}, {"msg3"}, nil} {
+		rArgs := &proto.ReapQueueRequest{
+			RequestHeader: queueHeader(keys.InboxPrefix(key), txn, txn.Timestamp, tc.store.StoreID()),
+			MaxResults:    2,
+		}
+		reply, err := tc.rng.AddCmd(tc.rng.context(), rArgs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var msgs []string
+		for _, v := range reply.(*proto.ReapQueueResponse).Messages {
+			msgs = append(msgs, string(v.Bytes))
+		}
+		if !reflect.DeepEqual(msgs, expMsgs) {
+			t.Errorf("%d: expected messages %q; got %q", i, expMsgs, msgs)
+		}
+	}
+
+	// Reaping requires a transaction.
+	rArgs := &proto.ReapQueueRequest{
+		RequestHeader: queueHeader(keys.InboxPrefix(key), nil, tc.clock.Now(), tc.store.StoreID()),
+	}
+	if _, err := tc.rng.AddCmd(tc.rng.context(), rArgs); !testutils.IsError(err, "no transaction specified") {
+		t.Errorf("expected missing transaction error; got %v", err)
+	}
+}
+
+// TestRangeEnqueueUpdate verifies that updates are enqueued to the
+// outbox of the key they modify, where the outbox queue finds them.
+func TestRangeEnqueueUpdate(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	key := proto.Key("a")
+	pArgs := putArgs(key, []byte("value"), 1, tc.store.StoreID())
+	gArgs := getArgs(key, 1, tc.store.StoreID())
+	testCases := []struct {
+		prefix proto.Key
+		update proto.Request
+		expErr string
+	}{
+		{keys.OutboxPrefix(key), &pArgs, ""},
+		{keys.InboxPrefix(key), &pArgs, "does not span"},
+		{keys.OutboxPrefix(proto.Key("b")), &pArgs, "cannot be enqueued to the outbox"},
+		{keys.OutboxPrefix(key), &gArgs, "Get cannot be enqueued"},
+		{keys.OutboxPrefix(key), nil, "no update specified"},
+	}
+	for i, test := range testCases {
+		eArgs := &proto.EnqueueUpdateRequest{
+			RequestHeader: queueHeader(test.prefix, nil, tc.clock.Now(), tc.store.StoreID()),
+		}
+		if test.update != nil && !eArgs.Update.SetValue(test.update) {
+			t.Fatalf("%d: unable to set update %s", i, test.update.Method())
+		}
+		_, err := tc.rng.AddCmd(tc.rng.context(), eArgs)
+		if test.expErr == "" && err != nil {
+			t.Errorf("%d: unexpected error: %s", i, err)
+		} else if test.expErr != "" && !testutils.IsError(err, test.expErr) {
+			t.Errorf("%d: expected error %q; got %v", i, test.expErr, err)
+		}
+	}
+
+	entries, err := outboxEntries(tc.engine, tc.rng.Desc(), tc.clock.Now(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || !keys.KeyAddress(entries[0]).Equal(key) {
+		t.Fatalf("expected one outbox entry for key %q; got %q", key, entries)
+	}
+	kvs, _, err := engine.MVCCScan(tc.engine, keys.OutboxPrefix(key), keys.OutboxPrefix(key).PrefixEnd(), 0, tc.clock.Now(), true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var update proto.RequestUnion
+	if err := gogoproto.Unmarshal(kvs[0].Value.Bytes, &update); err != nil {
+		t.Fatal(err)
+	}
+	if put, ok := update.GetValue().(*proto.PutRequest); !ok || !bytes.Equal(put.Value.Bytes, []byte("value")) {
+		t.Errorf("unexpected enqueued update %+v", update.GetValue())
+	}
+}
+
 func verifyRangeStats(eng engine.Engine, raftID proto.RaftID, expMS engine.MVCCStats, t *testing.T) {
 	var ms engine.MVCCStats
 	if err := engine.MVCCGetRangeStats(eng, raftID, &ms); err != nil {
@@ -2230,6 +2705,136 @@ func TestRangeStatsComputation(t *testing.T) {
 	verifyRangeStats(tc.engine, tc.rng.Desc().RaftID, expMS, t)
 }
 
+// TestRangeImport verifies that an import writes the sorted rows
+// within the key range of the request, keeping MVCC stats as if the
+// rows had been put individually.
+func TestRangeImport(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{
+		bootstrapMode: bootstrapRangeOnly,
+	}
+	tc.Start(t)
+	defer tc.Stop()
+
+	args := proto.ImportRequest{
+		RequestHeader: proto.RequestHeader{
+			Key:       proto.Key("a"),
+			EndKey:    proto.Key("c"),
+			Timestamp: tc.clock.Now(),
+			RaftID:    1,
+			Replica:   proto.Replica{StoreID: tc.store.StoreID()},
+		},
+		Rows: []proto.KeyValue{
+			{Key: proto.Key("a"), Value: proto.Value{Bytes: []byte("value1")}},
+			{Key: proto.Key("b"), Value: proto.Value{Bytes: []byte("value2")}},
+			{Key: proto.Key("c"), Value: proto.Value{Bytes: []byte("value3")}},
+		},
+	}
+	reply, err := tc.rng.AddCmd(tc.rng.context(), &args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := reply.(*proto.ImportResponse).NumImported; n != 2 {
+		t.Errorf("expected 2 rows to be imported; got %d", n)
+	}
+	for _, row := range args.Rows {
+		val, _, err := engine.MVCCGet(tc.engine, row.Key, args.Timestamp, true, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exists := row.Key.Less(args.EndKey); exists != (val != nil) {
+			t.Errorf("%q: expected existence %t; got %+v", row.Key, exists, val)
+		}
+	}
+	expMS := engine.MVCCStats{LiveBytes: 78, KeyBytes: 30, ValBytes: 48, LiveCount: 2, KeyCount: 2, ValCount: 2, SysBytes: 58, SysCount: 1}
+	verifyRangeStats(tc.engine, tc.rng.Desc().RaftID, expMS, t)
+
+	// Rows over existing keys can't be put as a sorted chunk, and are
+	// put one by one instead.
+	args.Timestamp = tc.clock.Now()
+	args.Rows[0].Value.Bytes = []byte("value4")
+	if _, err := tc.rng.AddCmd(tc.rng.context(), &args); err != nil {
+		t.Fatal(err)
+	}
+	val, _, err := engine.MVCCGet(tc.engine, args.Rows[0].Key, args.Timestamp, true, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val == nil || !bytes.Equal(val.Bytes, []byte("value4")) {
+		t.Errorf("expected re-imported value %q; got %+v", "value4", val)
+	}
+
+	// Unsorted rows and transactional imports are rejected.
+	args.Timestamp = tc.clock.Now()
+	args.Rows[0], args.Rows[1] = args.Rows[1], args.Rows[0]
+	if _, err := tc.rng.AddCmd(tc.rng.context(), &args); !testutils.IsError(err, "not sorted") {
+		t.Errorf("expected unsorted rows to be rejected; got %v", err)
+	}
+	args.Rows[0], args.Rows[1] = args.Rows[1], args.Rows[0]
+	args.Txn = newTransaction("test", proto.Key("a"), 1, proto.SERIALIZABLE, tc.clock)
+	if _, err := tc.rng.AddCmd(tc.rng.context(), &args); !testutils.IsError(err, "within a transaction") {
+		t.Errorf("expected transactional import to be rejected; got %v", err)
+	}
+}
+
+// TestRangeSpanStats verifies that SpanStats returns the range's stats
+// for a span covering the range and scans a span which does not.
+func TestRangeSpanStats(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{
+		bootstrapMode: bootstrapRangeOnly,
+	}
+	tc.Start(t)
+	defer tc.Stop()
+
+	// Write "a" twice, so that its first version is GC'able, and "b" once.
+	for _, key := range []string{"a", "a", "b"} {
+		tc.manualClock.Increment(1)
+		pArgs := putArgs([]byte(key), []byte("value"), 1, tc.store.StoreID())
+		pArgs.Timestamp = tc.clock.Now()
+		if _, err := tc.rng.AddCmd(tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	spanStats := func(start, end proto.Key) *proto.SpanStatsResponse {
+		args := proto.SpanStatsRequest{
+			RequestHeader: proto.RequestHeader{
+				Key:       start,
+				EndKey:    end,
+				Timestamp: tc.clock.Now(),
+				RaftID:    1,
+				Replica:   proto.Replica{StoreID: tc.store.StoreID()},
+			},
+		}
+		reply, err := tc.rng.AddCmd(tc.rng.context(), &args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return reply.(*proto.SpanStatsResponse)
+	}
+
+	// A span within the range is scanned.
+	stats := spanStats(proto.Key("a"), proto.Key("b"))
+	if stats.ScannedRangeCount != 1 || stats.RangeCount != 1 {
+		t.Errorf("expected a single scanned range; got %+v", stats)
+	}
+	if stats.LiveCount != 1 || stats.KeyCount != 1 || stats.GCBytes() == 0 || stats.SysCount != 0 {
+		t.Errorf("unexpected stats for [a,b): %+v", stats)
+	}
+
+	// A span covering the range returns the range's stats.
+	stats = spanStats(proto.KeyMin, proto.KeyMax)
+	ms := tc.rng.GetMVCCStats()
+	if stats.ScannedRangeCount != 0 || stats.RangeCount != 1 {
+		t.Errorf("expected a single unscanned range; got %+v", stats)
+	}
+	if stats.LiveBytes != ms.LiveBytes || stats.KeyBytes != ms.KeyBytes || stats.ValBytes != ms.ValBytes ||
+		stats.LiveCount != 2 || stats.SysBytes != ms.SysBytes {
+		t.Errorf("expected range stats %+v; got %+v", ms, stats)
+	}
+}
+
 // TestInternalMerge verifies that the InternalMerge command is behaving as
 // expected. Merge semantics for different data types are tested more robustly
 // at the engine level; this test is intended only to show that values passed to
@@ -2306,6 +2911,16 @@ func TestInternalTruncateLog(t *testing.T) {
 	if firstIndex != indexes[5] {
 		t.Errorf("expected firstIndex == %d, got %d", indexes[5], firstIndex)
 	}
+	// And is read back from the truncated state when the range is loaded.
+	rng, err := NewRange(tc.rng.Desc(), tc.store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firstIndex, err = rng.FirstIndex(); err != nil {
+		t.Fatal(err)
+	} else if firstIndex != indexes[5] {
+		t.Errorf("expected reloaded firstIndex == %d, got %d", indexes[5], firstIndex)
+	}
 
 	// We can still get what remains of the log.
 	entries, err := tc.rng.Entries(indexes[5], indexes[9], math.MaxUint64)
@@ -2763,3 +3378,67 @@ func TestRequestLeaderEncounterGroupDeleteError(t *testing.T) {
 		t.Fatalf("expected a RangeNotFoundError, get %s", err)
 	}
 }
+
+// TestRangeApplySnapshotInBatches verifies that a snapshot applied in
+// several batches replaces the range's data and clears the marker of
+// the apply, and that a replica found with the marker when it is loaded
+// is quarantined.
+func TestRangeApplySnapshotInBatches(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+	defer func(size int) { snapshotApplyBatchSize = size }(snapshotApplyBatchSize)
+	snapshotApplyBatchSize = 1
+
+	for _, key := range []string{"a", "b", "c"} {
+		pArgs := putArgs([]byte(key), []byte("value-"+key), 1, tc.store.StoreID())
+		if _, err := tc.rng.AddCmd(tc.rng.context(), &pArgs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	snap, err := tc.rng.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Write a key which the snapshot does not contain.
+	pArgs := putArgs([]byte("d"), []byte("value-d"), 1, tc.store.StoreID())
+	if _, err := tc.rng.AddCmd(tc.rng.context(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
+	if err := tc.rng.ApplySnapshot(snap); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		val, _, err := engine.MVCCGet(tc.engine, proto.Key(key), tc.clock.Now(), true, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if exists := key != "d"; exists != (val != nil) {
+			t.Errorf("%q: expected existence %t after applying snapshot; got %+v", key, exists, val)
+		} else if exists && !bytes.Equal(val.Bytes, []byte("value-"+key)) {
+			t.Errorf("%q: expected value %q; got %q", key, "value-"+key, val.Bytes)
+		}
+	}
+	applyKey := keys.RangeSnapshotApplyKey(tc.rng.Desc().RaftID)
+	if ok, err := engine.MVCCGetProto(tc.engine, applyKey, proto.ZeroTimestamp, true, nil, nil); err != nil || ok {
+		t.Errorf("expected the snapshot apply marker to be cleared; got %t, %v", ok, err)
+	}
+	if tc.rng.isQuarantined() {
+		t.Error("expected replica not to be quarantined")
+	}
+
+	// A replica loaded with the marker, as after a restart while
+	// applying a snapshot, is quarantined.
+	if err := engine.MVCCPutProto(tc.engine, nil, applyKey, proto.ZeroTimestamp, nil, tc.rng.Desc()); err != nil {
+		t.Fatal(err)
+	}
+	rng, err := NewRange(tc.rng.Desc(), tc.store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rng.isQuarantined() {
+		t.Error("expected replica interrupted while applying a snapshot to be quarantined")
+	}
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/gossip"
 	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/hlc"
 	"github.com/cockroachdb/cockroach/util/log"
 )
@@ -73,21 +74,113 @@ func (rq *replicateQueue) shouldQueue(now proto.Timestamp, rng *Range) (
 		return
 	}
 
-	return rq.needsReplication(zone, rng)
+	action, priority, _ := rq.needsReplication(zone, rng)
+	return action != replicateNone, priority
 }
 
-func (rq *replicateQueue) needsReplication(zone proto.ZoneConfig, rng *Range) (bool, float64) {
-	// TODO(bdarnell): handle non-empty ReplicaAttrs.
+// replicateAction is the change to the replicas of a range which the
+// replicate queue makes next.
+type replicateAction int
+
+const (
+	replicateNone replicateAction = iota
+	// replicateAdd adds a replica to an under-replicated range.
+	replicateAdd
+	// replicateRemove removes a replica from an over-replicated range.
+	replicateRemove
+	// replicateRepair adds a replica satisfying an unmet attribute
+	// constraint to a range with a replica which satisfies none, for
+	// instance because the attributes of its store have changed. The
+	// range is then over-replicated, and the latter replica is removed
+	// when the range is processed again.
+	replicateRepair
+	// replicateRebalance adds a replica on a less loaded store to a
+	// range with a replica on an overloaded store. The range is then
+	// over-replicated, and the replica on the most loaded store is
+	// removed when the range is processed again.
+	replicateRebalance
+)
+
+// needsReplication returns the change to make to the replicas of the
+// range and the priority with which to make it, along with the match
+// of the replicas against the attribute constraints of the zone.
+// Ranges with quarantined, missing, superfluous or misplaced replicas
+// take precedence over ranges to rebalance.
+func (rq *replicateQueue) needsReplication(zone proto.ZoneConfig, rng *Range) (
+	replicateAction, float64, constraintMatch) {
+	desc := rng.Desc()
+	var healthy, quarantined []proto.Replica
+	for _, replica := range desc.Replicas {
+		if rq.allocator.IsQuarantined(replica, desc.RaftID) {
+			quarantined = append(quarantined, replica)
+		} else {
+			healthy = append(healthy, replica)
+		}
+	}
+	m := rq.allocator.MatchConstraints(zone.ReplicaAttrs, healthy)
 	need := len(zone.ReplicaAttrs)
-	have := len(rng.Desc().Replicas)
+	have := len(desc.Replicas)
+	if need == 0 {
+		return replicateNone, 0, m
+	}
+	if len(quarantined) > 0 {
+		// Quarantined replicas count as missing until they have been
+		// replaced, so that the range doesn't lose redundancy while
+		// their copies are removed.
+		if log.V(1) {
+			log.Infof("%s has %d quarantined replicas", rng, len(quarantined))
+		}
+		if need > len(healthy) {
+			return replicateAdd, float64(len(quarantined) + need - len(healthy)), m
+		}
+		m.removable = quarantined
+		return replicateRemove, float64(len(quarantined)), m
+	}
 	if need > have {
 		if log.V(1) {
 			log.Infof("%s needs %d nodes; has %d", rng, need, have)
 		}
-		return true, float64(need - have)
+		return replicateAdd, float64(need - have), m
+	}
+	if need < have {
+		if log.V(1) {
+			log.Infof("%s needs %d nodes; has %d", rng, need, have)
+		}
+		return replicateRemove, float64(have - need), m
+	}
+	if len(m.unmet) > 0 {
+		if log.V(1) {
+			log.Infof("%s has no replicas satisfying %s", rng, m.unmet)
+		}
+		return replicateRepair, float64(len(m.unmet)), m
 	}
+	if _, ok := rq.needsRebalance(rng, m); ok {
+		return replicateRebalance, 0, m
+	}
+	return replicateNone, 0, m
+}
 
-	return false, 0
+// needsRebalance returns whether any replica of the range other than
+// the leader's is on a store which is overloaded according to the
+// cluster mean, along with the attribute constraint which a new
+// replica taking its place must satisfy. The leader's replica is never
+// removed, so rebalancing on its behalf would be futile.
+func (rq *replicateQueue) needsRebalance(rng *Range, m constraintMatch) (proto.Attributes, bool) {
+	leader := rng.rm.StoreID()
+	for _, replica := range rng.Desc().Replicas {
+		attrs, ok := m.constraints[replica.StoreID]
+		if replica.StoreID == leader || !ok {
+			continue
+		}
+		s, err := storeDescFromGossip(gossip.MakeCapacityKey(replica.NodeID, replica.StoreID), rq.gossip)
+		if err != nil {
+			continue
+		}
+		if rq.allocator.ShouldRebalance(s) {
+			return attrs, true
+		}
+	}
+	return proto.Attributes{}, false
 }
 
 func (rq *replicateQueue) process(now proto.Timestamp, rng *Range) error {
@@ -96,29 +189,89 @@ func (rq *replicateQueue) process(now proto.Timestamp, rng *Range) error {
 		return err
 	}
 
-	if needs, _ := rq.needsReplication(zone, rng); !needs {
+	desc := rng.Desc()
+	switch action, _, m := rq.needsReplication(zone, rng); action {
+	case replicateAdd, replicateRepair:
+		// When adding a missing replica, allow constraints to be relaxed
+		// if necessary, as more redundancy is better than less. Repairs
+		// only make sense with a replica that satisfies the constraint.
+		newReplica, err := rq.allocator.AllocateTarget(m.unmet[0], desc.Replicas, action == replicateAdd)
+		if err != nil {
+			return err
+		}
+		if err := rq.addReplica(rng, newReplica); err != nil {
+			return err
+		}
+	case replicateRemove:
+		removeReplica, err := rq.allocator.RemoveTarget(m.removable, desc.Replicas, rng.rm.StoreID())
+		if err != nil {
+			return err
+		}
+		if removeReplica.StoreID == rng.rm.StoreID() {
+			// The leader's own replica is to be removed; hand the leader
+			// lease over to a replica which stays first. The removal
+			// below is carried out by a transaction, which doesn't need
+			// the local replica to hold the lease.
+			target, ok := leaseTransferTarget(desc.Replicas, m.removable, rng.rm.StoreID())
+			if !ok {
+				return util.Errorf("unable to remove replica %+v from %s: no replica to transfer the leader lease to",
+					removeReplica, rng)
+			}
+			log.Infof("transferring leader lease of %s to store %d to remove the local replica", rng, target.StoreID)
+			if err := rng.transferLeaderLease(target); err != nil {
+				return err
+			}
+		}
+		if log.V(1) {
+			log.Infof("removing replica %+v from %s", removeReplica, rng)
+		}
+		if err := rng.ChangeReplicas(proto.REMOVE_REPLICA, removeReplica); err != nil {
+			return err
+		}
+	case replicateRebalance:
+		// Rebalance targets are chosen at random from the stores
+		// sufficiently below the mean; if none of those considered
+		// qualifies, leave the range alone until it's processed again.
+		attrs, _ := rq.needsRebalance(rng, m)
+		newReplica := rq.allocator.RebalanceTarget(attrs, desc.Replicas)
+		if newReplica == nil {
+			return nil
+		}
+		if err := rq.addReplica(rng, newReplica); err != nil {
+			return err
+		}
+	default:
 		// Something changed between shouldQueue and process.
 		return nil
 	}
 
-	// TODO(bdarnell): handle non-homogenous ReplicaAttrs.
-	// Allow constraints to be relaxed if necessary.
-	newReplica, err := rq.allocator.AllocateTarget(zone.ReplicaAttrs[0], rng.Desc().Replicas, true)
-	if err != nil {
-		return err
-	}
+	// Enqueue this range again to see if there are more changes to be made.
+	go rq.MaybeAdd(rng, rq.clock.Now())
+	return nil
+}
 
+// addReplica adds a replica on the specified store to the range.
+func (rq *replicateQueue) addReplica(rng *Range, store *proto.StoreDescriptor) error {
 	replica := proto.Replica{
-		NodeID:  newReplica.Node.NodeID,
-		StoreID: newReplica.StoreID,
+		NodeID:  store.Node.NodeID,
+		StoreID: store.StoreID,
 	}
-	if err = rng.ChangeReplicas(proto.ADD_REPLICA, replica); err != nil {
-		return err
+	if log.V(1) {
+		log.Infof("adding replica %+v to %s", replica, rng)
 	}
+	return rng.ChangeReplicas(proto.ADD_REPLICA, replica)
+}
 
-	// Enqueue this range again to see if there are more changes to be made.
-	go rq.MaybeAdd(rng, rq.clock.Now())
-	return nil
+// leaseTransferTarget returns a replica on a store other than the
+// local one whose replica isn't slated for removal, to which the
+// leader lease can be transferred.
+func leaseTransferTarget(replicas, removable []proto.Replica, localStoreID proto.StoreID) (proto.Replica, bool) {
+	for _, replica := range replicas {
+		if replica.StoreID != localStoreID && !containsStore(removable, replica.StoreID) {
+			return replica, true
+		}
+	}
+	return proto.Replica{}, false
 }
 
 func (rq *replicateQueue) timer() time.Duration {
@@ -0,0 +1,276 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/storage/engine"
+	"github.com/cockroachdb/cockroach/util"
+	"github.com/cockroachdb/cockroach/util/log"
+	gogoproto "github.com/gogo/protobuf/proto"
+)
+
+// The key/value pairs of a raft snapshot are not carried in the
+// snapshot's data, which would hold all of the range's data in memory
+// while the snapshot is sent and applied. Instead, Snapshot() registers
+// a snapshot stream which encodes them from an iterator over the engine
+// snapshot it was taken from, and the data only records the stream's
+// ID. A transport sending the snapshot to another process reads the
+// stream in chunks; the receiving transport stages the chunks and
+// registers a stream reading back the staged data, which ApplySnapshot
+// decodes one key/value pair at a time. A transport which drops a
+// snapshot message releases its stream with DiscardSnapshotData.
+//
+// A stream is encoded like the repeated KV field of RaftSnapshotData
+// and may be read only once.
+
+// snapshotStreamTimeout is the duration after which a snapshot stream
+// which has not been read is discarded. Raft drops snapshot messages
+// which are no longer needed, so not every stream is read. Streams are
+// expired by each store; see Store.Start.
+const snapshotStreamTimeout = time.Minute
+
+// raftSnapshotDataKVTag is the encoded key of an element of the KV
+// field of RaftSnapshotData: field number 2 with the length-delimited
+// wire type.
+const raftSnapshotDataKVTag = 2<<3 | 2
+
+type snapshotStream struct {
+	kvs        io.ReadCloser
+	owner      engine.Engine // The engine the stream reads from, if any
+	registered time.Time
+}
+
+// snapshotStreams holds the snapshot streams registered in this
+// process by ID.
+var snapshotStreams = struct {
+	sync.Mutex
+	nextID  uint64
+	streams map[uint64]snapshotStream
+}{streams: map[uint64]snapshotStream{}}
+
+// registerSnapshotStream registers the encoded key/value pairs read
+// from kvs as a snapshot stream and returns its ID. If the stream reads
+// from an engine, owner is that engine; see closeSnapshotStreams.
+func registerSnapshotStream(kvs io.ReadCloser, owner engine.Engine) uint64 {
+	snapshotStreams.Lock()
+	defer snapshotStreams.Unlock()
+	snapshotStreams.nextID++
+	id := snapshotStreams.nextID
+	snapshotStreams.streams[id] = snapshotStream{kvs: kvs, owner: owner, registered: time.Now()}
+	return id
+}
+
+// takeSnapshotStream unregisters and returns the snapshot stream with
+// the given ID. The caller must close it.
+func takeSnapshotStream(id uint64) (io.ReadCloser, error) {
+	snapshotStreams.Lock()
+	defer snapshotStreams.Unlock()
+	s, ok := snapshotStreams.streams[id]
+	if !ok {
+		return nil, util.Errorf("snapshot stream %d not found", id)
+	}
+	delete(snapshotStreams.streams, id)
+	return s.kvs, nil
+}
+
+// closeSnapshotStreams discards the snapshot streams reading from the
+// given engine, which is about to be closed.
+func closeSnapshotStreams(owner engine.Engine) {
+	snapshotStreams.Lock()
+	defer snapshotStreams.Unlock()
+	for id, s := range snapshotStreams.streams {
+		if s.owner == owner {
+			delete(snapshotStreams.streams, id)
+			s.kvs.Close()
+		}
+	}
+}
+
+// expireSnapshotStreams discards the snapshot streams which have not
+// been read within snapshotStreamTimeout of the given time.
+func expireSnapshotStreams(now time.Time) {
+	snapshotStreams.Lock()
+	defer snapshotStreams.Unlock()
+	for id, s := range snapshotStreams.streams {
+		if now.Sub(s.registered) > snapshotStreamTimeout {
+			log.Warningf("discarding snapshot stream %d: not read since %s", id, s.registered)
+			delete(snapshotStreams.streams, id)
+			s.kvs.Close()
+		}
+	}
+}
+
+// DiscardSnapshotData releases the snapshot stream the given raft
+// snapshot data refers to, if any. It is called by transports which
+// drop a snapshot message instead of delivering it.
+func DiscardSnapshotData(data []byte) {
+	var snapData proto.RaftSnapshotData
+	if err := gogoproto.Unmarshal(data, &snapData); err != nil || snapData.StreamID == 0 {
+		return
+	}
+	if kvs, err := takeSnapshotStream(snapData.StreamID); err == nil {
+		kvs.Close()
+	}
+}
+
+// OpenSnapshotData returns the given raft snapshot data without its
+// key/value pairs, and a reader of the encoded key/value pairs. If the
+// data refers to a snapshot stream, the stream is unregistered; the
+// caller must close the returned reader.
+func OpenSnapshotData(data []byte) ([]byte, io.ReadCloser, error) {
+	var snapData proto.RaftSnapshotData
+	if err := gogoproto.Unmarshal(data, &snapData); err != nil {
+		return nil, nil, err
+	}
+	var kvs io.ReadCloser
+	if snapData.StreamID != 0 {
+		var err error
+		if kvs, err = takeSnapshotStream(snapData.StreamID); err != nil {
+			return nil, nil, err
+		}
+	} else {
+		var buf []byte
+		for _, kv := range snapData.KV {
+			var err error
+			if buf, err = appendSnapshotKV(buf, kv.Key, kv.Value); err != nil {
+				return nil, nil, err
+			}
+		}
+		kvs = ioutil.NopCloser(bytes.NewReader(buf))
+	}
+	snapData.KV = nil
+	snapData.StreamID = 0
+	header, err := gogoproto.Marshal(&snapData)
+	if err != nil {
+		kvs.Close()
+		return nil, nil, err
+	}
+	return header, kvs, nil
+}
+
+// NewSnapshotData registers the encoded key/value pairs read from kvs
+// as a snapshot stream and returns the raft snapshot data made of the
+// given header, as returned by OpenSnapshotData, and the stream. The
+// stream takes ownership of kvs.
+func NewSnapshotData(header []byte, kvs io.ReadCloser) ([]byte, error) {
+	var snapData proto.RaftSnapshotData
+	if err := gogoproto.Unmarshal(header, &snapData); err != nil {
+		kvs.Close()
+		return nil, err
+	}
+	snapData.StreamID = registerSnapshotStream(kvs, nil)
+	return gogoproto.Marshal(&snapData)
+}
+
+// appendSnapshotKV appends the encoding of a key/value pair as an
+// element of the KV field of RaftSnapshotData to buf.
+func appendSnapshotKV(buf []byte, key, value []byte) ([]byte, error) {
+	kv := proto.RaftSnapshotData_KeyValue{Key: key, Value: value}
+	kvData, err := kv.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	buf = append(buf, raftSnapshotDataKVTag)
+	buf = append(buf, gogoproto.EncodeVarint(uint64(len(kvData)))...)
+	return append(buf, kvData...), nil
+}
+
+// decodeSnapshotKVs decodes the encoded key/value pairs read from r,
+// calling f with each of them in turn.
+func decodeSnapshotKVs(r io.Reader, f func(key proto.EncodedKey, value []byte) error) error {
+	br := bufio.NewReader(r)
+	var buf []byte
+	for {
+		tag, err := br.ReadByte()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if tag != raftSnapshotDataKVTag {
+			return util.Errorf("unexpected tag %d in snapshot data", tag)
+		}
+		n, err := binary.ReadUvarint(br)
+		if err != nil {
+			return err
+		}
+		if uint64(cap(buf)) < n {
+			buf = make([]byte, n)
+		}
+		buf = buf[:n]
+		if _, err := io.ReadFull(br, buf); err != nil {
+			return err
+		}
+		var kv proto.RaftSnapshotData_KeyValue
+		if err := kv.Unmarshal(buf); err != nil {
+			return err
+		}
+		if err := f(kv.Key, kv.Value); err != nil {
+			return err
+		}
+	}
+}
+
+// snapshotKVReader encodes the key/value pairs of a range from an
+// engine snapshot as they are read. The snapshot is closed along with
+// the reader.
+type snapshotKVReader struct {
+	snap engine.Engine
+	desc *proto.RangeDescriptor
+	iter *rangeDataIterator // Created on the first read
+	buf  []byte             // The encoded key/value pair being read
+}
+
+// Read implements io.Reader.
+func (r *snapshotKVReader) Read(p []byte) (int, error) {
+	if r.iter == nil {
+		r.iter = newRangeDataIterator(r.desc, r.snap)
+	}
+	for len(r.buf) == 0 {
+		if !r.iter.Valid() {
+			if err := r.iter.Error(); err != nil {
+				return 0, err
+			}
+			return 0, io.EOF
+		}
+		var err error
+		if r.buf, err = appendSnapshotKV(r.buf[:0], r.iter.Key(), r.iter.Value()); err != nil {
+			return 0, err
+		}
+		r.iter.Next()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Close implements io.Closer.
+func (r *snapshotKVReader) Close() error {
+	if r.iter != nil {
+		r.iter.Close()
+	}
+	r.snap.Close()
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2015 The Cockroach Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+// implied. See the License for the specific language governing
+// permissions and limitations under the License. See the AUTHORS file
+// for names of contributors.
+
+package storage
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util/leaktest"
+	gogoproto "github.com/gogo/protobuf/proto"
+)
+
+// closeRecorder is a snapshot stream which records whether it has
+// been closed.
+type closeRecorder struct {
+	*bytes.Reader
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return nil
+}
+
+// TestExpireSnapshotStreams verifies that only streams which have not
+// been read within snapshotStreamTimeout are expired, and that expired
+// streams are closed.
+func TestExpireSnapshotStreams(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	kvs := &closeRecorder{Reader: bytes.NewReader(nil)}
+	id := registerSnapshotStream(kvs, nil)
+
+	expireSnapshotStreams(time.Now())
+	if kvs.closed {
+		t.Fatal("expected stream not to be expired before the timeout")
+	}
+	expireSnapshotStreams(time.Now().Add(2 * snapshotStreamTimeout))
+	if !kvs.closed {
+		t.Error("expected stream to be closed after the timeout")
+	}
+	if _, err := takeSnapshotStream(id); err == nil {
+		t.Error("expected expired stream to be unregistered")
+	}
+}
+
+// TestDiscardSnapshotData verifies that discarding the data of a
+// dropped snapshot message closes and unregisters its stream.
+func TestDiscardSnapshotData(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	header, err := gogoproto.Marshal(&proto.RaftSnapshotData{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kvs := &closeRecorder{Reader: bytes.NewReader(nil)}
+	data, err := NewSnapshotData(header, kvs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	DiscardSnapshotData(data)
+	if !kvs.closed {
+		t.Error("expected stream to be closed")
+	}
+	if _, _, err := OpenSnapshotData(data); err == nil {
+		t.Error("expected discarded stream to be unregistered")
+	}
+
+	// Data which carries its key/value pairs has no stream to discard.
+	DiscardSnapshotData(header)
+	_, r, err := OpenSnapshotData(header)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+}
@@ -19,11 +19,13 @@ package storage
 
 import (
 	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/cockroachdb/cockroach/client"
 	"github.com/cockroachdb/cockroach/gossip"
 	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/storage/engine"
 	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/log"
 )
@@ -36,7 +38,8 @@ const (
 )
 
 // splitQueue manages a queue of ranges slated to be split due to size
-// or along intersecting accounting or zone config boundaries.
+// or request rate, or along intersecting accounting or zone config
+// boundaries.
 type splitQueue struct {
 	*baseQueue
 	db     *client.DB
@@ -60,7 +63,7 @@ func (sq *splitQueue) needsLeaderLease() bool {
 // shouldQueue determines whether a range should be queued for
 // splitting. This is true if the range is intersected by any
 // accounting or zone config prefix or if the range's size in
-// bytes exceeds the limit for the zone.
+// bytes or request rate exceeds the limit for the zone.
 func (sq *splitQueue) shouldQueue(now proto.Timestamp, rng *Range) (shouldQ bool, priority float64) {
 	// Set priority to 1 in the event the range is split by acct or zone configs.
 	if len(computeSplitKeys(sq.gossip, rng)) > 0 {
@@ -79,6 +82,15 @@ func (sq *splitQueue) shouldQueue(now proto.Timestamp, rng *Range) (shouldQ bool
 		priority += ratio
 		shouldQ = true
 	}
+
+	// Add priority based on the request rate of the range compared to
+	// the max rate for the zone it's in.
+	if zone.RangeMaxQPS > 0 {
+		if ratio := rng.load.QPS(now.WallTime) / float64(zone.RangeMaxQPS); ratio > 1 {
+			priority += ratio
+			shouldQ = true
+		}
+	}
 	return
 }
 
@@ -102,12 +114,44 @@ func (sq *splitQueue) process(now proto.Timestamp, rng *Range) error {
 	}
 	// FIXME: why is this implementation not the same as the one above?
 	if float64(rng.stats.GetSize())/float64(zone.RangeMaxBytes) > 1 {
-		log.Infof("splitting %s size=%d max=%d", rng, rng.stats.GetSize(), zone.RangeMaxBytes)
+		// The split key is looked up here rather than by AdminSplit so
+		// that writes aren't backpressured on the size of a range which
+		// no split would relieve.
+		desc := rng.Desc()
+		snap := rng.rm.NewSnapshot()
+		splitKey, err := engine.MVCCFindSplitKey(snap, desc.RaftID, desc.StartKey, desc.EndKey)
+		snap.Close()
+		if err != nil {
+			atomic.StoreInt32(&rng.noSplitKey, 1)
+			return util.Errorf("unable to find a key to split %s at: %s", rng, err)
+		}
+		atomic.StoreInt32(&rng.noSplitKey, 0)
+		log.Infof("splitting %s at key %s size=%d max=%d", rng, splitKey, rng.stats.GetSize(), zone.RangeMaxBytes)
 		if _, err = rng.AddCmd(rng.context(), &proto.AdminSplitRequest{
-			RequestHeader: proto.RequestHeader{Key: rng.Desc().StartKey},
+			RequestHeader: proto.RequestHeader{Key: desc.StartKey},
+			SplitKey:      splitKey,
 		}); err != nil {
 			return err
 		}
+		return nil
+	}
+	// Finally handle case of splitting due to request rate, at a key
+	// dividing the sampled requests in half.
+	if zone.RangeMaxQPS > 0 {
+		if qps := rng.load.QPS(now.WallTime); qps > float64(zone.RangeMaxQPS) {
+			splitKey := rng.load.splitKey(rng.Desc().StartKey)
+			if splitKey == nil {
+				log.Infof("unable to find a key dividing the load of %s qps=%.1f", rng, qps)
+				return nil
+			}
+			log.Infof("splitting %s at key %s qps=%.1f max=%d", rng, splitKey, qps, zone.RangeMaxQPS)
+			if _, err = rng.AddCmd(rng.context(), &proto.AdminSplitRequest{
+				RequestHeader: proto.RequestHeader{Key: rng.Desc().StartKey},
+				SplitKey:      splitKey,
+			}); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -106,6 +106,55 @@ func TestSplitQueueShouldQueue(t *testing.T) {
 	}
 }
 
+// TestSplitQueueShouldQueueLoad verifies that shouldQueue queues a
+// range whose request rate exceeds the max for its zone.
+func TestSplitQueueShouldQueueLoad(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	zoneMap, err := NewPrefixConfigMap([]*PrefixConfig{
+		{proto.KeyMin, nil, &proto.ZoneConfig{RangeMaxBytes: 64 << 20, RangeMaxQPS: 100}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tc.gossip.AddInfo(gossip.KeyConfigZone, zoneMap, 0*time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	window := loadStatsWindow.Nanoseconds()
+	testCases := []struct {
+		requests int
+		shouldQ  bool
+		priority float64
+	}{
+		// No requests.
+		{0, false, 0},
+		// Max request rate.
+		{1000, false, 0},
+		// Max request rate * 2.
+		{2000, true, 2},
+	}
+
+	splitQ := newSplitQueue(nil, tc.gossip)
+
+	for i, test := range testCases {
+		tc.rng.load.reset(0)
+		for j := 0; j < test.requests; j++ {
+			tc.rng.load.record(0, proto.Key("a"), 0)
+		}
+		shouldQ, priority := splitQ.shouldQueue(makeTS(window, 0), tc.rng)
+		if shouldQ != test.shouldQ {
+			t.Errorf("%d: should queue expected %t; got %t", i, test.shouldQ, shouldQ)
+		}
+		if math.Abs(priority-test.priority) > 0.00001 {
+			t.Errorf("%d: priority expected %f; got %f", i, test.priority, priority)
+		}
+	}
+}
+
 ////
 // NOTE: tests which actually verify processing of the split queue are
 // in client_split_test.go, which is in a different test package in
@@ -107,6 +107,14 @@ func (rs *rangeStats) GetAvgIntentAge(nowNanos int64) float64 {
 	return float64(advancedIntentAge) / float64(rs.IntentCount)
 }
 
+// GetGCBytes returns the number of gc'able bytes, which includes all
+// non-live keys and versioned values.
+func (rs *rangeStats) GetGCBytes() int64 {
+	rs.Lock()
+	defer rs.Unlock()
+	return rs.KeyBytes + rs.ValBytes - rs.LiveBytes
+}
+
 // GetGCBytesAge returns the total age of outstanding gc'able
 // bytes, based on current wall time specified via nowNanos.
 func (rs *rangeStats) GetGCBytesAge(nowNanos int64) int64 {
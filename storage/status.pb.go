@@ -32,16 +32,17 @@ var _ = math.Inf
 // StoreStatus contains the stats needed to calculate the current status of a
 // store.
 type StoreStatus struct {
-	Desc                 cockroach_proto.StoreDescriptor               `protobuf:"bytes,1,opt,name=desc" json:"desc"`
-	NodeID               github_com_cockroachdb_cockroach_proto.NodeID `protobuf:"varint,2,opt,name=node_id,casttype=github.com/cockroachdb/cockroach/proto.NodeID" json:"node_id"`
-	RangeCount           int32                                         `protobuf:"varint,3,opt,name=range_count" json:"range_count"`
-	StartedAt            int64                                         `protobuf:"varint,4,opt,name=started_at" json:"started_at"`
-	UpdatedAt            int64                                         `protobuf:"varint,5,opt,name=updated_at" json:"updated_at"`
-	Stats                cockroach_storage_engine.MVCCStats            `protobuf:"bytes,6,opt,name=stats" json:"stats"`
-	LeaderRangeCount     int32                                         `protobuf:"varint,7,opt,name=leader_range_count" json:"leader_range_count"`
-	ReplicatedRangeCount int32                                         `protobuf:"varint,8,opt,name=replicated_range_count" json:"replicated_range_count"`
-	AvailableRangeCount  int32                                         `protobuf:"varint,9,opt,name=available_range_count" json:"available_range_count"`
-	XXX_unrecognized     []byte                                        `json:"-"`
+	Desc                  cockroach_proto.StoreDescriptor               `protobuf:"bytes,1,opt,name=desc" json:"desc"`
+	NodeID                github_com_cockroachdb_cockroach_proto.NodeID `protobuf:"varint,2,opt,name=node_id,casttype=github.com/cockroachdb/cockroach/proto.NodeID" json:"node_id"`
+	RangeCount            int32                                         `protobuf:"varint,3,opt,name=range_count" json:"range_count"`
+	StartedAt             int64                                         `protobuf:"varint,4,opt,name=started_at" json:"started_at"`
+	UpdatedAt             int64                                         `protobuf:"varint,5,opt,name=updated_at" json:"updated_at"`
+	Stats                 cockroach_storage_engine.MVCCStats            `protobuf:"bytes,6,opt,name=stats" json:"stats"`
+	LeaderRangeCount      int32                                         `protobuf:"varint,7,opt,name=leader_range_count" json:"leader_range_count"`
+	ReplicatedRangeCount  int32                                         `protobuf:"varint,8,opt,name=replicated_range_count" json:"replicated_range_count"`
+	AvailableRangeCount   int32                                         `protobuf:"varint,9,opt,name=available_range_count" json:"available_range_count"`
+	QuarantinedRangeCount int32                                         `protobuf:"varint,10,opt,name=quarantined_range_count" json:"quarantined_range_count"`
+	XXX_unrecognized      []byte                                        `json:"-"`
 }
 
 func (m *StoreStatus) Reset()         { *m = StoreStatus{} }
@@ -104,6 +105,13 @@ func (m *StoreStatus) GetAvailableRangeCount() int32 {
 	return 0
 }
 
+func (m *StoreStatus) GetQuarantinedRangeCount() int32 {
+	if m != nil {
+		return m.QuarantinedRangeCount
+	}
+	return 0
+}
+
 func init() {
 }
 func (m *StoreStatus) Unmarshal(data []byte) error {
@@ -278,6 +286,21 @@ func (m *StoreStatus) Unmarshal(data []byte) error {
 					break
 				}
 			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QuarantinedRangeCount", wireType)
+			}
+			for shift := uint(0); ; shift += 7 {
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := data[iNdEx]
+				iNdEx++
+				m.QuarantinedRangeCount |= (int32(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			var sizeOfWire int
 			for {
@@ -400,6 +423,7 @@ func (m *StoreStatus) Size() (n int) {
 	n += 1 + sovStatus(uint64(m.LeaderRangeCount))
 	n += 1 + sovStatus(uint64(m.ReplicatedRangeCount))
 	n += 1 + sovStatus(uint64(m.AvailableRangeCount))
+	n += 1 + sovStatus(uint64(m.QuarantinedRangeCount))
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -471,6 +495,9 @@ func (m *StoreStatus) MarshalTo(data []byte) (n int, err error) {
 	data[i] = 0x48
 	i++
 	i = encodeVarintStatus(data, i, uint64(m.AvailableRangeCount))
+	data[i] = 0x50
+	i++
+	i = encodeVarintStatus(data, i, uint64(m.QuarantinedRangeCount))
 	if m.XXX_unrecognized != nil {
 		i += copy(data[i:], m.XXX_unrecognized)
 	}
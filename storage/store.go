@@ -20,6 +20,7 @@ package storage
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -57,6 +58,28 @@ const (
 	defaultRaftElectionTimeoutTicks = 15
 	// ttlCapacityGossip is time-to-live for capacity-related info.
 	ttlCapacityGossip = 2 * time.Minute
+	// defaultBackpressureRangeSizeMultiple is the multiple of a range's
+	// max bytes beyond which writes to it wait for a split.
+	defaultBackpressureRangeSizeMultiple = 4
+	// defaultBackpressureMaxRaftLogEntries is the number of entries in a
+	// range's Raft log beyond which writes to it wait for a truncation.
+	// The raft log queue truncates logs beyond raftLogMaxEntries, but
+	// not past followers within raftLogLaggardEntries of the leader, so
+	// a healthy log may hold up to their sum; this leaves headroom of
+	// several truncations on top.
+	defaultBackpressureMaxRaftLogEntries = raftLogLaggardEntries + 10*raftLogMaxEntries
+	// defaultBackpressureTimeout is how long a write waits for a
+	// backpressuring range to catch up before failing.
+	defaultBackpressureTimeout = 30 * time.Second
+	// defaultClosedTimestampLag is how far behind the present range
+	// leaders close timestamps. Writes below a closed timestamp are
+	// pushed above it, restarting their transactions, so the lag is
+	// well above the duration of most transactions.
+	defaultClosedTimestampLag = 30 * time.Second
+	// closedTimestampIdleTimeout is the duration after which a range
+	// which has served no bounded staleness read or change feed request
+	// stops closing timestamps.
+	closedTimestampIdleTimeout = 1 * time.Minute
 )
 
 var (
@@ -98,6 +121,9 @@ func verifyKeyLength(key proto.Key) error {
 	if bytes.HasPrefix(key, keys.LocalRangePrefix) {
 		key = key[len(keys.LocalRangePrefix):]
 		_, key = encoding.DecodeBytes(key, nil)
+	} else if bytes.HasPrefix(key, keys.LocalOutboxPrefix) {
+		key = key[len(keys.LocalOutboxPrefix):]
+		_, key = encoding.DecodeBytes(key, nil)
 	}
 	if bytes.HasPrefix(key, keys.MetaPrefix) {
 		key = key[len(keys.Meta1Prefix):]
@@ -249,30 +275,39 @@ func (rs *storeRangeSet) EstimatedCount() int {
 // A Store maintains a map of ranges by start key. A Store corresponds
 // to one physical device.
 type Store struct {
-	Ident          proto.StoreIdent
-	ctx            StoreContext
-	db             *client.DB
-	engine         engine.Engine   // The underlying key-value store
-	_allocator     *allocator      // Makes allocation decisions
-	raftIDAlloc    *idAllocator    // Raft ID allocator
-	gcQueue        *gcQueue        // Garbage collection queue
-	_splitQueue    *splitQueue     // Range splitting queue
-	verifyQueue    *verifyQueue    // Checksum verification queue
-	replicateQueue *replicateQueue // Replication queue
-	rangeGCQueue   *rangeGCQueue   // Range GC queue
-	scanner        *rangeScanner   // Range scanner
-	feed           StoreEventFeed  // Event Feed
-	multiraft      *multiraft.MultiRaft
-	started        int32
-	stopper        *stop.Stopper
-	startedAt      int64
-	nodeDesc       *proto.NodeDescriptor
-	initComplete   sync.WaitGroup // Signaled by async init tasks
+	Ident            proto.StoreIdent
+	ctx              StoreContext
+	db               *client.DB
+	engine           engine.Engine     // The underlying key-value store
+	_allocator       *allocator        // Makes allocation decisions
+	raftIDAlloc      *idAllocator      // Raft ID allocator
+	gcQueue          *gcQueue          // Garbage collection queue
+	_splitQueue      *splitQueue       // Range splitting queue
+	mergeQueue       *mergeQueue       // Range merging queue
+	verifyQueue      *verifyQueue      // Checksum verification queue
+	consistencyQueue *consistencyQueue // Cross-replica consistency check queue
+	replicateQueue   *replicateQueue   // Replication queue
+	leaseQueue       *leaseQueue       // Leader lease rebalancing queue
+	rangeGCQueue     *rangeGCQueue     // Range GC queue
+	outboxQueue      *outboxQueue      // Enqueued update execution queue
+	_raftLogQueue    *raftLogQueue     // Raft log truncation queue
+	scanner          *rangeScanner     // Range scanner
+	feed             StoreEventFeed    // Event Feed
+	multiraft        *multiraft.MultiRaft
+	started          int32
+	leaseCount       int32 // Number of ranges whose lease this store holds; updated atomically
+	stopper          *stop.Stopper
+	startedAt        int64
+	nodeDesc         *proto.NodeDescriptor
+	initComplete     sync.WaitGroup // Signaled by async init tasks
 
 	mu           sync.RWMutex            // Protects variables below...
 	ranges       map[proto.RaftID]*Range // Map of ranges by Raft ID
 	rangesByKey  *btree.BTree            // btree keyed by ranges end keys.
 	uninitRanges map[proto.RaftID]*Range // Map of uninitialized ranges by Raft ID
+
+	qMu         sync.RWMutex              // Protects quarantined
+	quarantined map[proto.RaftID]struct{} // Set of quarantined ranges by Raft ID
 }
 
 var _ multiraft.Storage = &Store{}
@@ -317,6 +352,42 @@ type StoreContext struct {
 
 	// Tracer is a request tracer.
 	Tracer *tracer.Tracer
+
+	// QuarantineInconsistentReplicas, if set, quarantines replicas whose
+	// data is found to diverge from the range leader's by a consistency
+	// check. Otherwise the divergence is only logged.
+	QuarantineInconsistentReplicas bool
+
+	// BackpressureRangeSizeMultiple is the multiple of a range's max
+	// bytes beyond which writes to the range wait until it has been
+	// split. A negative value disables backpressure on range size.
+	BackpressureRangeSizeMultiple float64
+
+	// BackpressureMaxRaftLogEntries is the number of entries in a
+	// range's Raft log beyond which writes to the range wait until the
+	// log has been truncated. A negative value disables backpressure on
+	// log length.
+	BackpressureMaxRaftLogEntries int64
+
+	// BackpressureTimeout is how long a write waits on backpressure
+	// before it fails.
+	BackpressureTimeout time.Duration
+
+	// ClosedTimestampInterval is the interval at which the holder of a
+	// range's leader lease closes the timestamp ClosedTimestampLag ago,
+	// promising not to serve writes at or below it. Replicas which have
+	// applied the closing serve bounded staleness reads at or below the
+	// closed timestamp, so reads allowing a staleness of more than the
+	// lag plus the interval are generally served by the nearest replica.
+	// Only ranges which have recently served bounded staleness reads or
+	// change feed requests close timestamps. Zero disables the closing
+	// of timestamps.
+	ClosedTimestampInterval time.Duration
+
+	// ClosedTimestampLag is how far behind the present timestamps are
+	// closed. Transactions writing for longer than the lag are pushed
+	// above the closed timestamp and restart.
+	ClosedTimestampLag time.Duration
 }
 
 // Valid returns true if the StoreContext is populated correctly.
@@ -343,6 +414,18 @@ func (sc *StoreContext) setDefaults() {
 	if sc.RaftElectionTimeoutTicks == 0 {
 		sc.RaftElectionTimeoutTicks = defaultRaftElectionTimeoutTicks
 	}
+	if sc.BackpressureRangeSizeMultiple == 0 {
+		sc.BackpressureRangeSizeMultiple = defaultBackpressureRangeSizeMultiple
+	}
+	if sc.BackpressureMaxRaftLogEntries == 0 {
+		sc.BackpressureMaxRaftLogEntries = defaultBackpressureMaxRaftLogEntries
+	}
+	if sc.BackpressureTimeout == 0 {
+		sc.BackpressureTimeout = defaultBackpressureTimeout
+	}
+	if sc.ClosedTimestampLag == 0 {
+		sc.ClosedTimestampLag = defaultClosedTimestampLag
+	}
 }
 
 // NewStore returns a new instance of a store.
@@ -363,16 +446,24 @@ func NewStore(ctx StoreContext, eng engine.Engine, nodeDesc *proto.NodeDescripto
 		rangesByKey:  btree.New(64 /* degree */),
 		uninitRanges: map[proto.RaftID]*Range{},
 		nodeDesc:     nodeDesc,
+		quarantined:  map[proto.RaftID]struct{}{},
 	}
 
 	// Add range scanner and configure with queues.
 	s.scanner = newRangeScanner(ctx.ScanInterval, ctx.ScanMaxIdleTime, newStoreRangeSet(s))
 	s.gcQueue = newGCQueue()
 	s._splitQueue = newSplitQueue(s.db, s.ctx.Gossip)
+	s.mergeQueue = newMergeQueue(s.ctx.Gossip)
 	s.verifyQueue = newVerifyQueue(s.RangeCount)
+	s.consistencyQueue = newConsistencyQueue(s.RangeCount)
 	s.replicateQueue = newReplicateQueue(s.ctx.Gossip, s.allocator(), s.ctx.Clock)
+	s.leaseQueue = newLeaseQueue(s.ctx.Gossip, s.LeaseCount)
 	s.rangeGCQueue = newRangeGCQueue(s.db)
-	s.scanner.AddQueues(s.gcQueue, s.splitQueue(), s.verifyQueue, s.replicateQueue, s.rangeGCQueue)
+	s.outboxQueue = newOutboxQueue(s.db)
+	s._raftLogQueue = newRaftLogQueue(s.RaftStatus)
+	s.scanner.AddQueues(s.gcQueue, s.splitQueue(), s.mergeQueue, s.verifyQueue,
+		s.consistencyQueue, s.replicateQueue, s.leaseQueue, s.rangeGCQueue, s.outboxQueue,
+		s._raftLogQueue)
 
 	return s
 }
@@ -406,6 +497,22 @@ func (s *Store) StartedAt() int64 {
 // Start the engine, set the GC and read the StoreIdent.
 func (s *Store) Start(stopper *stop.Stopper) error {
 	s.stopper = stopper
+	// Expire the raft snapshot streams which are never read, and release
+	// the engine snapshots held by the streams of the store's ranges
+	// before the engine is closed.
+	s.stopper.RunWorker(func() {
+		ticker := time.NewTicker(snapshotStreamTimeout / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				expireSnapshotStreams(time.Now())
+			case <-s.stopper.ShouldStop():
+				closeSnapshotStreams(s.engine)
+				return
+			}
+		}
+	})
 
 	if s.Ident.NodeID == 0 {
 		// Open engine (i.e. initialize RocksDB database). "NodeID != 0"
@@ -457,7 +564,7 @@ func (s *Store) Start(stopper *stop.Stopper) error {
 	end := keys.RangeDescriptorKey(proto.KeyMax)
 
 	if s.multiraft, err = multiraft.NewMultiRaft(s.RaftNodeID(), &multiraft.Config{
-		Transport:              s.ctx.Transport,
+		Transport:              quarantineTransport{s.ctx.Transport, s},
 		Storage:                s,
 		StateMachine:           s,
 		TickInterval:           s.ctx.RaftTickInterval,
@@ -495,6 +602,13 @@ func (s *Store) Start(stopper *stop.Stopper) error {
 		if err = s.addRangeInternal(rng); err != nil {
 			return false, err
 		}
+		if rng.isQuarantined() {
+			// The quarantine is gossiped along with the store descriptor.
+			log.Warningf("replica of %s remains quarantined", rng)
+			s.qMu.Lock()
+			s.quarantined[desc.RaftID] = struct{}{}
+			s.qMu.Unlock()
+		}
 		s.feed.registerRange(rng, true /* scan */)
 		// Note that we do not create raft groups at this time; they will be created
 		// on-demand the first time they are needed. This helps reduce the amount of
@@ -516,6 +630,10 @@ func (s *Store) Start(stopper *stop.Stopper) error {
 	s.multiraft.Start()
 	s.processRaft()
 
+	if s.ctx.ClosedTimestampInterval > 0 {
+		s.startClosingTimestamps()
+	}
+
 	// Gossip is only ever nil while bootstrapping a cluster and
 	// in unittests.
 	if s.ctx.Gossip != nil {
@@ -609,6 +727,57 @@ func (s *Store) startGossip() {
 	})
 }
 
+// startClosingTimestamps runs a goroutine which periodically has the
+// ranges whose leader lease this store holds close a timestamp, so that
+// their other replicas may serve bounded staleness reads even if no
+// writes are applied. Ranges which have served no bounded staleness
+// read or change feed request within closedTimestampIdleTimeout are
+// skipped: their followers' reads fall back to the leader, which
+// resumes closing timestamps.
+func (s *Store) startClosingTimestamps() {
+	s.stopper.RunWorker(func() {
+		ticker := time.NewTicker(s.ctx.ClosedTimestampInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.closeTimestamps()
+			case <-s.stopper.ShouldStop():
+				return
+			}
+		}
+	})
+}
+
+// closeTimestamps has each range whose leader lease this store holds
+// and which has recently served bounded staleness reads or change feed
+// requests close the timestamp ClosedTimestampLag ago.
+func (s *Store) closeTimestamps() {
+	now := s.ctx.Clock.Now()
+	closed := proto.Timestamp{WallTime: now.WallTime - s.ctx.ClosedTimestampLag.Nanoseconds()}
+	idleSince := now.WallTime - closedTimestampIdleTimeout.Nanoseconds()
+	raftNodeID := s.RaftNodeID()
+	var rngs []*Range
+	s.mu.RLock()
+	for _, rng := range s.ranges {
+		if atomic.LoadInt64(&rng.closedTSRequested) < idleSince {
+			continue
+		}
+		if lease := rng.getLease(); lease.OwnedBy(raftNodeID) && lease.Covers(now) {
+			rngs = append(rngs, rng)
+		}
+	}
+	s.mu.RUnlock()
+	for _, rng := range rngs {
+		rng := rng
+		s.stopper.RunAsyncTask(func() {
+			if err := rng.closeTimestamp(closed); err != nil && log.V(1) {
+				log.Infof("%s: unable to close timestamp %s: %s", rng, closed, err)
+			}
+		})
+	}
+}
+
 // maybeGossipFirstRange checks whether the store has a replia of the first
 // range and if so, reminds it to gossip the first range descriptor and
 // sentinel gossip.
@@ -721,6 +890,17 @@ func (s *Store) ForceReplicationScan(t util.Tester) {
 	}
 }
 
+// ForceMergeScan iterates over all ranges and enqueues any that
+// may need to be merged. Exposed only for testing.
+func (s *Store) ForceMergeScan(t util.Tester) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range s.ranges {
+		s.mergeQueue.MaybeAdd(r, s.ctx.Clock.Now())
+	}
+}
+
 // ForceRangeGCScan iterates over all ranges and enqueues any that
 // may need to be GC'd. Exposed only for testing.
 func (s *Store) ForceRangeGCScan(t util.Tester) {
@@ -732,8 +912,20 @@ func (s *Store) ForceRangeGCScan(t util.Tester) {
 	}
 }
 
-// setRangesMaxBytes sets the max bytes for every range according
-// to the zone configs.
+// ForceOutboxScan iterates over all ranges and enqueues any that have
+// pending updates in the outboxes of their keys. Exposed only for
+// testing.
+func (s *Store) ForceOutboxScan(t util.Tester) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range s.ranges {
+		s.outboxQueue.MaybeAdd(r, s.ctx.Clock.Now())
+	}
+}
+
+// setRangesMaxBytes sets the max bytes and max request rate for every
+// range according to the zone configs.
 //
 // TODO(spencer): scanning all ranges with the lock held could cause
 // perf issues if the number of ranges grows large enough.
@@ -751,6 +943,7 @@ func (s *Store) setRangesMaxBytes(zoneMap PrefixConfigMap) {
 			zone = zoneMap[idx].Config.(*proto.ZoneConfig)
 		}
 		rng.SetMaxBytes(zone.RangeMaxBytes)
+		rng.SetMaxQPS(zone.RangeMaxQPS)
 		return true
 	})
 }
@@ -861,6 +1054,10 @@ func (s *Store) BootstrapRange() error {
 	if err := engine.MVCCPutProto(batch, ms, keys.RangeLastVerificationTimestampKey(desc.RaftID), proto.ZeroTimestamp, nil, &now); err != nil {
 		return err
 	}
+	// Change log start; the writes above aren't logged.
+	if err := engine.MVCCPutProto(batch, nil, keys.RangeChangeLogStartKey(desc.RaftID), proto.ZeroTimestamp, nil, &now); err != nil {
+		return err
+	}
 	// Range addressing for meta2.
 	meta2Key := keys.RangeMetaKey(proto.KeyMax)
 	if err := engine.MVCCPutProto(batch, ms, meta2Key, now, nil, desc); err != nil {
@@ -965,6 +1162,9 @@ func (s *Store) Gossip() *gossip.Gossip { return s.ctx.Gossip }
 // SplitQueue accessor.
 func (s *Store) splitQueue() *splitQueue { return s._splitQueue }
 
+// raftLogQueue accessor.
+func (s *Store) raftLogQueue() *raftLogQueue { return s._raftLogQueue }
+
 // Stopper accessor.
 func (s *Store) Stopper() *stop.Stopper { return s.stopper }
 
@@ -1019,6 +1219,10 @@ func (s *Store) SplitRange(origRng, newRng *Range) error {
 		return util.Errorf("couldn't insert range %v in rangesByKey btree: %s", newRng, err)
 	}
 
+	// The load measured before the split no longer reflects the original
+	// range's narrowed key span.
+	origRng.load.reset(s.ctx.Clock.PhysicalNow())
+
 	// Update the max bytes and other information of the new range.
 	if err := newRng.updateRangeInfo(); err != nil {
 		return err
@@ -1107,6 +1311,9 @@ func (s *Store) addRangeToRangeMap(rng *Range) error {
 		return &rangeAlreadyExists{exRng}
 	}
 	s.ranges[rng.Desc().RaftID] = rng
+	if rng.getLease().OwnedBy(s.RaftNodeID()) {
+		atomic.AddInt32(&s.leaseCount, 1)
+	}
 	return nil
 }
 
@@ -1122,13 +1329,100 @@ func (s *Store) RemoveRange(rng *Range) error {
 	defer s.mu.Unlock()
 
 	delete(s.ranges, rng.Desc().RaftID)
+	if rng.getLease().OwnedBy(s.RaftNodeID()) {
+		atomic.AddInt32(&s.leaseCount, -1)
+	}
 	if s.rangesByKey.Delete(rng) == nil {
 		return util.Errorf("couldn't find range in rangesByKey btree")
 	}
 	s.scanner.RemoveRange(rng)
+
+	s.qMu.Lock()
+	delete(s.quarantined, rng.Desc().RaftID)
+	s.qMu.Unlock()
 	return nil
 }
 
+// QuarantineRange takes the store's replica of the range out of
+// service after its data was found to be corrupt. The replica stops
+// serving commands and becomes a non-participating raft follower, as
+// all raft messages to and from it are dropped. The quarantine is
+// advertised in the gossiped store descriptor so that the replicate
+// queue on the range leader replaces the replica from a healthy peer
+// and removes it, after which the range GC queue destroys it.
+func (s *Store) QuarantineRange(rng *Range) {
+	if !rng.quarantine() {
+		return
+	}
+	log.Errorc(s.Context(nil), "quarantined replica of %s", rng)
+	s.qMu.Lock()
+	s.quarantined[rng.Desc().RaftID] = struct{}{}
+	s.qMu.Unlock()
+	s.GossipCapacity()
+}
+
+// ReportInconsistency is called when a consistency check has found the
+// store's replica of the range to diverge from the range leader's in
+// the given key spans.
+func (s *Store) ReportInconsistency(rng *Range, spans []string) {
+	log.Errorc(s.Context(nil), "replica of %s diverges from the range leader in spans %s",
+		rng, strings.Join(spans, ", "))
+	if s.ctx.QuarantineInconsistentReplicas {
+		s.QuarantineRange(rng)
+	}
+}
+
+// isQuarantined returns whether the store's replica of the range with
+// the given Raft ID is quarantined.
+func (s *Store) isQuarantined(raftID proto.RaftID) bool {
+	s.qMu.RLock()
+	defer s.qMu.RUnlock()
+	_, ok := s.quarantined[raftID]
+	return ok
+}
+
+// quarantineTransport wraps a store's raft transport to cut quarantined
+// replicas off from their raft groups.
+type quarantineTransport struct {
+	multiraft.Transport
+	store *Store
+}
+
+// Listen implements the multiraft.Transport interface.
+func (qt quarantineTransport) Listen(id proto.RaftNodeID, server multiraft.ServerInterface) error {
+	return qt.Transport.Listen(id, quarantineServer{server, qt.store})
+}
+
+// Send implements the multiraft.Transport interface, dropping messages
+// sent by quarantined replicas.
+func (qt quarantineTransport) Send(req *multiraft.RaftMessageRequest) error {
+	if qt.store.isQuarantined(req.GroupID) {
+		if req.Message.Type == raftpb.MsgSnap {
+			DiscardSnapshotData(req.Message.Snapshot.Data)
+		}
+		return nil
+	}
+	return qt.Transport.Send(req)
+}
+
+// quarantineServer drops raft messages sent to quarantined replicas.
+type quarantineServer struct {
+	multiraft.ServerInterface
+	store *Store
+}
+
+// RaftMessage implements the multiraft.ServerInterface interface.
+func (qs quarantineServer) RaftMessage(req *multiraft.RaftMessageRequest,
+	resp *multiraft.RaftMessageResponse) error {
+	if qs.store.isQuarantined(req.GroupID) {
+		if req.Message.Type == raftpb.MsgSnap {
+			DiscardSnapshotData(req.Message.Snapshot.Data)
+		}
+		return nil
+	}
+	return qs.ServerInterface.RaftMessage(req, resp)
+}
+
 // processRangeDescriptorUpdate is called whenever a range's
 // descriptor is updated.
 func (s *Store) processRangeDescriptorUpdate(rng *Range) error {
@@ -1182,12 +1476,20 @@ func (s *Store) Descriptor() (*proto.StoreDescriptor, error) {
 		return nil, err
 	}
 	capacity.RangeCount = int32(s.RangeCount())
+	capacity.LeaseCount = int32(s.LeaseCount())
+	var quarantined []proto.RaftID
+	s.qMu.RLock()
+	for raftID := range s.quarantined {
+		quarantined = append(quarantined, raftID)
+	}
+	s.qMu.RUnlock()
 	// Initialize the store descriptor.
 	return &proto.StoreDescriptor{
-		StoreID:  s.Ident.StoreID,
-		Attrs:    s.Attrs(),
-		Node:     *s.nodeDesc,
-		Capacity: capacity,
+		StoreID:            s.Ident.StoreID,
+		Attrs:              s.Attrs(),
+		Node:               *s.nodeDesc,
+		Capacity:           capacity,
+		QuarantinedRaftIDs: quarantined,
 	}, nil
 }
 
@@ -1198,6 +1500,24 @@ func (s *Store) RangeCount() int {
 	return len(s.ranges)
 }
 
+// LeaseCount returns the number of ranges whose leader lease was last
+// granted to this store. The count is maintained as ranges are added
+// and removed and as their leases change hands, and includes leases
+// which have expired without being taken over.
+func (s *Store) LeaseCount() int {
+	return int(atomic.LoadInt32(&s.leaseCount))
+}
+
+// leaseOwnershipChanged implements the rangeManager interface, updating
+// the count of leases held by this store.
+func (s *Store) leaseOwnershipChanged(owned bool) {
+	if owned {
+		atomic.AddInt32(&s.leaseCount, 1)
+	} else {
+		atomic.AddInt32(&s.leaseCount, -1)
+	}
+}
+
 // ExecuteCmd fetches a range based on the header's replica, assembles
 // method, args & reply into a Raft Cmd struct and executes the
 // command using the fetched range.
@@ -1245,6 +1565,9 @@ func (s *Store) ExecuteCmd(ctx context.Context, args proto.Request) (proto.Respo
 		if err != nil {
 			return nil, err
 		}
+		if err = s.maybeBackpressure(rng, args); err != nil {
+			return nil, err
+		}
 
 		var reply proto.Response
 		reply, err = rng.AddCmd(ctx, args)
@@ -1316,6 +1639,95 @@ func (s *Store) ExecuteCmd(ctx context.Context, args proto.Request) (proto.Respo
 	return nil, err
 }
 
+// backpressurable returns whether the request is a write of user data
+// which is subject to backpressure. Writes to system keys, deletions
+// and the internal commands which splits, log truncation and intent
+// resolution rely on are not.
+func backpressurable(args proto.Request) bool {
+	if args.Header().Key.Less(keys.SystemMax) {
+		return false
+	}
+	switch args.(type) {
+	case *proto.PutRequest, *proto.ConditionalPutRequest, *proto.IncrementRequest,
+		*proto.EnqueueUpdateRequest, *proto.EnqueueMessageRequest, *proto.ImportRequest:
+		return true
+	}
+	return false
+}
+
+// backpressureReason returns why writes to the range must wait, or the
+// empty string if they need not: the range is more than
+// BackpressureRangeSizeMultiple times over its max bytes, or its Raft
+// log has more than BackpressureMaxRaftLogEntries entries. Ranges which
+// the split queue finds no key to split at are not backpressured on
+// size, as no split would relieve them.
+func (s *Store) backpressureReason(rng *Range) string {
+	if multiple := s.ctx.BackpressureRangeSizeMultiple; multiple > 0 && atomic.LoadInt32(&rng.noSplitKey) == 0 {
+		maxBytes := rng.backpressureMaxBytes(multiple)
+		if size := rng.stats.GetSize(); maxBytes > 0 && float64(size) > multiple*float64(maxBytes) {
+			return fmt.Sprintf("size %d exceeds %gx max bytes %d", size, multiple, maxBytes)
+		}
+	}
+	if maxEntries := s.ctx.BackpressureMaxRaftLogEntries; maxEntries > 0 {
+		firstIndex, err := rng.FirstIndex()
+		if err != nil {
+			log.Warningf("%s: unable to read first raft log index: %s", rng, err)
+			return ""
+		}
+		lastIndex, err := rng.LastIndex()
+		if err != nil {
+			log.Warningf("%s: unable to read last raft log index: %s", rng, err)
+			return ""
+		}
+		if entries := lastIndex + 1 - firstIndex; lastIndex >= firstIndex && entries > uint64(maxEntries) {
+			return fmt.Sprintf("raft log has %d entries, more than %d", entries, maxEntries)
+		}
+	}
+	return ""
+}
+
+// maybeBackpressure blocks a write to the range while the range is
+// too far over its size limit or its Raft log too long, until splits
+// or log truncation have caught up. An error is returned if they have
+// not done so within BackpressureTimeout.
+func (s *Store) maybeBackpressure(rng *Range, args proto.Request) error {
+	if !backpressurable(args) {
+		return nil
+	}
+	// Only the leader lease holder holds up writes; other replicas
+	// redirect them to it.
+	if lease := rng.getLease(); !lease.Covers(s.ctx.Clock.Now()) || !lease.OwnedBy(s.RaftNodeID()) {
+		return nil
+	}
+	reason := s.backpressureReason(rng)
+	if reason == "" {
+		return nil
+	}
+	// Make sure a split or log truncation is on its way; the range is
+	// only added to either queue if it does need it.
+	rng.maybeAddToSplitQueue()
+	s.raftLogQueue().MaybeAdd(rng, s.ctx.Clock.Now())
+	if log.V(1) {
+		log.Infof("%s: backpressuring %s: %s", rng, args.Method(), reason)
+	}
+	deadline := time.Now().Add(s.ctx.BackpressureTimeout)
+	opts := retry.Options{
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+		Stopper:        s.stopper,
+	}
+	for r := retry.Start(opts); r.Next(); {
+		if reason = s.backpressureReason(rng); reason == "" {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			break
+		}
+	}
+	return util.Errorf("%s: write backpressure did not clear within %s: %s", rng, s.ctx.BackpressureTimeout, reason)
+}
+
 // resolveWriteIntentError tries to push the conflicting transaction:
 // either move its timestamp forward on a read/write conflict, or
 // abort it on a write/write conflict. If the push succeeds, we
@@ -1606,7 +2018,7 @@ func (s *Store) GetStatus() (*StoreStatus, error) {
 // ranges. An ideal solution would be to create incremental events whenever
 // availability changes.
 func (s *Store) computeReplicationStatus(now int64) (
-	leaderRangeCount, replicatedRangeCount, availableRangeCount int32) {
+	leaderRangeCount, replicatedRangeCount, availableRangeCount, quarantinedRangeCount int32) {
 	// Get the zone configs, which are needed to determine if a range is
 	// under-replicated.
 	zoneMap, err := s.Gossip().GetInfo(gossip.KeyConfigZone)
@@ -1619,6 +2031,10 @@ func (s *Store) computeReplicationStatus(now int64) (
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	for raftID, rng := range s.ranges {
+		if rng.isQuarantined() {
+			quarantinedRangeCount++
+			continue
+		}
 		zoneConfig := zoneMap.(PrefixConfigMap).MatchByPrefix(rng.Desc().StartKey).Config.(*proto.ZoneConfig)
 		raftStatus := s.RaftStatus(raftID)
 		if raftStatus == nil {
@@ -1669,9 +2085,10 @@ func (s *Store) PublishStatus() error {
 
 	// broadcast replication status.
 	now := s.ctx.Clock.Now().WallTime
-	leaderRangeCount, replicatedRangeCount, availableRangeCount :=
+	leaderRangeCount, replicatedRangeCount, availableRangeCount, quarantinedRangeCount :=
 		s.computeReplicationStatus(now)
-	s.feed.replicationStatus(leaderRangeCount, replicatedRangeCount, availableRangeCount)
+	s.feed.replicationStatus(leaderRangeCount, replicatedRangeCount, availableRangeCount,
+		quarantinedRangeCount)
 	return nil
 }
 
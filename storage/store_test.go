@@ -609,6 +609,114 @@ func TestStoreExecuteCmdWithClockOffset(t *testing.T) {
 	}
 }
 
+// TestStoreLeaseCount verifies that the store counts the leader leases
+// it acquires and loses.
+func TestStoreLeaseCount(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	store, _, stopper := createTestStore(t)
+	defer stopper.Stop()
+	rng := store.LookupRange(proto.KeyMin, nil)
+
+	// Acquire the leader lease.
+	pArgs := putArgs([]byte("a"), []byte("value"), 1, store.StoreID())
+	if _, err := store.ExecuteCmd(context.Background(), &pArgs); err != nil {
+		t.Fatal(err)
+	}
+	if count := store.LeaseCount(); count != 1 {
+		t.Fatalf("expected 1 lease; got %d", count)
+	}
+	// Renewing the lease leaves the count as it is.
+	lease := *rng.getLease()
+	rng.setLease(&lease)
+	if count := store.LeaseCount(); count != 1 {
+		t.Fatalf("expected 1 lease after renewal; got %d", count)
+	}
+	// Losing it to another store does not.
+	rng.setLease(&proto.Lease{RaftNodeID: proto.MakeRaftNodeID(2, 2)})
+	if count := store.LeaseCount(); count != 0 {
+		t.Fatalf("expected no leases after losing the lease; got %d", count)
+	}
+}
+
+// TestStoreExecuteCmdBackpressure verifies that writes to a range
+// which is too far over its max bytes or whose Raft log is too long
+// wait for the range to catch up, and fail if it does not in time.
+func TestStoreExecuteCmdBackpressure(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	store, _, stopper := createTestStore(t)
+	defer stopper.Stop()
+	store.ctx.BackpressureTimeout = 50 * time.Millisecond
+	rng := store.LookupRange(proto.KeyMin, nil)
+
+	putValue := func(key string, value []byte) error {
+		pArgs := putArgs([]byte(key), value, 1, store.StoreID())
+		pArgs.Timestamp = store.ctx.Clock.Now()
+		_, err := store.ExecuteCmd(context.Background(), &pArgs)
+		return err
+	}
+	put := func(key string) error {
+		return putValue(key, []byte("value"))
+	}
+	for _, key := range []string{"a", "b", "c"} {
+		if err := put(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Shrinking the max bytes far below the range's size does not block
+	// writes, as splits have yet to catch up with the new limit.
+	maxBytes := rng.GetMaxBytes()
+	rng.SetMaxBytes(1)
+	if err := put("d"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Growing the range far over its max bytes blocks writes, but not
+	// reads.
+	rng.SetMaxBytes(rng.stats.GetSize())
+	if err := putValue("e", make([]byte, 4*rng.GetMaxBytes())); err != nil {
+		t.Fatal(err)
+	}
+	if err := put("f"); !testutils.IsError(err, "backpressure") {
+		t.Fatalf("expected backpressure error; got %v", err)
+	}
+	gArgs := getArgs([]byte("a"), 1, store.StoreID())
+	if _, err := store.ExecuteCmd(context.Background(), &gArgs); err != nil {
+		t.Fatal(err)
+	}
+	// Nor does it if the split queue finds no key to split the range at.
+	atomic.StoreInt32(&rng.noSplitKey, 1)
+	if err := put("f"); err != nil {
+		t.Fatal(err)
+	}
+	atomic.StoreInt32(&rng.noSplitKey, 0)
+	// A blocked write proceeds once the range is back within limits.
+	store.ctx.BackpressureTimeout = 10 * time.Second
+	time.AfterFunc(50*time.Millisecond, func() { rng.SetMaxBytes(maxBytes) })
+	if err := put("g"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Likewise for a Raft log with too many entries, until it is truncated.
+	store.ctx.BackpressureRangeSizeMultiple = -1
+	store.ctx.BackpressureMaxRaftLogEntries = 2
+	store.ctx.BackpressureTimeout = 50 * time.Millisecond
+	if err := put("h"); !testutils.IsError(err, "raft log") {
+		t.Fatalf("expected raft log backpressure error; got %v", err)
+	}
+	lastIndex, err := rng.LastIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tArgs := internalTruncateLogArgs(lastIndex+1, 1, store.StoreID())
+	if _, err := store.ExecuteCmd(context.Background(), &tArgs); err != nil {
+		t.Fatal(err)
+	}
+	if err := put("h"); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestStoreExecuteCmdBadRange passes a bad range.
 func TestStoreExecuteCmdBadRange(t *testing.T) {
 	defer leaktest.AfterTest(t)
@@ -18,6 +18,7 @@
 package storage
 
 import (
+	"sort"
 	"time"
 
 	"github.com/cockroachdb/cockroach/proto"
@@ -171,6 +172,53 @@ func (tc *TimestampCache) MergeInto(dest *TimestampCache, clear bool) {
 	})
 }
 
+// Summary returns a summary of the cache, which holds its low water
+// mark and the entries above it. If there are more than maxEntries
+// such entries, only the most recent ones are kept and the low water
+// mark of the summary is raised to the timestamps of the others.
+func (tc *TimestampCache) Summary(maxEntries int) *proto.TimestampCacheSummary {
+	summary := &proto.TimestampCacheSummary{LowWater: tc.lowWater}
+	tc.cache.Do(func(k, v interface{}) {
+		key, ce := k.(*cache.IntervalKey), v.(cacheEntry)
+		if !tc.lowWater.Less(ce.timestamp) {
+			return
+		}
+		summary.Entries = append(summary.Entries, proto.TimestampCacheEntry{
+			StartKey:  key.Start().(proto.Key),
+			EndKey:    key.End().(proto.Key),
+			Timestamp: ce.timestamp,
+			TxnID:     ce.txnID,
+			ReadOnly:  ce.readOnly,
+		})
+	})
+	if len(summary.Entries) > maxEntries {
+		sort.Sort(sort.Reverse(cacheEntriesByTimestamp(summary.Entries)))
+		for _, e := range summary.Entries[maxEntries:] {
+			summary.LowWater.Forward(e.Timestamp)
+		}
+		summary.Entries = summary.Entries[:maxEntries]
+	}
+	return summary
+}
+
+// Seed adds the entries of the summary of another cache to this cache
+// and raises its low water mark to that of the summary, so that it
+// returns timestamps at least as high as the other cache from calls
+// to GetMax().
+func (tc *TimestampCache) Seed(summary *proto.TimestampCacheSummary) {
+	tc.SetLowWater(summary.LowWater)
+	for _, e := range summary.Entries {
+		tc.Add(e.StartKey, e.EndKey, e.Timestamp, e.TxnID, e.ReadOnly)
+	}
+}
+
+// cacheEntriesByTimestamp sorts the entries of a summary by timestamp.
+type cacheEntriesByTimestamp []proto.TimestampCacheEntry
+
+func (s cacheEntriesByTimestamp) Len() int           { return len(s) }
+func (s cacheEntriesByTimestamp) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s cacheEntriesByTimestamp) Less(i, j int) bool { return s[i].Timestamp.Less(s[j].Timestamp) }
+
 // shouldEvict returns true if the cache entry's timestamp is no
 // longer within the MinTSCacheWindow.
 func (tc *TimestampCache) shouldEvict(size int, key, value interface{}) bool {
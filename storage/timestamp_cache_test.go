@@ -236,6 +236,50 @@ func TestTimestampCacheMergeInto(t *testing.T) {
 	}
 }
 
+// TestTimestampCacheSummary verifies that a cache seeded with the
+// summary of another returns timestamps at least as high as the other,
+// also when the summary is truncated.
+func TestTimestampCacheSummary(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	manual := hlc.NewManualClock(0)
+	clock := hlc.NewClock(manual.UnixNano)
+	txnID := []byte("txn")
+
+	tc1 := NewTimestampCache(clock)
+	aTS := clock.Now()
+	tc1.Add(proto.Key("a"), nil, aTS, nil, true)
+	bdTS := clock.Now()
+	tc1.Add(proto.Key("b"), proto.Key("d"), bdTS, txnID, false)
+	cTS := clock.Now()
+	tc1.Add(proto.Key("c"), nil, cTS, txnID, true)
+
+	keys := []proto.Key{proto.Key("a"), proto.Key("b"), proto.Key("c"), proto.Key("e")}
+	for _, maxEntries := range []int{3, 2, 0} {
+		summary := tc1.Summary(maxEntries)
+		if len(summary.Entries) != maxEntries {
+			t.Errorf("%d: expected %d entries; got %d", maxEntries, maxEntries, len(summary.Entries))
+		}
+		tc2 := NewTimestampCache(hlc.NewClock(hlc.NewManualClock(0).UnixNano))
+		tc2.Seed(summary)
+		for _, key := range keys {
+			for _, id := range [][]byte{nil, txnID} {
+				expR, expW := tc1.GetMax(key, nil, id)
+				rTS, wTS := tc2.GetMax(key, nil, id)
+				if rTS.Less(expR) || wTS.Less(expW) {
+					t.Errorf("%d: expected at least %s, %s for key %q; got %s, %s", maxEntries, expR, expW, key, rTS, wTS)
+				}
+				if maxEntries == 3 && (!rTS.Equal(expR) || !wTS.Equal(expW)) {
+					t.Errorf("%d: expected %s, %s for key %q; got %s, %s", maxEntries, expR, expW, key, rTS, wTS)
+				}
+			}
+		}
+	}
+	// A truncated summary keeps the most recent entries.
+	if summary := tc1.Summary(2); !summary.LowWater.Equal(aTS) {
+		t.Errorf("expected low water mark %s; got %s", aTS, summary.LowWater)
+	}
+}
+
 // TestTimestampCacheLayeredIntervals verifies the maximum timestamp
 // is chosen if previous entries have ranges which are layered over
 // each other.
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/proto"
+	"github.com/cockroachdb/cockroach/util"
 	"github.com/cockroachdb/cockroach/util/log"
 )
 
@@ -60,6 +61,10 @@ func (vq *verifyQueue) needsLeaderLease() bool {
 // for shouldQ in the event that it's been longer since the last scan
 // than the verification interval.
 func (vq *verifyQueue) shouldQueue(now proto.Timestamp, rng *Range) (shouldQ bool, priority float64) {
+	if rng.isQuarantined() {
+		// Already known to be corrupt.
+		return
+	}
 	// Get last verification timestamp.
 	lastVerify, err := rng.GetLastVerificationTimestamp()
 	if err != nil {
@@ -87,12 +92,12 @@ func (vq *verifyQueue) process(now proto.Timestamp, rng *Range) error {
 	for ; iter.Valid(); iter.Next() {
 	}
 	// An error during iteration is presumed to mean a checksum failure
-	// while iterating over the underlying key/value data.
-	if iter.Error() != nil {
-		// TODO(spencer): do something other than fatal error here. We
-		// want to quarantine this range, make it a non-participating raft
-		// follower until it can be replaced and then destroyed.
-		log.Fatalf("unhandled failure when scanning range %s; probable data corruption: %s", rng, iter.Error())
+	// while iterating over the underlying key/value data. Quarantine the
+	// replica until it has been replaced from a healthy peer and
+	// destroyed.
+	if err := iter.Error(); err != nil {
+		rng.rm.QuarantineRange(rng)
+		return util.Errorf("failure when scanning range %s; probable data corruption: %s", rng, err)
 	}
 
 	// Store current timestamp as last verification for this range.
@@ -19,6 +19,7 @@ package storage
 
 import (
 	"math"
+	"reflect"
 	"testing"
 
 	"github.com/cockroachdb/cockroach/keys"
@@ -65,3 +66,51 @@ func TestVerifyQueueShouldQueue(t *testing.T) {
 		}
 	}
 }
+
+// TestVerifyQueueQuarantine verifies that a quarantined replica stops
+// serving commands and verification, is reported in the gossiped
+// store descriptor, and is queued for range GC, also once reloaded.
+func TestVerifyQueueQuarantine(t *testing.T) {
+	defer leaktest.AfterTest(t)
+	tc := testContext{}
+	tc.Start(t)
+	defer tc.Stop()
+
+	tc.store.QuarantineRange(tc.rng)
+
+	gArgs := getArgs([]byte("a"), tc.rangeID, tc.store.StoreID())
+	if _, err := tc.rng.AddCmd(tc.rng.context(), &gArgs); err == nil {
+		t.Fatal("expected error reading from quarantined replica")
+	} else if _, ok := err.(*proto.NotLeaderError); !ok {
+		t.Fatalf("expected NotLeaderError; got %s", err)
+	}
+
+	now := makeTS(verificationInterval.Nanoseconds()*2, 0)
+	if shouldQ, _ := newVerifyQueue(nil).shouldQueue(now, tc.rng); shouldQ {
+		t.Error("expected quarantined replica not to be verified")
+	}
+	if shouldQ, _ := tc.store.rangeGCQueue.shouldQueue(now, tc.rng); !shouldQ {
+		t.Error("expected quarantined replica to be queued for range GC")
+	}
+
+	desc, err := tc.store.Descriptor()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(desc.QuarantinedRaftIDs, []proto.RaftID{tc.rangeID}) {
+		t.Errorf("expected range %d to be quarantined; got %v", tc.rangeID, desc.QuarantinedRaftIDs)
+	}
+	if !tc.store.allocator().IsQuarantined(*tc.rng.GetReplica(), tc.rangeID) {
+		t.Error("expected gossiped store descriptor to report the quarantined replica")
+	}
+
+	// The quarantine is persisted, so the replica stays quarantined when
+	// it is loaded again, as after a restart.
+	rng, err := NewRange(tc.rng.Desc(), tc.store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !rng.isQuarantined() {
+		t.Error("expected reloaded replica to be quarantined")
+	}
+}